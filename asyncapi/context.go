@@ -5,12 +5,16 @@
 // Package asyncapi contains vacuum's AsyncAPI execution context and document
 // detection helpers. It intentionally keeps the parsing contract small: command
 // code can cheaply detect AsyncAPI, while motor and rule functions receive the
-// full libasyncapi document graph when linting AsyncAPI 3.x documents.
+// full libasyncapi document graph when linting AsyncAPI 3.x documents. AsyncAPI
+// 2.x documents are not supported by libasyncapi, so they are linted from the
+// raw YAML tree and a reference index only.
 package asyncapi
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -38,13 +42,17 @@ type Context struct {
 	Index        *index.SpecIndex
 	Rolodex      *index.Rolodex
 
+	// indexErrors are the reference errors collected while indexing documents
+	// that have no libasyncapi Document (AsyncAPI 2.x).
+	indexErrors []error
+
 	pathIndexOnce sync.Once
 	pathIndex     *vacuumUtils.NodePathIndex
 }
 
-// DetectFormat returns vacuum's AsyncAPI format for spec bytes. It only
-// recognizes AsyncAPI 3.x and returns an error for AsyncAPI 2.x or invalid
-// AsyncAPI version strings. Non-AsyncAPI documents return an empty format.
+// DetectFormat returns vacuum's AsyncAPI format for spec bytes. It recognizes
+// AsyncAPI 2.x and 3.x and returns an error for any other or invalid AsyncAPI
+// version strings. Non-AsyncAPI documents return an empty format.
 func DetectFormat(spec []byte) (string, error) {
 	if !hasAsyncAPIMarker(spec) {
 		return "", nil
@@ -75,13 +83,16 @@ func IsDocument(spec []byte) (bool, error) {
 }
 
 // FormatForVersion maps an AsyncAPI version string onto vacuum's format
-// constants. AsyncAPI 2.x is intentionally unsupported.
+// constants.
 func FormatForVersion(version string) (string, error) {
 	clean := strings.TrimSpace(version)
 	if clean == "" {
 		return "", libasyncapi.ErrNoAsyncAPIVersion
 	}
 	info, err := libasyncapi.ParseAsyncAPIVersion(clean)
+	if errors.Is(err, libasyncapi.ErrAsyncAPI2NotSupported) {
+		return formatForVersion2(clean)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -99,9 +110,47 @@ func FormatForVersion(version string) (string, error) {
 	}
 }
 
+// formatForVersion2 maps an AsyncAPI 2.x version string, which libasyncapi
+// rejects, onto vacuum's 2.x format constants.
+func formatForVersion2(version string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 || parts[0] != "2" {
+		return "", libasyncapi.ErrInvalidAsyncAPIVersion
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return "", libasyncapi.ErrInvalidAsyncAPIVersion
+	}
+	switch minor {
+	case 0:
+		return vacuumModel.AsyncAPI20, nil
+	case 1:
+		return vacuumModel.AsyncAPI21, nil
+	case 2:
+		return vacuumModel.AsyncAPI22, nil
+	case 3:
+		return vacuumModel.AsyncAPI23, nil
+	case 4:
+		return vacuumModel.AsyncAPI24, nil
+	case 5:
+		return vacuumModel.AsyncAPI25, nil
+	case 6:
+		return vacuumModel.AsyncAPI26, nil
+	default:
+		return vacuumModel.AsyncAPI2, nil
+	}
+}
+
 // NewContext parses spec bytes into a libasyncapi document and builds the
-// metadata vacuum needs for format filtering, locations and reports.
+// metadata vacuum needs for format filtering, locations and reports. AsyncAPI
+// 2.x documents have no libasyncapi document, only the YAML tree and index.
 func NewContext(spec []byte, specFileName string, config *libasyncapi.DocumentConfiguration) (*Context, error) {
+	if version, ok, err := detectVersion(spec); err == nil && ok {
+		if format, formatErr := FormatForVersion(version); formatErr == nil && vacuumModel.FormatMatches(vacuumModel.AsyncAPI2, format) {
+			return newAsyncAPI2Context(spec, specFileName, config, version, format)
+		}
+	}
+
 	doc, err := libasyncapi.NewDocumentWithConfiguration(spec, config)
 	if err != nil {
 		return nil, err
@@ -159,9 +208,12 @@ func (c *Context) Root() *yaml.Node {
 
 // DocumentErrors returns libasyncapi document validation errors.
 func (c *Context) DocumentErrors() []error {
-	if c == nil || c.Document == nil {
+	if c == nil {
 		return nil
 	}
+	if c.Document == nil {
+		return c.indexErrors
+	}
 	return c.Document.Errors()
 }
 
@@ -30,11 +30,49 @@ func TestDetectFormatReturnsAsyncAPIModelConstants(t *testing.T) {
 	assert.Equal(t, model.AsyncAPI31, format)
 }
 
-func TestDetectFormatRejectsAsyncAPI2(t *testing.T) {
-	_, err := DetectFormat([]byte("asyncapi: 2.6.0\ninfo:\n  title: Test\n  version: 1.0.0\n"))
+func TestDetectFormatReturnsAsyncAPI2Formats(t *testing.T) {
+	format, err := DetectFormat([]byte("asyncapi: 2.6.0\ninfo:\n  title: Test\n  version: 1.0.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, model.AsyncAPI26, format)
+
+	format, err = DetectFormat([]byte("asyncapi: '2.0.0'\ninfo:\n  title: Test\n  version: 1.0.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, model.AsyncAPI20, format)
+
+	format, err = DetectFormat([]byte("asyncapi: 2.9.0\ninfo:\n  title: Test\n  version: 1.0.0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, model.AsyncAPI2, format)
+}
+
+func TestDetectFormatRejectsAsyncAPI1(t *testing.T) {
+	_, err := DetectFormat([]byte("asyncapi: 1.2.0\ninfo:\n  title: Test\n  version: 1.0.0\n"))
 
 	require.Error(t, err)
-	assert.True(t, errors.Is(err, libasyncapi.ErrAsyncAPI2NotSupported))
+	assert.True(t, errors.Is(err, libasyncapi.ErrInvalidAsyncAPIVersion))
+}
+
+func TestNewContextBuildsAsyncAPI2Context(t *testing.T) {
+	ctx, err := NewContext([]byte(`asyncapi: 2.6.0
+info:
+  title: Test
+  version: 1.0.0
+channels:
+  user/signedup:
+    publish:
+      message:
+        $ref: '#/components/messages/Missing'
+components:
+  messages: {}
+`), "events.yaml", nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, ctx.Document)
+	assert.Equal(t, model.AsyncAPI26, ctx.Format)
+	assert.Equal(t, "2.6.0", ctx.Version)
+	assert.Equal(t, "asyncapi", ctx.SpecInfo.SpecType)
+	require.NotNil(t, ctx.Index)
+	require.NotNil(t, ctx.Root())
+	assert.NotEmpty(t, ctx.DocumentErrors())
 }
 
 func TestDetectFormatRejectsInvalidAsyncAPIMinor(t *testing.T) {
@@ -0,0 +1,110 @@
+// Copyright 2020-2026 Dave Shanley / Quobix / Princess Beef Heavy Industries, LLC
+// https://quobix.com/vacuum/ | https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libasyncapi"
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// newAsyncAPI2Context builds a context for an AsyncAPI 2.x document. libasyncapi
+// only models AsyncAPI 3.x, so the context carries the YAML tree and a rolodex
+// index for reference lookups; reference errors become document errors.
+func newAsyncAPI2Context(spec []byte, specFileName string, config *libasyncapi.DocumentConfiguration, version, format string) (*Context, error) {
+	if config == nil {
+		config = libasyncapi.NewDocumentConfiguration()
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(spec, &root); err != nil {
+		return nil, fmt.Errorf("%w: %v", libasyncapi.ErrInvalidYAML, err)
+	}
+
+	rolodex, err := buildAsyncAPI2Rolodex(&root, config)
+	if err != nil {
+		return nil, err
+	}
+	var indexErrors []error
+	if indexErr := rolodex.IndexTheRolodex(context.Background()); indexErr != nil {
+		indexErrors = append(indexErrors, indexErr)
+	}
+	if !config.SkipCircularReferenceCheck {
+		rolodex.CheckForCircularReferences()
+	}
+	indexErrors = append(indexErrors, rolodex.GetCaughtErrors()...)
+
+	return &Context{
+		Spec:         spec,
+		SpecFileName: specFileName,
+		SpecInfo:     BuildSpecInfo(spec, &root, version, format),
+		RootNode:     &root,
+		Version:      version,
+		Format:       format,
+		Index:        rolodex.GetRootIndex(),
+		Rolodex:      rolodex,
+		indexErrors:  indexErrors,
+	}, nil
+}
+
+func buildAsyncAPI2Rolodex(root *yaml.Node, config *libasyncapi.DocumentConfiguration) (*index.Rolodex, error) {
+	idxConfig := index.CreateClosedAPIIndexConfig()
+	idxConfig.SkipDocumentCheck = true
+	idxConfig.AvoidCircularReferenceCheck = config.SkipCircularReferenceCheck
+	idxConfig.ExtractRefsSequentially = config.ExtractRefsSequentially
+	idxConfig.BasePath = config.BasePath
+	if config.BaseURL != nil {
+		baseURL := *config.BaseURL
+		baseURL.Path = strings.TrimSuffix(baseURL.Path, "/")
+		idxConfig.BaseURL = &baseURL
+	}
+	if config.Logger != nil {
+		idxConfig.Logger = config.Logger
+	}
+
+	rolodex := index.NewRolodex(idxConfig)
+	rolodex.SetRootNode(root)
+
+	if idxConfig.BasePath != "" || config.AllowFileReferences {
+		baseDir, err := filepath.Abs(config.BasePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve base path: %w", err)
+		}
+		localFSConfig := index.LocalFSConfig{
+			BaseDirectory: baseDir,
+			IndexConfig:   idxConfig,
+		}
+		if config.LocalFS != nil {
+			localFSConfig.DirFS = config.LocalFS
+		}
+		localFS, err := index.NewLocalFSWithConfig(&localFSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local filesystem: %w", err)
+		}
+		idxConfig.AllowFileLookup = true
+		rolodex.AddLocalFS(baseDir, localFS)
+	}
+
+	if idxConfig.BaseURL != nil || config.AllowRemoteReferences {
+		remoteFS, err := index.NewRemoteFSWithConfig(idxConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create remote filesystem: %w", err)
+		}
+		if config.RemoteURLHandler != nil {
+			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
+		}
+		idxConfig.AllowRemoteLookup = true
+		location := "default"
+		if config.BaseURL != nil {
+			location = config.BaseURL.String()
+		}
+		rolodex.AddRemoteFS(location, remoteFS)
+	}
+	return rolodex, nil
+}
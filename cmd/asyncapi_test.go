@@ -43,7 +43,7 @@ func TestRejectAsyncAPIForOpenAPICommandRejectsMalformedAsyncAPI(t *testing.T) {
 	assert.Contains(t, err.Error(), "only supports OpenAPI")
 }
 
-func TestLintMultipleFilesReturnsInputErrorForAsyncAPI2WithFailSeverityNone(t *testing.T) {
+func TestLintMultipleFilesReturnsInputErrorForAsyncAPI1WithFailSeverityNone(t *testing.T) {
 	dir := t.TempDir()
 	openAPIPath := filepath.Join(dir, "openapi.yaml")
 	asyncAPIPath := filepath.Join(dir, "asyncapi.yaml")
@@ -55,11 +55,11 @@ info:
 paths: {}
 `)
 	writeTestFile(t, asyncAPIPath, `
-asyncapi: 2.6.0
+asyncapi: 1.2.0
 info:
   title: Legacy Events
   version: 1.0.0
-channels: {}
+topics: {}
 `)
 
 	cmd := GetLintCommand()
@@ -41,6 +41,7 @@ type ExecutionFlags struct {
 	ExtractReferencesFromExtensions bool
 	IgnoreCircularArrayRef          bool
 	IgnoreCircularPolymorphicRef    bool
+	ProfileRules                    bool
 }
 
 func BuildResultsWithDocCheckSkip(
@@ -218,6 +219,7 @@ func executeBuildResults(
 		exec.ExtractReferencesFromExtensions = executionFlags.ExtractReferencesFromExtensions
 		exec.IgnoreCircularArrayRef = executionFlags.IgnoreCircularArrayRef
 		exec.IgnoreCircularPolymorphicRef = executionFlags.IgnoreCircularPolymorphicRef
+		exec.ProfileRules = executionFlags.ProfileRules
 	}
 	if turboFlags != nil {
 		exec.TurboMode = turboFlags.TurboMode
@@ -227,7 +229,7 @@ func executeBuildResults(
 
 	resultSet := model.NewRuleResultSet(ruleset.Results)
 	resultSet.SortResultsByLineNumber()
-	resultSet.Results = utils.FilterIgnoredResultsPtrWithOptions(
+	resultSet.Results, resultSet.Suppressed = utils.PartitionIgnoredResultsPtrWithOptions(
 		resultSet.Results,
 		ignoredItems,
 		buildIgnoreFilterOptions(specBytes, ruleset, int(lookupTimeout/time.Millisecond)),
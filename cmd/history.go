@@ -0,0 +1,342 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/history"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/tui"
+	"github.com/spf13/cobra"
+)
+
+// HistoryRun is a recorded run, as rendered by the history command with --json.
+type HistoryRun struct {
+	CreatedAt   time.Time          `json:"createdAt"`
+	GitSHA      string             `json:"gitSha,omitempty"`
+	RulesetHash string             `json:"rulesetHash,omitempty"`
+	Score       int                `json:"score"`
+	Errors      int                `json:"errors"`
+	Warnings    int                `json:"warnings"`
+	Info        int                `json:"info"`
+	Hints       int                `json:"hints"`
+	Categories  []*HistoryCategory `json:"categories,omitempty"`
+}
+
+// HistoryCategory is the score of a single category in a recorded run.
+type HistoryCategory struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Score    int    `json:"score"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+}
+
+// HistorySpec is the recorded history of a single specification.
+type HistorySpec struct {
+	SpecPath string        `json:"specPath"`
+	Runs     int           `json:"runs"`
+	History  []*HistoryRun `json:"history"`
+}
+
+const historySparkline = "▁▂▃▄▅▆▇█"
+
+func GetHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "history [spec]",
+		Short:         "Show score, error and warning trends of recorded lint runs",
+		Long: `Show the trends of lint runs recorded with 'vacuum lint --record' or 'vacuum report --record'.
+
+Without a specification, every recorded API is listed with its latest score and how it has changed. With a
+specification, each recorded run is listed with the change since the previous run, followed by the trend of every
+category. Runs linted with a different ruleset than the run before them are marked with '*'.`,
+		Example: `  vacuum history
+  vacuum history specs/petstore.yaml
+  vacuum history specs/petstore.yaml --limit 50 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runHistory,
+	}
+	cmd.Flags().String("history-db", "", "Path to the history database (defaults to history.db in the vacuum config directory)")
+	cmd.Flags().IntP("limit", "l", 20, "Number of recent runs to show per API, 0 shows every run")
+	cmd.Flags().Bool("json", false, "Render the history as JSON")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	historyDBFlag, _ := cmd.Flags().GetString("history-db")
+	limitFlag, _ := cmd.Flags().GetInt("limit")
+	jsonFlag, _ := cmd.Flags().GetBool("json")
+	noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+
+	if noStyleFlag {
+		color.DisableColors()
+	}
+
+	store, err := OpenHistoryStore(historyDBFlag)
+	if err != nil {
+		tui.RenderErrorString("%s", err.Error())
+		return NewInputError("%s", err.Error())
+	}
+	defer store.Close()
+
+	var specs []*HistorySpec
+	if len(args) > 0 {
+		spec, specErr := loadHistorySpec(store, args[0], limitFlag)
+		if specErr != nil {
+			tui.RenderErrorString("%s", specErr.Error())
+			return NewInputError("%s", specErr.Error())
+		}
+		specs = append(specs, spec)
+	} else {
+		recorded, specsErr := store.Specs()
+		if specsErr != nil {
+			tui.RenderErrorString("%s", specsErr.Error())
+			return specsErr
+		}
+		for _, s := range recorded {
+			runs, runsErr := store.Runs(s.SpecPath, limitFlag)
+			if runsErr != nil {
+				tui.RenderErrorString("%s", runsErr.Error())
+				return runsErr
+			}
+			specs = append(specs, &HistorySpec{SpecPath: s.SpecPath, Runs: s.Runs, History: historyRuns(runs)})
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if jsonFlag {
+		if specs == nil {
+			specs = []*HistorySpec{}
+		}
+		encoded, _ := json.MarshalIndent(specs, "", "  ")
+		_, err = out.Write(append(encoded, '\n'))
+		return err
+	}
+
+	if len(args) > 0 {
+		renderHistorySpec(out, specs[0])
+	} else {
+		renderHistorySpecs(out, specs)
+	}
+	return nil
+}
+
+// loadHistorySpec loads the runs of a specification, which may be named by its path or by the key it is
+// recorded under.
+func loadHistorySpec(store *history.Store, spec string, limit int) (*HistorySpec, error) {
+	key, _ := history.SpecKey(spec)
+	for _, candidate := range []string{key, spec} {
+		runs, err := store.Runs(candidate, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			recorded, err := store.Specs()
+			if err != nil {
+				return nil, err
+			}
+			total := len(runs)
+			for _, s := range recorded {
+				if s.SpecPath == candidate {
+					total = s.Runs
+				}
+			}
+			return &HistorySpec{SpecPath: candidate, Runs: total, History: historyRuns(runs)}, nil
+		}
+	}
+	return nil, fmt.Errorf("no runs of '%s' have been recorded, record runs with 'vacuum lint --record'", spec)
+}
+
+func historyRuns(runs []*history.Run) []*HistoryRun {
+	converted := make([]*HistoryRun, 0, len(runs))
+	for _, run := range runs {
+		stats := run.Statistics
+		hr := &HistoryRun{
+			CreatedAt:   run.CreatedAt,
+			GitSHA:      run.GitSHA,
+			RulesetHash: run.RulesetHash,
+			Score:       stats.OverallScore,
+			Errors:      stats.TotalErrors,
+			Warnings:    stats.TotalWarnings,
+			Info:        stats.TotalInfo,
+			Hints:       stats.TotalHints,
+		}
+		for _, cat := range stats.CategoryStatistics {
+			hr.Categories = append(hr.Categories, historyCategory(cat))
+		}
+		converted = append(converted, hr)
+	}
+	return converted
+}
+
+func historyCategory(cat *reports.CategoryStatistic) *HistoryCategory {
+	return &HistoryCategory{
+		Id:       cat.CategoryId,
+		Name:     cat.CategoryName,
+		Score:    cat.Score,
+		Errors:   cat.Errors,
+		Warnings: cat.Warnings,
+	}
+}
+
+// renderHistorySpecs lists every recorded API with its latest score, errors and warnings.
+func renderHistorySpecs(out io.Writer, specs []*HistorySpec) {
+	if len(specs) == 0 {
+		fmt.Fprintf(out, "\n %sNo runs have been recorded, record runs with 'vacuum lint --record'%s\n\n",
+			color.ASCIIGrey, color.ASCIIReset)
+		return
+	}
+	width := len("API")
+	for _, s := range specs {
+		width = max(width, len(s.SpecPath))
+	}
+
+	fmt.Fprintf(out, "\n %s%-*s  %5s  %-11s  %-10s  %-10s  %-16s  %s%s\n", color.ASCIIBold,
+		width, "API", "Runs", "Score", "Errors", "Warnings", "Last run", "Trend", color.ASCIIReset)
+	for _, s := range specs {
+		latest := s.History[len(s.History)-1]
+		var previous *HistoryRun
+		if len(s.History) > 1 {
+			previous = s.History[len(s.History)-2]
+		}
+		fmt.Fprintf(out, " %-*s  %5d  %s  %s  %s  %s  %s\n", width, s.SpecPath, s.Runs,
+			historyCell(latest.Score, previous, func(r *HistoryRun) int { return r.Score }, true, 11),
+			historyCell(latest.Errors, previous, func(r *HistoryRun) int { return r.Errors }, false, 10),
+			historyCell(latest.Warnings, previous, func(r *HistoryRun) int { return r.Warnings }, false, 10),
+			latest.CreatedAt.Local().Format("2006-01-02 15:04"),
+			historyTrend(s.History, func(r *HistoryRun) int { return r.Score }))
+	}
+	fmt.Fprintln(out)
+}
+
+// renderHistorySpec lists the recorded runs of a single API, followed by the trend of every category.
+func renderHistorySpec(out io.Writer, spec *HistorySpec) {
+	fmt.Fprintf(out, "\n %s%s%s %s(%d runs recorded, showing %d)%s\n\n", color.ASCIIBold, spec.SpecPath, color.ASCIIReset,
+		color.ASCIIGrey, spec.Runs, len(spec.History), color.ASCIIReset)
+
+	fmt.Fprintf(out, " %s%-16s  %-8s  %-9s  %-11s  %-10s  %s%s\n", color.ASCIIBold,
+		"Date", "Commit", "Ruleset", "Score", "Errors", "Warnings", color.ASCIIReset)
+	rulesetChanged := false
+	for i, run := range spec.History {
+		var previous *HistoryRun
+		ruleset := shortHash(run.RulesetHash, 7)
+		if i > 0 {
+			previous = spec.History[i-1]
+			if previous.RulesetHash != run.RulesetHash {
+				ruleset += "*"
+				rulesetChanged = true
+			}
+		}
+		fmt.Fprintf(out, " %-16s  %-8s  %-9s  %s  %s  %s\n",
+			run.CreatedAt.Local().Format("2006-01-02 15:04"), shortHash(run.GitSHA, 7), ruleset,
+			historyCell(run.Score, previous, func(r *HistoryRun) int { return r.Score }, true, 11),
+			historyCell(run.Errors, previous, func(r *HistoryRun) int { return r.Errors }, false, 10),
+			historyCell(run.Warnings, previous, func(r *HistoryRun) int { return r.Warnings }, false, 0))
+	}
+	if rulesetChanged {
+		fmt.Fprintf(out, "\n %s* linted with a different ruleset than the run before%s\n", color.ASCIIGrey, color.ASCIIReset)
+	}
+
+	categories := historyCategoryTrends(spec.History)
+	if len(categories) > 0 {
+		width := len("Category")
+		for _, c := range categories {
+			width = max(width, len(c.name))
+		}
+		fmt.Fprintf(out, "\n %s%-*s  %5s  %-11s  %-10s  %-10s  %s%s\n", color.ASCIIBold,
+			width, "Category", "First", "Score", "Errors", "Warnings", "Trend", color.ASCIIReset)
+		for _, c := range categories {
+			first, latest := c.runs[0], c.runs[len(c.runs)-1]
+			var previous *HistoryRun
+			if len(c.runs) > 1 {
+				previous = c.runs[len(c.runs)-2]
+			}
+			fmt.Fprintf(out, " %-*s  %5d  %s  %s  %s  %s\n", width, c.name, first.Score,
+				historyCell(latest.Score, previous, func(r *HistoryRun) int { return r.Score }, true, 11),
+				historyCell(latest.Errors, previous, func(r *HistoryRun) int { return r.Errors }, false, 10),
+				historyCell(latest.Warnings, previous, func(r *HistoryRun) int { return r.Warnings }, false, 10),
+				historyTrend(c.runs, func(r *HistoryRun) int { return r.Score }))
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// historyCategoryTrend is the history of a single category, each run only holds the scores of the category.
+type historyCategoryTrend struct {
+	name string
+	runs []*HistoryRun
+}
+
+// historyCategoryTrends collects the history of each category, ordered by category name.
+func historyCategoryTrends(runs []*HistoryRun) []*historyCategoryTrend {
+	trends := make(map[string]*historyCategoryTrend)
+	for _, run := range runs {
+		for _, cat := range run.Categories {
+			trend, ok := trends[cat.Id]
+			if !ok {
+				trend = &historyCategoryTrend{name: cat.Name}
+				trends[cat.Id] = trend
+			}
+			trend.runs = append(trend.runs, &HistoryRun{Score: cat.Score, Errors: cat.Errors, Warnings: cat.Warnings})
+		}
+	}
+	ordered := make([]*historyCategoryTrend, 0, len(trends))
+	for _, trend := range trends {
+		ordered = append(ordered, trend)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].name < ordered[j].name })
+	return ordered
+}
+
+// historyCell renders a value and its change since the previous run, padded to width. Improvements are green and
+// regressions are red.
+func historyCell(value int, previous *HistoryRun, field func(*HistoryRun) int, higherIsBetter bool, width int) string {
+	cell := fmt.Sprintf("%d", value)
+	if previous == nil {
+		return fmt.Sprintf("%-*s", width, cell)
+	}
+	delta := value - field(previous)
+	if delta == 0 {
+		return fmt.Sprintf("%-*s", width, cell)
+	}
+	change := fmt.Sprintf("(%+d)", delta)
+	padding := strings.Repeat(" ", max(0, width-len(cell)-1-len(change)))
+	changeColor := color.ASCIIRed
+	if (delta > 0) == higherIsBetter {
+		changeColor = color.ASCIIGreen
+	}
+	return fmt.Sprintf("%s %s%s%s%s", cell, changeColor, change, color.ASCIIReset, padding)
+}
+
+// historyTrend renders a sparkline of a value over the runs, scaled from 0 to 100.
+func historyTrend(runs []*HistoryRun, field func(*HistoryRun) int) string {
+	bars := []rune(historySparkline)
+	var sb strings.Builder
+	for _, run := range runs {
+		value := min(max(field(run), 0), 100)
+		sb.WriteRune(bars[value*(len(bars)-1)/100])
+	}
+	return sb.String()
+}
+
+func shortHash(hash string, length int) string {
+	if hash == "" {
+		return "-"
+	}
+	if len(hash) > length {
+		return hash[:length]
+	}
+	return hash
+}
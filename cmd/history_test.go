@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/history"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func runHistoryForTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := GetHistoryCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	cmd.SetArgs(append([]string{"--no-style"}, args...))
+
+	var err error
+	stdout, _ := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	return stdout + b.String(), err
+}
+
+func TestGetHistoryCommand_RecordedLint(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	dbPath := filepath.Join(dir, "history.db")
+	specPath := filepath.Join(dir, "openapi.yaml")
+	otherPath := filepath.Join(dir, "other.yaml")
+	writeTestFile(t, specPath, baselineTestSpec)
+	writeTestFile(t, otherPath, baselineTestSpec)
+
+	_, _ = runLintForBaselineTest(t, "--record", "--history-db", dbPath, specPath)
+	_, _ = runLintForBaselineTest(t, "--record", "--history-db", dbPath, specPath, otherPath)
+
+	output, err := runHistoryForTest(t, "--history-db", dbPath, "--json")
+	require.NoError(t, err)
+	var specs []*HistorySpec
+	require.NoError(t, json.Unmarshal([]byte(output), &specs))
+	require.Len(t, specs, 2)
+	assert.Equal(t, filepath.ToSlash(specPath), specs[0].SpecPath)
+	assert.Equal(t, 2, specs[0].Runs)
+	assert.Equal(t, 1, specs[1].Runs)
+
+	latest := specs[0].History[1]
+	assert.NotZero(t, latest.Errors)
+	assert.Less(t, latest.Score, 100)
+	assert.Len(t, latest.RulesetHash, 64)
+	assert.NotEmpty(t, latest.Categories)
+	assert.Equal(t, specs[0].History[0].Score, latest.Score)
+
+	output, err = runHistoryForTest(t, "--history-db", dbPath, specPath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "(2 runs recorded, showing 2)")
+	assert.Contains(t, output, "Category")
+
+	output, err = runHistoryForTest(t, "--history-db", dbPath)
+	require.NoError(t, err)
+	assert.Contains(t, output, filepath.ToSlash(otherPath))
+
+	_, err = runHistoryForTest(t, "--history-db", dbPath, filepath.Join(dir, "nope.yaml"))
+	assert.ErrorContains(t, err, "have been recorded")
+}
+
+func TestGetHistoryCommand_Empty(t *testing.T) {
+	output, err := runHistoryForTest(t, "--history-db", filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	assert.Contains(t, output, "No runs have been recorded")
+}
+
+func TestHistoryCell(t *testing.T) {
+	color.DisableColors()
+	previous := &HistoryRun{Score: 70, Errors: 3}
+	score := func(r *HistoryRun) int { return r.Score }
+	errs := func(r *HistoryRun) int { return r.Errors }
+
+	assert.Equal(t, "80        ", historyCell(80, nil, score, true, 10))
+	assert.Equal(t, "80 (+10)  ", historyCell(80, previous, score, true, 10))
+	assert.Equal(t, "1 (-2)    ", historyCell(1, previous, errs, false, 10))
+	assert.Equal(t, "3         ", historyCell(3, previous, errs, false, 10))
+}
+
+func TestHistoryTrend(t *testing.T) {
+	runs := []*HistoryRun{{Score: 0}, {Score: 50}, {Score: 100}, {Score: 120}}
+	assert.Equal(t, "▁▄██", historyTrend(runs, func(r *HistoryRun) int { return r.Score }))
+}
+
+func TestGetVacuumReportCommand_Record(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "history.db")
+	cmd := GetVacuumReportCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{
+		"--no-style", "--junit", "--record", "--history-db", dbPath,
+		"../model/test_files/petstorev3.json",
+		filepath.Join(dir, "vacuum-report"),
+	})
+	require.NoError(t, cmd.Execute())
+
+	store, err := OpenHistoryStore(dbPath)
+	require.NoError(t, err)
+	defer store.Close()
+	specs, err := store.Specs()
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+	assert.Contains(t, specs[0].SpecPath, "petstorev3.json")
+}
+
+func TestProcessSingleFileOptimized_HistoryError(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	specPath := filepath.Join(dir, "openapi.yaml")
+	writeTestFile(t, specPath, baselineTestSpec)
+
+	store, err := history.Open(filepath.Join(dir, "history.db"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// a run that cannot be recorded is still linted.
+	result := ProcessSingleFileOptimized(specPath, &FileProcessingConfig{
+		Flags:           &LintFlags{TimeoutFlag: 5},
+		SelectedRuleset: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		History:         store,
+	})
+	require.NotNil(t, result)
+	assert.NoError(t, result.Error)
+	assert.Error(t, result.HistoryError)
+	assert.NotEmpty(t, result.Results)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/daveshanley/vacuum/statistics"
 	"github.com/daveshanley/vacuum/tui"
 	"github.com/daveshanley/vacuum/utils"
@@ -82,6 +83,17 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 				color.DisableColors()
 			}
 
+			profiler, profileErr := newRuleProfiler(cmd)
+			if profileErr != nil {
+				return profileErr
+			}
+			defer profiler.render()
+
+			workers, workersErr := readWorkersFlag(cmd)
+			if workersErr != nil {
+				return workersErr
+			}
+
 			if !noBannerFlag {
 				PrintBanner()
 			}
@@ -150,12 +162,116 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 				}
 			}
 
+			functionsFlag, _ := cmd.Flags().GetString("functions")
+			customFunctions, _ := LoadCustomFunctions(functionsFlag, silent)
+
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			turboFlag, _ := cmd.Flags().GetBool("turbo")
+			resolveAllRefsFlag, _ := cmd.Flags().GetBool("resolve-all-refs")
+			nestedRefsDocContextFlag, _ := cmd.Flags().GetBool("nested-refs-doc-context")
+
+			// Certificate/TLS configuration
+			certFile, _ := cmd.Flags().GetString("cert-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			caFile, _ := cmd.Flags().GetString("ca-file")
+			insecure, _ := cmd.Flags().GetBool("insecure")
+			allowPrivateNetworks, _ := cmd.Flags().GetBool("allow-private-networks")
+			allowHTTP, _ := cmd.Flags().GetBool("allow-http")
+			fetchTimeout, _ := cmd.Flags().GetInt("fetch-timeout")
+
+			httpFlags := &LintFlags{
+				CertFile:             certFile,
+				KeyFile:              keyFile,
+				CAFile:               caFile,
+				Insecure:             insecure,
+				AllowPrivateNetworks: allowPrivateNetworks,
+				AllowHTTP:            allowHTTP,
+				FetchTimeout:         fetchTimeout,
+			}
+			httpClientConfig, cfgErr := GetHTTPClientConfig(httpFlags)
+			if cfgErr != nil {
+				return fmt.Errorf("failed to resolve TLS configuration: %w", cfgErr)
+			}
+
+			fetchConfig, fetchCfgErr := GetFetchConfig(httpFlags)
+			if fetchCfgErr != nil {
+				return fmt.Errorf("failed to resolve fetch configuration: %w", fetchCfgErr)
+			}
+
+			// Custom rulesets are loaded once; built-in defaults are selected per
+			// document so AsyncAPI inputs do not run against OpenAPI defaults.
+			var selectedRS *rulesets.RuleSet
+			hardModeBoxRendered := false
+			if rulesetFlag != "" {
+				httpClient, clientErr := utils.CreateHTTPClientIfNeeded(httpClientConfig)
+				if clientErr != nil {
+					tui.RenderErrorString("Failed to create custom HTTP client: %s", clientErr.Error())
+					return clientErr
+				}
+
+				var rsErr error
+				selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, rulesets.BuildDefaultRuleSets(), remoteFlag, httpClient)
+				if rsErr != nil {
+					tui.RenderErrorString("Unable to load ruleset '%s': %s", rulesetFlag, rsErr.Error())
+					return rsErr
+				}
+
+				// Merge OWASP rules if hard mode is enabled
+				if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
+					tui.RenderStyledBox(HardModeWithCustomRuleset, tui.BoxTypeHard, false)
+					hardModeBoxRendered = true
+				}
+				if turboFlag {
+					rulesets.FilterRulesForTurbo(selectedRS)
+				}
+				tui.RenderInfo("Linting against %d rules: %s", len(selectedRS.Rules), selectedRS.DocumentationURI)
+			}
+
 			var processedFiles int
 
-			for _, specFile := range filesToProcess {
-				start := time.Now()
+			// every file gets its own default ruleset, hard mode and turbo change the ruleset they are given.
+			lintSpec := func(specFile string) *lintedHTMLSpec {
+				linted := &lintedHTMLSpec{lintedReportSpec: lintedReportSpec{start: time.Now()}}
+				linted.vacuumReport, linted.specBytes, _ = vacuum_report.BuildVacuumReportFromFile(specFile)
+
+				// if we have a pre-compiled report, there is nothing to lint.
+				if len(linted.specBytes) <= 0 || linted.vacuumReport != nil {
+					return linted
+				}
+
+				// Resolve base path for this specific file
+				var resolvedBase string
+				resolvedBase, linted.baseErr = ResolveBasePathForFile(specFile, baseFlag)
+				if linted.baseErr != nil {
+					return linted
+				}
+
+				linted.ruleSet = selectedRS
+				specFormat := ""
+				if rulesetFlag == "" {
+					linted.ruleSet, specFormat, linted.asyncDefault = prepareDefaultRuleSetForSpec(rulesets.BuildDefaultRuleSets(),
+						linted.specBytes, hardModeFlag, turboFlag)
+				}
+
+				linted.resultSet, linted.result, linted.err = executeBuildResults(linted.ruleSet, specFormat, linted.specBytes,
+					customFunctions, resolvedBase, remoteFlag, skipCheckFlag, time.Duration(timeoutFlag)*time.Second,
+					time.Duration(lookupTimeoutFlag)*time.Millisecond, httpClientConfig, fetchConfig, ignoredItems,
+					&TurboFlags{TurboMode: turboFlag}, &ExecutionFlags{
+						ResolveAllRefs:               resolveAllRefsFlag,
+						NestedRefsDocContext:         nestedRefsDocContextFlag,
+						SpecFilePath:                 specFile,
+						IgnoreCircularArrayRef:       ignoreArrayCircleRef,
+						IgnoreCircularPolymorphicRef: ignorePolymorphCircleRef,
+						ProfileRules:                 profiler.enabled(),
+					})
+				return linted
+			}
+
+			for i, linted := range lintInOrder(filesToProcess, workers, lintSpec) {
+				specFile := filesToProcess[i]
+				start := linted.start
 
-				vacuumReport, specBytes, _ := vacuum_report.BuildVacuumReportFromFile(specFile)
+				vacuumReport, specBytes := linted.vacuumReport, linted.specBytes
 				if len(specBytes) <= 0 {
 					tui.RenderErrorString("Failed to read specification: %v", specFile)
 					if isMultiFile {
@@ -169,66 +285,28 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 				var specIndex *index.SpecIndex
 				var specInfo *datamodel.SpecInfo
 				var stats *reports.ReportStatistics
-				resolveAllRefsFlag, _ := cmd.Flags().GetBool("resolve-all-refs")
-				nestedRefsDocContextFlag, _ := cmd.Flags().GetBool("nested-refs-doc-context")
+				var scoreModel *model.ScoreModel
 
 				// if we have a pre-compiled report, jump straight to the end and collect $500
 				if vacuumReport == nil {
 
-					functionsFlag, _ := cmd.Flags().GetString("functions")
-					customFunctions, _ := LoadCustomFunctions(functionsFlag, silent)
-
-					rulesetFlag, _ := cmd.Flags().GetString("ruleset")
-
-					// Certificate/TLS configuration
-					certFile, _ := cmd.Flags().GetString("cert-file")
-					keyFile, _ := cmd.Flags().GetString("key-file")
-					caFile, _ := cmd.Flags().GetString("ca-file")
-					insecure, _ := cmd.Flags().GetBool("insecure")
-					allowPrivateNetworks, _ := cmd.Flags().GetBool("allow-private-networks")
-					allowHTTP, _ := cmd.Flags().GetBool("allow-http")
-					fetchTimeout, _ := cmd.Flags().GetInt("fetch-timeout")
-
-					// Resolve base path for this specific file
-					resolvedBase, baseErr := ResolveBasePathForFile(specFile, baseFlag)
-					if baseErr != nil {
-						tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, baseErr.Error())
+					if linted.baseErr != nil {
+						tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, linted.baseErr.Error())
 						if isMultiFile {
 							continue
 						}
-						return fmt.Errorf("failed to resolve base path: %w", baseErr)
-					}
-
-					httpFlags := &LintFlags{
-						CertFile:             certFile,
-						KeyFile:              keyFile,
-						CAFile:               caFile,
-						Insecure:             insecure,
-						AllowPrivateNetworks: allowPrivateNetworks,
-						AllowHTTP:            allowHTTP,
-						FetchTimeout:         fetchTimeout,
-					}
-					httpClientConfig, cfgErr := GetHTTPClientConfig(httpFlags)
-					if cfgErr != nil {
-						return fmt.Errorf("failed to resolve TLS configuration: %w", cfgErr)
+						return fmt.Errorf("failed to resolve base path: %w", linted.baseErr)
 					}
 
-					fetchConfig, fetchCfgErr := GetFetchConfig(httpFlags)
-					if fetchCfgErr != nil {
-						return fmt.Errorf("failed to resolve fetch configuration: %w", fetchCfgErr)
+					if rulesetFlag == "" {
+						if hardModeFlag && !linted.asyncDefault && !hardModeBoxRendered {
+							tui.RenderStyledBox(HardModeEnabled, tui.BoxTypeHard, false)
+							hardModeBoxRendered = true
+						}
+						tui.RenderInfo("Linting against %d rules: %s", len(linted.ruleSet.Rules), linted.ruleSet.DocumentationURI)
 					}
 
-					turboFlag, _ := cmd.Flags().GetBool("turbo")
-					turboFlags := &TurboFlags{TurboMode: turboFlag}
-					resultSet, ruleset, err = BuildResultsWithDocCheckSkipAndExecutionFlags(false, hardModeFlag, rulesetFlag, specBytes, customFunctions,
-						resolvedBase, remoteFlag, skipCheckFlag, time.Duration(timeoutFlag)*time.Second, time.Duration(lookupTimeoutFlag)*time.Millisecond, httpClientConfig, fetchConfig, ignoredItems,
-						turboFlags, &ExecutionFlags{
-							ResolveAllRefs:               resolveAllRefsFlag,
-							NestedRefsDocContext:         nestedRefsDocContextFlag,
-							SpecFilePath:                 specFile,
-							IgnoreCircularArrayRef:       ignoreArrayCircleRef,
-							IgnoreCircularPolymorphicRef: ignorePolymorphCircleRef,
-						})
+					resultSet, ruleset, err = linted.resultSet, linted.result, linted.err
 					if err != nil {
 						tui.RenderError(err)
 						if isMultiFile {
@@ -236,6 +314,7 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 						}
 						return err
 					}
+					profiler.add(specFile, ruleset)
 					specIndex = ruleset.Index
 					specInfo = ruleset.SpecInfo
 
@@ -247,7 +326,12 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 						return NewInputError("failed to parse specification '%s'", specFile)
 					}
 					specInfo.Generated = time.Now()
-					stats = statistics.CreateReportStatistics(specIndex, specInfo, resultSet)
+					scoreModel, err = resolveExecutionScoreModel(ruleset)
+					if err != nil {
+						tui.RenderError(err)
+						return err
+					}
+					stats = statistics.CreateReportStatisticsWithScoreModel(specIndex, specInfo, resultSet, scoreModel)
 
 				} else {
 
@@ -260,6 +344,11 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 					)
 					specInfo = vacuumReport.SpecInfo
 					stats = vacuumReport.Statistics
+					scoreModel, err = ResolveScoreModel(nil)
+					if err != nil {
+						tui.RenderError(err)
+						return err
+					}
 
 					// Recalculate error/warning/info counts and score after filtering
 					if stats != nil && len(ignoredItems) > 0 {
@@ -268,36 +357,10 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 						stats.TotalInfo = resultSet.GetInfoCount()
 
 						// Recalculate category statistics
-						var catStats []*reports.CategoryStatistic
-						for _, cat := range model.RuleCategoriesOrdered {
-							var numIssues, numWarnings, numErrors, numInfo, numHints int
-							numIssues = len(resultSet.GetResultsByRuleCategory(cat.Id))
-							numWarnings = len(resultSet.GetWarningsByRuleCategory(cat.Id))
-							numErrors = len(resultSet.GetErrorsByRuleCategory(cat.Id))
-							numInfo = len(resultSet.GetInfoByRuleCategory(cat.Id))
-							numHints = len(resultSet.GetHintByRuleCategory(cat.Id))
-							numResults := len(resultSet.Results)
-							var score int
-							if numResults == 0 && numIssues == 0 {
-								score = 100 // perfect
-							} else if numResults > 0 {
-								score = 100 - (numIssues * 100 / numResults)
-							}
-							catStats = append(catStats, &reports.CategoryStatistic{
-								CategoryName: cat.Name,
-								CategoryId:   cat.Id,
-								NumIssues:    numIssues,
-								Warnings:     numWarnings,
-								Errors:       numErrors,
-								Info:         numInfo,
-								Hints:        numHints,
-								Score:        score,
-							})
-						}
-						stats.CategoryStatistics = catStats
+						stats.CategoryStatistics = statistics.CalculateCategoryStatistics(resultSet, scoreModel)
 
 						// Use the shared score calculation function
-						stats.OverallScore = statistics.CalculateQualityScore(resultSet)
+						stats.OverallScore = statistics.CalculateQualityScoreWithModel(resultSet, scoreModel)
 					}
 
 					specInfo.Generated = vacuumReport.Generated
@@ -390,35 +453,10 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 						stats.TotalInfo = resultSet.GetInfoCount()
 
 						// Recalculate category statistics
-						var catStats []*reports.CategoryStatistic
-						for _, cat := range model.RuleCategoriesOrdered {
-							numIssues := len(resultSet.GetResultsByRuleCategory(cat.Id))
-							numWarnings := len(resultSet.GetWarningsByRuleCategory(cat.Id))
-							numErrors := len(resultSet.GetErrorsByRuleCategory(cat.Id))
-							numInfo := len(resultSet.GetInfoByRuleCategory(cat.Id))
-							numHints := len(resultSet.GetHintByRuleCategory(cat.Id))
-							numResults := len(resultSet.Results)
-							var score int
-							if numResults == 0 && numIssues == 0 {
-								score = 100 // perfect
-							} else if numResults > 0 {
-								score = 100 - (numIssues * 100 / numResults)
-							}
-							catStats = append(catStats, &reports.CategoryStatistic{
-								CategoryName: cat.Name,
-								CategoryId:   cat.Id,
-								NumIssues:    numIssues,
-								Warnings:     numWarnings,
-								Errors:       numErrors,
-								Info:         numInfo,
-								Hints:        numHints,
-								Score:        score,
-							})
-						}
-						stats.CategoryStatistics = catStats
+						stats.CategoryStatistics = statistics.CalculateCategoryStatistics(resultSet, scoreModel)
 
 						// Recalculate overall score
-						stats.OverallScore = statistics.CalculateQualityScore(resultSet)
+						stats.OverallScore = statistics.CalculateQualityScoreWithModel(resultSet, scoreModel)
 					}
 				}
 
@@ -473,6 +511,16 @@ vacuum html-report --globbed-files "api/**/*.json"`,
 	cmd.Flags().Bool("ignore-polymorph-circle-ref", false, "Ignore circular polymorphic references")
 	cmd.Flags().String("globbed-files", "", "Glob pattern of files to process (e.g., 'specs/*.yaml')")
 	cmd.Flags().String("output-dir", "", "Directory to write report files to (default: current directory)")
+	addProfileFlag(cmd)
+	addWorkersFlag(cmd)
 
 	return cmd
 }
+
+// lintedHTMLSpec is a specification linted for an HTML report, or a pre-compiled vacuum report that needs no linting.
+type lintedHTMLSpec struct {
+	lintedReportSpec
+	vacuumReport *vacuum_report.VacuumReport
+	resultSet    *model.RuleResultSet
+	err          error
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// LoadBaselineFile loads the baseline named by --baseline. When the baseline is being updated a missing file is
+// not an error, a new baseline is created instead.
+func LoadBaselineFile(flags *LintFlags) (*utils.Baseline, error) {
+	if flags.BaselineFile == "" {
+		if flags.UpdateBaseline {
+			err := fmt.Errorf("--update-baseline requires a baseline file, set one with --baseline")
+			renderBaselineError(flags, err)
+			return nil, err
+		}
+		return nil, nil
+	}
+	baseline, err := utils.LoadBaseline(flags.BaselineFile)
+	if err != nil {
+		if flags.UpdateBaseline && errors.Is(err, os.ErrNotExist) {
+			return utils.NewBaseline(), nil
+		}
+		renderBaselineError(flags, err)
+		return nil, err
+	}
+	if !flags.SilentFlag && !flags.PipelineOutput && !flags.UpdateBaseline {
+		renderInfoMessage(fmt.Sprintf("Using baseline '%s' (%d accepted violations)",
+			flags.BaselineFile, baseline.Size()), flags.NoStyleFlag)
+	}
+	return baseline, nil
+}
+
+// applyBaseline records the results of a specification when the baseline is being updated, and then removes all
+// baselined results, returning the number removed.
+func applyBaseline(baseline *utils.Baseline, flags *LintFlags, specPath string, results []*model.RuleFunctionResult) ([]*model.RuleFunctionResult, int) {
+	if baseline == nil {
+		return results, 0
+	}
+	if flags.UpdateBaseline {
+		baseline.Record(specPath, results)
+	}
+	return baseline.Filter(specPath, results)
+}
+
+// applyBaselineToValues is the same as applyBaseline, for non-pointer results.
+func applyBaselineToValues(baseline *utils.Baseline, flags *LintFlags, specPath string, results []model.RuleFunctionResult) ([]model.RuleFunctionResult, int) {
+	if baseline == nil {
+		return results, 0
+	}
+	if flags.UpdateBaseline {
+		resultPtrs := make([]*model.RuleFunctionResult, len(results))
+		for i := range results {
+			resultPtrs[i] = &results[i]
+		}
+		baseline.Record(specPath, resultPtrs)
+	}
+	return baseline.FilterValues(specPath, results)
+}
+
+// finishBaseline writes the baseline when it is being updated, otherwise it lets the user know how many
+// violations were accepted and how many baselined violations have since been fixed.
+func finishBaseline(baseline *utils.Baseline, flags *LintFlags, baselined int) error {
+	if baseline == nil {
+		return nil
+	}
+	quiet := flags.SilentFlag || flags.PipelineOutput
+	if flags.UpdateBaseline {
+		if err := baseline.Save(flags.BaselineFile); err != nil {
+			renderBaselineError(flags, err)
+			return err
+		}
+		if !quiet {
+			renderInfoMessage(fmt.Sprintf("Baseline '%s' updated with %d violations",
+				flags.BaselineFile, baseline.Size()), flags.NoStyleFlag)
+		}
+		return nil
+	}
+	if quiet {
+		return nil
+	}
+	if baselined > 0 {
+		renderInfoMessage(fmt.Sprintf("%d violations were accepted by the baseline", baselined), flags.NoStyleFlag)
+	}
+	if resolved := baseline.Resolved(); resolved > 0 {
+		renderInfoMessage(fmt.Sprintf("%d baselined violations no longer occur, run with --update-baseline to remove them",
+			resolved), flags.NoStyleFlag)
+	}
+	return nil
+}
+
+func renderBaselineError(flags *LintFlags, err error) {
+	if !flags.SilentFlag {
+		fmt.Printf("%sError: %v%s\n\n", color.ASCIIRed, err, color.ASCIIReset)
+	}
+}
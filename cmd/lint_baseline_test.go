@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+const baselineTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza/{id}:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: ok`
+
+func runLintForBaselineTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	cmd.SetArgs(append([]string{"--no-banner", "--no-style"}, args...))
+
+	var err error
+	stdout, stderr := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	return stdout + stderr + b.String(), err
+}
+
+func TestGetLintCommand_Baseline(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	baselinePath := filepath.Join(dir, "baseline.yaml")
+	writeTestFile(t, specPath, baselineTestSpec)
+
+	// the path parameter is never defined, which is an error.
+	_, err := runLintForBaselineTest(t, specPath)
+	require.Error(t, err)
+
+	output, err := runLintForBaselineTest(t, "--baseline", baselinePath, "--update-baseline", specPath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Baseline '"+baselinePath+"' updated")
+
+	baseline, err := utils.LoadBaseline(baselinePath)
+	require.NoError(t, err)
+	assert.NotZero(t, baseline.Size())
+
+	output, err = runLintForBaselineTest(t, "--baseline", baselinePath, specPath)
+	require.NoError(t, err)
+	assert.Contains(t, output, "violations were accepted by the baseline")
+
+	// moving the existing violation down the file keeps it in the baseline, a new error path fails linting.
+	writeTestFile(t, specPath, strings.Replace(baselineTestSpec, "paths:\n", `paths:
+  /cake/{id}:
+    get:
+      operationId: getCake
+      responses:
+        "200":
+          description: ok
+`, 1))
+	_, err = runLintForBaselineTest(t, "--baseline", baselinePath, specPath)
+	require.Error(t, err)
+
+	output, err = runLintForBaselineTest(t, "-d", "--errors", "--no-clip", "--baseline", baselinePath, specPath)
+	require.Error(t, err)
+	assert.Contains(t, output, "/cake/{id}")
+	assert.NotContains(t, output, "/pizza/{id}")
+}
+
+func TestGetLintCommand_BaselineMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	pizzaPath := filepath.Join(dir, "pizza.yaml")
+	cakePath := filepath.Join(dir, "cake.yaml")
+	baselinePath := filepath.Join(dir, "baseline.yaml")
+	writeTestFile(t, pizzaPath, baselineTestSpec)
+	writeTestFile(t, cakePath, strings.ReplaceAll(baselineTestSpec, "pizza", "cake"))
+
+	_, err := runLintForBaselineTest(t, "--baseline", baselinePath, "--update-baseline", pizzaPath, cakePath)
+	require.NoError(t, err)
+
+	baseline, err := utils.LoadBaseline(baselinePath)
+	require.NoError(t, err)
+	files := make(map[string]bool)
+	for _, v := range baseline.Violations {
+		files[v.File] = true
+	}
+	assert.Len(t, files, 2)
+
+	_, err = runLintForBaselineTest(t, "--baseline", baselinePath, pizzaPath, cakePath)
+	require.NoError(t, err)
+}
+
+func TestGetLintCommand_BaselineErrors(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	writeTestFile(t, specPath, baselineTestSpec)
+
+	_, err := runLintForBaselineTest(t, "--update-baseline", specPath)
+	assert.ErrorContains(t, err, "--update-baseline requires a baseline file")
+
+	_, err = runLintForBaselineTest(t, "--baseline", filepath.Join(dir, "missing.yaml"), specPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
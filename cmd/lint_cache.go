@@ -0,0 +1,416 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+)
+
+// lintCache is an on-disk cache of lint results, so specifications that have not changed are not linted again
+// (pre-commit hooks run over the same specifications over and over). Results are keyed by a fingerprint of the
+// specification, the ruleset and its overrides, the settings of the execution, custom functions (and the files of
+// functions shipped with the ruleset) and the vacuum version. Each entry
+// also records a digest of every other file the rolodex loaded, and is only used if none of them have changed.
+//
+// The raw results of an execution are cached, ignore files, baselines and change filters are applied to them the
+// same as any other results.
+type lintCache struct {
+	dir       string
+	functions *docsPathDigest // custom functions can change without the ruleset changing
+}
+
+// lintCacheEntry is the cached result of linting a specification.
+type lintCacheEntry struct {
+	Key        string                      `json:"key"`
+	Files      []lintCacheFile             `json:"files,omitempty"`
+	SpecInfo   lintCacheSpecInfo           `json:"specInfo"`
+	Statistics *reports.ReportStatistics   `json:"statistics,omitempty"`
+	Rules      map[string]*model.Rule      `json:"rules,omitempty"`
+	Results    []*model.RuleFunctionResult `json:"results"`
+}
+
+// lintCacheFile is a file loaded by the rolodex, and the digest of its content when it was linted.
+type lintCacheFile struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+// lintCacheSpecInfo is the part of datamodel.SpecInfo that is not the specification itself.
+type lintCacheSpecInfo struct {
+	SpecType       string  `json:"type"`
+	NumLines       int     `json:"numLines"`
+	Version        string  `json:"version"`
+	VersionNumeric float32 `json:"versionNumeric"`
+	SpecFormat     string  `json:"format"`
+	SpecFileType   string  `json:"fileType"`
+}
+
+// newLintCache returns a cache in dir, or nil (no caching) when dir is empty.
+func newLintCache(dir, functionsFlag string) *lintCache {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil
+	}
+	return &lintCache{
+		dir:       dir,
+		functions: docsPathFingerprint(functionsFlag),
+	}
+}
+
+// applyRules runs an execution, unless its results are in the cache. The statistics of the document (paths,
+// schemas and so on) are returned with the result, a cached result has no index to count them from, see
+// lintStatistics. A nil cache always runs the execution.
+//
+// Executions that apply auto-fixes or profile rules always run, their results are not only the violations found.
+// So do executions of rulesets that ship remote functions without a pin, they can change without the ruleset
+// changing.
+func (c *lintCache) applyRules(execution *motor.RuleSetExecution,
+	options *motor.ExecutionOptions) (*motor.RuleSetExecutionResult, *reports.ReportStatistics) {
+	if c == nil || execution.ApplyAutoFixes || execution.ProfileRules || strings.Contains(execution.SpecFileName, "://") ||
+		!lintCacheFunctionsPinned(execution.RuleSet) {
+		return motor.ApplyRulesToRuleSetWithOptions(execution, options), nil
+	}
+
+	key := c.key(execution, options)
+	if entry, ok := c.read(key); ok {
+		return entry.replay(execution), entry.Statistics
+	}
+
+	result := motor.ApplyRulesToRuleSetWithOptions(execution, options)
+	var documentStats *reports.ReportStatistics
+	if result.Index != nil && result.SpecInfo != nil {
+		documentStats = statistics.CreateReportStatisticsWithScoreModel(result.Index, result.SpecInfo,
+			model.NewRuleResultSet(nil), nil)
+	}
+	if entry := newLintCacheEntry(key, execution, result, documentStats); entry != nil {
+		// a cache that cannot be written only means linting again next time.
+		_ = c.write(entry)
+	}
+	return result, documentStats
+}
+
+// lintStatistics builds the statistics of a result, using its index, or the document statistics of a cached result.
+func lintStatistics(result *motor.RuleSetExecutionResult, documentStats *reports.ReportStatistics,
+	results *model.RuleResultSet, scoreModel *model.ScoreModel) *reports.ReportStatistics {
+	if result != nil && result.Index != nil && result.SpecInfo != nil {
+		return statistics.CreateReportStatisticsWithScoreModel(result.Index, result.SpecInfo, results, scoreModel)
+	}
+	return statistics.RescoreReportStatistics(documentStats, results, scoreModel)
+}
+
+// key fingerprints everything that can change the results of an execution, other than the files it references.
+func (c *lintCache) key(execution *motor.RuleSetExecution, options *motor.ExecutionOptions) string {
+	customFunctions := make([]string, 0, len(execution.CustomFunctions))
+	for name := range execution.CustomFunctions {
+		customFunctions = append(customFunctions, name)
+	}
+	sort.Strings(customFunctions)
+
+	payload := map[string]any{
+		"vacuumVersion":   GetVersion(),
+		"vacuumCommit":    GetCommit(),
+		"spec":            fmt.Sprintf("%016x", xxhash.Sum64(execution.Spec)),
+		"specFileName":    execution.SpecFileName,
+		"specFormat":      execution.SpecFormat,
+		"base":            execution.Base,
+		"ruleset":         docsCanonicalRuleSet(execution.RuleSet),
+		"overrides":       lintCacheOverrides(execution.RuleSet),
+		"functions":       c.functions,
+		"customFunctions": customFunctions,
+		"functionFiles":   lintCacheFunctionFiles(execution.RuleSet),
+		"execution": map[string]any{
+			"allowLookup":          execution.AllowLookup,
+			"skipDocumentCheck":    execution.SkipDocumentCheck,
+			"buildDeepGraph":       execution.BuildDeepGraph,
+			"timeout":              execution.Timeout,
+			"nodeLookupTimeout":    execution.NodeLookupTimeout,
+			"ignoreArrayCircleRef": execution.IgnoreCircularArrayRef,
+			"ignorePolyCircleRef":  execution.IgnoreCircularPolymorphicRef,
+			"extRefs":              execution.ExtractReferencesFromExtensions,
+			"turbo":                execution.TurboMode,
+			"skipResolve":          execution.SkipResolve,
+			"skipCircularCheck":    execution.SkipCircularCheck,
+			"skipSchemaErrors":     execution.SkipSchemaErrors,
+		},
+		"options": options,
+	}
+	encoded, _ := json.Marshal(payload)
+	return fmt.Sprintf("%016x", xxhash.Sum64(encoded))
+}
+
+// lintCacheOverrides returns the overrides of a ruleset, and the directory their file patterns are relative to.
+func lintCacheOverrides(rs *rulesets.RuleSet) map[string]any {
+	if rs == nil || len(rs.Overrides) == 0 {
+		return nil
+	}
+	return map[string]any{
+		"overrides": rs.Overrides,
+		"base":      rs.OverridesBase,
+	}
+}
+
+// lintCacheFunctionFiles returns a digest of every function file shipped with a ruleset, or with the rulesets it
+// extends, keyed by function name. Remote functions are identified by the digest they are pinned to.
+func lintCacheFunctionFiles(rs *rulesets.RuleSet) map[string]string {
+	locations := rs.FunctionLocations()
+	if len(locations) == 0 {
+		return nil
+	}
+	pins := rs.FunctionPins()
+	files := make(map[string]string, len(locations))
+	for name, location := range locations {
+		if strings.Contains(location, "://") {
+			files[name] = "sha256:" + pins[name]
+			continue
+		}
+		files[name] = lintCacheFileHash(location)
+	}
+	return files
+}
+
+// lintCacheFunctionsPinned returns false if a ruleset ships a remote function that is not pinned to a digest.
+func lintCacheFunctionsPinned(rs *rulesets.RuleSet) bool {
+	pins := rs.FunctionPins()
+	for name, location := range rs.FunctionLocations() {
+		if strings.Contains(location, "://") && pins[name] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// read returns the entry cached for key, and false if there is none, or if a file it references has changed.
+func (c *lintCache) read(key string) (*lintCacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry lintCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	for _, file := range entry.Files {
+		if lintCacheFileHash(file.Path) != file.Hash {
+			return nil, false
+		}
+	}
+	return &entry, true
+}
+
+// write stores an entry, replacing the file in one step so a concurrent read never sees half an entry.
+func (c *lintCache) write(entry *lintCacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(entry.Key)
+	tmpFile, err := os.CreateTemp(c.dir, filepath.Base(path)+".tmp.")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err = tmpFile.Write(encoded); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Chmod(0o644); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (c *lintCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// newLintCacheEntry returns the entry to cache for a result, or nil if the result cannot be cached: it failed, or
+// it references a remote file that cannot be checked for changes.
+func newLintCacheEntry(key string, execution *motor.RuleSetExecution, result *motor.RuleSetExecutionResult,
+	documentStats *reports.ReportStatistics) *lintCacheEntry {
+	if len(result.Errors) > 0 || result.SpecInfo == nil {
+		return nil
+	}
+
+	entry := &lintCacheEntry{
+		Key: key,
+		SpecInfo: lintCacheSpecInfo{
+			SpecType:       result.SpecInfo.SpecType,
+			NumLines:       result.SpecInfo.NumLines,
+			Version:        result.SpecInfo.Version,
+			VersionNumeric: result.SpecInfo.VersionNumeric,
+			SpecFormat:     result.SpecInfo.SpecFormat,
+			SpecFileType:   result.SpecInfo.SpecFileType,
+		},
+		Statistics: documentStats,
+		Rules:      make(map[string]*model.Rule),
+		Results:    make([]*model.RuleFunctionResult, len(result.Results)),
+	}
+
+	files, local := lintCacheReferencedFiles(execution.SpecFileName, result.Index)
+	if !local {
+		return nil
+	}
+	for _, path := range files {
+		entry.Files = append(entry.Files, lintCacheFile{Path: path, Hash: lintCacheFileHash(path)})
+	}
+
+	for i := range result.Results {
+		res := result.Results[i]
+		var start, end reports.RangeItem
+		if res.StartNode != nil {
+			start = reports.RangeItem{Line: res.StartNode.Line, Char: res.StartNode.Column}
+		}
+		if res.EndNode != nil {
+			end = reports.RangeItem{Line: res.EndNode.Line, Char: res.EndNode.Column}
+		}
+		res.Range = reports.Range{Start: start, End: end}
+		if res.Rule != nil {
+			if res.RuleId == "" {
+				res.RuleId = res.Rule.Id
+			}
+			if res.RuleSeverity == "" {
+				res.RuleSeverity = res.Rule.Severity
+			}
+			entry.Rules[res.RuleId] = res.Rule
+		}
+		entry.Results[i] = &res
+	}
+	return entry
+}
+
+// replay rebuilds the result of an execution from a cached entry, the nodes of each result only carry the line
+// and column of the violation, the same as results loaded from a report.
+func (e *lintCacheEntry) replay(execution *motor.RuleSetExecution) *motor.RuleSetExecutionResult {
+	specBytes := execution.Spec
+	result := &motor.RuleSetExecutionResult{
+		RuleSetExecution: execution,
+		Results:          make([]model.RuleFunctionResult, len(e.Results)),
+		SpecInfo: &datamodel.SpecInfo{
+			SpecType:       e.SpecInfo.SpecType,
+			NumLines:       e.SpecInfo.NumLines,
+			Version:        e.SpecInfo.Version,
+			VersionNumeric: e.SpecInfo.VersionNumeric,
+			SpecFormat:     e.SpecInfo.SpecFormat,
+			SpecFileType:   e.SpecInfo.SpecFileType,
+			SpecBytes:      &specBytes,
+		},
+	}
+	adjusted := make(map[string]*model.Rule)
+	for i, res := range e.Results {
+		if res == nil {
+			continue
+		}
+		replayed := *res
+		replayed.StartNode = &yaml.Node{Line: res.Range.Start.Line, Column: res.Range.Start.Char}
+		replayed.EndNode = &yaml.Node{Line: res.Range.End.Line, Column: res.Range.End.Char}
+		if execution.RuleSet != nil {
+			replayed.Rule = execution.RuleSet.Rules[res.RuleId]
+		}
+		if replayed.Rule == nil {
+			replayed.Rule = e.Rules[res.RuleId]
+		}
+		replayed.Rule = replayedRule(adjusted, replayed.Rule, res.RuleSeverity)
+		result.Results[i] = replayed
+	}
+	return result
+}
+
+// replayedRule returns the rule of a replayed result with the severity it was reported with, overrides change
+// the severity of a rule per file and path. Every result of a rule with the same severity shares one copy.
+func replayedRule(adjusted map[string]*model.Rule, rule *model.Rule, severity string) *model.Rule {
+	if rule == nil || severity == "" || severity == rule.Severity {
+		return rule
+	}
+	key := rule.Id + "|" + severity
+	if copied, ok := adjusted[key]; ok {
+		return copied
+	}
+	copied := *rule
+	copied.Severity = severity
+	adjusted[key] = &copied
+	return &copied
+}
+
+// lintCacheReferencedFiles returns every file loaded or referenced by the rolodex of an index, other than the
+// specification itself, and false if any of them are remote. Files that could not be found are included, creating
+// them changes the results too.
+func lintCacheReferencedFiles(specFileName string, specIndex *index.SpecIndex) ([]string, bool) {
+	if specIndex == nil || specIndex.GetRolodex() == nil {
+		return nil, true
+	}
+	rolodex := specIndex.GetRolodex()
+
+	seen := map[string]bool{specFileName: true}
+	var files []string
+	add := func(location string) bool {
+		location, _, _ = strings.Cut(location, "#")
+		if location == "" || seen[location] {
+			return true
+		}
+		if strings.Contains(location, "://") {
+			return false
+		}
+		seen[location] = true
+		files = append(files, location)
+		return true
+	}
+
+	indexes := append([]*index.SpecIndex{rolodex.GetRootIndex()}, rolodex.GetIndexes()...)
+	for _, idx := range indexes {
+		if idx == nil {
+			continue
+		}
+		if !add(idx.GetSpecAbsolutePath()) {
+			return nil, false
+		}
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			if ref != nil && !add(ref.FullDefinition) {
+				return nil, false
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, true
+}
+
+// lintCacheFileHash returns the digest of a file, a file that cannot be read has no digest.
+func lintCacheFileHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%016x", xxhash.Sum64(data))
+}
+
+// addCacheDirFlag adds --cache-dir to a command that lints specifications.
+func addCacheDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String("cache-dir", "", "Directory to cache results in, specifications (and the files they reference) that have not changed are not linted again")
+}
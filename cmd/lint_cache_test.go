@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func writeCacheTestSpec(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "openapi.yaml")
+	schemas := filepath.Join(dir, "schemas.yaml")
+	writeTestFile(t, spec, `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: './schemas.yaml#/Pet'
+`)
+	writeTestFile(t, schemas, `
+Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`)
+	return spec, schemas
+}
+
+func newCacheTestExecution(t *testing.T, spec string) *motor.RuleSetExecution {
+	specBytes, err := os.ReadFile(spec)
+	require.NoError(t, err)
+	return &motor.RuleSetExecution{
+		RuleSet:      rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		Spec:         specBytes,
+		SpecFileName: spec,
+		Base:         filepath.Dir(spec),
+		SilenceLogs:  true,
+	}
+}
+
+// sortedCacheTestLines sorts the lines of output, rules with the same number of violations are rendered in any order.
+func sortedCacheTestLines(output string) []string {
+	lines := strings.Split(output, "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestLintCache_ApplyRules(t *testing.T) {
+	spec, schemas := writeCacheTestSpec(t)
+	cache := newLintCache(t.TempDir(), "")
+
+	execution := newCacheTestExecution(t, spec)
+	linted, lintedStats := cache.applyRules(execution, nil)
+	require.NotNil(t, linted.Index)
+	require.NotEmpty(t, linted.Results)
+	require.NotNil(t, lintedStats)
+
+	entries, err := filepath.Glob(filepath.Join(cache.dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	execution = newCacheTestExecution(t, spec)
+	replayed, replayedStats := cache.applyRules(execution, nil)
+	assert.Nil(t, replayed.Index, "the results should be replayed, not linted")
+	require.NotNil(t, replayed.SpecInfo)
+	assert.Equal(t, linted.SpecInfo.SpecFormat, replayed.SpecInfo.SpecFormat)
+	assert.Equal(t, lintedStats, replayedStats)
+
+	require.Len(t, replayed.Results, len(linted.Results))
+	for i := range linted.Results {
+		assert.Equal(t, linted.Results[i].Message, replayed.Results[i].Message)
+		assert.Equal(t, linted.Results[i].Path, replayed.Results[i].Path)
+		assert.Equal(t, linted.Results[i].StartNode.Line, replayed.Results[i].StartNode.Line)
+		assert.Same(t, execution.RuleSet.Rules[linted.Results[i].RuleId], replayed.Results[i].Rule)
+	}
+
+	lintedSet := model.NewRuleResultSet(linted.Results)
+	replayedSet := model.NewRuleResultSet(replayed.Results)
+	assert.Equal(t, lintStatistics(linted, lintedStats, lintedSet, nil),
+		lintStatistics(replayed, replayedStats, replayedSet, nil))
+
+	// a referenced file that changes means the specification is linted again.
+	writeTestFile(t, schemas, `
+Pet:
+  type: object
+`)
+	relinted, _ := cache.applyRules(newCacheTestExecution(t, spec), nil)
+	assert.NotNil(t, relinted.Index)
+}
+
+func TestLintCache_ApplyRules_MissingFile(t *testing.T) {
+	spec, schemas := writeCacheTestSpec(t)
+	require.NoError(t, os.Remove(schemas))
+	cache := newLintCache(t.TempDir(), "")
+
+	linted, _ := cache.applyRules(newCacheTestExecution(t, spec), nil)
+	require.NotNil(t, linted.Index)
+	replayed, _ := cache.applyRules(newCacheTestExecution(t, spec), nil)
+	assert.Nil(t, replayed.Index)
+
+	// creating a file that could not be found changes the results.
+	writeTestFile(t, schemas, `
+Pet:
+  type: object
+`)
+	relinted, _ := cache.applyRules(newCacheTestExecution(t, spec), nil)
+	assert.NotNil(t, relinted.Index)
+}
+
+func TestLintCache_ApplyRules_Bypassed(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+
+	var cache *lintCache
+	result, stats := cache.applyRules(newCacheTestExecution(t, spec), nil)
+	assert.NotNil(t, result.Index)
+	assert.Nil(t, stats)
+
+	// fixes change the specification, they always run.
+	cache = newLintCache(t.TempDir(), "")
+	execution := newCacheTestExecution(t, spec)
+	execution.ApplyAutoFixes = true
+	result, _ = cache.applyRules(execution, nil)
+	assert.NotNil(t, result.Index)
+	entries, err := filepath.Glob(filepath.Join(cache.dir, "*.json"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLintCache_Key(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+	cache := newLintCache(t.TempDir(), "")
+
+	execution := newCacheTestExecution(t, spec)
+	key := cache.key(execution, nil)
+	assert.Equal(t, key, cache.key(newCacheTestExecution(t, spec), nil))
+
+	execution.TurboMode = true
+	assert.NotEqual(t, key, cache.key(execution, nil))
+
+	execution = newCacheTestExecution(t, spec)
+	delete(execution.RuleSet.Rules, "operation-operationId")
+	assert.NotEqual(t, key, cache.key(execution, nil))
+
+	execution = newCacheTestExecution(t, spec)
+	execution.Spec = append(execution.Spec, []byte("\n# changed\n")...)
+	assert.NotEqual(t, key, cache.key(execution, nil))
+
+	assert.NotEqual(t, key, cache.key(newCacheTestExecution(t, spec), newMotorExecutionOptions(true, false)))
+}
+
+func TestLintCache_Key_OverridesAndFunctionFiles(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+	cache := newLintCache(t.TempDir(), "")
+
+	dir := t.TempDir()
+	extended := filepath.Join(dir, "extended.yaml")
+	function := filepath.Join(dir, "functions", "check.js")
+	writeTestFile(t, extended, "functions: [check]\nrules: {}\n")
+	require.NoError(t, os.Mkdir(filepath.Dir(function), 0o755))
+	writeTestFile(t, function, "function check() { return [] }")
+
+	generate := func() *motor.RuleSetExecution {
+		rs, err := rulesets.CreateRuleSetFromData([]byte(`extends:
+  - [vacuum:oas, recommended]
+  - ` + extended + `
+rules: {}`))
+		require.NoError(t, err)
+		rs.Location = filepath.Join(dir, "ruleset.yaml")
+		execution := newCacheTestExecution(t, spec)
+		execution.RuleSet = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+		return execution
+	}
+
+	execution := generate()
+	key := cache.key(execution, nil)
+	assert.Equal(t, key, cache.key(generate(), nil))
+
+	// a function shipped by an extended ruleset that changes changes the results.
+	writeTestFile(t, function, "function check() { return [{message: 'changed'}] }")
+	changed := cache.key(generate(), nil)
+	assert.NotEqual(t, key, changed)
+
+	execution = generate()
+	execution.RuleSet.Overrides = []*rulesets.RuleSetOverride{
+		{Files: []string{"openapi.yaml"}, Rules: map[string]interface{}{"operation-operationId": "off"}},
+	}
+	assert.NotEqual(t, changed, cache.key(execution, nil))
+	assert.True(t, lintCacheFunctionsPinned(execution.RuleSet))
+}
+
+func TestGetLintCommand_CacheDir(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+	other := filepath.Join(filepath.Dir(spec), "other.yaml")
+	writeTestFile(t, other, `
+openapi: 3.1.0
+info:
+  title: Other
+  version: 1.0.0
+paths: {}
+`)
+	cacheDir := t.TempDir()
+
+	linted, err := runLintForBaselineTest(t, "-d", "--no-clip", "--fail-severity", "none", "--cache-dir", cacheDir, spec)
+	require.NoError(t, err)
+	assert.Contains(t, linted, "operation-operationId")
+
+	replayed, err := runLintForBaselineTest(t, "-d", "--no-clip", "--fail-severity", "none", "--cache-dir", cacheDir, spec)
+	require.NoError(t, err)
+	assert.Equal(t, sortedCacheTestLines(linted), sortedCacheTestLines(replayed))
+
+	linted, err = runLintForBaselineTest(t, "-d", "--no-clip", "--fail-severity", "none", "--cache-dir", cacheDir, spec, other)
+	require.NoError(t, err)
+	replayed, err = runLintForBaselineTest(t, "-d", "--no-clip", "--fail-severity", "none", "--cache-dir", cacheDir, spec, other)
+	require.NoError(t, err)
+	assert.Equal(t, sortedCacheTestLines(linted), sortedCacheTestLines(replayed))
+
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestGetVacuumReportCommand_CacheDir(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+	cacheDir := t.TempDir()
+
+	report := func() *vacuum_report.VacuumReport {
+		cmd := GetVacuumReportCommand()
+		cmd.SetArgs([]string{"-o", "--no-style", "--cache-dir", cacheDir, spec})
+		var cmdErr error
+		stdout, _ := captureOSStreams(t, func() {
+			cmdErr = cmd.Execute()
+		})
+		require.NoError(t, cmdErr)
+		var vr vacuum_report.VacuumReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &vr))
+		return &vr
+	}
+
+	linted := report()
+	replayed := report()
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.Equal(t, linted.Statistics, replayed.Statistics)
+	assert.Equal(t, linted.SpecInfo.SpecFormat, replayed.SpecInfo.SpecFormat)
+	require.Len(t, replayed.ResultSet.Results, len(linted.ResultSet.Results))
+	for i := range linted.ResultSet.Results {
+		assert.Equal(t, linted.ResultSet.Results[i].Message, replayed.ResultSet.Results[i].Message)
+		assert.Equal(t, linted.ResultSet.Results[i].Range, replayed.ResultSet.Results[i].Range)
+	}
+}
+
+func TestLintCache_ApplyRules_SeverityOverride(t *testing.T) {
+	spec, _ := writeCacheTestSpec(t)
+	cache := newLintCache(t.TempDir(), "")
+
+	generate := func() *motor.RuleSetExecution {
+		execution := newCacheTestExecution(t, spec)
+		execution.RuleSet.Overrides = []*rulesets.RuleSetOverride{
+			{Files: []string{spec}, Rules: map[string]interface{}{"operation-operationId": model.SeverityHint}},
+		}
+		return execution
+	}
+
+	linted, lintedStats := cache.applyRules(generate(), nil)
+	require.NotNil(t, linted.Index)
+	replayed, replayedStats := cache.applyRules(generate(), nil)
+	require.Nil(t, replayed.Index, "the results should be replayed, not linted")
+
+	found := false
+	require.Len(t, replayed.Results, len(linted.Results))
+	for i := range linted.Results {
+		assert.Equal(t, linted.Results[i].Rule.Severity, replayed.Results[i].Rule.Severity)
+		assert.Equal(t, linted.Results[i].RuleSeverity, replayed.Results[i].RuleSeverity)
+		if replayed.Results[i].RuleId == "operation-operationId" {
+			found = true
+			assert.Equal(t, model.SeverityHint, replayed.Results[i].Rule.Severity)
+		}
+	}
+	assert.True(t, found)
+
+	lintedSet := model.NewRuleResultSet(linted.Results)
+	replayedSet := model.NewRuleResultSet(replayed.Results)
+	assert.Equal(t, lintedSet.GetErrorCount(), replayedSet.GetErrorCount())
+	assert.Equal(t, lintedSet.GetHintCount(), replayedSet.GetHintCount())
+	assert.Equal(t, lintStatistics(linted, lintedStats, lintedSet, nil),
+		lintStatistics(replayed, replayedStats, replayedSet, nil))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"iter"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+)
+
+// catalogRuleSet returns the ruleset with the catalog rules to run, the built-in catalog rules are used unless a
+// custom ruleset is supplied (it can extend vacuum:catalog to use them).
+func catalogRuleSet(selected *rulesets.RuleSet) *rulesets.RuleSet {
+	if selected != nil {
+		return selected
+	}
+	return rulesets.GenerateCatalogRuleSet()
+}
+
+type catalogFile struct {
+	config *FileProcessingConfig
+	linted *lintedFile
+	failed *FileProcessingResult
+}
+
+// lintCatalog lints files as a catalog. Every file is linted on its own first, then the catalog rules of ruleSet run
+// against all of them at once, so violations across files (two APIs with the same operationId) are found. The
+// catalog violations are added to the file they occur in, with the overrides of that file applied, before the
+// results of each file are filtered, counted and yielded in the order the files were given.
+//
+// Every document is kept in memory until the catalog has been linted, which is the price of seeing them all at once.
+func lintCatalog(files []string, workers int, newConfig func() *FileProcessingConfig, ruleSet *rulesets.RuleSet,
+	customFunctions map[string]model.RuleFunction) iter.Seq2[int, *FileProcessingResult] {
+	return func(yield func(int, *FileProcessingResult) bool) {
+		lint := func(fileName string) catalogFile {
+			config := newConfig()
+			linted, failed := lintSingleFile(fileName, config)
+			return catalogFile{config: config, linted: linted, failed: failed}
+		}
+
+		linted := make([]catalogFile, len(files))
+		next := 0
+		defer func() {
+			// files that were never yielded still hold their executions.
+			for _, f := range linted[next:] {
+				if f.linted != nil {
+					f.linted.result.ReleaseOwnedResources()
+				}
+			}
+		}()
+
+		var documents []*model.CatalogDocument
+		var positions []int
+		for i, f := range lintInOrder(files, workers, lint) {
+			linted[i] = f
+			if f.linted != nil {
+				documents = append(documents, f.linted.result.CatalogDocument(files[i]))
+				positions = append(positions, i)
+			}
+		}
+
+		catalog := motor.ApplyCatalogRules(&motor.CatalogExecution{
+			RuleSet:         ruleSet,
+			Documents:       documents,
+			CustomFunctions: customFunctions,
+		})
+		for i, results := range catalog.Results {
+			linted[positions[i]].linted.result.AddCatalogResults(results)
+		}
+
+		for i, f := range linted {
+			next = i + 1
+			result := f.failed
+			if result == nil {
+				result = finishLintedFile(f.linted, f.config)
+			}
+			if !yield(i, result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func writeCatalogTestSpecs(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	pets := filepath.Join(dir, "pets.yaml")
+	stores := filepath.Join(dir, "stores.yaml")
+	writeTestFile(t, pets, `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listThings
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Error:
+      type: object
+`)
+	writeTestFile(t, stores, `
+openapi: 3.1.0
+info:
+  title: Stores
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listThings
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Error:
+      type: string
+`)
+	return pets, stores
+}
+
+func TestGetLintCommand_Catalog(t *testing.T) {
+	pets, stores := writeCatalogTestSpecs(t)
+
+	output, _ := runLintForBaselineTest(t, "-d", "--no-clip", pets, stores)
+	assert.NotContains(t, output, "catalog-")
+
+	output, _ = runLintForBaselineTest(t, "-d", "--no-clip", "--catalog", "--workers", "2", pets, stores)
+	assert.Contains(t, output, "pets.yaml:8:7")
+	assert.Contains(t, output, "the operationId `listThings` is also used by `"+stores+"`")
+	assert.Contains(t, output, "`GET /pets` is also defined by `"+pets+"`")
+	assert.Contains(t, output, "the schema `Error` is different to the copy in `"+stores+"`")
+	assert.Contains(t, output, "catalog-schema-consistent")
+
+	// a single file is a catalog of one, there is nothing to compare it to.
+	output, _ = runLintForBaselineTest(t, "-d", "--no-clip", "--catalog", pets)
+	assert.NotContains(t, output, "catalog-")
+}
+
+func TestGetLintCommand_CatalogRuleset(t *testing.T) {
+	pets, stores := writeCatalogTestSpecs(t)
+	ruleset := filepath.Join(t.TempDir(), "ruleset.yaml")
+	writeTestFile(t, ruleset, `
+extends: [[vacuum:catalog, all]]
+rules:
+  catalog-operation-id-unique: off
+  catalog-path-conflict: error
+`)
+
+	cmd := GetLintCommand()
+	registerPersistentFlags(cmd)
+	cmd.SetArgs([]string{"--no-banner", "--no-style", "-d", "--no-clip", "--catalog", "--ruleset", ruleset, pets, stores})
+
+	var err error
+	output, _ := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.Error(t, err)
+	assert.NotContains(t, output, "catalog-operation-id-unique")
+	assert.Contains(t, output, "is also defined by")
+	assert.Contains(t, output, "is different to the copy in")
+}
+
+func TestGetLintCommand_CatalogBaseline(t *testing.T) {
+	pets, stores := writeCatalogTestSpecs(t)
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+
+	_, err := runLintForBaselineTest(t, "--catalog", "--baseline", baseline, "--update-baseline", pets, stores)
+	require.NoError(t, err)
+
+	output, err := runLintForBaselineTest(t, "-d", "--no-clip", "--catalog", "--baseline", baseline, pets, stores)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "is also used by")
+}
+
+func TestGetLintCommand_CatalogOverrides(t *testing.T) {
+	pets, stores := writeCatalogTestSpecs(t)
+	ruleset := filepath.Join(filepath.Dir(pets), "ruleset.yaml")
+	writeTestFile(t, ruleset, `
+extends: [[vacuum:catalog, all]]
+overrides:
+  - files: [pets.yaml]
+    rules:
+      catalog-operation-id-unique: off
+`)
+
+	cmd := GetLintCommand()
+	registerPersistentFlags(cmd)
+	cmd.SetArgs([]string{"--no-banner", "--no-style", "-d", "--no-clip", "--catalog", "--ruleset", ruleset, pets, stores})
+
+	output, _ := captureOSStreams(t, func() {
+		_ = cmd.Execute()
+	})
+	// the operationId is only reported against the file the override does not match.
+	assert.NotContains(t, output, "is also used by `"+stores+"`")
+	assert.Contains(t, output, "is also used by `"+pets+"`")
+	assert.Contains(t, output, "is also defined by")
+}
@@ -20,12 +20,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/functions/autofix"
 	"github.com/daveshanley/vacuum/logging"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
-	"github.com/daveshanley/vacuum/statistics"
 	"github.com/daveshanley/vacuum/tui"
 	"github.com/daveshanley/vacuum/utils"
 )
@@ -67,7 +67,17 @@ func GetLintCommand() *cobra.Command {
 	cmd.Flags().String("globbed-files", "", "Glob pattern of files to lint")
 	cmd.Flags().Bool("fix", false, "Apply auto-fixes for rules that support it")
 	cmd.Flags().String("fix-file", "", "Write fixes to specified file instead of overwriting original")
+	cmd.Flags().String("fix-overlay", "", "Write auto-fixes to an OpenAPI Overlay file instead of changing the specification")
 	cmd.Flags().BoolP("abs-paths", "", false, "If --details(-d) flag is active then output absolute paths")
+	cmd.Flags().String("baseline", "", "Path to a baseline file, violations recorded in the baseline do not fail linting")
+	cmd.Flags().Bool("update-baseline", false, "Record all current violations in the --baseline file")
+	cmd.Flags().Bool("record", false, "Record the score and statistics of the run in the history database")
+	cmd.Flags().String("history-db", "", "Path to the history database (defaults to history.db in the vacuum config directory)")
+	cmd.Flags().Bool("catalog", false, "Lint multiple files as one catalog, checking for conflicts and inconsistencies across them")
+	cmd.Flags().String("since", "", "Lint the specifications changed since a git ref, reporting only the violations the changes introduced")
+	addCacheDirFlag(cmd)
+	addProfileFlag(cmd)
+	addWorkersFlag(cmd)
 	// base, remote, skip-check, timeout, ruleset, functions, time, hard-mode are inherited from root as persistent flags
 	// cert-file, key-file, ca-file, insecure, debug are inherited from root as persistent flags
 	// ext-refs is inherited from root as a persistent flag
@@ -77,6 +87,12 @@ func GetLintCommand() *cobra.Command {
 
 func runLint(cmd *cobra.Command, args []string) error {
 	flags := ReadLintFlags(cmd)
+	if err := checkProfileFormat(flags.ProfileFlag); err != nil {
+		return err
+	}
+	if err := checkWorkers(flags.WorkersFlag); err != nil {
+		return err
+	}
 
 	SetupVacuumEnvironment(flags)
 
@@ -105,6 +121,31 @@ func runLint(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// with --since, only the specifications changed since the ref are linted. Without any files, every
+	// specification in the repository is a candidate.
+	var since *sinceRevision
+	if flags.SinceFlag != "" {
+		if flags.OriginalFlag != "" || flags.ChangesFlag != "" {
+			return NewInputError("--since cannot be used with --original or --changes")
+		}
+		if flags.CatalogFlag {
+			return NewInputError("--since cannot be used with --catalog")
+		}
+		since, err = newSinceRevision(flags.SinceFlag, filesToLint)
+		if err == nil {
+			filesToLint, err = since.changedSpecs(filesToLint)
+		}
+		if err != nil {
+			return NewInputError("unable to find the specifications changed since '%s': %v", flags.SinceFlag, err)
+		}
+		if len(filesToLint) < 1 {
+			if !flags.SilentFlag {
+				fmt.Printf(" no specifications have changed since '%s'\n\n", flags.SinceFlag)
+			}
+			return nil
+		}
+	}
+
 	if len(filesToLint) < 1 {
 		fmt.Printf("🚨 %s%sPlease supply an OpenAPI or AsyncAPI specification to lint%s\n\n",
 			color.ASCIIBold, color.ASCIIRed, color.ASCIIReset)
@@ -113,18 +154,48 @@ func runLint(cmd *cobra.Command, args []string) error {
 
 	// for multiple files, run each one and combine results
 	if len(filesToLint) > 1 {
-		return runMultipleFiles(cmd, filesToLint)
+		if flags.FixOverlayFlag != "" {
+			return NewInputError("--fix-overlay can only be used when linting a single file")
+		}
+		if flags.FixFileFlag != "" {
+			return NewInputError("--fix-file can only be used when linting a single file, files linted together are fixed in place")
+		}
+		return runMultipleFiles(cmd, filesToLint, since)
 	}
 
 	// single file processing continues below
 	fileName := filesToLint[0]
 
+	// the version of the specification at the ref is compared with exactly as if it was given to --original.
+	if since != nil {
+		originalPath, cleanup, snapshotErr := since.snapshot(fileName)
+		switch {
+		case snapshotErr != nil:
+			if !flags.SilentFlag {
+				fmt.Printf("\033[33mWarning: Failed to read the original spec at '%s': %v\033[0m\n", flags.SinceFlag, snapshotErr)
+				fmt.Printf("\033[33mProceeding without change filtering.\033[0m\n\n")
+			}
+		case originalPath == "":
+			if !flags.SilentFlag && !flags.PipelineOutput {
+				fmt.Printf(" '%s' is new since '%s', all of its violations are reported\n\n", fileName, flags.SinceFlag)
+			}
+		default:
+			defer cleanup()
+			flags.OriginalFlag = originalPath
+		}
+	}
+
 	// ignore file
 	ignoredItems, err := LoadIgnoreFile(flags.IgnoreFile, flags.SilentFlag, flags.PipelineOutput, flags.NoStyleFlag)
 	if err != nil {
 		return err
 	}
 
+	baseline, err := LoadBaselineFile(flags)
+	if err != nil {
+		return err
+	}
+
 	// Create HTTP client early for URL support (cert/TLS config)
 	httpClientConfig, cfgErr := GetHTTPClientConfig(flags)
 	if cfgErr != nil {
@@ -161,6 +232,8 @@ func runLint(cmd *cobra.Command, args []string) error {
 	var displayFileName string
 	var stats *reports.ReportStatistics
 	var fixesApplied int
+	var ruleProfile *RuleProfileReport
+	var baselined int
 	var changeFilterStats *utils.ChangeFilterStats
 	start := time.Now()
 
@@ -180,6 +253,7 @@ func runLint(cmd *cobra.Command, args []string) error {
 				ignoredItems,
 				buildIgnoreFilterOptions(specBytes, nil, flags.LookupTimeoutFlag),
 			)
+			filteredResults, baselined = applyBaseline(baseline, flags, reportOrSpec.FileName, filteredResults)
 			// create properly initialized RuleResultSet
 			resultSet = model.NewRuleResultSetPointer(filteredResults)
 		} else {
@@ -200,6 +274,14 @@ func runLint(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		scoreModel, err := ResolveScoreModel(selectedRS)
+		if err != nil {
+			if !flags.SilentFlag {
+				fmt.Printf("%sError: %v%s\n\n", color.ASCIIRed, err, color.ASCIIReset)
+			}
+			return err
+		}
+
 		// Resolve base path before comparison-mode setup so the same-spec
 		// shortcut can refuse non-default --base executions.
 		resolvedBase, baseErr := ResolveBasePathForFile(fileName, flags.BaseFlag)
@@ -266,7 +348,7 @@ func runLint(cmd *cobra.Command, args []string) error {
 			Spec:                            specBytes,
 			SpecFileName:                    resolvedSpecPath,
 			CustomFunctions:                 customFuncs,
-			AutoFixFunctions:                make(map[string]model.AutoFixFunction),
+			AutoFixFunctions:                autofix.GetBuiltInAutoFixFunctions(),
 			Base:                            resolvedBase,
 			AllowLookup:                     flags.RemoteFlag,
 			SkipDocumentCheck:               flags.SkipCheckFlag,
@@ -278,14 +360,21 @@ func runLint(cmd *cobra.Command, args []string) error {
 			IgnoreCircularPolymorphicRef:    flags.IgnorePolymorphCircleRef,
 			ExtractReferencesFromExtensions: flags.ExtRefsFlag,
 			HTTPClientConfig:                httpClientConfig,
-			ApplyAutoFixes:                  flags.FixFlag,
+			ApplyAutoFixes:                  flags.FixFlag || flags.FixOverlayFlag != "",
+			ProfileRules:                    flags.ProfileFlag != "",
 			FetchConfig:                     fetchConfig,
 			TurboMode:                       flags.TurboMode,
 			SpecFormat:                      specFormat,
 		}
 
+		// results compared with another version of the specification need the document, so they are not cached.
+		var cache *lintCache
+		if flags.OriginalFlag == "" && flags.ChangesFlag == "" {
+			cache = newLintCache(flags.CacheDirFlag, flags.FunctionsFlag)
+		}
+
 		executionOptions := newMotorExecutionOptionsFromLintFlags(flags)
-		result := motor.ApplyRulesToRuleSetWithOptions(execution, executionOptions)
+		result, documentStats := cache.applyRules(execution, executionOptions)
 
 		result.Results = utils.FilterIgnoredResultsWithOptions(
 			result.Results,
@@ -315,6 +404,8 @@ func runLint(cmd *cobra.Command, args []string) error {
 			result.Results, changeFilterStats = changeFilter.FilterResultsValues(result.Results)
 		}
 
+		result.Results, baselined = applyBaselineToValues(baseline, flags, fileName, result.Results)
+
 		// Inject change violations if requested
 		if documentChanges != nil && (flags.WarnOnChanges || flags.ErrorOnBreaking) {
 			changeViolations := utils.GenerateChangeViolations(documentChanges, utils.ChangeViolationOptions{
@@ -339,6 +430,10 @@ func runLint(cmd *cobra.Command, args []string) error {
 			return NewInputError("linting failed due to %d issues", len(result.Errors))
 		}
 
+		if flags.ProfileFlag != "" {
+			ruleProfile = newRuleProfileReport(displayFileName, result)
+		}
+
 		resultSet = model.NewRuleResultSet(result.Results)
 		resultSet.AddFixedResults(result.FixedResults)
 		fixesApplied = len(result.FixedResults)
@@ -351,8 +446,33 @@ func runLint(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		if result.Index != nil && result.SpecInfo != nil {
-			stats = statistics.CreateReportStatistics(result.Index, result.SpecInfo, resultSet)
+		// the fixes were applied to the document in memory, the overlay carries them without touching the spec.
+		if fixesApplied > 0 && flags.FixOverlayFlag != "" {
+			overlayActions, overlayWarnings, err := writeFixOverlay(result, specBytes, fileName, flags.FixOverlayFlag)
+			for _, warning := range overlayWarnings {
+				renderLintWarning(flags, "%s", warning)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write fix overlay: %w", err)
+			}
+			if overlayActions == 0 && !flags.FixFlag {
+				fixesApplied = 0
+			}
+		}
+
+		stats = lintStatistics(result, documentStats, resultSet, scoreModel)
+
+		historyStore, err := openLintHistory(flags)
+		if err != nil {
+			return err
+		}
+		if historyStore != nil {
+			err = recordLintHistory(historyStore, fileName, selectedRS, stats)
+			_ = historyStore.Close()
+			if err != nil {
+				renderHistoryError(flags.SilentFlag, err)
+				return err
+			}
 		}
 	}
 
@@ -435,6 +555,18 @@ func runLint(cmd *cobra.Command, args []string) error {
 		renderFixedTiming(duration, fileSize)
 	}
 
+	if flags.ProfileFlag != "" {
+		if ruleProfile == nil {
+			renderLintWarning(flags, "No rules were profiled, '%s' is a pre-compiled report.", displayFileName)
+		} else if err = renderRuleProfiles(os.Stderr, flags.ProfileFlag, ruleProfile); err != nil {
+			return err
+		}
+	}
+
+	if err = finishBaseline(baseline, flags, baselined); err != nil {
+		return err
+	}
+
 	// severity failure
 	errs := resultSet.GetErrorCount()
 	warnings := resultSet.GetWarnCount()
@@ -497,13 +629,16 @@ func renderNoFixesAppliedWarning(flags *LintFlags, resultSet *model.RuleResultSe
 	if flags == nil || resultSet == nil {
 		return
 	}
-	if !flags.FixFlag || fixesApplied > 0 || len(resultSet.Results) == 0 {
+	if (!flags.FixFlag && flags.FixOverlayFlag == "") || fixesApplied > 0 || len(resultSet.Results) == 0 {
 		return
 	}
 	messagePrefix := "No fixes were applied"
 	if flags.FixFileFlag != "" {
 		messagePrefix = fmt.Sprintf("No fixes were written to '%s'", flags.FixFileFlag)
 	}
+	if flags.FixOverlayFlag != "" {
+		messagePrefix = fmt.Sprintf("No fix overlay was written to '%s'", flags.FixOverlayFlag)
+	}
 	if hasAutoFixableResults(resultSet.Results) {
 		renderLintWarning(flags, "%s; no auto-fixes were applied.", messagePrefix)
 		return
@@ -537,8 +672,10 @@ type fileResult struct {
 	hints        int
 	size         int64
 	logs         []string
+	fixErr       error
 	err          error
 	fixesApplied int
+	profile      *RuleProfileReport
 }
 
 func PrintBanner(noStyle ...bool) {
@@ -614,10 +751,11 @@ func renderInfoMessage(message string, noStyle bool) {
 }
 
 // renderIgnoredItems displays the ignored paths and rules in tree format
-func renderIgnoredItems(ignoredItems model.IgnoredItems, noStyle bool) {
+func renderIgnoredItems(ignoredItems model.IgnoreEntries, noStyle bool) {
 	type ignoredItem struct {
-		rule string
-		path string
+		rule          string
+		path          string
+		justification string
 	}
 	var items []ignoredItem
 
@@ -626,8 +764,9 @@ func renderIgnoredItems(ignoredItems model.IgnoredItems, noStyle bool) {
 		if len(paths) > 0 {
 			for _, path := range paths {
 				items = append(items, ignoredItem{
-					rule: category,
-					path: path,
+					rule:          category,
+					path:          path.Path,
+					justification: path.Justification(),
 				})
 			}
 		}
@@ -647,6 +786,9 @@ func renderIgnoredItems(ignoredItems model.IgnoredItems, noStyle bool) {
 			formattedItem := fmt.Sprintf("%s%s%s%s: %s",
 				color.ASCIIPink, color.ASCIIBold, item.rule, color.ASCIIReset,
 				color.ColorizePath(item.path))
+			if item.justification != "" {
+				formattedItem += fmt.Sprintf(" %s(%s)%s", color.ASCIIGrey, item.justification, color.ASCIIReset)
+			}
 
 			if isLast {
 				fmt.Printf(" %s└─%s %s\n", color.ASCIIPink, color.ASCIIReset, formattedItem)
@@ -654,10 +796,14 @@ func renderIgnoredItems(ignoredItems model.IgnoredItems, noStyle bool) {
 				fmt.Printf(" %s├─%s %s\n", color.ASCIIPink, color.ASCIIReset, formattedItem)
 			}
 		} else {
+			formattedItem := fmt.Sprintf("%s: %s", item.rule, item.path)
+			if item.justification != "" {
+				formattedItem += fmt.Sprintf(" (%s)", item.justification)
+			}
 			if isLast {
-				fmt.Printf(" └─ %s: %s\n", item.rule, item.path)
+				fmt.Printf(" └─ %s\n", formattedItem)
 			} else {
-				fmt.Printf(" ├─ %s: %s\n", item.rule, item.path)
+				fmt.Printf(" ├─ %s\n", formattedItem)
 			}
 		}
 	}
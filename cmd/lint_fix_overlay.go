@@ -0,0 +1,329 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	highoverlay "github.com/pb33f/libopenapi/datamodel/high/overlay"
+	"go.yaml.in/yaml/v4"
+)
+
+// writeFixOverlay writes the auto-fixes applied during linting to an Overlay document, instead of rewriting the
+// specification. It returns the number of actions written, no file is written if the fixes changed nothing, and a
+// warning for every fix an overlay cannot carry.
+func writeFixOverlay(result *motor.RuleSetExecutionResult, specBytes []byte, fileName, overlayFile string) (int, []string, error) {
+	if result.RuleSetExecution == nil || result.RuleSetExecution.CanonicalDocument == nil {
+		return 0, nil, fmt.Errorf("no fixed document available")
+	}
+	fixOverlay, warnings, err := buildFixOverlay(specBytes, result.RuleSetExecution.CanonicalDocument, result.FixedResults, fileName)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(fixOverlay.Actions) == 0 {
+		return 0, warnings, nil
+	}
+	rendered, err := fixOverlay.Render()
+	if err != nil {
+		return 0, warnings, fmt.Errorf("failed to render overlay: %w", err)
+	}
+	if err = os.WriteFile(overlayFile, rendered, 0o644); err != nil {
+		return 0, warnings, fmt.Errorf("failed to write overlay %s: %w", overlayFile, err)
+	}
+	return len(fixOverlay.Actions), warnings, nil
+}
+
+// buildFixOverlay compares the original specification with the document the auto-fixes were applied to, and
+// returns an Overlay 1.0 document with the update and remove actions that turn one into the other. Each action
+// is described with the rules whose fixes it carries.
+//
+// An overlay cannot reorder the keys of the document itself, a warning naming the fix is returned for each change
+// that cannot be carried.
+func buildFixOverlay(original []byte, fixed *yaml.Node, fixedResults []model.RuleFunctionResult,
+	fileName string) (*highoverlay.Overlay, []string, error) {
+	var originalDoc yaml.Node
+	if err := yaml.Unmarshal(original, &originalDoc); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse original specification: %w", err)
+	}
+	originalRoot, fixedRoot := documentRoot(&originalDoc), documentRoot(fixed)
+	if originalRoot == nil || fixedRoot == nil ||
+		originalRoot.Kind != yaml.MappingNode || fixedRoot.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("unable to build an overlay, the specification is not an object")
+	}
+
+	diff := &fixOverlayDiff{}
+	var warnings []string
+	if !diff.compareMappings("$", originalRoot, fixedRoot) {
+		// the root has no parent to write it again, so only the order of its keys is lost.
+		diff.compareMappingValues("$", originalRoot, fixedRoot)
+		warnings = append(warnings, fmt.Sprintf("%s the keys of the document, an overlay cannot reorder keys so the "+
+			"fix is not part of the overlay", describeDroppedFix("$", fixedResults)))
+	}
+
+	actions := make([]*highoverlay.Action, len(diff.actions))
+	for i, action := range diff.actions {
+		actions[i] = action.Action
+		actions[i].Description = describeFixOverlayAction(action.changed, fixedResults)
+	}
+
+	return &highoverlay.Overlay{
+		Overlay: "1.0.0",
+		Info: &highoverlay.Info{
+			Title:       fmt.Sprintf("vacuum auto-fixes for %s", filepath.Base(fileName)),
+			Version:     "1.0.0",
+			Description: "Review these fixes, then apply them with 'vacuum apply-overlay'",
+		},
+		Actions: actions,
+	}, warnings, nil
+}
+
+// fixOverlayAction is an overlay action, and the paths it changes in the document.
+type fixOverlayAction struct {
+	*highoverlay.Action
+	changed []string
+}
+
+// fixOverlayDiff collects the overlay actions for the differences between two documents. Actions are collected in
+// the order they have to be applied: a value that cannot be merged is removed before it is written again.
+type fixOverlayDiff struct {
+	actions []*fixOverlayAction
+}
+
+func (d *fixOverlayDiff) remove(target string) {
+	d.actions = append(d.actions, &fixOverlayAction{
+		Action:  &highoverlay.Action{Target: target, Remove: true},
+		changed: []string{target},
+	})
+}
+
+// compareMappings diffs two objects found at path, returning false if the object has to be replaced as a whole
+// because its keys were reordered, an update merges keys into an object but cannot move them.
+func (d *fixOverlayDiff) compareMappings(path string, original, fixed *yaml.Node) bool {
+	if mappingKeysReordered(original, fixed) {
+		return false
+	}
+	d.compareMappingValues(path, original, fixed)
+	return true
+}
+
+// compareMappingValues diffs the values of two objects found at path. Missing keys are removed, and new or
+// replaced values are merged into the object with a single update action.
+func (d *fixOverlayDiff) compareMappingValues(path string, original, fixed *yaml.Node) {
+	originalValues := make(map[string]*yaml.Node, len(original.Content)/2)
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		originalValues[original.Content[i].Value] = original.Content[i+1]
+	}
+	fixedKeys := make(map[string]bool, len(fixed.Content)/2)
+	for i := 0; i+1 < len(fixed.Content); i += 2 {
+		fixedKeys[fixed.Content[i].Value] = true
+	}
+
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		if key := original.Content[i].Value; !fixedKeys[key] {
+			d.remove(overlayPathSegment(path, key))
+		}
+	}
+
+	update := &fixOverlayAction{Action: &highoverlay.Action{Target: path, Update: &yaml.Node{Kind: yaml.MappingNode}}}
+	for i := 0; i+1 < len(fixed.Content); i += 2 {
+		key, value := fixed.Content[i], fixed.Content[i+1]
+		childPath := overlayPathSegment(path, key.Value)
+		originalValue, exists := originalValues[key.Value]
+		switch {
+		case exists && yamlNodesEqual(originalValue, value):
+			continue
+		case exists && originalValue.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			if d.compareMappings(childPath, originalValue, value) {
+				continue
+			}
+			d.remove(childPath)
+		case exists && originalValue.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			if d.compareSequences(childPath, originalValue, value) {
+				continue
+			}
+			d.remove(childPath)
+		case exists && originalValue.Kind == value.Kind && originalValue.ShortTag() != value.ShortTag():
+			// an update only changes the value of a scalar, not its type.
+			d.remove(childPath)
+		}
+		update.Update.Content = append(update.Update.Content, key, value)
+		update.changed = append(update.changed, childPath)
+	}
+	if len(update.Update.Content) > 0 {
+		d.actions = append(d.actions, update)
+	}
+}
+
+// compareSequences diffs two arrays found at path, returning false if the array has to be replaced as a whole.
+// Removed items and items appended to the end are handled individually, as are changes inside objects.
+func (d *fixOverlayDiff) compareSequences(path string, original, fixed *yaml.Node) bool {
+	switch {
+	case len(fixed.Content) == len(original.Content):
+		for i := range fixed.Content {
+			if !yamlNodesEqual(original.Content[i], fixed.Content[i]) &&
+				(original.Content[i].Kind != yaml.MappingNode || fixed.Content[i].Kind != yaml.MappingNode ||
+					mappingKeysReordered(original.Content[i], fixed.Content[i])) {
+				return false
+			}
+		}
+		for i := range fixed.Content {
+			if !yamlNodesEqual(original.Content[i], fixed.Content[i]) {
+				d.compareMappings(vacuumUtils.AppendResultPathIndex(path, i), original.Content[i], fixed.Content[i])
+			}
+		}
+		return true
+
+	case len(fixed.Content) < len(original.Content):
+		var removed []int
+		next := 0
+		for i, item := range original.Content {
+			if next < len(fixed.Content) && yamlNodesEqual(item, fixed.Content[next]) {
+				next++
+				continue
+			}
+			removed = append(removed, i)
+		}
+		if next < len(fixed.Content) {
+			return false
+		}
+		// items are removed from the end, so the indexes of the remaining items do not move.
+		for _, i := range slices.Backward(removed) {
+			d.remove(vacuumUtils.AppendResultPathIndex(path, i))
+		}
+		return true
+
+	default:
+		for i, item := range original.Content {
+			if !yamlNodesEqual(item, fixed.Content[i]) {
+				return false
+			}
+		}
+		// an update appends the items of an array to the target array.
+		d.actions = append(d.actions, &fixOverlayAction{
+			Action: &highoverlay.Action{Target: path, Update: &yaml.Node{
+				Kind:    yaml.SequenceNode,
+				Content: fixed.Content[len(original.Content):],
+			}},
+			changed: []string{path},
+		})
+		return true
+	}
+}
+
+// mappingKeysReordered reports whether the keys found in both objects are in a different order.
+func mappingKeysReordered(original, fixed *yaml.Node) bool {
+	fixedKeys := make(map[string]bool, len(fixed.Content)/2)
+	for i := 0; i+1 < len(fixed.Content); i += 2 {
+		fixedKeys[fixed.Content[i].Value] = true
+	}
+	var kept []string
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		if key := original.Content[i].Value; fixedKeys[key] {
+			kept = append(kept, key)
+		}
+	}
+	next := 0
+	for i := 0; i+1 < len(fixed.Content) && next < len(kept); i += 2 {
+		if key := fixed.Content[i].Value; slices.Contains(kept, key) {
+			if kept[next] != key {
+				return true
+			}
+			next++
+		}
+	}
+	return false
+}
+
+// describeDroppedFix names the rules whose fixes changed a path that cannot be carried by an overlay.
+func describeDroppedFix(path string, fixedResults []model.RuleFunctionResult) string {
+	var ruleIds []string
+	for i := range fixedResults {
+		if fixedResults[i].Rule != nil && fixedResults[i].Path == path {
+			ruleIds = append(ruleIds, fixedResults[i].Rule.Id)
+		}
+	}
+	if len(ruleIds) == 0 {
+		return "A fix reorders"
+	}
+	slices.Sort(ruleIds)
+	return fmt.Sprintf("The fix for %s reorders", strings.Join(slices.Compact(ruleIds), ", "))
+}
+
+// describeFixOverlayAction names the rules whose fixes changed one of the paths an action changes.
+func describeFixOverlayAction(changed []string, fixedResults []model.RuleFunctionResult) string {
+	var ruleIds []string
+	for i := range fixedResults {
+		if fixedResults[i].Rule == nil {
+			continue
+		}
+		for _, path := range changed {
+			if isRelatedJSONPath(path, fixedResults[i].Path) {
+				ruleIds = append(ruleIds, fixedResults[i].Rule.Id)
+				break
+			}
+		}
+	}
+	if len(ruleIds) == 0 {
+		return ""
+	}
+	slices.Sort(ruleIds)
+	return "Fixes " + strings.Join(slices.Compact(ruleIds), ", ")
+}
+
+// isRelatedJSONPath reports whether two paths are the same, or one contains the other.
+func isRelatedJSONPath(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return strings.HasPrefix(b, a) && (len(a) == len(b) || b[len(a)] == '.' || b[len(a)] == '[')
+}
+
+// overlayPathSegment appends a key to a JSONPath, quoting keys that cannot use dot notation.
+func overlayPathSegment(path, key string) string {
+	if vacuumUtils.IsSimpleResultPathKey(key) {
+		return path + "." + key
+	}
+	key = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(key)
+	return path + "['" + key + "']"
+}
+
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return node.Content[0]
+	}
+	return node
+}
+
+// yamlNodesEqual compares the content of two nodes, ignoring styles, comments and positions.
+func yamlNodesEqual(a, b *yaml.Node) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil || a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return a.Value == b.Value && a.ShortTag() == b.ShortTag()
+	case yaml.AliasNode:
+		return a.Value == b.Value
+	}
+	for i := range a.Content {
+		if !yamlNodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/overlay"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestBuildFixOverlay_RoundTrip(t *testing.T) {
+	original := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+  description: pizza
+tags:
+  - name: a
+  - name: b
+  - name: c
+servers:
+  - url: https://api.pb33f.io/
+security:
+  - key: []
+paths:
+  /pizza/:
+    get:
+      operationId: "12"
+      parameters:
+        - name: size
+          in: query
+  "/it's":
+    get:
+      operationId: quoted
+`
+	fixed := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+  contact:
+    name: pb33f
+tags:
+  - name: a
+  - name: c
+servers:
+  - url: https://api.pb33f.io
+security:
+  - key: []
+  - other: []
+paths:
+  /pizza:
+    get:
+      operationId: 12
+      parameters:
+        - name: size
+          in: query
+          required: true
+  "/it's":
+    get:
+      operationId: unquoted
+`
+	var fixedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(fixed), &fixedDoc))
+
+	fixedResults := []model.RuleFunctionResult{
+		{Path: "$.paths['/pizza/']", Rule: &model.Rule{Id: "path-keys-no-trailing-slash"}},
+		{Path: "$.servers[0].url", Rule: &model.Rule{Id: "no-server-trailing-slash"}},
+	}
+	fixOverlay, _, err := buildFixOverlay([]byte(original), &fixedDoc, fixedResults, "specs/pizza.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", fixOverlay.Overlay)
+	assert.Equal(t, "vacuum auto-fixes for pizza.yaml", fixOverlay.Info.Title)
+
+	var targets []string
+	for _, action := range fixOverlay.Actions {
+		targets = append(targets, action.Target)
+	}
+	assert.Equal(t, []string{
+		"$.info.description",
+		"$.info",
+		"$.tags[1]",
+		"$.servers[0]",
+		"$.security",
+		"$.paths['/pizza/']",
+		"$.paths['/it\\'s'].get",
+		"$.paths",
+	}, targets)
+	assert.True(t, fixOverlay.Actions[0].Remove)
+	assert.Equal(t, "Fixes no-server-trailing-slash", fixOverlay.Actions[3].Description)
+	assert.Equal(t, "Fixes path-keys-no-trailing-slash", fixOverlay.Actions[5].Description)
+	assert.Empty(t, fixOverlay.Actions[1].Description)
+
+	// the rendered overlay turns the original into the fixed document.
+	rendered, err := fixOverlay.Render()
+	require.NoError(t, err)
+	parsed, err := libopenapi.NewOverlayDocument(rendered)
+	require.NoError(t, err)
+	applied, err := overlay.Apply([]byte(original), parsed)
+	require.NoError(t, err)
+	assert.Empty(t, applied.Warnings)
+
+	var appliedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal(applied.Bytes, &appliedDoc))
+	assertSameDocument(t, &fixedDoc, &appliedDoc)
+}
+
+func TestBuildFixOverlay_ReplacedSequence(t *testing.T) {
+	var fixedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("openapi: 3.1.0\nversion: 1\ntags: [c, a]\nitems: [[1], [3]]\n"), &fixedDoc))
+	original := "openapi: 3.1.0\nversion: '1'\ntags: [a, b]\nitems: [[1], [2]]\n"
+
+	fixOverlay, _, err := buildFixOverlay([]byte(original), &fixedDoc, nil, "openapi.yaml")
+	require.NoError(t, err)
+	var targets []string
+	for _, action := range fixOverlay.Actions {
+		targets = append(targets, action.Target)
+	}
+	// a value changing type, or an array that was reordered, is removed and written again.
+	assert.Equal(t, []string{"$.version", "$.tags", "$.items", "$"}, targets)
+	assert.True(t, fixOverlay.Actions[0].Remove)
+
+	applied, err := overlay.Apply([]byte(original), fixOverlay)
+	require.NoError(t, err)
+	var appliedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal(applied.Bytes, &appliedDoc))
+	assertSameDocument(t, &fixedDoc, &appliedDoc)
+}
+
+func TestBuildFixOverlay_SortedMapping(t *testing.T) {
+	original := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+components:
+  schemas:
+    Pizza:
+      type: object
+    Cake:
+      type: object
+    Burger:
+      type: object
+`
+	fixed := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+components:
+  schemas:
+    Burger:
+      type: object
+    Cake:
+      type: object
+    Pizza:
+      type: object
+`
+	var fixedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(fixed), &fixedDoc))
+	fixedResults := []model.RuleFunctionResult{
+		{Path: "$.components.schemas", Rule: &model.Rule{Id: "schemas-alphabetical"}},
+	}
+
+	fixOverlay, warnings, err := buildFixOverlay([]byte(original), &fixedDoc, fixedResults, "openapi.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// the keys cannot be moved by an update, so the object is removed and written again.
+	var targets []string
+	for _, action := range fixOverlay.Actions {
+		targets = append(targets, action.Target)
+	}
+	assert.Equal(t, []string{"$.components.schemas", "$.components"}, targets)
+	assert.True(t, fixOverlay.Actions[0].Remove)
+	assert.Equal(t, "Fixes schemas-alphabetical", fixOverlay.Actions[1].Description)
+
+	rendered, err := fixOverlay.Render()
+	require.NoError(t, err)
+	parsed, err := libopenapi.NewOverlayDocument(rendered)
+	require.NoError(t, err)
+	applied, err := overlay.Apply([]byte(original), parsed)
+	require.NoError(t, err)
+	assert.Empty(t, applied.Warnings)
+
+	var appliedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal(applied.Bytes, &appliedDoc))
+	assertSameDocument(t, &fixedDoc, &appliedDoc)
+	schemas := appliedDoc.Content[0].Content[5].Content[1]
+	assert.Equal(t, "Burger", schemas.Content[0].Value)
+	assert.Equal(t, "Cake", schemas.Content[2].Value)
+	assert.Equal(t, "Pizza", schemas.Content[4].Value)
+}
+
+func TestBuildFixOverlay_SortedRoot(t *testing.T) {
+	var fixedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("info: {title: pizza}\nopenapi: 3.1.0\n"), &fixedDoc))
+	fixedResults := []model.RuleFunctionResult{{Path: "$", Rule: &model.Rule{Id: "root-alphabetical"}}}
+
+	// the document itself cannot be written again, so the fix is dropped with a warning.
+	fixOverlay, warnings, err := buildFixOverlay([]byte("openapi: 3.1.0\ninfo: {title: cake}\n"), &fixedDoc,
+		fixedResults, "openapi.yaml")
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "The fix for root-alphabetical reorders the keys of the document")
+	require.Len(t, fixOverlay.Actions, 1)
+	assert.Equal(t, "$.info", fixOverlay.Actions[0].Target)
+}
+
+func TestBuildFixOverlay_NoChanges(t *testing.T) {
+	var fixedDoc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("openapi: 3.1.0"), &fixedDoc))
+	fixOverlay, _, err := buildFixOverlay([]byte("openapi: 3.1.0"), &fixedDoc, nil, "openapi.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, fixOverlay.Actions)
+
+	_, _, err = buildFixOverlay([]byte("- a"), &fixedDoc, nil, "openapi.yaml")
+	assert.ErrorContains(t, err, "not an object")
+}
+
+func TestGetLintCommand_FixOverlay(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+servers:
+  - url: https://api.pb33f.io/
+paths:
+  /pizza/:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: ok`
+	writeTestFile(t, specPath, spec)
+	original, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	overlayPath := filepath.Join(dir, "fixes.yaml")
+
+	cmd := GetLintCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--no-banner", "--no-style", "--fail-severity", "none", "--fix-overlay", overlayPath, specPath})
+	captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+
+	// the specification is left alone.
+	unchanged, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(unchanged))
+
+	outputPath := filepath.Join(dir, "fixed.yaml")
+	apply := GetApplyOverlayCommand()
+	apply.SetOut(bytes.NewBufferString(""))
+	apply.SetArgs([]string{"--no-style", specPath, overlayPath, outputPath})
+	captureOSStreams(t, func() {
+		err = apply.Execute()
+	})
+	require.NoError(t, err)
+
+	fixed, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(fixed), "/pizza:")
+	assert.NotContains(t, string(fixed), "/pizza/:")
+}
+
+func TestGetLintCommand_FixOverlayMultipleFiles(t *testing.T) {
+	_, err := runLintForBaselineTest(t, "--fix-overlay", filepath.Join(t.TempDir(), "fixes.yaml"),
+		"../model/test_files/burgershop.openapi.yaml", "../model/test_files/petstorev3.json")
+	assert.ErrorContains(t, err, "--fix-overlay can only be used when linting a single file")
+}
+
+func assertSameDocument(t *testing.T, expected, actual *yaml.Node) {
+	t.Helper()
+	var expectedValue, actualValue any
+	require.NoError(t, expected.Decode(&expectedValue))
+	require.NoError(t, actual.Decode(&actualValue))
+	assert.Equal(t, expectedValue, actualValue)
+}
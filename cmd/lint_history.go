@@ -0,0 +1,63 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/history"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/rulesets"
+)
+
+// OpenHistoryStore opens the history database at path, or the default database when path is empty.
+func OpenHistoryStore(path string) (*history.Store, error) {
+	if path == "" {
+		var err error
+		path, err = history.DefaultPath()
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate the history database, set one with --history-db: %w", err)
+		}
+	}
+	return history.Open(path)
+}
+
+// openLintHistory opens the history database when --record is set, otherwise it returns nil.
+func openLintHistory(flags *LintFlags) (*history.Store, error) {
+	if !flags.RecordFlag {
+		return nil, nil
+	}
+	store, err := OpenHistoryStore(flags.HistoryDBFlag)
+	if err != nil {
+		renderHistoryError(flags.SilentFlag, err)
+		return nil, err
+	}
+	return store, nil
+}
+
+// recordLintHistory records the statistics of a linted specification, keyed by its path and the ruleset used.
+func recordLintHistory(store *history.Store, specPath string, rs *rulesets.RuleSet, stats *reports.ReportStatistics) error {
+	if store == nil || stats == nil {
+		return nil
+	}
+	key, sha := history.SpecKey(specPath)
+	err := store.Record(&history.Run{
+		SpecPath:    key,
+		GitSHA:      sha,
+		RulesetHash: history.RuleSetHash(rs),
+		Statistics:  stats,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to record history for '%s': %w", specPath, err)
+	}
+	return nil
+}
+
+func renderHistoryError(silent bool, err error) {
+	if !silent {
+		fmt.Printf("%sError: %v%s\n\n", color.ASCIIRed, err, color.ASCIIReset)
+	}
+}
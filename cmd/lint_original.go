@@ -367,18 +367,31 @@ func collectExternalReferenceFiles(specBytes []byte) ([]string, bool) {
 
 	seen := make(map[string]struct{})
 	var refs []string
-	ok := collectExternalReferenceFilesFromNode(&root, seen, &refs)
+	ok := collectExternalReferenceFilesFromNode(&root, seen, &refs, false)
 	return refs, ok
 }
 
-func collectExternalReferenceFilesFromNode(node *yaml.Node, seen map[string]struct{}, refs *[]string) bool {
+// collectLocalReferenceFiles returns the local files a specification references, remote references are skipped.
+func collectLocalReferenceFiles(specBytes []byte) []string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(specBytes, &root); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var refs []string
+	collectExternalReferenceFilesFromNode(&root, seen, &refs, true)
+	return refs
+}
+
+func collectExternalReferenceFilesFromNode(node *yaml.Node, seen map[string]struct{}, refs *[]string, skipRemote bool) bool {
 	if node == nil {
 		return true
 	}
 	switch node.Kind {
 	case yaml.DocumentNode:
 		for _, child := range node.Content {
-			if !collectExternalReferenceFilesFromNode(child, seen, refs) {
+			if !collectExternalReferenceFilesFromNode(child, seen, refs, skipRemote) {
 				return false
 			}
 		}
@@ -389,7 +402,7 @@ func collectExternalReferenceFilesFromNode(node *yaml.Node, seen map[string]stru
 			if keyNode != nil && keyNode.Value == "$ref" && valueNode != nil && valueNode.Kind == yaml.ScalarNode {
 				ref := strings.TrimSpace(valueNode.Value)
 				refFile, ok := externalReferenceFile(ref)
-				if !ok {
+				if !ok && !skipRemote {
 					return false
 				}
 				if refFile != "" {
@@ -399,13 +412,13 @@ func collectExternalReferenceFilesFromNode(node *yaml.Node, seen map[string]stru
 					}
 				}
 			}
-			if !collectExternalReferenceFilesFromNode(valueNode, seen, refs) {
+			if !collectExternalReferenceFilesFromNode(valueNode, seen, refs, skipRemote) {
 				return false
 			}
 		}
 	case yaml.SequenceNode:
 		for _, child := range node.Content {
-			if !collectExternalReferenceFilesFromNode(child, seen, refs) {
+			if !collectExternalReferenceFilesFromNode(child, seen, refs, skipRemote) {
 				return false
 			}
 		}
@@ -20,18 +20,24 @@ import (
 
 // FileProcessingResult contains the results of processing a single file
 type FileProcessingResult struct {
-	Results  []*model.RuleFunctionResult
-	Errors   int
-	Warnings int
-	Informs  int
-	Hints    int
-	FileSize int64
-	Logs     []string
-	Error    error
+	Results      []*model.RuleFunctionResult
+	Errors       int
+	Warnings     int
+	Informs      int
+	Hints        int
+	FixesApplied int
+	Baselined    int
+	FileSize     int64
+	Logs         []string
+	Profile      *RuleProfileReport // only set when rules are profiled
+	FixError     error              // the fixes could not be written, the results are still reported
+	HistoryError error              // the run could not be recorded, the results are still reported
+	Error        error
 }
 
-// runMultipleFiles processes multiple files for lint command
-func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
+// runMultipleFiles processes multiple files for lint command, since is the git ref the files are compared with
+// when --since is used.
+func runMultipleFiles(cmd *cobra.Command, filesToLint []string, since *sinceRevision) error {
 
 	flags := ReadLintFlags(cmd)
 	bufferedLogger, _ := createLogger(flags.DebugFlag)
@@ -45,8 +51,28 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 		}
 	}
 
+	scoreModel, err := ResolveScoreModel(selectedRS)
+	if err != nil {
+		if !flags.SilentFlag {
+			fmt.Printf("%sError: %v%s\n\n", color.ASCIIRed, err, color.ASCIIReset)
+		}
+		return err
+	}
+
 	customFuncs, _ := LoadCustomFunctions(flags.FunctionsFlag, flags.SilentFlag)
 	ignoredItems, _ := LoadIgnoreFile(flags.IgnoreFile, flags.SilentFlag, flags.PipelineOutput, flags.NoStyleFlag)
+	baseline, err := LoadBaselineFile(flags)
+	if err != nil {
+		return err
+	}
+
+	historyStore, err := openLintHistory(flags)
+	if err != nil {
+		return err
+	}
+	if historyStore != nil {
+		defer historyStore.Close()
+	}
 
 	fetchConfig, fetchCfgErr := GetFetchConfig(flags)
 	if fetchCfgErr != nil {
@@ -63,6 +89,7 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 
 	var totalErrors, totalWarnings, totalInforms, totalHints int
 	var processingErrors int
+	var totalBaselined int
 	var totalSize int64
 	start := time.Now()
 
@@ -123,19 +150,14 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 		}()
 	}
 
-	// process all files
-	for i, fileName := range filesToLint {
-		// update progress display
-		if !flags.SilentFlag && !flags.PipelineOutput {
-			if !flags.NoStyleFlag {
-				currentFile <- fileName
-				progressChan <- float64(i) / float64(len(filesToLint))
-			} else {
-				// plain text progress for no-style mode
-				fmt.Printf("[%d/%d] vacuuming %s...\n", i+1, len(filesToLint), fileName)
-			}
-		}
+	// catalog rules need every document, so a catalog is always linted in full. Results compared with another
+	// version of the specification need the document, so they are not cached either.
+	var cache *lintCache
+	if !flags.CatalogFlag && since == nil {
+		cache = newLintCache(flags.CacheDirFlag, flags.FunctionsFlag)
+	}
 
+	newProcessingConfig := func() *FileProcessingConfig {
 		var bf *logging.BufferedLogger
 		if flags.DebugFlag {
 			bf = logging.NewBufferedLoggerWithLevel(logging.LogLevelDebug)
@@ -143,27 +165,56 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 			bf = logging.NewBufferedLoggerWithLevel(logging.LogLevelError)
 		}
 
-		processingConfig := &FileProcessingConfig{
+		return &FileProcessingConfig{
 			Flags:           flags,
 			BufferedLogger:  bf,
 			SelectedRuleset: selectedRS,
 			CustomFunctions: customFuncs,
 			IgnoredItems:    ignoredItems,
+			Baseline:        baseline,
 			FetchConfig:     fetchConfig,
+			ScoreModel:      scoreModel,
+			History:         historyStore,
+			Cache:           cache,
+			Since:           since,
 		}
+	}
+
+	// files are linted concurrently, the results come back in the order the files were given so output and
+	// totals are always the same.
+	lintFile := func(fileName string) *FileProcessingResult {
+		return ProcessSingleFileOptimized(fileName, newProcessingConfig())
+	}
+	processed := lintInOrder(filesToLint, flags.WorkersFlag, lintFile)
+	if flags.CatalogFlag {
+		processed = lintCatalog(filesToLint, flags.WorkersFlag, newProcessingConfig, catalogRuleSet(selectedRS), customFuncs)
+	}
+
+	if !flags.SilentFlag && !flags.PipelineOutput && !flags.NoStyleFlag {
+		currentFile <- filesToLint[0]
+	}
 
-		result := ProcessSingleFileOptimized(fileName, processingConfig)
+	// a history database that cannot be written fails every file the same way, so it is only reported once.
+	var historyErr error
+	historyFailures := 0
+
+	// process all files
+	for i, result := range processed {
+		fileName := filesToLint[i]
 
 		fileResults[i] = fileResult{
-			fileName: fileName,
-			results:  result.Results,
-			errors:   result.Errors,
-			warnings: result.Warnings,
-			informs:  result.Informs,
-			hints:    result.Hints,
-			size:     result.FileSize,
-			logs:     result.Logs,
-			err:      result.Error,
+			fileName:     fileName,
+			results:      result.Results,
+			errors:       result.Errors,
+			warnings:     result.Warnings,
+			informs:      result.Informs,
+			hints:        result.Hints,
+			fixesApplied: result.FixesApplied,
+			size:         result.FileSize,
+			logs:         result.Logs,
+			profile:      result.Profile,
+			fixErr:       result.FixError,
+			err:          result.Error,
 		}
 
 		// accumulate totals
@@ -172,9 +223,29 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 		totalInforms += result.Informs
 		totalHints += result.Hints
 		totalSize += result.FileSize
-		if result.Error != nil {
+		totalBaselined += result.Baselined
+		if result.Error != nil || result.FixError != nil {
 			processingErrors++
 		}
+		if result.HistoryError != nil {
+			if historyErr == nil {
+				historyErr = result.HistoryError
+			}
+			historyFailures++
+		}
+
+		// update progress display
+		if !flags.SilentFlag && !flags.PipelineOutput {
+			if !flags.NoStyleFlag {
+				if i+1 < len(filesToLint) {
+					currentFile <- filesToLint[i+1]
+					progressChan <- float64(i+1) / float64(len(filesToLint))
+				}
+			} else {
+				// plain text progress for no-style mode
+				fmt.Printf("[%d/%d] vacuumed %s\n", i+1, len(filesToLint), fileName)
+			}
+		}
 	}
 
 	// stop spinner and clear line properly
@@ -196,7 +267,7 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 			}
 
 			resultSet := model.NewRuleResultSetPointer(fr.results)
-			score := statistics.CalculateQualityScore(resultSet)
+			score := statistics.CalculateQualityScoreWithModel(resultSet, scoreModel)
 
 			// Create minimal stats for pipeline output
 			stats := &reports.ReportStatistics{
@@ -219,6 +290,10 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 				Silent:         false,
 			})
 
+			if fr.fixErr != nil {
+				fmt.Printf("> ❌ %v\n\n", fr.fixErr)
+			}
+
 			fmt.Println() // Add spacing between files
 		}
 	} else {
@@ -292,6 +367,14 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 					PipelineOutput: flags.PipelineOutput,
 					ShowRules:      false,
 				})
+
+				if fr.fixErr != nil {
+					if !flags.NoStyleFlag {
+						fmt.Printf("%sError: %v%s\n", color.ASCIIRed, fr.fixErr, color.ASCIIReset)
+					} else {
+						fmt.Printf("Error: %v\n", fr.fixErr)
+					}
+				}
 			}
 
 			// Logs are chrome - only show when not silent
@@ -311,12 +394,35 @@ func runMultipleFiles(cmd *cobra.Command, filesToLint []string) error {
 		}
 	}
 
+	if historyErr != nil {
+		if historyFailures > 1 {
+			historyErr = fmt.Errorf("%w (and %d other files)", historyErr, historyFailures-1)
+		}
+		renderHistoryError(flags.SilentFlag, historyErr)
+	}
+
 	// show timing
 	if flags.TimeFlag && !flags.PipelineOutput && !flags.SilentFlag {
 		duration := time.Since(start)
 		RenderTimeAndFiles(flags.TimeFlag, duration, totalSize, len(filesToLint))
 	}
 
+	if flags.ProfileFlag != "" {
+		var profiles []*RuleProfileReport
+		for _, fr := range fileResults {
+			if fr.profile != nil {
+				profiles = append(profiles, fr.profile)
+			}
+		}
+		if err = renderRuleProfiles(os.Stderr, flags.ProfileFlag, profiles...); err != nil {
+			return err
+		}
+	}
+
+	if err = finishBaseline(baseline, flags, totalBaselined); err != nil {
+		return err
+	}
+
 	if processingErrors > 0 {
 		return NewInputError("linting failed due to %d input/tool errors", processingErrors)
 	}
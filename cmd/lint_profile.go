@@ -0,0 +1,176 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/spf13/cobra"
+)
+
+const (
+	profileFormatTable = "table"
+	profileFormatJSON  = "json"
+)
+
+// RuleProfileReport is the rule profile of a single specification, as rendered by --profile=json.
+type RuleProfileReport struct {
+	Spec      string              `json:"spec"`
+	TimeoutMs int64               `json:"timeoutMs"`
+	Rules     []*RuleProfileEntry `json:"rules"`
+}
+
+// RuleProfileEntry is how a single rule performed, the slowest rules come first.
+type RuleProfileEntry struct {
+	RuleId       string  `json:"ruleId"`
+	DurationMs   float64 `json:"durationMs"`
+	NodesMatched int     `json:"nodesMatched"`
+	Results      int     `json:"results"`
+	TimedOut     bool    `json:"timedOut,omitempty"`
+	NearTimeout  bool    `json:"nearTimeout,omitempty"`
+}
+
+// addProfileFlag adds --profile to a command. The flag can be used on its own to render a table, or as
+// --profile=json.
+func addProfileFlag(cmd *cobra.Command) {
+	cmd.Flags().String("profile", "", "Profile each rule and render the results as a 'table' or 'json' (use --profile=json)")
+	cmd.Flags().Lookup("profile").NoOptDefVal = profileFormatTable
+}
+
+// readProfileFlag returns the --profile format, or an empty string if rules are not being profiled.
+func readProfileFlag(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("profile")
+	return format, checkProfileFormat(format)
+}
+
+func checkProfileFormat(format string) error {
+	switch format {
+	case "", profileFormatTable, profileFormatJSON:
+		return nil
+	}
+	return NewInputError("unknown --profile format '%s', use '%s' or '%s'", format, profileFormatTable, profileFormatJSON)
+}
+
+// newRuleProfileReport collects the rule profiles of an execution, ordered from the slowest rule to the fastest.
+func newRuleProfileReport(spec string, result *motor.RuleSetExecutionResult) *RuleProfileReport {
+	report := &RuleProfileReport{Spec: spec, Rules: []*RuleProfileEntry{}}
+	if result == nil {
+		return report
+	}
+	if result.RuleSetExecution != nil {
+		report.TimeoutMs = result.RuleSetExecution.Timeout.Milliseconds()
+	}
+	profiles := slices.Clone(result.RuleProfiles)
+	slices.SortStableFunc(profiles, func(a, b *motor.RuleProfile) int {
+		if c := cmp.Compare(b.Duration, a.Duration); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RuleId, b.RuleId)
+	})
+	for _, profile := range profiles {
+		report.Rules = append(report.Rules, &RuleProfileEntry{
+			RuleId:       profile.RuleId,
+			DurationMs:   float64(profile.Duration.Microseconds()) / 1000,
+			NodesMatched: profile.NodesMatched,
+			Results:      profile.Results,
+			TimedOut:     profile.TimedOut,
+			NearTimeout:  profile.NearTimeout,
+		})
+	}
+	return report
+}
+
+// renderRuleProfiles renders rule profiles as a table per specification, or as a single JSON array.
+func renderRuleProfiles(out io.Writer, format string, reports ...*RuleProfileReport) error {
+	if format == profileFormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reports)
+	}
+
+	for _, report := range reports {
+		fmt.Fprintf(out, "\n %sRule profile for %s%s %s(%d rules, timeout %s)%s\n\n", color.ASCIIBold, report.Spec,
+			color.ASCIIReset, color.ASCIIGrey, len(report.Rules), time.Duration(report.TimeoutMs)*time.Millisecond, color.ASCIIReset)
+		if len(report.Rules) == 0 {
+			fmt.Fprintf(out, " No rules were run.\n")
+			continue
+		}
+
+		width := len("Rule")
+		for _, rule := range report.Rules {
+			width = max(width, len(rule.RuleId))
+		}
+		fmt.Fprintf(out, " %s%-*s  %10s  %8s  %8s  %s%s\n", color.ASCIIBold,
+			width, "Rule", "Time", "Nodes", "Results", "Status", color.ASCIIReset)
+		flagged := 0
+		for _, rule := range report.Rules {
+			status := ""
+			switch {
+			case rule.TimedOut:
+				status = color.ASCIIRed + "timed out" + color.ASCIIReset
+				flagged++
+			case rule.NearTimeout:
+				status = color.ASCIIYellow + "near timeout" + color.ASCIIReset
+				flagged++
+			}
+			fmt.Fprintf(out, " %-*s  %10s  %8d  %8d  %s\n", width, rule.RuleId,
+				formatProfileDuration(rule.DurationMs), rule.NodesMatched, rule.Results, status)
+		}
+		if flagged > 0 {
+			fmt.Fprintf(out, "\n %s%d rules timed out or used more than %d%% of the timeout, it can be raised with --timeout%s\n",
+				color.ASCIIGrey, flagged, int(motor.NearTimeoutRatio*100), color.ASCIIReset)
+		}
+	}
+	fmt.Fprintln(out)
+	return nil
+}
+
+func formatProfileDuration(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.2fms", ms)
+}
+
+// ruleProfiler collects the rule profiles of every file a report command lints, and renders them to stderr once
+// the command is done, so they never mix with a report written to stdout. A nil profiler collects nothing.
+type ruleProfiler struct {
+	format  string
+	reports []*RuleProfileReport
+}
+
+// newRuleProfiler returns a profiler if --profile is set, or nil if it is not.
+func newRuleProfiler(cmd *cobra.Command) (*ruleProfiler, error) {
+	format, err := readProfileFlag(cmd)
+	if err != nil || format == "" {
+		return nil, err
+	}
+	return &ruleProfiler{format: format}, nil
+}
+
+func (p *ruleProfiler) enabled() bool {
+	return p != nil
+}
+
+func (p *ruleProfiler) add(spec string, result *motor.RuleSetExecutionResult) {
+	if p != nil {
+		p.reports = append(p.reports, newRuleProfileReport(spec, result))
+	}
+}
+
+func (p *ruleProfiler) render() {
+	if p != nil && len(p.reports) > 0 {
+		_ = renderRuleProfiles(os.Stderr, p.format, p.reports...)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestNewRuleProfileReport(t *testing.T) {
+	report := newRuleProfileReport("pizza.yaml", &motor.RuleSetExecutionResult{
+		RuleSetExecution: &motor.RuleSetExecution{Timeout: 5 * time.Second},
+		RuleProfiles: []*motor.RuleProfile{
+			{RuleId: "fast", Duration: time.Millisecond, NodesMatched: 3, Results: 1},
+			{RuleId: "slow", Duration: 4500 * time.Millisecond, NodesMatched: 1, NearTimeout: true},
+			{RuleId: "also-fast", Duration: time.Millisecond},
+		},
+	})
+	assert.Equal(t, int64(5000), report.TimeoutMs)
+	require.Len(t, report.Rules, 3)
+	assert.Equal(t, "slow", report.Rules[0].RuleId)
+	assert.Equal(t, 4500.0, report.Rules[0].DurationMs)
+	assert.Equal(t, "also-fast", report.Rules[1].RuleId)
+	assert.Equal(t, 3, report.Rules[2].NodesMatched)
+
+	assert.Empty(t, newRuleProfileReport("pizza.yaml", nil).Rules)
+}
+
+func TestRenderRuleProfiles(t *testing.T) {
+	color.DisableColors()
+	report := &RuleProfileReport{Spec: "pizza.yaml", TimeoutMs: 5000, Rules: []*RuleProfileEntry{
+		{RuleId: "slow", DurationMs: 5000, TimedOut: true},
+		{RuleId: "close", DurationMs: 4100, NodesMatched: 12, Results: 4, NearTimeout: true},
+		{RuleId: "operation-description", DurationMs: 0.25, NodesMatched: 19},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, renderRuleProfiles(&out, profileFormatTable, report))
+	assert.Contains(t, out.String(), "Rule profile for pizza.yaml (3 rules, timeout 5s)")
+	assert.Contains(t, out.String(), " slow                        5.00s         0         0  timed out")
+	assert.Contains(t, out.String(), " close                       4.10s        12         4  near timeout")
+	assert.Contains(t, out.String(), " operation-description      0.25ms        19         0")
+	assert.Contains(t, out.String(), "2 rules timed out or used more than 80% of the timeout")
+
+	out.Reset()
+	require.NoError(t, renderRuleProfiles(&out, profileFormatJSON, report))
+	var decoded []*RuleProfileReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Len(t, decoded, 1)
+	assert.Equal(t, report, decoded[0])
+}
+
+func TestGetLintCommand_Profile(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--no-banner", "--no-style", "--profile=json", "../model/test_files/petstorev3.json"})
+
+	var err error
+	_, stderr := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+
+	var profiles []*RuleProfileReport
+	require.NoError(t, json.Unmarshal([]byte(stderr), &profiles))
+	require.Len(t, profiles, 1)
+	assert.Equal(t, int64(5000), profiles[0].TimeoutMs)
+	assert.NotEmpty(t, profiles[0].Rules)
+	for i := 1; i < len(profiles[0].Rules); i++ {
+		assert.GreaterOrEqual(t, profiles[0].Rules[i-1].DurationMs, profiles[0].Rules[i].DurationMs)
+	}
+
+	_, err = runLintForBaselineTest(t, "--profile=csv", "../model/test_files/petstorev3.json")
+	assert.ErrorContains(t, err, "unknown --profile format 'csv'")
+}
+
+func TestGetLintCommand_ProfileMultipleFiles(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--no-banner", "--no-style", "--profile",
+		"../model/test_files/petstorev3.json", "../model/test_files/burgershop.openapi.yaml"})
+
+	var err error
+	_, stderr := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Rule profile for ../model/test_files/petstorev3.json")
+	assert.Contains(t, stderr, "Rule profile for ../model/test_files/burgershop.openapi.yaml")
+}
+
+func TestGetSpectralReportCommand_Profile(t *testing.T) {
+	cmd := GetSpectralReportCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--no-style", "-o", "--profile=json", "../model/test_files/petstorev3.json"})
+
+	var err error
+	stdout, stderr := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+
+	// the report and the profile do not mix.
+	var report []reports.SpectralReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	var profiles []*RuleProfileReport
+	require.NoError(t, json.Unmarshal([]byte(stderr), &profiles))
+	require.Len(t, profiles, 1)
+	assert.Equal(t, "../model/test_files/petstorev3.json", profiles[0].Spec)
+}
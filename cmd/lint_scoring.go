@@ -0,0 +1,61 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v4"
+)
+
+// ResolveScoreModel builds the model used to score a specification. The scoring section of the ruleset is merged
+// over the default model, and the scoring section of the configuration file is merged over that.
+func ResolveScoreModel(selectedRS *rulesets.RuleSet) (*model.ScoreModel, error) {
+	var rulesetScoring *model.ScoreModel
+	if selectedRS != nil {
+		rulesetScoring = selectedRS.Scoring
+	}
+	configScoring, err := loadConfigScoreModel(viper.ConfigFileUsed())
+	if err != nil {
+		return nil, err
+	}
+	scoreModel := model.ResolveScoreModel(rulesetScoring, configScoring)
+	if err = scoreModel.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring configuration: %w", err)
+	}
+	return scoreModel, nil
+}
+
+// resolveExecutionScoreModel resolves the score model for the ruleset used by an execution.
+func resolveExecutionScoreModel(result *motor.RuleSetExecutionResult) (*model.ScoreModel, error) {
+	if result == nil || result.RuleSetExecution == nil {
+		return ResolveScoreModel(nil)
+	}
+	return ResolveScoreModel(result.RuleSetExecution.RuleSet)
+}
+
+// loadConfigScoreModel reads the scoring section of a configuration file. The file is read directly because
+// viper lower-cases keys, and rule IDs are case-sensitive.
+func loadConfigScoreModel(configFile string) (*model.ScoreModel, error) {
+	if configFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configuration file '%s': %w", configFile, err)
+	}
+	var config struct {
+		Scoring *model.ScoreModel `yaml:"scoring"`
+	}
+	if err = yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to read scoring from configuration file '%s': %w", configFile, err)
+	}
+	return config.Scoring, nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"github.com/spf13/viper"
+)
+
+func TestResolveScoreModel_ConfigOverridesRuleset(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	configFile := filepath.Join(t.TempDir(), "vacuum.conf.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`lint:
+  hard-mode: true
+scoring:
+  rules:
+    myCustomRule: 4
+  floors:
+    noErrors: 60
+`), 0o644))
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(`extends: [[vacuum:oas, recommended]]
+scoring:
+  severity:
+    warn: 1
+  rules:
+    myCustomRule: 2
+  fatal: [myCustomRule]
+`))
+	require.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	require.NotNil(t, rs.Scoring)
+
+	scoreModel, err := ResolveScoreModel(rs)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, scoreModel.Severity[model.SeverityWarn])
+	assert.Equal(t, 15.0, scoreModel.Severity[model.SeverityError])
+	assert.Equal(t, 4.0, scoreModel.Rules["myCustomRule"])
+	assert.Equal(t, []string{"myCustomRule"}, scoreModel.Fatal)
+	noErrors, _ := scoreModel.NoErrorsScore()
+	assert.Equal(t, 60.0, noErrors)
+}
+
+func TestResolveScoreModel_InvalidConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	configFile := filepath.Join(t.TempDir(), "vacuum.conf.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`scoring:
+  severity:
+    warning: 1
+`), 0o644))
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	_, err := ResolveScoreModel(nil)
+	assert.EqualError(t, err,
+		"invalid scoring configuration: scoring severity 'warning' is not a valid severity (error, warn, info or hint)")
+}
+
+func TestResolveScoreModel_NoConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	scoreModel, err := ResolveScoreModel(nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultScoreModel(), scoreModel)
+}
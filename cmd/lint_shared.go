@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,10 +18,14 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/functions/autofix"
+	"github.com/daveshanley/vacuum/history"
 	"github.com/daveshanley/vacuum/logging"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/tui"
 	"github.com/daveshanley/vacuum/utils"
 )
 
@@ -103,6 +108,7 @@ type LintFlags struct {
 	LookupTimeoutFlag        int
 	FixFlag                  bool
 	FixFileFlag              string
+	FixOverlayFlag           string // --fix-overlay: write auto-fixes to an overlay instead of the specification
 	ChangesFlag              string // --changes: path to JSON change report
 	OriginalFlag             string // --original: path to original spec for inline comparison
 	ChangesSummaryFlag       bool   // --changes-summary: show filtered results summary
@@ -113,6 +119,15 @@ type LintFlags struct {
 	ResolveAllRefs           bool   // --resolve-all-refs: force resolved execution for all rules
 	NestedRefsDocContext     bool   // --nested-refs-doc-context: resolve nested relative refs from the referenced document during resolved execution
 	OutputAbsPathsFlag       bool
+	BaselineFile             string // --baseline: path to a baseline of accepted violations
+	UpdateBaseline           bool   // --update-baseline: record current violations in the baseline
+	RecordFlag               bool   // --record: record the statistics of the run in the history database
+	HistoryDBFlag            string // --history-db: path to the history database
+	ProfileFlag              string // --profile: render how each rule performed, as a table or json
+	WorkersFlag              int    // --workers: number of files linted at the same time, 0 is one per CPU
+	CatalogFlag              bool   // --catalog: lint many files as one catalog, running the cross-document catalog rules
+	CacheDirFlag             string // --cache-dir: cache results, specifications that have not changed are not linted again
+	SinceFlag                string // --since: lint the specifications changed since a git ref, reporting only new violations
 }
 
 // FileProcessingConfig contains all configuration needed to process a file
@@ -123,7 +138,12 @@ type FileProcessingConfig struct {
 	SelectedRuleset *rulesets.RuleSet
 	CustomFunctions map[string]model.RuleFunction
 	IgnoredItems    model.IgnoredItems
+	Baseline        *utils.Baseline
 	FetchConfig     *utils.FetchConfig
+	ScoreModel      *model.ScoreModel
+	History         *history.Store // runs are recorded in the history database when set
+	Cache           *lintCache     // results are cached when set
+	Since           *sinceRevision // results are compared with the version of each file at a git ref when set
 }
 
 // ReadLintFlags reads all lint-related flags from the command
@@ -222,6 +242,8 @@ func ReadLintFlags(cmd *cobra.Command) *LintFlags {
 	}
 	flags.FixFlag, _ = cmd.Flags().GetBool("fix")
 	flags.FixFileFlag, _ = cmd.Flags().GetString("fix-file")
+	flags.FixOverlayFlag, _ = cmd.Flags().GetString("fix-overlay")
+	flags.ProfileFlag, _ = cmd.Flags().GetString("profile")
 	flags.ChangesFlag, _ = cmd.Flags().GetString("changes")
 	flags.OriginalFlag, _ = cmd.Flags().GetString("original")
 	flags.ChangesSummaryFlag, _ = cmd.Flags().GetBool("changes-summary")
@@ -241,6 +263,32 @@ func ReadLintFlags(cmd *cobra.Command) *LintFlags {
 		flags.NestedRefsDocContext = viper.GetBool("lint.nested-refs-doc-context")
 	}
 	flags.OutputAbsPathsFlag, _ = cmd.Flags().GetBool("abs-paths")
+	flags.BaselineFile, _ = cmd.Flags().GetString("baseline")
+	if flags.BaselineFile == "" && viper.IsSet("lint.baseline") {
+		flags.BaselineFile = viper.GetString("lint.baseline")
+	}
+	flags.UpdateBaseline, _ = cmd.Flags().GetBool("update-baseline")
+	flags.RecordFlag, _ = cmd.Flags().GetBool("record")
+	if !cmd.Flags().Changed("record") && viper.IsSet("lint.record") {
+		flags.RecordFlag = viper.GetBool("lint.record")
+	}
+	flags.HistoryDBFlag, _ = cmd.Flags().GetString("history-db")
+	if flags.HistoryDBFlag == "" && viper.IsSet("lint.history-db") {
+		flags.HistoryDBFlag = viper.GetString("lint.history-db")
+	}
+	flags.WorkersFlag, _ = cmd.Flags().GetInt("workers")
+	if !cmd.Flags().Changed("workers") && viper.IsSet("lint.workers") {
+		flags.WorkersFlag = viper.GetInt("lint.workers")
+	}
+	flags.CatalogFlag, _ = cmd.Flags().GetBool("catalog")
+	if !cmd.Flags().Changed("catalog") && viper.IsSet("lint.catalog") {
+		flags.CatalogFlag = viper.GetBool("lint.catalog")
+	}
+	flags.CacheDirFlag, _ = cmd.Flags().GetString("cache-dir")
+	if flags.CacheDirFlag == "" && viper.IsSet("lint.cache-dir") {
+		flags.CacheDirFlag = viper.GetString("lint.cache-dir")
+	}
+	flags.SinceFlag, _ = cmd.Flags().GetString("since")
 	return flags
 }
 
@@ -262,9 +310,19 @@ func SetupVacuumEnvironment(flags *LintFlags) {
 	}
 }
 
-// LoadIgnoreFile loads and parses the ignore file if specified
+// LoadIgnoreFile loads and parses the ignore file if specified, entries that have expired are left out.
 func LoadIgnoreFile(ignoreFile string, silent, pipeline, noStyle bool) (model.IgnoredItems, error) {
-	ignoredItems := model.IgnoredItems{}
+	entries, err := LoadIgnoreFileEntries(ignoreFile, silent, pipeline, noStyle)
+	if err != nil {
+		return model.IgnoredItems{}, err
+	}
+	return entries.Active(time.Now()), nil
+}
+
+// LoadIgnoreFileEntries loads and parses the ignore file if specified, along with the reason, owner and
+// expiry of each entry.
+func LoadIgnoreFileEntries(ignoreFile string, silent, pipeline, noStyle bool) (model.IgnoreEntries, error) {
+	ignoredItems := model.IgnoreEntries{}
 	if ignoreFile == "" {
 		return ignoredItems, nil
 	}
@@ -308,15 +366,44 @@ func LoadIgnoreFile(ignoreFile string, silent, pipeline, noStyle bool) (model.Ig
 		}
 		return ignoredItems, fmt.Errorf("failed to parse ignore file: %w", err)
 	}
+	if err = ignoredItems.Validate(); err != nil {
+		if !silent {
+			fmt.Printf("%sError: Invalid ignore file '%s': %v%s\n\n",
+				color.ASCIIRed, resolvedPath, err, color.ASCIIReset)
+		}
+		return ignoredItems, fmt.Errorf("invalid ignore file: %w", err)
+	}
 
 	if !silent && !pipeline {
 		renderInfoMessage(fmt.Sprintf("Using ignore file '%s'", resolvedPath), noStyle)
 		renderIgnoredItems(ignoredItems, noStyle)
 	}
+	if !silent {
+		renderExpiredIgnoredItems(ignoredItems.Expired(time.Now()))
+	}
 
 	return ignoredItems, nil
 }
 
+// renderExpiredIgnoredItems warns about ignore entries that have expired, and are no longer honored.
+func renderExpiredIgnoredItems(expired model.IgnoreEntries) {
+	ruleIDs := make([]string, 0, len(expired))
+	for ruleID := range expired {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+	for _, ruleID := range ruleIDs {
+		for _, ignored := range expired[ruleID] {
+			owner := ""
+			if ignored.Owner != "" {
+				owner = fmt.Sprintf(" (owner: %s)", ignored.Owner)
+			}
+			tui.RenderWarning("Ignore entry for '%s' at '%s' expired on %s and is no longer honored%s",
+				ruleID, ignored.Path, ignored.Expires, owner)
+		}
+	}
+}
+
 // CreateHTTPClientFromFlags creates an HTTP client based on certificate flags
 func CreateHTTPClientFromFlags(flags *LintFlags) (*http.Client, error) {
 	httpClientConfig, err := GetHTTPClientConfig(flags)
@@ -477,6 +564,27 @@ func GetFetchConfig(flags *LintFlags) (*utils.FetchConfig, error) {
 
 // ProcessSingleFileOptimized processes a single file using pre-loaded configuration
 func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *FileProcessingResult {
+	linted, failed := lintSingleFile(fileName, config)
+	if failed != nil {
+		return failed
+	}
+	return finishLintedFile(linted, config)
+}
+
+// lintedFile is a file that has been linted, but whose results are yet to be filtered, counted and recorded.
+type lintedFile struct {
+	fileName       string
+	fileSize       int64
+	specBytes      []byte
+	bufferedLogger *logging.BufferedLogger
+	ruleSet        *rulesets.RuleSet
+	result         *motor.RuleSetExecutionResult
+	documentStats  *reports.ReportStatistics // the statistics of a document replayed from the cache
+}
+
+// lintSingleFile runs the rules against a file, the result of a file that could not be linted is returned instead.
+// The execution is kept until finishLintedFile releases it.
+func lintSingleFile(fileName string, config *FileProcessingConfig) (*lintedFile, *FileProcessingResult) {
 	var fileSize int64
 	fileInfo, err := os.Stat(fileName)
 	if err == nil {
@@ -503,7 +611,7 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 
 	specBytes, err := os.ReadFile(fileName)
 	if err != nil {
-		return &FileProcessingResult{
+		return nil, &FileProcessingResult{
 			FileSize: fileSize,
 			Error:    err,
 		}
@@ -518,14 +626,14 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 	// Resolve base path for this specific file
 	resolvedBase, baseErr := ResolveBasePathForFile(fileName, config.Flags.BaseFlag)
 	if baseErr != nil {
-		return &FileProcessingResult{
+		return nil, &FileProcessingResult{
 			FileSize: fileSize,
 			Error:    fmt.Errorf("failed to resolve base path: %w", baseErr),
 		}
 	}
 	resolvedSpecPath, specPathErr := ResolveSpecPathForExecution(fileName)
 	if specPathErr != nil {
-		return &FileProcessingResult{
+		return nil, &FileProcessingResult{
 			FileSize: fileSize,
 			Error:    fmt.Errorf("failed to resolve spec path: %w", specPathErr),
 		}
@@ -533,18 +641,18 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 
 	httpClientConfig, err := GetHTTPClientConfig(config.Flags)
 	if err != nil {
-		return &FileProcessingResult{
+		return nil, &FileProcessingResult{
 			FileSize: fileSize,
 			Error:    err,
 		}
 	}
 
-	result := motor.ApplyRulesToRuleSetWithOptions(&motor.RuleSetExecution{
+	execution := &motor.RuleSetExecution{
 		RuleSet:                         selectedRuleset,
 		Spec:                            specBytes,
 		SpecFileName:                    resolvedSpecPath,
 		CustomFunctions:                 config.CustomFunctions,
-		AutoFixFunctions:                make(map[string]model.AutoFixFunction),
+		AutoFixFunctions:                autofix.GetBuiltInAutoFixFunctions(),
 		Base:                            resolvedBase,
 		AllowLookup:                     config.Flags.RemoteFlag,
 		SkipDocumentCheck:               config.Flags.SkipCheckFlag,
@@ -558,13 +666,17 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 		Logger:                          logger,
 		HTTPClientConfig:                httpClientConfig,
 		ApplyAutoFixes:                  config.Flags.FixFlag,
+		ProfileRules:                    config.Flags.ProfileFlag != "",
 		FetchConfig:                     config.FetchConfig,
 		TurboMode:                       config.Flags.TurboMode,
 		SpecFormat:                      specFormat,
-	}, newMotorExecutionOptionsFromLintFlags(config.Flags))
-	defer result.ReleaseOwnedResources()
+	}
+	executionOptions := newMotorExecutionOptionsFromLintFlags(config.Flags)
+	result, documentStats := config.Cache.applyRules(execution, executionOptions)
 
 	if len(result.Errors) > 0 {
+		lintErr := result.Errors[0]
+		result.ReleaseOwnedResources()
 		var logs []string
 		if bufferedLogger != nil {
 			// Render the buffered logs as a tree
@@ -575,10 +687,42 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 				logs = append(logs, treeOutput)
 			}
 		}
-		return &FileProcessingResult{
+		return nil, &FileProcessingResult{
 			FileSize: fileSize,
 			Logs:     logs,
-			Error:    result.Errors[0],
+			Error:    lintErr,
+		}
+	}
+
+	if config.Since != nil {
+		result.Results = config.Since.compare(fileName, specBytes, result.Results, execution, executionOptions,
+			config.Flags, logger)
+	}
+
+	return &lintedFile{
+		fileName:       fileName,
+		fileSize:       fileSize,
+		specBytes:      specBytes,
+		bufferedLogger: bufferedLogger,
+		ruleSet:        selectedRuleset,
+		result:         result,
+		documentStats:  documentStats,
+	}, nil
+}
+
+// finishLintedFile filters, counts and records the results of a linted file, then releases its execution.
+func finishLintedFile(linted *lintedFile, config *FileProcessingConfig) *FileProcessingResult {
+	fileName, fileSize, specBytes := linted.fileName, linted.fileSize, linted.specBytes
+	bufferedLogger, selectedRuleset, result := linted.bufferedLogger, linted.ruleSet, linted.result
+	defer result.ReleaseOwnedResources()
+
+	// files linted together are always fixed in place, --fix-file is rejected for many files. A fixed file that
+	// cannot be written is reported with the results, they are still correct.
+	fixesApplied := len(result.FixedResults)
+	var fixErr error
+	if fixesApplied > 0 && config.Flags.FixFlag {
+		if err := writeFixedFile(result, fileName, ""); err != nil {
+			fixErr = fmt.Errorf("failed to write fixed file: %w", err)
 		}
 	}
 
@@ -596,8 +740,12 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 		}
 
 		results = append(results, &result.Results[i])
+	}
 
-		switch result.Results[i].Rule.Severity {
+	// fingerprints need the rolodex, so the baseline is applied before the execution releases it.
+	results, baselined := applyBaseline(config.Baseline, config.Flags, fileName, results)
+	for _, r := range results {
+		switch r.Rule.Severity {
 		case "error":
 			errors++
 		case "warn":
@@ -609,6 +757,15 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 		}
 	}
 
+	var historyErr error
+	if config.History != nil {
+		// the index is needed for the statistics, so the run is recorded before the execution releases it.
+		stats := lintStatistics(result, linted.documentStats, model.NewRuleResultSetPointer(results), config.ScoreModel)
+		if stats != nil {
+			historyErr = recordLintHistory(config.History, fileName, selectedRuleset, stats)
+		}
+	}
+
 	var logs []string
 	if bufferedLogger != nil {
 		// Render the buffered logs as a tree
@@ -620,14 +777,23 @@ func ProcessSingleFileOptimized(fileName string, config *FileProcessingConfig) *
 		}
 	}
 
+	var profile *RuleProfileReport
+	if config.Flags.ProfileFlag != "" {
+		profile = newRuleProfileReport(fileName, result)
+	}
+
 	return &FileProcessingResult{
-		Results:  results,
-		Errors:   errors,
-		Warnings: warnings,
-		Informs:  informs,
-		Hints:    hints,
-		FileSize: fileSize,
-		Logs:     logs,
-		Error:    nil,
+		Results:      results,
+		Profile:      profile,
+		Errors:       errors,
+		Warnings:     warnings,
+		Informs:      informs,
+		Hints:        hints,
+		FixesApplied: fixesApplied,
+		Baselined:    baselined,
+		FileSize:     fileSize,
+		Logs:         logs,
+		FixError:     fixErr,
+		HistoryError: historyErr,
 	}
 }
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestLoadIgnoreFile_Justifications(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	writeTestFile(t, ignoreFile, `
+info-contact:
+  - $.info
+  - path: $.info.contact
+    reason: contact details are added by the portal
+    owner: docs-team
+    expires: 2020-01-01
+`)
+
+	var ignored model.IgnoreEntries
+	var err error
+	stdout, stderr := captureOSStreams(t, func() {
+		ignored, err = LoadIgnoreFileEntries(ignoreFile, false, false, true)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.IgnoreEntries{"info-contact": {
+		{Path: "$.info"},
+		{Path: "$.info.contact", Reason: "contact details are added by the portal", Owner: "docs-team", Expires: "2020-01-01"},
+	}}, ignored)
+
+	output := stdout + stderr
+	assert.Contains(t, output, "info-contact: $.info.contact (contact details are added by the portal, owner: docs-team, expires: 2020-01-01)")
+	assert.Contains(t, output, "Ignore entry for 'info-contact' at '$.info.contact' expired on 2020-01-01 and is no longer honored (owner: docs-team)")
+
+	items, err := LoadIgnoreFile(ignoreFile, true, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, model.IgnoredItems{"info-contact": {"$.info"}}, items)
+}
+
+func TestLoadIgnoreFile_InvalidExpiry(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	writeTestFile(t, ignoreFile, `
+info-contact:
+  - path: $.info
+    expires: soon
+`)
+
+	_, err := LoadIgnoreFile(ignoreFile, true, false, true)
+	assert.ErrorContains(t, err, "expiry date 'soon' is not a valid date")
+}
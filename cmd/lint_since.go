@@ -0,0 +1,261 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// sinceRevision is a git ref that specifications are compared with. The specifications changed since the ref
+// are linted, and only the violations the changes introduced are reported, as if their old versions had been
+// given to --original.
+type sinceRevision struct {
+	ref     string
+	commit  string // the commit HEAD left the ref at, changes made to the ref since are not ours.
+	repo    *utils.GitRepository
+	changed map[string]struct{} // files changed since the commit, relative to the root of the repository.
+}
+
+// newSinceRevision opens the git repository the files are in (or the working directory, when there are no
+// files), and finds the files changed since the ref.
+func newSinceRevision(ref string, files []string) (*sinceRevision, error) {
+	dir := "."
+	if len(files) > 0 {
+		dir = filepath.Dir(files[0])
+	}
+	repo, err := utils.OpenGitRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.ResolveCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+	commit = repo.MergeBase(commit)
+
+	changedFiles, err := repo.ChangedFiles(commit)
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[string]struct{}, len(changedFiles))
+	for _, file := range changedFiles {
+		if rel, ok := repo.RelativePath(file); ok {
+			changed[rel] = struct{}{}
+		}
+	}
+	return &sinceRevision{ref: ref, commit: commit, repo: repo, changed: changed}, nil
+}
+
+// changedSpecs returns the files that have changed since the ref, or reference a file that has. Without any
+// files, every specification in the repository is checked.
+func (s *sinceRevision) changedSpecs(files []string) ([]string, error) {
+	candidates := files
+	if len(candidates) == 0 {
+		repoFiles, err := s.repo.Files()
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range repoFiles {
+			if utils.IsSpecFile(file) && isSpecDocument(file) {
+				candidates = append(candidates, file)
+			}
+		}
+	}
+
+	var changed []string
+	for _, file := range candidates {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		rel, ok := s.repo.RelativePath(abs)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not in the git repository '%s'", file, s.repo.Root)
+		}
+		if s.specChanged(rel) {
+			changed = append(changed, file)
+		}
+	}
+	return changed, nil
+}
+
+// specChanged reports if a specification, or any local file it references, has changed since the ref.
+func (s *sinceRevision) specChanged(rel string) bool {
+	queue := []string{rel}
+	seen := make(map[string]struct{})
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[current]; ok {
+			continue
+		}
+		seen[current] = struct{}{}
+		if _, ok := s.changed[current]; ok {
+			return true
+		}
+
+		path := filepath.Join(s.repo.Root, filepath.FromSlash(current))
+		specBytes, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, refFile := range collectLocalReferenceFiles(specBytes) {
+			if next, ok := s.repo.RelativePath(resolveReferenceFilePath(filepath.Dir(path), refFile)); ok {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// snapshot writes the version a specification had at the ref, and the local files it referenced, to a temporary
+// directory laid out like the repository. The path of the old specification is returned, along with a function
+// that removes the directory. The path is empty when the specification did not exist at the ref.
+func (s *sinceRevision) snapshot(fileName string) (string, func(), error) {
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return "", nil, err
+	}
+	rel, ok := s.repo.RelativePath(abs)
+	if !ok {
+		return "", nil, fmt.Errorf("'%s' is not in the git repository '%s'", fileName, s.repo.Root)
+	}
+	specBytes, err := s.repo.ReadFile(s.commit, rel)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "vacuum-since-")
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create a directory for the original specification: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	queue := []string{rel}
+	contents := map[string][]byte{rel: specBytes}
+	seen := make(map[string]struct{})
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[current]; ok {
+			continue
+		}
+		seen[current] = struct{}{}
+
+		data, read := contents[current]
+		if !read {
+			// files that were not referenced yet, or were outside the repository, are not part of the old version.
+			if data, err = s.repo.ReadFile(s.commit, current); err != nil {
+				continue
+			}
+		}
+		path := filepath.Join(dir, filepath.FromSlash(current))
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("unable to write the original version of '%s': %w", current, err)
+		}
+
+		repoPath := filepath.Join(s.repo.Root, filepath.FromSlash(current))
+		for _, refFile := range collectLocalReferenceFiles(data) {
+			if next, ok := s.repo.RelativePath(resolveReferenceFilePath(filepath.Dir(repoPath), refFile)); ok {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return filepath.Join(dir, filepath.FromSlash(rel)), cleanup, nil
+}
+
+// compare removes the violations a specification already had at the ref from its results, and adds the change
+// violations requested by --warn-on-changes and --error-on-breaking. A specification that is new since the ref
+// keeps all of its results. Problems comparing are logged, and the results are returned as they are.
+func (s *sinceRevision) compare(fileName string, specBytes []byte, results []model.RuleFunctionResult,
+	execution *motor.RuleSetExecution, executionOptions *motor.ExecutionOptions, flags *LintFlags,
+	logger *slog.Logger) []model.RuleFunctionResult {
+
+	originalPath, cleanup, err := s.snapshot(fileName)
+	if err != nil {
+		logger.Warn("unable to read the original specification", "ref", s.ref, "error", err.Error())
+		return results
+	}
+	if originalPath == "" {
+		return results
+	}
+	defer cleanup()
+
+	results, _ = applyOriginalDiffToValues(originalValueDiffOptions{
+		OriginalPath:     originalPath,
+		CurrentPath:      fileName,
+		Results:          results,
+		Execution:        execution,
+		ExecutionOptions: executionOptions,
+		WarnOriginalLintFailure: func(err error) {
+			logger.Warn("unable to lint the original specification", "ref", s.ref, "error", err.Error())
+		},
+	})
+
+	if flags.WarnOnChanges || flags.ErrorOnBreaking {
+		changeResult, changeErr := utils.GenerateChangeReportWithTree(originalPath, specBytes, fileName)
+		if changeErr != nil {
+			logger.Warn("unable to compare with the original specification", "ref", s.ref, "error", changeErr.Error())
+			return results
+		}
+		changeViolations := utils.GenerateChangeViolations(changeResult.DocumentChanges, utils.ChangeViolationOptions{
+			WarnOnChanges:   flags.WarnOnChanges,
+			ErrorOnBreaking: flags.ErrorOnBreaking,
+		})
+		for _, v := range changeViolations {
+			if v != nil {
+				results = append(results, *v)
+			}
+		}
+	}
+	return results
+}
+
+// isSpecDocument reports if a file is an OpenAPI, Swagger or AsyncAPI document, rather than a file of schemas
+// or anything else that happens to be YAML or JSON.
+func isSpecDocument(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	// avoid parsing files that cannot be a specification.
+	if !bytes.Contains(data, []byte("openapi")) && !bytes.Contains(data, []byte("swagger")) &&
+		!bytes.Contains(data, []byte("asyncapi")) {
+		return false
+	}
+	var root yaml.Node
+	if err = yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return false
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(doc.Content)-1; i += 2 {
+		switch doc.Content[i].Value {
+		case "openapi", "swagger", "asyncapi":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+const sinceTestSpec = `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: './schemas.yaml#/Pet'
+`
+
+const sinceTestSchemas = `
+Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`
+
+// newSinceTestRepository creates a git repository with a specification that references a file of schemas, both
+// committed.
+func newSinceTestRepository(t *testing.T) (string, func(args ...string)) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=vacuum", "-c", "user.email=vacuum@pb33f.io",
+			"-c", "commit.gpgsign=false"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	writeTestFile(t, filepath.Join(dir, "openapi.yaml"), sinceTestSpec)
+	writeTestFile(t, filepath.Join(dir, "schemas.yaml"), sinceTestSchemas)
+	git("add", "-A")
+	git("commit", "-q", "-m", "pets")
+	return dir, git
+}
+
+// runLintForSinceTest runs the lint command with the persistent flags of the root command, the change flags are
+// among them.
+func runLintForSinceTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := GetLintCommand()
+	registerPersistentFlags(cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	cmd.SetArgs(append([]string{"--no-banner", "--no-style", "--remote=false"}, args...))
+
+	var err error
+	stdout, stderr := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	return stdout + stderr + b.String(), err
+}
+
+func TestSinceRevision_ChangedSpecs(t *testing.T) {
+	dir, git := newSinceTestRepository(t)
+	spec := filepath.Join(dir, "openapi.yaml")
+	other := filepath.Join(dir, "other.yaml")
+	writeTestFile(t, other, `
+openapi: 3.1.0
+info:
+  title: Other
+  version: 1.0.0
+paths: {}
+`)
+	git("add", "-A")
+	git("commit", "-q", "-m", "other")
+
+	since, err := newSinceRevision("HEAD", []string{spec, other})
+	require.NoError(t, err)
+	changed, err := since.changedSpecs([]string{spec, other})
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// a change to a referenced file changes the specification referencing it.
+	writeTestFile(t, filepath.Join(dir, "schemas.yaml"), `
+Pet:
+  type: object
+`)
+	since, err = newSinceRevision("HEAD", []string{spec, other})
+	require.NoError(t, err)
+	changed, err = since.changedSpecs([]string{spec, other})
+	require.NoError(t, err)
+	assert.Equal(t, []string{spec}, changed)
+
+	// without files, the specifications of the repository are found, the file of schemas is not one.
+	t.Chdir(dir)
+	changed, err = since.changedSpecs(nil)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, "openapi.yaml", filepath.Base(changed[0]))
+
+	_, err = newSinceRevision("no-such-branch", []string{spec})
+	assert.Error(t, err)
+}
+
+func TestSinceRevision_Snapshot(t *testing.T) {
+	dir, _ := newSinceTestRepository(t)
+	spec := filepath.Join(dir, "openapi.yaml")
+	writeTestFile(t, filepath.Join(dir, "schemas.yaml"), "Pet:\n  type: object\n")
+
+	since, err := newSinceRevision("HEAD", []string{spec})
+	require.NoError(t, err)
+	original, cleanup, err := since.snapshot(spec)
+	require.NoError(t, err)
+	require.NotEmpty(t, original)
+
+	specBytes, err := os.ReadFile(original)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(sinceTestSpec, "\n"), string(specBytes))
+	schemaBytes, err := os.ReadFile(filepath.Join(filepath.Dir(original), "schemas.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(sinceTestSchemas, "\n"), string(schemaBytes), "referenced files have their old version")
+
+	cleanup()
+	_, err = os.Stat(original)
+	assert.True(t, os.IsNotExist(err))
+
+	// a specification that did not exist at the ref has no old version.
+	added := filepath.Join(dir, "added.yaml")
+	writeTestFile(t, added, sinceTestSpec)
+	original, _, err = since.snapshot(added)
+	require.NoError(t, err)
+	assert.Empty(t, original)
+}
+
+func TestGetLintCommand_Since(t *testing.T) {
+	dir, _ := newSinceTestRepository(t)
+	spec := filepath.Join(dir, "openapi.yaml")
+
+	output, err := runLintForSinceTest(t, "-d", "--no-clip", "--fail-severity", "none", "--since", "HEAD", spec)
+	require.NoError(t, err)
+	assert.Contains(t, output, "no specifications have changed since 'HEAD'")
+
+	// the new operation's violations are reported, the ones /pets already had are not.
+	writeTestFile(t, spec, sinceTestSpec+`
+  /owners:
+    get:
+      responses:
+        '200':
+          description: ok
+`)
+	output, err = runLintForSinceTest(t, "-d", "--no-clip", "--fail-severity", "none", "--since", "HEAD", spec)
+	require.NoError(t, err)
+	assert.Contains(t, output, "/owners")
+	assert.NotContains(t, output, "paths['/pets']")
+
+	all, err := runLintForSinceTest(t, "-d", "--no-clip", "--fail-severity", "none", spec)
+	require.NoError(t, err)
+	assert.Contains(t, all, "paths['/pets']")
+}
+
+func TestGetLintCommand_Since_MultipleFiles(t *testing.T) {
+	dir, git := newSinceTestRepository(t)
+	spec := filepath.Join(dir, "openapi.yaml")
+	copied := filepath.Join(dir, "copied.yaml")
+	writeTestFile(t, copied, sinceTestSpec)
+	git("add", "-A")
+	git("commit", "-q", "-m", "copied")
+
+	// removing an operation is a breaking change, adding a new specification reports all of its violations.
+	writeTestFile(t, copied, `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+`)
+	added := filepath.Join(dir, "added.yaml")
+	writeTestFile(t, added, sinceTestSpec)
+
+	output, err := runLintForSinceTest(t, "-d", "--no-clip", "--fail-severity", "none", "--error-on-breaking",
+		"--since", "HEAD", spec, copied, added)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Breaking change")
+	assert.Contains(t, output, "added.yaml")
+	assert.Contains(t, output, "paths['/pets']")
+	assert.NotContains(t, output, "openapi.yaml", "unchanged specifications are not linted")
+}
+
+func TestGetLintCommand_Since_Invalid(t *testing.T) {
+	dir, _ := newSinceTestRepository(t)
+	spec := filepath.Join(dir, "openapi.yaml")
+
+	_, err := runLintForSinceTest(t, "--since", "HEAD", "--original", spec, spec)
+	assert.ErrorContains(t, err, "--since cannot be used with --original")
+
+	_, err = runLintForSinceTest(t, "--since", "no-such-branch", spec)
+	assert.ErrorContains(t, err, "unknown git ref 'no-such-branch'")
+}
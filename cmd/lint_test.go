@@ -213,6 +213,49 @@ func TestGetLintCommand_FixWarnsWhenNoReportedViolationsSupportAutoFix(t *testin
 	assert.Contains(t, output, "none of the reported violations support auto-fix")
 }
 
+func TestGetLintCommand_FixFileWritesBuiltInFixes(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	writeTestFile(t, specPath, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+servers:
+  - url: https://api.pb33f.io/
+paths:
+  /pizza/:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: ok`)
+
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+
+	fixPath := filepath.Join(t.TempDir(), "fixed.yaml")
+	cmd.SetArgs([]string{
+		"--no-banner",
+		"--no-style",
+		"--fail-severity", "none",
+		"--fix",
+		"--fix-file", fixPath,
+		specPath,
+	})
+
+	var err error
+	captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+
+	fixed, readErr := os.ReadFile(fixPath)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(fixed), "/pizza:")
+	assert.NotContains(t, string(fixed), "/pizza/:")
+}
+
 func TestRenderNoFixesAppliedWarningRespectsOutputMode(t *testing.T) {
 	resultSet := &model.RuleResultSet{
 		Results: []*model.RuleFunctionResult{
@@ -0,0 +1,122 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/spf13/cobra"
+)
+
+// addWorkersFlag adds --workers to a command that can lint more than one file.
+func addWorkersFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("workers", 0, "Number of files to lint at the same time when linting more than one file (defaults to the number of CPUs)")
+}
+
+// readWorkersFlag returns the --workers value, 0 means one worker per CPU.
+func readWorkersFlag(cmd *cobra.Command) (int, error) {
+	workers, _ := cmd.Flags().GetInt("workers")
+	return workers, checkWorkers(workers)
+}
+
+func checkWorkers(workers int) error {
+	if workers < 0 {
+		return NewInputError("--workers must be 0 (one per CPU) or more, not %d", workers)
+	}
+	return nil
+}
+
+// lintInOrder lints files using up to workers goroutines (one per CPU when workers is 0), and yields the result of
+// each file in the order the files were given, so output and totals do not depend on which file finished first.
+//
+// Files are only linted up to twice the number of workers ahead of the file being yielded, a slow file does not
+// leave the results of every other file waiting in memory. Breaking out of the loop stops the remaining files from
+// being linted. Rulesets and custom functions passed to lint are shared by every worker, so lint must not modify them.
+func lintInOrder[T any](files []string, workers int, lint func(file string) T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		poolSize := workers
+		if poolSize < 1 {
+			poolSize = runtime.NumCPU()
+		}
+		poolSize = min(poolSize, len(files))
+		if poolSize <= 1 {
+			for i, file := range files {
+				if !yield(i, lint(file)) {
+					return
+				}
+			}
+			return
+		}
+
+		results := make([]chan T, len(files))
+		for i := range results {
+			results[i] = make(chan T, 1)
+		}
+		jobs := make(chan int)
+		window := make(chan struct{}, poolSize*2)
+		done := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		go func() {
+			defer close(jobs)
+			for i := range files {
+				select {
+				case window <- struct{}{}:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- i:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		for range poolSize {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					results[i] <- lint(files[i])
+				}
+			}()
+		}
+
+		for i := range files {
+			result := <-results[i]
+			<-window
+			if !yield(i, result) {
+				return
+			}
+		}
+	}
+}
+
+// lintedReportSpec is a specification linted by a report command, anything that went wrong is rendered once the
+// specification's turn comes around.
+type lintedReportSpec struct {
+	start            time.Time
+	specBytes        []byte
+	ruleSet          *rulesets.RuleSet // the ruleset the specification was linted against
+	asyncDefault     bool              // the ruleset is the default AsyncAPI ruleset
+	executionOptions *motor.ExecutionOptions
+	result           *motor.RuleSetExecutionResult
+	documentStats    *reports.ReportStatistics // the statistics of a specification replayed from the cache
+	readErr          error
+	baseErr          error
+	specPathErr      error
+}
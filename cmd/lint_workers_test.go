@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestLintInOrder(t *testing.T) {
+	files := []string{"a", "b", "c", "d", "e", "f"}
+	var running, mostRunning atomic.Int64
+	lint := func(file string) string {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			most := mostRunning.Load()
+			if current <= most || mostRunning.CompareAndSwap(most, current) {
+				break
+			}
+		}
+		// the first files are the slowest, so they finish last.
+		time.Sleep(time.Duration(len(files)-strings.Index("abcdef", file)) * 5 * time.Millisecond)
+		return strings.ToUpper(file)
+	}
+
+	var linted []string
+	for i, result := range lintInOrder(files, 3, lint) {
+		assert.Equal(t, strings.ToUpper(files[i]), result)
+		linted = append(linted, result)
+	}
+	assert.Equal(t, []string{"A", "B", "C", "D", "E", "F"}, linted)
+	assert.LessOrEqual(t, mostRunning.Load(), int64(3))
+	assert.Greater(t, mostRunning.Load(), int64(1))
+}
+
+func TestLintInOrder_Break(t *testing.T) {
+	files := make([]string, 100)
+	for i := range files {
+		files[i] = fmt.Sprintf("spec-%d.yaml", i)
+	}
+	var count atomic.Int64
+	for i := range lintInOrder(files, 2, func(string) int { return int(count.Add(1)) }) {
+		if i == 1 {
+			break
+		}
+	}
+	// only the files within the window of the first two files are linted.
+	assert.LessOrEqual(t, count.Load(), int64(6))
+
+	count.Store(0)
+	for range lintInOrder(files, 1, func(string) int { return int(count.Add(1)) }) {
+		break
+	}
+	assert.Equal(t, int64(1), count.Load())
+}
+
+func TestGetLintCommand_Workers(t *testing.T) {
+	specs := []string{
+		"../model/test_files/petstorev3.json",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/pegel-online-api.yaml",
+		"../model/test_files/petstorev2.json",
+	}
+	sequential, seqErr := runLintForBaselineTest(t, append([]string{"--workers", "1"}, specs...)...)
+	concurrent, conErr := runLintForBaselineTest(t, append([]string{"--workers", "4"}, specs...)...)
+
+	// results come back in the order the files were given, however long each file takes.
+	assert.Equal(t, seqErr, conErr)
+	assert.Equal(t, fileOutline(sequential), fileOutline(concurrent))
+	assert.Less(t, strings.Index(concurrent, "> ../model/test_files/petstorev3.json"),
+		strings.Index(concurrent, "> ../model/test_files/pegel-online-api.yaml"))
+	assert.Contains(t, concurrent, "[4/4] vacuumed ../model/test_files/petstorev2.json")
+
+	_, err := runLintForBaselineTest(t, "--workers", "-1", specs[0], specs[1])
+	assert.ErrorContains(t, err, "--workers must be 0 (one per CPU) or more, not -1")
+}
+
+func TestGetLintCommand_WorkersUpdateBaseline(t *testing.T) {
+	dir := t.TempDir()
+	var specs []string
+	for i := range 6 {
+		specPath := filepath.Join(dir, fmt.Sprintf("openapi-%d.yaml", i))
+		writeTestFile(t, specPath, baselineTestSpec)
+		specs = append(specs, specPath)
+	}
+	baselinePath := filepath.Join(dir, "baseline.yaml")
+
+	_, err := runLintForBaselineTest(t, append([]string{"--workers", "3", "--baseline", baselinePath, "--update-baseline"}, specs...)...)
+	require.NoError(t, err)
+	first, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+
+	// every file is accepted by the baseline, and writing it again gives the same file.
+	_, err = runLintForBaselineTest(t, append([]string{"--workers", "3", "--baseline", baselinePath}, specs...)...)
+	require.NoError(t, err)
+	_, err = runLintForBaselineTest(t, append([]string{"--workers", "2", "--baseline", baselinePath, "--update-baseline"}, specs...)...)
+	require.NoError(t, err)
+	second, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+}
+
+func TestGetSpectralReportCommand_Workers(t *testing.T) {
+	outputDir := t.TempDir()
+	cmd := GetSpectralReportCommand()
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--no-style", "--workers", "2",
+		"--globbed-files", "../model/test_files/petstorev*.json", "--output-dir", outputDir})
+
+	var err error
+	stdout, _ := captureOSStreams(t, func() {
+		err = cmd.Execute()
+	})
+	require.NoError(t, err)
+
+	files, globErr := filepath.Glob(filepath.Join(outputDir, "*.json"))
+	require.NoError(t, globErr)
+	assert.Len(t, files, 2)
+	assert.Less(t, strings.Index(stdout, "petstorev2.json"), strings.Index(stdout, "petstorev3.json"))
+}
+
+// fileOutline returns the progress, file headers and verdicts of multi-file lint output. Rules with the same number
+// of violations can be listed in any order, so the rest of the output is left out.
+func fileOutline(output string) []string {
+	var outline []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, " > ") || strings.HasPrefix(line, " | ") {
+			outline = append(outline, line)
+		}
+	}
+	return outline
+}
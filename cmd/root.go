@@ -97,6 +97,10 @@ func GetRootCommand() *cobra.Command {
 				tui.RenderError(err)
 				return err
 			}
+			if err = ConfigureRemoteRuleSetCache(cmd); err != nil {
+				tui.RenderError(err)
+				return err
+			}
 			StartUpdateCheck(cmd)
 			return nil
 		},
@@ -139,14 +143,19 @@ func GetRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().Bool("resolve-all-refs", false, "Force all rules to execute against the resolved document")
 	rootCmd.PersistentFlags().Bool("nested-refs-doc-context", false, "Resolve nested relative refs from the referenced document during resolved execution")
 	rootCmd.PersistentFlags().Bool("no-update-check", false, "Disable checking for newer vacuum releases")
+	rootCmd.PersistentFlags().Bool("offline", false, "Only use cached copies of remote rulesets, never download them")
 	rootCmd.AddCommand(GetLintCommand())
 	rootCmd.AddCommand(GetVacuumReportCommand())
 	rootCmd.AddCommand(GetSpectralReportCommand())
+	rootCmd.AddCommand(GetSarifReportCommand())
 	rootCmd.AddCommand(GetHTMLReportCommand())
 	rootCmd.AddCommand(GetDashboardCommand())
 	rootCmd.AddCommand(GetDocsCommand())
 	rootCmd.AddCommand(GetUpgradeCommand())
 	rootCmd.AddCommand(GetGenerateRulesetCommand())
+	rootCmd.AddCommand(GetTestRuleSetCommand())
+	rootCmd.AddCommand(GetHistoryCommand())
+	rootCmd.AddCommand(GetServeCommand())
 	rootCmd.AddCommand(GetGenerateIgnoreFileCommand())
 	rootCmd.AddCommand(GetGenerateVersionCommand())
 	rootCmd.AddCommand(GetLanguageServerCommand())
@@ -217,6 +226,9 @@ func GetRootCommand() *cobra.Command {
 	if regErr := rootCmd.RegisterFlagCompletionFunc("no-update-check", cobra.NoFileCompletions); regErr != nil {
 		panic(regErr)
 	}
+	if regErr := rootCmd.RegisterFlagCompletionFunc("offline", cobra.NoFileCompletions); regErr != nil {
+		panic(regErr)
+	}
 
 	return rootCmd
 }
@@ -0,0 +1,36 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/spf13/cobra"
+)
+
+// remoteRuleSetCacheFactory creates the cache used for remote rulesets, it is replaced in tests.
+var remoteRuleSetCacheFactory = rulesets.DefaultRemoteRuleSetCache
+
+// ConfigureRemoteRuleSetCache sets up the on-disk cache for remote rulesets. Remote rulesets are revalidated
+// with the server on every run, unless --offline is set, in which case only cached copies are used. Without a
+// cache an offline run would download remote rulesets anyway, so --offline fails if no cache can be opened.
+func ConfigureRemoteRuleSetCache(cmd *cobra.Command) error {
+	offline := boolFlagValue(cmd, "offline")
+	cache, err := remoteRuleSetCacheFactory()
+	if err != nil || cache == nil {
+		rulesets.SetRemoteRuleSetCache(nil)
+		if !offline {
+			return nil
+		}
+		if err == nil {
+			err = errors.New("no cache directory is available")
+		}
+		return NewInputError("--offline needs a cache for remote rulesets: %v", err)
+	}
+	cache.Offline = offline
+	rulesets.SetRemoteRuleSetCache(cache)
+	return nil
+}
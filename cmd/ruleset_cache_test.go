@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestConfigureRemoteRuleSetCache(t *testing.T) {
+	dir := t.TempDir()
+	previousFactory := remoteRuleSetCacheFactory
+	remoteRuleSetCacheFactory = func() (*rulesets.RemoteRuleSetCache, error) {
+		return &rulesets.RemoteRuleSetCache{Dir: dir}, nil
+	}
+	t.Cleanup(func() {
+		remoteRuleSetCacheFactory = previousFactory
+		rulesets.SetRemoteRuleSetCache(nil)
+	})
+
+	require.NoError(t, ConfigureRemoteRuleSetCache(boolFlagCommand("lint", "offline")))
+	cache := rulesets.GetRemoteRuleSetCache()
+	require.NotNil(t, cache)
+	assert.Equal(t, dir, cache.Dir)
+	assert.True(t, cache.Offline)
+
+	require.NoError(t, ConfigureRemoteRuleSetCache(boolFlagCommand("lint", "no-update-check")))
+	cache = rulesets.GetRemoteRuleSetCache()
+	require.NotNil(t, cache)
+	assert.False(t, cache.Offline)
+}
+
+func TestConfigureRemoteRuleSetCache_OfflineWithoutCache(t *testing.T) {
+	previousFactory := remoteRuleSetCacheFactory
+	remoteRuleSetCacheFactory = func() (*rulesets.RemoteRuleSetCache, error) {
+		return nil, errors.New("no user cache directory")
+	}
+	t.Cleanup(func() {
+		remoteRuleSetCacheFactory = previousFactory
+		rulesets.SetRemoteRuleSetCache(nil)
+	})
+
+	// an offline run without a cache would download remote rulesets, so it must fail.
+	err := ConfigureRemoteRuleSetCache(boolFlagCommand("lint", "offline"))
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, ExitCodeInputError, exitErr.Code)
+	assert.Contains(t, err.Error(), "no user cache directory")
+	assert.Nil(t, rulesets.GetRemoteRuleSetCache())
+
+	// without --offline, remote rulesets are downloaded without a cache.
+	assert.NoError(t, ConfigureRemoteRuleSetCache(boolFlagCommand("lint", "no-update-check")))
+	assert.Nil(t, rulesets.GetRemoteRuleSetCache())
+}
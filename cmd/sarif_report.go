@@ -0,0 +1,70 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/spf13/cobra"
+)
+
+var sarifJSONReport = jsonReport{
+	name:          "SARIF",
+	fileType:      "sarif-report",
+	extension:     ".sarif",
+	defaultOutput: "vacuum-report.sarif",
+	build: func(resultSet *model.RuleResultSet, source string) any {
+		// locations are relative to the directory vacuum runs from, which is usually the root of the repository.
+		baseDir, _ := os.Getwd()
+		return resultSet.GenerateSarifReport(source, baseDir, GetVersion())
+	},
+}
+
+func GetSarifReportCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "sarif-report",
+		Short:        "Generate a SARIF 2.1.0 report",
+		Long: `Generate a SARIF 2.1.0 (Static Analysis Results Interchange Format) report, for use with GitHub code scanning
+and other SARIF consumers. Default output filename is 'vacuum-report.sarif' located in the working directory.
+Use the -i flag for using stdin instead of reading a file, and -o for stdout, instead of writing to a file.
+
+For multiple files, use --globbed-files to specify a glob pattern:
+  vacuum sarif-report --globbed-files "specs/*.yaml" --output-dir reports/
+
+This generates one SARIF report per input file, named after the source spec.`,
+		Example: `vacuum sarif-report my-awesome-spec.yaml vacuum-report.sarif
+vacuum sarif-report --globbed-files "specs/*.yaml" --output-dir reports/
+vacuum sarif-report -o my-awesome-spec.yaml > results.sarif`,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			switch len(args) {
+			case 0:
+				return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+			case 1:
+				return []string{"sarif", "json"}, cobra.ShellCompDirectiveFilterFileExt
+			default:
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJSONReport(cmd, args, sarifJSONReport)
+		},
+	}
+	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
+	cmd.Flags().BoolP("stdout", "o", false, "Use stdout as output, instead of a file")
+	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().Bool("ignore-array-circle-ref", false, "Ignore circular array references")
+	cmd.Flags().Bool("ignore-polymorph-circle-ref", false, "Ignore circular polymorphic references")
+	cmd.Flags().String("globbed-files", "", "Glob pattern of files to process (e.g., 'specs/*.yaml')")
+	cmd.Flags().String("output-dir", "", "Directory to write report files to (default: current directory)")
+	addProfileFlag(cmd)
+	addWorkersFlag(cmd)
+	return cmd
+
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestGetSarifReportCommand(t *testing.T) {
+	cmd := GetSarifReportCommand()
+	b := bytes.NewBufferString("")
+	reportFile := filepath.Join(t.TempDir(), "vacuum-report.sarif")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--no-style",
+		"../model/test_files/petstorev3.json",
+		reportFile,
+	})
+	cmdErr := cmd.Execute()
+	assert.NoError(t, cmdErr)
+
+	data, err := os.ReadFile(requireSingleGeneratedFile(t, reportFile))
+	require.NoError(t, err)
+
+	var report reports.SarifReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, reports.SarifVersion, report.Version)
+	require.Len(t, report.Runs, 1)
+	assert.NotEmpty(t, report.Runs[0].Results)
+	assert.NotEmpty(t, report.Runs[0].Tool.Driver.Rules)
+
+	for _, result := range report.Runs[0].Results {
+		assert.Equal(t, report.Runs[0].Tool.Driver.Rules[result.RuleIndex].Id, result.RuleId)
+	}
+}
+
+func TestGetSarifReportCommand_StdInOut(t *testing.T) {
+	cmd := GetSarifReportCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"-i", "-o", "-n"})
+	cmd.SetIn(strings.NewReader("openapi: 3.1.0"))
+
+	var cmdErr error
+	stdout, _ := captureOSStreams(t, func() {
+		cmdErr = cmd.Execute()
+	})
+	assert.NoError(t, cmdErr)
+
+	var report reports.SarifReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, "stdin", report.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestGetSarifReportCommand_MultipleFiles(t *testing.T) {
+	cmd := GetSarifReportCommand()
+	b := bytes.NewBufferString("")
+	outputDir := t.TempDir()
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--no-style",
+		"--globbed-files", "../model/test_files/petstorev*.json",
+		"--output-dir", outputDir,
+	})
+	assert.NoError(t, cmd.Execute())
+
+	files, err := filepath.Glob(filepath.Join(outputDir, "*.sarif"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, files)
+}
+
+func TestGetSarifReportCommand_SuppressedResults(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte(`info-description:
+  - path: $.info
+    reason: internal API
+    owner: platform-team
+`), 0o644))
+
+	cmd := GetSarifReportCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"-i", "-o", "-n", "--ignore-file", ignoreFile})
+	cmd.SetIn(strings.NewReader("openapi: 3.1.0\ninfo:\n  title: pizza\n  version: 1.0.0\n"))
+
+	var cmdErr error
+	stdout, _ := captureOSStreams(t, func() {
+		cmdErr = cmd.Execute()
+	})
+	require.NoError(t, cmdErr)
+
+	var report reports.SarifReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	var suppressed []reports.SarifResult
+	for _, result := range report.Runs[0].Results {
+		if len(result.Suppressions) > 0 {
+			suppressed = append(suppressed, result)
+		}
+	}
+	require.Len(t, suppressed, 1)
+	assert.Equal(t, "info-description", suppressed[0].RuleId)
+	assert.Equal(t, "internal API, owner: platform-team", suppressed[0].Suppressions[0].Justification)
+}
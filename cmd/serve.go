@@ -0,0 +1,602 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	asyncapi_context "github.com/daveshanley/vacuum/asyncapi"
+	"github.com/daveshanley/vacuum/functions/autofix"
+	"github.com/daveshanley/vacuum/logging"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/daveshanley/vacuum/tui"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ServeRuleSetDefault is the ID of the ruleset supplied with --ruleset, used when a request names no ruleset.
+	ServeRuleSetDefault = "default"
+
+	serveFormatVacuum   = "vacuum"
+	serveFormatSpectral = "spectral"
+)
+
+// ServeLintRequest is the body of a lint request. Spec is the specification as a string (YAML or JSON), or as a
+// JSON object. A request body without a spec is linted as the specification itself, with the ruleset and format
+// taken from the query string.
+type ServeLintRequest struct {
+	Spec     json.RawMessage `json:"spec"`
+	FileName string          `json:"fileName,omitempty"` // used as the source of results, defaults to 'openapi.yaml'
+	Ruleset  string          `json:"ruleset,omitempty"`  // ID of the ruleset to lint with, see GET /v1/rulesets
+	Format   string          `json:"format,omitempty"`   // 'vacuum' (default) or 'spectral'
+}
+
+// ServeRuleSet describes a ruleset available to lint requests.
+type ServeRuleSet struct {
+	Id               string `json:"id"`
+	OpenAPIRules     int    `json:"openapiRules"`  // rules used for OpenAPI (and other non-AsyncAPI) specifications
+	AsyncAPIRules    int    `json:"asyncapiRules"` // rules used for AsyncAPI specifications
+	DocumentationURI string `json:"documentationUrl,omitempty"`
+	Default          bool   `json:"default,omitempty"`
+}
+
+// serveError is the body of every failed request.
+type serveError struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// servedRuleSet is a ruleset kept warm by the server. Built-in rulesets have an AsyncAPI variant, custom rulesets
+// are used for every specification.
+type servedRuleSet struct {
+	id       string
+	openAPI  *rulesets.RuleSet
+	asyncAPI *rulesets.RuleSet
+}
+
+func (s *servedRuleSet) forSpec(async bool) *rulesets.RuleSet {
+	if async && s.asyncAPI != nil {
+		return s.asyncAPI
+	}
+	return s.openAPI
+}
+
+// lintServerConfig configures a lint server.
+type lintServerConfig struct {
+	RuleSets          []*servedRuleSet
+	DefaultRuleSet    string // ID of the ruleset used when a request names none
+	CustomFunctions   map[string]model.RuleFunction
+	Timeout           time.Duration // rule timeout
+	LookupTimeout     time.Duration
+	AllowLookup       bool // look up local and remote references
+	ExtRefs           bool
+	TurboMode         bool
+	MaxBodyBytes      int64
+	MaxConcurrent     int
+	ExecutionOptions  *motor.ExecutionOptions
+	HTTPClientConfig  utils.HTTPClientConfig
+	FetchConfig       *utils.FetchConfig
+	Logger            *slog.Logger
+	IgnoreArrayCircle bool
+	IgnorePolyCircle  bool
+}
+
+// lintServer lints specifications posted over HTTP. Rulesets and custom functions are loaded once, and shared by
+// every request.
+type lintServer struct {
+	config   *lintServerConfig
+	ruleSets map[string]*servedRuleSet
+	limiter  chan struct{}
+	metrics  *lintServerMetrics
+	started  time.Time
+}
+
+func GetServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "serve",
+		Short:         "Run an HTTP server that lints specifications",
+		Long: `Run an HTTP server with a JSON API for linting OpenAPI and AsyncAPI specifications. Rulesets and custom
+functions are loaded once when the server starts, and kept warm between requests.
+
+Endpoints:
+  POST /v1/lint       lint a specification, returns a vacuum report (or a Spectral report with "format": "spectral")
+  GET  /v1/rulesets   list the rulesets requests can name
+  GET  /health        health check
+  GET  /metrics       request metrics, in the Prometheus text format
+
+The built-in rulesets are available as 'recommended', 'all' and 'owasp'. The ruleset supplied with --ruleset is
+available as 'default', and more rulesets can be added with --add-ruleset id=location. References are not looked
+up unless --allow-refs is set, as specifications are supplied by clients.`,
+		Example: `  vacuum serve
+  vacuum serve --listen :8080 -r my-ruleset.yaml --add-ruleset strict=strict-ruleset.yaml
+  curl -s localhost:8080/v1/lint -d '{"spec": "openapi: 3.1.0 ...", "ruleset": "strict"}'`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+	cmd.Flags().String("listen", "localhost:8080", "Address to listen on")
+	cmd.Flags().StringArray("add-ruleset", nil, "Make a ruleset available to requests, as id=location (repeatable)")
+	cmd.Flags().Bool("allow-refs", false, "Look up local and remote references in linted specifications")
+	cmd.Flags().Int("max-body-size", 10, "Largest request body accepted, in megabytes")
+	cmd.Flags().Int("max-concurrent", runtime.NumCPU(), "Largest number of specifications linted at the same time")
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	flags := ReadLintFlags(cmd)
+	listenFlag, _ := cmd.Flags().GetString("listen")
+	addRuleSetFlags, _ := cmd.Flags().GetStringArray("add-ruleset")
+	allowRefsFlag, _ := cmd.Flags().GetBool("allow-refs")
+	maxBodyFlag, _ := cmd.Flags().GetInt("max-body-size")
+	maxConcurrentFlag, _ := cmd.Flags().GetInt("max-concurrent")
+
+	PrintBanner()
+
+	logger := slog.New(logging.NewBufferedLogHandler(logging.NewDiscardLogger()))
+	if flags.DebugFlag {
+		logger = slog.Default()
+	}
+
+	config, err := buildLintServerConfig(flags, addRuleSetFlags, logger)
+	if err != nil {
+		tui.RenderErrorString("%s", err.Error())
+		return NewInputError("%s", err.Error())
+	}
+	config.AllowLookup = allowRefsFlag
+	config.MaxBodyBytes = int64(maxBodyFlag) << 20
+	config.MaxConcurrent = maxConcurrentFlag
+
+	server := newLintServer(config)
+	httpServer := &http.Server{
+		Addr:              listenFlag,
+		Handler:           server.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	listener, err := net.Listen("tcp", listenFlag)
+	if err != nil {
+		tui.RenderErrorString("Unable to listen on '%s': %s", listenFlag, err.Error())
+		return NewInputError("unable to listen on '%s': %s", listenFlag, err.Error())
+	}
+
+	var ids []string
+	for _, rs := range config.RuleSets {
+		ids = append(ids, rs.id)
+	}
+	tui.RenderInfo("Rulesets: %s (default: %s)", strings.Join(ids, ", "), config.DefaultRuleSet)
+	tui.RenderSuccess("Linting specifications at http://%s/v1/lint", listener.Addr().String())
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		shutdown <- httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err = httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-shutdown
+}
+
+// buildLintServerConfig loads every ruleset and custom function the server makes available.
+func buildLintServerConfig(flags *LintFlags, addRuleSets []string, logger *slog.Logger) (*lintServerConfig, error) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(logger)
+	// the built-in rulesets are shared, so each served ruleset gets its own copy of the rules before they change.
+	prepare := func(rs *rulesets.RuleSet, owasp bool) *rulesets.RuleSet {
+		rs = &rulesets.RuleSet{
+			Description:      rs.Description,
+			DocumentationURI: rs.DocumentationURI,
+			Formats:          rs.Formats,
+			RuleDefinitions:  rs.RuleDefinitions,
+			Rules:            maps.Clone(rs.Rules),
+			Scoring:          rs.Scoring,
+		}
+		MergeOWASPRulesToRuleSet(rs, owasp)
+		if flags.TurboMode {
+			rulesets.FilterRulesForTurbo(rs)
+		}
+		return rs
+	}
+
+	served := []*servedRuleSet{
+		{
+			id:       "recommended",
+			openAPI:  prepare(defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(), false),
+			asyncAPI: prepare(defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet(), false),
+		},
+		{
+			id:       "all",
+			openAPI:  prepare(defaultRuleSets.GenerateOpenAPIDefaultRuleSet(), false),
+			asyncAPI: prepare(defaultRuleSets.GenerateAsyncAPIDefaultRuleSet(), false),
+		},
+		{
+			id:       "owasp",
+			openAPI:  prepare(defaultRuleSets.GenerateOpenAPIDefaultRuleSet(), true),
+			asyncAPI: prepare(defaultRuleSets.GenerateAsyncAPIDefaultRuleSet(), false),
+		},
+	}
+	defaultID := "recommended"
+	if flags.HardModeFlag {
+		defaultID = "owasp"
+	}
+
+	httpClient, err := CreateHTTPClientFromFlags(flags)
+	if err != nil {
+		return nil, err
+	}
+	load := func(id, location string) error {
+		for _, existing := range served {
+			if existing.id == id {
+				return fmt.Errorf("ruleset id '%s' is used more than once", id)
+			}
+		}
+		rs, rsErr := BuildRuleSetFromUserSuppliedLocation(location, defaultRuleSets, flags.RemoteFlag, httpClient)
+		if rsErr != nil {
+			return fmt.Errorf("unable to load ruleset '%s': %w", location, rsErr)
+		}
+		MergeOWASPRulesToRuleSet(rs, flags.HardModeFlag)
+		if flags.TurboMode {
+			rulesets.FilterRulesForTurbo(rs)
+		}
+		served = append(served, &servedRuleSet{id: id, openAPI: rs})
+		return nil
+	}
+
+	if flags.RulesetFlag != "" {
+		if err = load(ServeRuleSetDefault, flags.RulesetFlag); err != nil {
+			return nil, err
+		}
+		defaultID = ServeRuleSetDefault
+	}
+	for _, entry := range addRuleSets {
+		id, location, ok := strings.Cut(entry, "=")
+		id, location = strings.TrimSpace(id), strings.TrimSpace(location)
+		if !ok || id == "" || location == "" {
+			return nil, fmt.Errorf("--add-ruleset '%s' must be id=location", entry)
+		}
+		if err = load(id, location); err != nil {
+			return nil, err
+		}
+	}
+
+	customFunctions, err := LoadCustomFunctions(flags.FunctionsFlag, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load custom functions '%s': %w", flags.FunctionsFlag, err)
+	}
+	httpClientConfig, err := GetHTTPClientConfig(flags)
+	if err != nil {
+		return nil, err
+	}
+	fetchConfig, err := GetFetchConfig(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lintServerConfig{
+		RuleSets:          served,
+		DefaultRuleSet:    defaultID,
+		CustomFunctions:   customFunctions,
+		Timeout:           time.Duration(flags.TimeoutFlag) * time.Second,
+		LookupTimeout:     time.Duration(flags.LookupTimeoutFlag) * time.Millisecond,
+		ExtRefs:           flags.ExtRefsFlag,
+		TurboMode:         flags.TurboMode,
+		ExecutionOptions:  newMotorExecutionOptionsFromLintFlags(flags),
+		HTTPClientConfig:  httpClientConfig,
+		FetchConfig:       fetchConfig,
+		Logger:            logger,
+		IgnoreArrayCircle: flags.IgnoreArrayCircleRef,
+		IgnorePolyCircle:  flags.IgnorePolymorphCircleRef,
+	}, nil
+}
+
+func newLintServer(config *lintServerConfig) *lintServer {
+	if config.MaxConcurrent < 1 {
+		config.MaxConcurrent = 1
+	}
+	if config.MaxBodyBytes < 1 {
+		config.MaxBodyBytes = 10 << 20
+	}
+	s := &lintServer{
+		config:   config,
+		ruleSets: make(map[string]*servedRuleSet),
+		limiter:  make(chan struct{}, config.MaxConcurrent),
+		metrics:  newLintServerMetrics(),
+		started:  time.Now(),
+	}
+	for _, rs := range config.RuleSets {
+		s.ruleSets[rs.id] = rs
+	}
+	return s
+}
+
+func (s *lintServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/lint", s.handleLint)
+	mux.HandleFunc("GET /v1/rulesets", s.handleRuleSets)
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return mux
+}
+
+func (s *lintServer) handleLint(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	status, body, results := s.lint(w, r)
+	s.metrics.observe(status, time.Since(start), results)
+	writeServeJSON(w, status, body)
+}
+
+// lint handles a lint request, returning the response status and body, and the results reported.
+func (s *lintServer) lint(w http.ResponseWriter, r *http.Request) (int, any, []*model.RuleFunctionResult) {
+	req, err := s.readLintRequest(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http.StatusRequestEntityTooLarge, serveError{Error: fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)}, nil
+		}
+		return http.StatusBadRequest, serveError{Error: err.Error()}, nil
+	}
+	spec, err := decodeServeSpec(req.Spec)
+	if err != nil {
+		return http.StatusBadRequest, serveError{Error: err.Error()}, nil
+	}
+
+	switch req.Format {
+	case "":
+		req.Format = serveFormatVacuum
+	case serveFormatVacuum, serveFormatSpectral:
+	default:
+		return http.StatusBadRequest, serveError{Error: fmt.Sprintf("unknown format '%s', use '%s' or '%s'",
+			req.Format, serveFormatVacuum, serveFormatSpectral)}, nil
+	}
+	if req.Ruleset == "" {
+		req.Ruleset = s.config.DefaultRuleSet
+	}
+	served, ok := s.ruleSets[req.Ruleset]
+	if !ok {
+		return http.StatusNotFound, serveError{Error: fmt.Sprintf("unknown ruleset '%s'", req.Ruleset)}, nil
+	}
+	if req.FileName == "" {
+		req.FileName = "openapi.yaml"
+	}
+
+	// the limiter keeps memory in check when many large specifications arrive at once.
+	select {
+	case s.limiter <- struct{}{}:
+		defer func() { <-s.limiter }()
+	case <-r.Context().Done():
+		return http.StatusServiceUnavailable, serveError{Error: "request cancelled while waiting to be linted"}, nil
+	}
+	s.metrics.inFlight.Add(1)
+	defer s.metrics.inFlight.Add(-1)
+
+	specFormat, _ := asyncapi_context.DetectFormat(spec)
+	ruleSet := served.forSpec(specFormat != "")
+	result := motor.ApplyRulesToRuleSetWithOptions(&motor.RuleSetExecution{
+		RuleSet:                         ruleSet,
+		Spec:                            spec,
+		SpecFileName:                    req.FileName,
+		CustomFunctions:                 s.config.CustomFunctions,
+		AutoFixFunctions:                autofix.GetBuiltInAutoFixFunctions(),
+		AllowLookup:                     s.config.AllowLookup,
+		SilenceLogs:                     true,
+		Timeout:                         s.config.Timeout,
+		NodeLookupTimeout:               s.config.LookupTimeout,
+		IgnoreCircularArrayRef:          s.config.IgnoreArrayCircle,
+		IgnoreCircularPolymorphicRef:    s.config.IgnorePolyCircle,
+		ExtractReferencesFromExtensions: s.config.ExtRefs,
+		Logger:                          s.config.Logger,
+		HTTPClientConfig:                s.config.HTTPClientConfig,
+		FetchConfig:                     s.config.FetchConfig,
+		TurboMode:                       s.config.TurboMode,
+		SpecFormat:                      specFormat,
+	}, s.config.ExecutionOptions)
+	defer result.ReleaseOwnedResources()
+
+	if result.SpecInfo == nil {
+		body := serveError{Error: "unable to parse specification"}
+		for _, e := range result.Errors {
+			body.Details = append(body.Details, e.Error())
+		}
+		return http.StatusUnprocessableEntity, body, nil
+	}
+
+	resultSet := model.NewRuleResultSet(result.Results)
+	resultSet.SortResultsByLineNumber()
+
+	if req.Format == serveFormatSpectral {
+		return http.StatusOK, resultSet.GenerateSpectralReport(req.FileName), resultSet.Results
+	}
+
+	scoreModel, err := ResolveScoreModel(ruleSet)
+	if err != nil {
+		return http.StatusInternalServerError, serveError{Error: err.Error()}, nil
+	}
+	resultSet.PrepareForSerialization(result.SpecInfo)
+	usedRules := make(map[string]*model.Rule)
+	for _, res := range resultSet.Results {
+		if res.Rule != nil && res.RuleId != "" {
+			usedRules[res.RuleId] = res.Rule
+		}
+	}
+	return http.StatusOK, &vacuum_report.VacuumReport{
+		Generated:  time.Now(),
+		SpecInfo:   result.SpecInfo,
+		ResultSet:  resultSet,
+		Statistics: statistics.CreateReportStatisticsWithScoreModel(result.Index, result.SpecInfo, resultSet, scoreModel),
+		Errors:     buildReportErrors(result.Errors),
+		Rules:      usedRules,
+	}, resultSet.Results
+}
+
+// readLintRequest reads a lint request. A body without a spec is the specification itself.
+func (s *lintServer) readLintRequest(w http.ResponseWriter, r *http.Request) (*ServeLintRequest, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, errors.New("request body is empty, post a specification or a lint request")
+	}
+
+	req := &ServeLintRequest{}
+	if json.Unmarshal(body, req) != nil || len(req.Spec) == 0 {
+		query := r.URL.Query()
+		encoded, _ := json.Marshal(string(body))
+		req = &ServeLintRequest{
+			Spec:     encoded,
+			FileName: query.Get("fileName"),
+			Ruleset:  query.Get("ruleset"),
+			Format:   query.Get("format"),
+		}
+	}
+	return req, nil
+}
+
+// decodeServeSpec returns the specification in a lint request, which is a string or an embedded JSON document.
+func decodeServeSpec(raw json.RawMessage) ([]byte, error) {
+	var spec string
+	if err := json.Unmarshal(raw, &spec); err == nil {
+		if strings.TrimSpace(spec) == "" {
+			return nil, errors.New("spec is empty")
+		}
+		return []byte(spec), nil
+	}
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "{") {
+		return []byte(trimmed), nil
+	}
+	return nil, errors.New("spec must be a string or a JSON object")
+}
+
+func (s *lintServer) handleRuleSets(w http.ResponseWriter, _ *http.Request) {
+	ruleSets := make([]*ServeRuleSet, 0, len(s.config.RuleSets))
+	for _, rs := range s.config.RuleSets {
+		ruleSets = append(ruleSets, &ServeRuleSet{
+			Id:               rs.id,
+			OpenAPIRules:     len(rs.forSpec(false).Rules),
+			AsyncAPIRules:    len(rs.forSpec(true).Rules),
+			DocumentationURI: rs.openAPI.DocumentationURI,
+			Default:          rs.id == s.config.DefaultRuleSet,
+		})
+	}
+	writeServeJSON(w, http.StatusOK, ruleSets)
+}
+
+func (s *lintServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeServeJSON(w, http.StatusOK, map[string]any{
+		"status":        "ok",
+		"version":       GetVersion(),
+		"rulesets":      len(s.config.RuleSets),
+		"uptimeSeconds": int64(time.Since(s.started).Seconds()),
+	})
+}
+
+func (s *lintServer) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.metrics.write(w, len(s.config.RuleSets), time.Since(s.started))
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// lintServerMetrics counts lint requests, rendered in the Prometheus text format.
+type lintServerMetrics struct {
+	lock       sync.Mutex
+	requests   map[int]int64    // by response status
+	results    map[string]int64 // by severity
+	durationNs int64
+	linted     int64
+	inFlight   atomic.Int64
+}
+
+func newLintServerMetrics() *lintServerMetrics {
+	return &lintServerMetrics{
+		requests: make(map[int]int64),
+		results:  make(map[string]int64),
+	}
+}
+
+func (m *lintServerMetrics) observe(status int, duration time.Duration, results []*model.RuleFunctionResult) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests[status]++
+	if status != http.StatusOK {
+		return
+	}
+	m.linted++
+	m.durationNs += duration.Nanoseconds()
+	for _, r := range results {
+		if r.Rule != nil {
+			m.results[r.Rule.Severity]++
+		}
+	}
+}
+
+func (m *lintServerMetrics) write(w io.Writer, ruleSets int, uptime time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	fmt.Fprintln(w, "# HELP vacuum_lint_requests_total Lint requests handled, by response status.")
+	fmt.Fprintln(w, "# TYPE vacuum_lint_requests_total counter")
+	statuses := make([]int, 0, len(m.requests))
+	for status := range m.requests {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(w, "vacuum_lint_requests_total{status=\"%d\"} %d\n", status, m.requests[status])
+	}
+
+	fmt.Fprintln(w, "# HELP vacuum_lint_duration_seconds Time spent linting specifications.")
+	fmt.Fprintln(w, "# TYPE vacuum_lint_duration_seconds summary")
+	fmt.Fprintf(w, "vacuum_lint_duration_seconds_sum %g\n", time.Duration(m.durationNs).Seconds())
+	fmt.Fprintf(w, "vacuum_lint_duration_seconds_count %d\n", m.linted)
+
+	fmt.Fprintln(w, "# HELP vacuum_lint_results_total Results reported, by severity.")
+	fmt.Fprintln(w, "# TYPE vacuum_lint_results_total counter")
+	for _, severity := range []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint} {
+		fmt.Fprintf(w, "vacuum_lint_results_total{severity=\"%s\"} %d\n", severity, m.results[severity])
+	}
+
+	fmt.Fprintln(w, "# HELP vacuum_lint_in_flight Specifications being linted.")
+	fmt.Fprintln(w, "# TYPE vacuum_lint_in_flight gauge")
+	fmt.Fprintf(w, "vacuum_lint_in_flight %d\n", m.inFlight.Load())
+
+	fmt.Fprintln(w, "# HELP vacuum_rulesets Rulesets available to lint requests.")
+	fmt.Fprintln(w, "# TYPE vacuum_rulesets gauge")
+	fmt.Fprintf(w, "vacuum_rulesets %d\n", ruleSets)
+
+	fmt.Fprintln(w, "# HELP vacuum_uptime_seconds Time since the server started.")
+	fmt.Fprintln(w, "# TYPE vacuum_uptime_seconds gauge")
+	fmt.Fprintf(w, "vacuum_uptime_seconds %g\n", uptime.Seconds())
+}
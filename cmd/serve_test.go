@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func newTestLintServer(t *testing.T, flags *LintFlags, addRuleSets ...string) *httptest.Server {
+	t.Helper()
+	flags.TimeoutFlag = 5
+	flags.LookupTimeoutFlag = 500
+	config, err := buildLintServerConfig(flags, addRuleSets, nil)
+	require.NoError(t, err)
+	config.MaxBodyBytes = 1 << 20
+	server := httptest.NewServer(newLintServer(config).handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func postLint(t *testing.T, url, contentType, body string) (int, []byte) {
+	t.Helper()
+	resp, err := http.Post(url, contentType, strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, out
+}
+
+func TestLintServer_VacuumReport(t *testing.T) {
+	server := newTestLintServer(t, &LintFlags{})
+
+	request, _ := json.Marshal(ServeLintRequest{Spec: mustJSONString(baselineTestSpec), FileName: "pizza.yaml"})
+	status, body := postLint(t, server.URL+"/v1/lint", "application/json", string(request))
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var report vacuum_report.VacuumReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	require.NotNil(t, report.Statistics)
+	assert.NotZero(t, report.Statistics.TotalErrors)
+	assert.NotEmpty(t, report.ResultSet.Results)
+	assert.NotEmpty(t, report.Rules)
+}
+
+func TestLintServer_SpectralReport(t *testing.T) {
+	server := newTestLintServer(t, &LintFlags{})
+
+	// a raw specification, with the options in the query string.
+	status, body := postLint(t, server.URL+"/v1/lint?format=spectral&ruleset=all&fileName=pizza.yaml",
+		"application/yaml", baselineTestSpec)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var report []reports.SpectralReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	require.NotEmpty(t, report)
+	assert.Equal(t, "pizza.yaml", report[0].Source)
+
+	// a JSON document embedded in the request.
+	status, body = postLint(t, server.URL+"/v1/lint", "application/json",
+		`{"format": "spectral", "spec": {"openapi": "3.1.0", "info": {"title": "t", "version": "1"}, "paths": {}}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+}
+
+func TestLintServer_CustomRuleSet(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "ruleset.yaml"), testRuleSetFixtureRuleset)
+	server := newTestLintServer(t, &LintFlags{}, "summaries="+filepath.Join(dir, "ruleset.yaml"))
+
+	resp, err := http.Get(server.URL + "/v1/rulesets")
+	require.NoError(t, err)
+	var ruleSets []*ServeRuleSet
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ruleSets))
+	_ = resp.Body.Close()
+	var ids []string
+	for _, rs := range ruleSets {
+		ids = append(ids, rs.Id)
+	}
+	assert.Equal(t, []string{"recommended", "all", "owasp", "summaries"}, ids)
+	assert.True(t, ruleSets[0].Default)
+	assert.Greater(t, ruleSets[2].OpenAPIRules, ruleSets[1].OpenAPIRules)
+	// built-in rulesets lint AsyncAPI specifications with their AsyncAPI variant, custom rulesets use the same rules.
+	assert.NotEqual(t, ruleSets[0].OpenAPIRules, ruleSets[0].AsyncAPIRules)
+	assert.NotZero(t, ruleSets[0].AsyncAPIRules)
+	assert.Equal(t, ruleSets[3].OpenAPIRules, ruleSets[3].AsyncAPIRules)
+
+	request, _ := json.Marshal(ServeLintRequest{Spec: mustJSONString(testRuleSetFixtureSpec), Ruleset: "summaries", Format: "spectral"})
+	status, body := postLint(t, server.URL+"/v1/lint", "application/json", string(request))
+	require.Equal(t, http.StatusOK, status, string(body))
+	var report []reports.SpectralReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	require.Len(t, report, 1)
+	assert.Equal(t, "operation-summary-required", report[0].Code)
+}
+
+func TestLintServer_BadRequests(t *testing.T) {
+	server := newTestLintServer(t, &LintFlags{})
+
+	for name, tc := range map[string]struct {
+		body   string
+		status int
+		error  string
+	}{
+		"empty":           {"", http.StatusBadRequest, "request body is empty"},
+		"unknown ruleset": {`{"spec": "openapi: 3.1.0", "ruleset": "nope"}`, http.StatusNotFound, "unknown ruleset 'nope'"},
+		"unknown format":  {`{"spec": "openapi: 3.1.0", "format": "sarif"}`, http.StatusBadRequest, "unknown format 'sarif'"},
+		"bad spec":        {`{"spec": 12}`, http.StatusBadRequest, "spec must be a string or a JSON object"},
+		"unparseable":     {`{"spec": "a: ["}`, http.StatusUnprocessableEntity, "unable to parse specification"},
+		"too large":       {strings.Repeat("a", 2<<20), http.StatusRequestEntityTooLarge, "request body is larger than"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			status, body := postLint(t, server.URL+"/v1/lint", "application/json", tc.body)
+			assert.Equal(t, tc.status, status)
+			var e serveError
+			require.NoError(t, json.Unmarshal(body, &e))
+			assert.Contains(t, e.Error, tc.error)
+		})
+	}
+
+	resp, err := http.Get(server.URL + "/v1/lint")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestLintServer_HealthAndMetrics(t *testing.T) {
+	server := newTestLintServer(t, &LintFlags{})
+
+	// the rulesets are shared by every request.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, _ := postLint(t, server.URL+"/v1/lint", "application/yaml", baselineTestSpec)
+			assert.Equal(t, http.StatusOK, status)
+		}()
+	}
+	wg.Wait()
+	_, _ = postLint(t, server.URL+"/v1/lint", "application/json", `{"spec": "x", "ruleset": "nope"}`)
+
+	resp, err := http.Get(server.URL + "/health")
+	require.NoError(t, err)
+	var health map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	_ = resp.Body.Close()
+	assert.Equal(t, "ok", health["status"])
+
+	resp, err = http.Get(server.URL + "/metrics")
+	require.NoError(t, err)
+	metrics, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Contains(t, string(metrics), `vacuum_lint_requests_total{status="200"} 4`)
+	assert.Contains(t, string(metrics), `vacuum_lint_requests_total{status="404"} 1`)
+	assert.Contains(t, string(metrics), "vacuum_lint_duration_seconds_count 4")
+	assert.Contains(t, string(metrics), "vacuum_lint_in_flight 0")
+	assert.NotContains(t, string(metrics), `vacuum_lint_results_total{severity="error"} 0`)
+}
+
+func TestBuildLintServerConfig_Invalid(t *testing.T) {
+	_, err := buildLintServerConfig(&LintFlags{}, []string{"no-location"}, nil)
+	assert.ErrorContains(t, err, "must be id=location")
+
+	_, err = buildLintServerConfig(&LintFlags{}, []string{"all=ruleset.yaml"}, nil)
+	assert.ErrorContains(t, err, "ruleset id 'all' is used more than once")
+
+	_, err = buildLintServerConfig(&LintFlags{}, []string{"x=" + filepath.Join(t.TempDir(), "nope.yaml")}, nil)
+	assert.ErrorContains(t, err, "unable to load ruleset")
+
+	_, err = buildLintServerConfig(&LintFlags{FunctionsFlag: filepath.Join(t.TempDir(), "nope")}, nil, nil)
+	assert.ErrorContains(t, err, "unable to load custom functions")
+}
+
+func mustJSONString(s string) json.RawMessage {
+	encoded, _ := json.Marshal(s)
+	return encoded
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -35,15 +36,7 @@ func BuildRuleSetFromUserSuppliedSet(rsBytes []byte, rs rulesets.RuleSets) (*rul
 // BuildRuleSetFromUserSuppliedSetWithHTTPClient creates a ready to run ruleset, augmented or provided by a user
 // configured ruleset with HTTP client support for certificate authentication.
 func BuildRuleSetFromUserSuppliedSetWithHTTPClient(rsBytes []byte, rs rulesets.RuleSets, httpClient *http.Client) (*rulesets.RuleSet, error) {
-
-	// load in our user supplied ruleset and try to validate it.
-	userRS, userErr := rulesets.CreateRuleSetFromData(rsBytes)
-	if userErr != nil {
-		tui.RenderErrorString("Unable to parse ruleset file: %s", userErr.Error())
-		return nil, userErr
-
-	}
-	return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient), nil
+	return buildRuleSetFromUserSuppliedSet(rsBytes, "", rs, httpClient)
 }
 
 // BuildRuleSetFromUserSuppliedLocation creates a ready to run ruleset from a location (file path or URL)
@@ -57,7 +50,7 @@ func BuildRuleSetFromUserSuppliedLocation(rulesetFlag string, rs rulesets.RuleSe
 		if rsErr != nil {
 			return nil, rsErr
 		}
-		return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient), nil
+		return generateUserSuppliedRuleSet(downloadedRS, rs, httpClient)
 	} else {
 		// Handle local ruleset file
 		resolvedPath, err := ResolveConfigPath(rulesetFlag)
@@ -68,8 +61,46 @@ func BuildRuleSetFromUserSuppliedLocation(rulesetFlag string, rs rulesets.RuleSe
 		if rsErr != nil {
 			return nil, rsErr
 		}
-		return BuildRuleSetFromUserSuppliedSetWithHTTPClient(rsBytes, rs, httpClient)
+		if absPath, absErr := filepath.Abs(resolvedPath); absErr == nil {
+			resolvedPath = absPath
+		}
+		return buildRuleSetFromUserSuppliedSet(rsBytes, resolvedPath, rs, httpClient)
+	}
+}
+
+// buildRuleSetFromUserSuppliedSet parses and generates a user supplied ruleset. The location of the ruleset is used
+// to resolve override file patterns and functions, the same as Spectral, it defaults to the working directory.
+func buildRuleSetFromUserSuppliedSet(rsBytes []byte, location string, rs rulesets.RuleSets, httpClient *http.Client) (*rulesets.RuleSet, error) {
+
+	// load in our user supplied ruleset and try to validate it.
+	userRS, userErr := rulesets.CreateRuleSetFromData(rsBytes)
+	if userErr != nil {
+		tui.RenderErrorString("Unable to parse ruleset file: %s", userErr.Error())
+		return nil, userErr
+
+	}
+	if location != "" {
+		userRS.Location = location
+		userRS.OverridesBase = filepath.Dir(location)
+	}
+	return generateUserSuppliedRuleSet(userRS, rs, httpClient)
+}
+
+// generateUserSuppliedRuleSet generates a ready to run ruleset, and loads the functions shipped with it (and the
+// rulesets it extends).
+func generateUserSuppliedRuleSet(userRS *rulesets.RuleSet, rs rulesets.RuleSets, httpClient *http.Client) (*rulesets.RuleSet, error) {
+	builtRS := rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient)
+	if extendsErr := builtRS.ExtendsError(); extendsErr != nil {
+		return nil, extendsErr
+	}
+	if len(builtRS.FunctionLocations()) > 0 {
+		pm, err := plugin.LoadRuleSetFunctions(builtRS, httpClient, true)
+		if err != nil {
+			return nil, err
+		}
+		builtRS.LoadedFunctions = pm.GetCustomFunctions()
 	}
+	return builtRS, nil
 }
 
 // MergeOWASPRulesToRuleSet merges OWASP rules into the provided ruleset when hard mode is enabled.
@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
 )
 
 func TestRenderTime(t *testing.T) {
@@ -12,3 +18,36 @@ func TestRenderTime(t *testing.T) {
 	fi, _ := os.Stat("shared_functions.go")
 	RenderTime(true, time.Since(start), fi.Size())
 }
+
+func TestBuildRuleSetFromUserSuppliedLocation_Functions(t *testing.T) {
+	dir := t.TempDir()
+	script, err := os.ReadFile("../plugin/sample/js/useless_func.js")
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "functions"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "functions", "useless_func.js"), script, 0o644))
+	rulesetPath := filepath.Join(dir, "ruleset.yaml")
+	require.NoError(t, os.WriteFile(rulesetPath, []byte(`extends: [[vacuum:oas, off]]
+functions: [useless_func]
+rules:
+  shipped-function:
+    given: $.info
+    severity: warn
+    then:
+      function: uselessFunc`), 0o644))
+
+	rs, err := BuildRuleSetFromUserSuppliedLocation(rulesetPath, rulesets.BuildDefaultRuleSets(), true, nil)
+	require.NoError(t, err)
+	require.NotNil(t, rs.LoadedFunctions["uselessFunc"])
+
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte("openapi: 3.1.0\ninfo:\n  title: test\n  version: 1.0.0\n"),
+	})
+	count := 0
+	for _, r := range result.Results {
+		if r.Rule != nil && r.Rule.Id == "shipped-function" {
+			count++
+		}
+	}
+	assert.Equal(t, 2, count)
+}
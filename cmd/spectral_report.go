@@ -49,429 +49,492 @@ vacuum spectral-report --globbed-files "api/**/*.json" -n`,
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJSONReport(cmd, args, spectralJSONReport)
+		},
+	}
+	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
+	cmd.Flags().BoolP("stdout", "o", false, "Use stdout as output, instead of a file")
+	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().Bool("ignore-array-circle-ref", false, "Ignore circular array references")
+	cmd.Flags().Bool("ignore-polymorph-circle-ref", false, "Ignore circular polymorphic references")
+	cmd.Flags().String("globbed-files", "", "Glob pattern of files to process (e.g., 'specs/*.yaml')")
+	cmd.Flags().String("output-dir", "", "Directory to write report files to (default: current directory)")
+	addProfileFlag(cmd)
+	addWorkersFlag(cmd)
+	return cmd
 
-			stdIn, _ := cmd.Flags().GetBool("stdin")
-			stdOut, _ := cmd.Flags().GetBool("stdout")
-			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
-			baseFlag, _ := cmd.Flags().GetString("base")
-			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
-			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
-			lookupTimeoutFlag, _ := cmd.Flags().GetInt("lookup-timeout")
-			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
-			extensionRefsFlag, _ := cmd.Flags().GetBool("ext-refs")
-			remoteFlag, _ := cmd.Flags().GetBool("remote")
-			ignoreFile, _ := cmd.Flags().GetString("ignore-file")
-			ignoreArrayCircleRef, _ := cmd.Flags().GetBool("ignore-array-circle-ref")
-			ignorePolymorphCircleRef, _ := cmd.Flags().GetBool("ignore-polymorph-circle-ref")
-			changesFlag, _ := cmd.Flags().GetString("changes")
-			originalFlag, _ := cmd.Flags().GetString("original")
-			globPattern, _ := cmd.Flags().GetString("globbed-files")
-			outputDir, _ := cmd.Flags().GetString("output-dir")
-			breakingConfigPath, _ := cmd.Flags().GetString("breaking-config")
-			warnOnChanges, _ := cmd.Flags().GetBool("warn-on-changes")
-			errorOnBreaking, _ := cmd.Flags().GetBool("error-on-breaking")
-			turboFlag, _ := cmd.Flags().GetBool("turbo")
-			resolveAllRefsFlag, _ := cmd.Flags().GetBool("resolve-all-refs")
-			nestedRefsDocContextFlag, _ := cmd.Flags().GetBool("nested-refs-doc-context")
-
-			// disable color and styling, for CI/CD use.
-			// https://github.com/daveshanley/vacuum/issues/234
-			if noStyleFlag {
-				color.DisableColors()
-			}
-
-			if !stdIn && !stdOut {
-				PrintBanner()
-			}
-
-			// Load and apply breaking rules config early, before any change comparison
-			breakingConfig, breakingConfigErr := utils.LoadBreakingRulesConfig(breakingConfigPath)
-			if breakingConfigErr != nil {
-				var validationErr *utils.ConfigValidationError
-				if errors.As(breakingConfigErr, &validationErr) {
-					tui.RenderErrorString("Breaking config validation error in %s:", validationErr.FilePath)
-					fmt.Print(validationErr.FormatValidationErrors())
-					return breakingConfigErr
-				}
-				tui.RenderErrorString("Error loading breaking config: %v", breakingConfigErr)
-				return breakingConfigErr
-			}
-			if breakingConfig != nil {
-				utils.ApplyBreakingRulesConfig(breakingConfig)
-				defer utils.ResetBreakingRulesConfig()
-			}
-
-			// Get files to process (handles glob patterns and direct args)
-			filesToProcess, globErr := GetFilesToProcess(globPattern, args)
-			if globErr != nil {
-				tui.RenderErrorString("Error resolving files: %s", globErr.Error())
-				return globErr
-			}
+}
 
-			// check for file args
-			if !stdIn && len(filesToProcess) == 0 {
-				errText := "please supply an OpenAPI or AsyncAPI specification to generate a spectral report, or use " +
-					"the -i flag to use stdin"
-				tui.RenderErrorString("%s", errText)
-				return errors.New(errText)
-			}
+// jsonReport describes a JSON report that is generated from lint results, one report per linted file.
+type jsonReport struct {
+	name          string // used in messages, e.g. 'spectral'
+	fileType      string // used to name reports when processing multiple files
+	extension     string // the file extension of reports, when processing multiple files
+	defaultOutput string // the report filename used when no filename is supplied
+	build         func(resultSet *model.RuleResultSet, source string) any
+}
 
-			// Ensure output directory exists for multi-file mode
-			if outputDir != "" {
-				if err := EnsureOutputDir(outputDir); err != nil {
-					tui.RenderErrorString("Failed to create output directory '%s': %s", outputDir, err.Error())
-					return err
-				}
-			}
+var spectralJSONReport = jsonReport{
+	name:          "spectral",
+	fileType:      "spectral-report",
+	extension:     ".json",
+	defaultOutput: "vacuum-spectral-report.json",
+	build: func(resultSet *model.RuleResultSet, source string) any {
+		return resultSet.GenerateSpectralReport(source)
+	},
+}
 
-			timeFlag, _ := cmd.Flags().GetBool("time")
-			noPretty, _ := cmd.Flags().GetBool("no-pretty")
-
-			// Certificate/TLS configuration
-			certFile, _ := cmd.Flags().GetString("cert-file")
-			keyFile, _ := cmd.Flags().GetString("key-file")
-			caFile, _ := cmd.Flags().GetString("ca-file")
-			insecure, _ := cmd.Flags().GetBool("insecure")
-			allowPrivateNetworks, _ := cmd.Flags().GetBool("allow-private-networks")
-			allowHTTP, _ := cmd.Flags().GetBool("allow-http")
-			fetchTimeout, _ := cmd.Flags().GetInt("fetch-timeout")
-
-			lintFlags := &LintFlags{
-				CertFile:             certFile,
-				KeyFile:              keyFile,
-				CAFile:               caFile,
-				Insecure:             insecure,
-				AllowPrivateNetworks: allowPrivateNetworks,
-				AllowHTTP:            allowHTTP,
-				FetchTimeout:         fetchTimeout,
-			}
+// runJSONReport lints each supplied file, and writes the report built by reportType for every file.
+func runJSONReport(cmd *cobra.Command, args []string, reportType jsonReport) error {
+
+	stdIn, _ := cmd.Flags().GetBool("stdin")
+	stdOut, _ := cmd.Flags().GetBool("stdout")
+	noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+	baseFlag, _ := cmd.Flags().GetString("base")
+	skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+	timeoutFlag, _ := cmd.Flags().GetInt("timeout")
+	lookupTimeoutFlag, _ := cmd.Flags().GetInt("lookup-timeout")
+	hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
+	extensionRefsFlag, _ := cmd.Flags().GetBool("ext-refs")
+	remoteFlag, _ := cmd.Flags().GetBool("remote")
+	ignoreFile, _ := cmd.Flags().GetString("ignore-file")
+	ignoreArrayCircleRef, _ := cmd.Flags().GetBool("ignore-array-circle-ref")
+	ignorePolymorphCircleRef, _ := cmd.Flags().GetBool("ignore-polymorph-circle-ref")
+	changesFlag, _ := cmd.Flags().GetString("changes")
+	originalFlag, _ := cmd.Flags().GetString("original")
+	globPattern, _ := cmd.Flags().GetString("globbed-files")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	breakingConfigPath, _ := cmd.Flags().GetString("breaking-config")
+	warnOnChanges, _ := cmd.Flags().GetBool("warn-on-changes")
+	errorOnBreaking, _ := cmd.Flags().GetBool("error-on-breaking")
+	turboFlag, _ := cmd.Flags().GetBool("turbo")
+	resolveAllRefsFlag, _ := cmd.Flags().GetBool("resolve-all-refs")
+	nestedRefsDocContextFlag, _ := cmd.Flags().GetBool("nested-refs-doc-context")
+
+	// disable color and styling, for CI/CD use.
+	// https://github.com/daveshanley/vacuum/issues/234
+	if noStyleFlag {
+		color.DisableColors()
+	}
 
-			httpClientConfig, cfgErr := GetHTTPClientConfig(lintFlags)
-			if cfgErr != nil {
-				return fmt.Errorf("failed to resolve TLS configuration: %w", cfgErr)
-			}
+	profiler, profileErr := newRuleProfiler(cmd)
+	if profileErr != nil {
+		return profileErr
+	}
+	defer profiler.render()
 
-			fetchConfig, fetchCfgErr := GetFetchConfig(lintFlags)
-			if fetchCfgErr != nil {
-				return fmt.Errorf("failed to resolve fetch configuration: %w", fetchCfgErr)
-			}
+	workers, workersErr := readWorkersFlag(cmd)
+	if workersErr != nil {
+		return workersErr
+	}
 
-			reportOutput := "vacuum-spectral-report.json"
+	if !stdIn && !stdOut {
+		PrintBanner()
+	}
 
-			if len(args) > 1 {
-				reportOutput = args[1]
-			}
+	// Load and apply breaking rules config early, before any change comparison
+	breakingConfig, breakingConfigErr := utils.LoadBreakingRulesConfig(breakingConfigPath)
+	if breakingConfigErr != nil {
+		var validationErr *utils.ConfigValidationError
+		if errors.As(breakingConfigErr, &validationErr) {
+			tui.RenderErrorString("Breaking config validation error in %s:", validationErr.FilePath)
+			fmt.Print(validationErr.FormatValidationErrors())
+			return breakingConfigErr
+		}
+		tui.RenderErrorString("Error loading breaking config: %v", breakingConfigErr)
+		return breakingConfigErr
+	}
+	if breakingConfig != nil {
+		utils.ApplyBreakingRulesConfig(breakingConfig)
+		defer utils.ResetBreakingRulesConfig()
+	}
 
-			ignoredItems, err := LoadIgnoreFile(ignoreFile, true, stdOut, noStyleFlag)
-			if err != nil {
-				return err
-			}
+	// Get files to process (handles glob patterns and direct args)
+	filesToProcess, globErr := GetFilesToProcess(globPattern, args)
+	if globErr != nil {
+		tui.RenderErrorString("Error resolving files: %s", globErr.Error())
+		return globErr
+	}
 
-			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+	// check for file args
+	if !stdIn && len(filesToProcess) == 0 {
+		errText := fmt.Sprintf("please supply an OpenAPI or AsyncAPI specification to generate a %s report, or use "+
+			"the -i flag to use stdin", reportType.name)
+		tui.RenderErrorString("%s", errText)
+		return errors.New(errText)
+	}
 
-			// read spec and parse to dashboard.
-			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	// Ensure output directory exists for multi-file mode
+	if outputDir != "" {
+		if err := EnsureOutputDir(outputDir); err != nil {
+			tui.RenderErrorString("Failed to create output directory '%s': %s", outputDir, err.Error())
+			return err
+		}
+	}
 
-			// Custom rulesets are loaded once; built-in defaults are selected per
-			// document so AsyncAPI inputs do not run against OpenAPI defaults.
-			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
-			hardModeBoxRendered := false
+	timeFlag, _ := cmd.Flags().GetBool("time")
+	noPretty, _ := cmd.Flags().GetBool("no-pretty")
+
+	// Certificate/TLS configuration
+	certFile, _ := cmd.Flags().GetString("cert-file")
+	keyFile, _ := cmd.Flags().GetString("key-file")
+	caFile, _ := cmd.Flags().GetString("ca-file")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	allowPrivateNetworks, _ := cmd.Flags().GetBool("allow-private-networks")
+	allowHTTP, _ := cmd.Flags().GetBool("allow-http")
+	fetchTimeout, _ := cmd.Flags().GetInt("fetch-timeout")
+
+	lintFlags := &LintFlags{
+		CertFile:             certFile,
+		KeyFile:              keyFile,
+		CAFile:               caFile,
+		Insecure:             insecure,
+		AllowPrivateNetworks: allowPrivateNetworks,
+		AllowHTTP:            allowHTTP,
+		FetchTimeout:         fetchTimeout,
+	}
 
-			functionsFlag, _ := cmd.Flags().GetString("functions")
-			customFunctions, _ := LoadCustomFunctions(functionsFlag, true)
+	httpClientConfig, cfgErr := GetHTTPClientConfig(lintFlags)
+	if cfgErr != nil {
+		return fmt.Errorf("failed to resolve TLS configuration: %w", cfgErr)
+	}
 
-			// if ruleset has been supplied, lets make sure it exists, then load it in
-			// and see if it's valid. If so - let's go!
-			if rulesetFlag != "" {
-				httpClient, clientErr := utils.CreateHTTPClientIfNeeded(httpClientConfig)
-				if clientErr != nil {
-					tui.RenderErrorString("Failed to create custom HTTP client: %s", clientErr.Error())
-					return clientErr
-				}
+	fetchConfig, fetchCfgErr := GetFetchConfig(lintFlags)
+	if fetchCfgErr != nil {
+		return fmt.Errorf("failed to resolve fetch configuration: %w", fetchCfgErr)
+	}
 
-				var rsErr error
-				selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, defaultRuleSets, remoteFlag, httpClient)
-				if rsErr != nil {
-					tui.RenderErrorString("Unable to load ruleset '%s': %s", rulesetFlag, rsErr.Error())
-					return rsErr
-				}
+	reportOutput := reportType.defaultOutput
 
-				// Merge OWASP rules if hard mode is enabled
-				if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
-					if !stdIn && !stdOut {
-						tui.RenderStyledBox(HardModeWithCustomRuleset, tui.BoxTypeHard, noStyleFlag)
-						hardModeBoxRendered = true
-					}
-				}
-			}
+	if len(args) > 1 {
+		reportOutput = args[1]
+	}
 
-			if rulesetFlag != "" && turboFlag {
-				rulesets.FilterRulesForTurbo(selectedRS)
-			}
+	ignoreEntries, err := LoadIgnoreFileEntries(ignoreFile, true, stdOut, noStyleFlag)
+	if err != nil {
+		return err
+	}
 
-			if rulesetFlag != "" && !stdIn && !stdOut {
-				tui.RenderInfo("Linting against %d rules: %s", len(selectedRS.Rules), selectedRS.DocumentationURI)
+	rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+
+	// read spec and parse to dashboard.
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+
+	// Custom rulesets are loaded once; built-in defaults are selected per
+	// document so AsyncAPI inputs do not run against OpenAPI defaults.
+	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+	hardModeBoxRendered := false
+
+	functionsFlag, _ := cmd.Flags().GetString("functions")
+	customFunctions, _ := LoadCustomFunctions(functionsFlag, true)
+
+	// if ruleset has been supplied, lets make sure it exists, then load it in
+	// and see if it's valid. If so - let's go!
+	if rulesetFlag != "" {
+		httpClient, clientErr := utils.CreateHTTPClientIfNeeded(httpClientConfig)
+		if clientErr != nil {
+			tui.RenderErrorString("Failed to create custom HTTP client: %s", clientErr.Error())
+			return clientErr
+		}
+
+		var rsErr error
+		selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, defaultRuleSets, remoteFlag, httpClient)
+		if rsErr != nil {
+			tui.RenderErrorString("Unable to load ruleset '%s': %s", rulesetFlag, rsErr.Error())
+			return rsErr
+		}
+
+		// Merge OWASP rules if hard mode is enabled
+		if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
+			if !stdIn && !stdOut {
+				tui.RenderStyledBox(HardModeWithCustomRuleset, tui.BoxTypeHard, noStyleFlag)
+				hardModeBoxRendered = true
 			}
+		}
+	}
 
-			// Multi-file mode detection
-			isMultiFile := len(filesToProcess) > 1 || globPattern != ""
+	if rulesetFlag != "" && turboFlag {
+		rulesets.FilterRulesForTurbo(selectedRS)
+	}
 
-			if isMultiFile && !stdIn && !stdOut {
-				tui.RenderInfo("Processing %d files...", len(filesToProcess))
-				// Warn if change filtering flags are used with multi-file mode
-				if changesFlag != "" || originalFlag != "" {
-					tui.RenderInfo("Note: --changes and --original flags are ignored in multi-file mode")
-				}
-			}
+	if rulesetFlag != "" && !stdIn && !stdOut {
+		tui.RenderInfo("Linting against %d rules: %s", len(selectedRS.Rules), selectedRS.DocumentationURI)
+	}
 
-			var processedFiles int
+	// Multi-file mode detection
+	isMultiFile := len(filesToProcess) > 1 || globPattern != ""
 
-			// Process files - for stdin mode, we only process once
-			filesToIterate := filesToProcess
-			if stdIn {
-				filesToIterate = []string{"stdin"}
-			}
+	if isMultiFile && !stdIn && !stdOut {
+		tui.RenderInfo("Processing %d files...", len(filesToProcess))
+		// Warn if change filtering flags are used with multi-file mode
+		if changesFlag != "" || originalFlag != "" {
+			tui.RenderInfo("Note: --changes and --original flags are ignored in multi-file mode")
+		}
+	}
 
-			for _, specFile := range filesToIterate {
-				start := time.Now()
-
-				var specBytes []byte
-				var fileError error
-
-				if stdIn {
-					// read file from stdin
-					inputReader := cmd.InOrStdin()
-					buf := &bytes.Buffer{}
-					_, fileError = buf.ReadFrom(inputReader)
-					specBytes = buf.Bytes()
-				} else {
-					// read file from filesystem
-					specBytes, fileError = os.ReadFile(specFile)
-				}
+	var processedFiles int
 
-				if fileError != nil {
-					tui.RenderErrorString("Unable to read file '%s': %s", specFile, fileError.Error())
-					if isMultiFile {
-						continue
-					}
-					return fileError
-				}
+	// Process files - for stdin mode, we only process once
+	filesToIterate := filesToProcess
+	if stdIn {
+		filesToIterate = []string{"stdin"}
+	}
 
-				// Resolve base path for this specific file
-				var resolvedBase string
-				var baseErr error
-				if stdIn {
-					// For stdin input, use the provided base flag or current directory as fallback
-					if baseFlag != "" {
-						resolvedBase = baseFlag
-					} else {
-						resolvedBase, baseErr = filepath.Abs(".")
-						if baseErr != nil {
-							return fmt.Errorf("failed to resolve current directory as base path: %w", baseErr)
-						}
-					}
-				} else {
-					resolvedBase, baseErr = ResolveBasePathForFile(specFile, baseFlag)
-					if baseErr != nil {
-						tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, baseErr.Error())
-						if isMultiFile {
-							continue
-						}
-						return fmt.Errorf("failed to resolve base path: %w", baseErr)
-					}
-				}
-				resolvedSpecPath, specPathErr := ResolveSpecPathForExecution(specFile)
-				if specPathErr != nil {
-					tui.RenderErrorString("Failed to resolve spec path for '%s': %s", specFile, specPathErr.Error())
-					if isMultiFile {
-						continue
-					}
-					return fmt.Errorf("failed to resolve spec path: %w", specPathErr)
-				}
+	// every file gets its own default ruleset, hard mode and turbo change the ruleset they are given.
+	lintSpec := func(specFile string) *lintedReportSpec {
+		linted := &lintedReportSpec{start: time.Now()}
+
+		if stdIn {
+			// read file from stdin
+			inputReader := cmd.InOrStdin()
+			buf := &bytes.Buffer{}
+			_, linted.readErr = buf.ReadFrom(inputReader)
+			linted.specBytes = buf.Bytes()
+		} else {
+			// read file from filesystem
+			linted.specBytes, linted.readErr = os.ReadFile(specFile)
+		}
+		if linted.readErr != nil {
+			return linted
+		}
+
+		// Resolve base path for this specific file
+		var resolvedBase string
+		if stdIn {
+			// For stdin input, use the provided base flag or current directory as fallback
+			if baseFlag != "" {
+				resolvedBase = baseFlag
+			} else {
+				resolvedBase, linted.baseErr = filepath.Abs(".")
+			}
+		} else {
+			resolvedBase, linted.baseErr = ResolveBasePathForFile(specFile, baseFlag)
+		}
+		if linted.baseErr != nil {
+			return linted
+		}
+		var resolvedSpecPath string
+		resolvedSpecPath, linted.specPathErr = ResolveSpecPathForExecution(specFile)
+		if linted.specPathErr != nil {
+			return linted
+		}
+
+		linted.ruleSet = selectedRS
+		specFormat := ""
+		if rulesetFlag == "" {
+			linted.ruleSet, specFormat, linted.asyncDefault = prepareDefaultRuleSetForSpec(rulesets.BuildDefaultRuleSets(),
+				linted.specBytes, hardModeFlag, turboFlag)
+		}
+
+		linted.executionOptions = newMotorExecutionOptions(resolveAllRefsFlag, nestedRefsDocContextFlag)
+		linted.result = motor.ApplyRulesToRuleSetWithOptions(&motor.RuleSetExecution{
+			RuleSet:                         linted.ruleSet,
+			Spec:                            linted.specBytes,
+			SpecFileName:                    resolvedSpecPath,
+			CustomFunctions:                 customFunctions,
+			SilenceLogs:                     true,
+			Base:                            resolvedBase,
+			AllowLookup:                     remoteFlag,
+			SkipDocumentCheck:               skipCheckFlag,
+			Timeout:                         time.Duration(timeoutFlag) * time.Second,
+			NodeLookupTimeout:               time.Duration(lookupTimeoutFlag) * time.Millisecond,
+			ExtractReferencesFromExtensions: extensionRefsFlag,
+			IgnoreCircularArrayRef:          ignoreArrayCircleRef,
+			IgnoreCircularPolymorphicRef:    ignorePolymorphCircleRef,
+			HTTPClientConfig:                httpClientConfig,
+			FetchConfig:                     fetchConfig,
+			TurboMode:                       turboFlag,
+			ProfileRules:                    profiler.enabled(),
+			SpecFormat:                      specFormat,
+		}, linted.executionOptions)
+		return linted
+	}
 
-				selectedRSForFile := selectedRS
-				specFormat := ""
-				if rulesetFlag == "" {
-					var asyncDefault bool
-					selectedRSForFile, specFormat, asyncDefault = prepareDefaultRuleSetForSpec(defaultRuleSets, specBytes, hardModeFlag, turboFlag)
-					if hardModeFlag && !asyncDefault && !hardModeBoxRendered && !stdIn && !stdOut {
-						tui.RenderStyledBox(HardModeEnabled, tui.BoxTypeHard, noStyleFlag)
-						hardModeBoxRendered = true
-					}
-					if !stdIn && !stdOut {
-						tui.RenderInfo("Linting against %d rules: %s", len(selectedRSForFile.Rules), selectedRSForFile.DocumentationURI)
-					}
-				}
+	for i, linted := range lintInOrder(filesToIterate, workers, lintSpec) {
+		specFile := filesToIterate[i]
+		start := linted.start
+		specBytes := linted.specBytes
 
-				executionOptions := newMotorExecutionOptions(resolveAllRefsFlag, nestedRefsDocContextFlag)
-				ruleset := motor.ApplyRulesToRuleSetWithOptions(&motor.RuleSetExecution{
-					RuleSet:                         selectedRSForFile,
-					Spec:                            specBytes,
-					SpecFileName:                    resolvedSpecPath,
-					CustomFunctions:                 customFunctions,
-					SilenceLogs:                     true,
-					Base:                            resolvedBase,
-					AllowLookup:                     remoteFlag,
-					SkipDocumentCheck:               skipCheckFlag,
-					Timeout:                         time.Duration(timeoutFlag) * time.Second,
-					NodeLookupTimeout:               time.Duration(lookupTimeoutFlag) * time.Millisecond,
-					ExtractReferencesFromExtensions: extensionRefsFlag,
-					IgnoreCircularArrayRef:          ignoreArrayCircleRef,
-					IgnoreCircularPolymorphicRef:    ignorePolymorphCircleRef,
-					HTTPClientConfig:                httpClientConfig,
-					FetchConfig:                     fetchConfig,
-					TurboMode:                       turboFlag,
-					SpecFormat:                      specFormat,
-				}, executionOptions)
-
-				// Check for spec parsing errors before generating report
-				if ruleset.SpecInfo == nil {
-					tui.RenderErrorString("Failed to parse specification '%s'", specFile)
-					if isMultiFile {
-						continue
-					}
-					return NewInputError("failed to parse specification '%s'", specFile)
-				}
+		if linted.readErr != nil {
+			tui.RenderErrorString("Unable to read file '%s': %s", specFile, linted.readErr.Error())
+			if isMultiFile {
+				continue // Skip this file and continue with others
+			}
+			return linted.readErr
+		}
 
-				resultSet := model.NewRuleResultSet(ruleset.Results)
-				resultSet.SortResultsByLineNumber()
-
-				resultSet.Results = utils.FilterIgnoredResultsPtrWithOptions(
-					resultSet.Results,
-					ignoredItems,
-					buildIgnoreFilterOptions(specBytes, ruleset, lookupTimeoutFlag),
-				)
-
-				// Apply change-based filtering if --changes or --original is specified
-				// Note: change filtering only makes sense for single-file mode
-				var documentChanges *wcModel.DocumentChanges
-				if !isMultiFile && ruleset != nil && ruleset.RuleSetExecution != nil {
-					// Use violation-set diffing when --original is specified
-					if originalFlag != "" {
-						documentChanges, _ = applyOriginalDiffToResultSet(originalResultSetDiffOptions{
-							OriginalPath:     originalFlag,
-							CurrentBytes:     specBytes,
-							CurrentPath:      specFile,
-							ResultSet:        resultSet,
-							Execution:        ruleset.RuleSetExecution,
-							ExecutionOptions: executionOptions,
-							WarnOriginalLintFailure: func(err error) {
-								if !stdIn && !stdOut {
-									tui.RenderErrorString("Warning: Failed to lint original spec: %v. Proceeding without change filtering.", err)
-								}
-							},
-							WarnChangeReportFailure: func(err error) {
-								if !stdIn && !stdOut {
-									tui.RenderErrorString("Warning: Failed to generate change report: %v. --warn-on-changes/--error-on-breaking will not take effect.", err)
-								}
-							},
-						})
-					} else if changesFlag != "" {
-						var loadErr error
-						documentChanges, loadErr = utils.LoadChangeReportFromFile(changesFlag)
-						if loadErr != nil {
-							if !stdIn && !stdOut {
-								tui.RenderErrorString("Warning: Failed to load change report: %v. Proceeding without change filtering.", loadErr)
-							}
-						}
+		if linted.baseErr != nil {
+			if stdIn {
+				return fmt.Errorf("failed to resolve current directory as base path: %w", linted.baseErr)
+			}
+			tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, linted.baseErr.Error())
+			if isMultiFile {
+				continue
+			}
+			return fmt.Errorf("failed to resolve base path: %w", linted.baseErr)
+		}
+		if linted.specPathErr != nil {
+			tui.RenderErrorString("Failed to resolve spec path for '%s': %s", specFile, linted.specPathErr.Error())
+			if isMultiFile {
+				continue
+			}
+			return fmt.Errorf("failed to resolve spec path: %w", linted.specPathErr)
+		}
+
+		selectedRSForFile := linted.ruleSet
+		if rulesetFlag == "" {
+			if hardModeFlag && !linted.asyncDefault && !hardModeBoxRendered && !stdIn && !stdOut {
+				tui.RenderStyledBox(HardModeEnabled, tui.BoxTypeHard, noStyleFlag)
+				hardModeBoxRendered = true
+			}
+			if !stdIn && !stdOut {
+				tui.RenderInfo("Linting against %d rules: %s", len(selectedRSForFile.Rules), selectedRSForFile.DocumentationURI)
+			}
+		}
 
-						// --changes mode: fall back to area-based ChangeFilter
-						if documentChanges != nil {
-							changeFilter := utils.NewChangeFilter(documentChanges, ruleset.RuleSetExecution.DrDocument)
-							resultSet.Results = changeFilter.FilterResults(resultSet.Results)
-						}
-					}
+		executionOptions := linted.executionOptions
+		ruleset := linted.result
 
-					// Inject change violations if requested
-					if documentChanges != nil && (warnOnChanges || errorOnBreaking) {
-						changeViolations := utils.GenerateChangeViolations(documentChanges, utils.ChangeViolationOptions{
-							WarnOnChanges:   warnOnChanges,
-							ErrorOnBreaking: errorOnBreaking,
-						})
-						for _, v := range changeViolations {
-							if v != nil {
-								resultSet.Results = append(resultSet.Results, v)
-							}
+		// Check for spec parsing errors before generating report
+		if ruleset.SpecInfo == nil {
+			tui.RenderErrorString("Failed to parse specification '%s'", specFile)
+			if isMultiFile {
+				continue
+			}
+			return NewInputError("failed to parse specification '%s'", specFile)
+		}
+		profiler.add(specFile, ruleset)
+
+		resultSet := model.NewRuleResultSet(ruleset.Results)
+		resultSet.SortResultsByLineNumber()
+
+		ignoreOptions := buildIgnoreFilterOptions(specBytes, ruleset, lookupTimeoutFlag)
+		ignoreOptions.Entries = ignoreEntries
+		resultSet.Results, resultSet.Suppressed = utils.PartitionIgnoredResultsPtrWithOptions(
+			resultSet.Results,
+			ignoreEntries.Paths(),
+			ignoreOptions,
+		)
+
+		// Apply change-based filtering if --changes or --original is specified
+		// Note: change filtering only makes sense for single-file mode
+		var documentChanges *wcModel.DocumentChanges
+		if !isMultiFile && ruleset != nil && ruleset.RuleSetExecution != nil {
+			// Use violation-set diffing when --original is specified
+			if originalFlag != "" {
+				documentChanges, _ = applyOriginalDiffToResultSet(originalResultSetDiffOptions{
+					OriginalPath:     originalFlag,
+					CurrentBytes:     specBytes,
+					CurrentPath:      specFile,
+					ResultSet:        resultSet,
+					Execution:        ruleset.RuleSetExecution,
+					ExecutionOptions: executionOptions,
+					WarnOriginalLintFailure: func(err error) {
+						if !stdIn && !stdOut {
+							tui.RenderErrorString("Warning: Failed to lint original spec: %v. Proceeding without change filtering.", err)
 						}
-					}
-				}
-
-				duration := time.Since(start)
-
-				var source string
-				if stdIn {
-					source = "stdin"
-				} else {
-					source = specFile
-					// Make the path relative to current working directory for consistency
-					if absPath, err := filepath.Abs(source); err == nil {
-						if cwd, err := os.Getwd(); err == nil {
-							if relPath, err := filepath.Rel(cwd, absPath); err == nil {
-								source = relPath
-							}
+					},
+					WarnChangeReportFailure: func(err error) {
+						if !stdIn && !stdOut {
+							tui.RenderErrorString("Warning: Failed to generate change report: %v. --warn-on-changes/--error-on-breaking will not take effect.", err)
 						}
+					},
+				})
+			} else if changesFlag != "" {
+				var loadErr error
+				documentChanges, loadErr = utils.LoadChangeReportFromFile(changesFlag)
+				if loadErr != nil {
+					if !stdIn && !stdOut {
+						tui.RenderErrorString("Warning: Failed to load change report: %v. Proceeding without change filtering.", loadErr)
 					}
 				}
-				// serialize
-				spectralReport := resultSet.GenerateSpectralReport(source)
-
-				var data []byte
-				if noPretty {
-					data, _ = json.Marshal(spectralReport)
-				} else {
-					data, _ = json.MarshalIndent(spectralReport, "", "    ")
-				}
 
-				if stdOut {
-					fmt.Print(string(data))
-					return nil
-				}
-
-				// Determine output filename
-				var outputFile string
-				if isMultiFile {
-					timestamp := time.Now().Format("01-02-06-15_04_05")
-					outputFile = GenerateReportFileName(specFile, outputDir, "spectral-report", timestamp, ".json")
-				} else {
-					outputFile = reportOutput
+				// --changes mode: fall back to area-based ChangeFilter
+				if documentChanges != nil {
+					changeFilter := utils.NewChangeFilter(documentChanges, ruleset.RuleSetExecution.DrDocument)
+					resultSet.Results = changeFilter.FilterResults(resultSet.Results)
 				}
+			}
 
-				if err = os.WriteFile(outputFile, data, 0664); err != nil {
-					tui.RenderErrorString("Unable to write report file: '%s': %s", outputFile, err.Error())
-					if isMultiFile {
-						continue
+			// Inject change violations if requested
+			if documentChanges != nil && (warnOnChanges || errorOnBreaking) {
+				changeViolations := utils.GenerateChangeViolations(documentChanges, utils.ChangeViolationOptions{
+					WarnOnChanges:   warnOnChanges,
+					ErrorOnBreaking: errorOnBreaking,
+				})
+				for _, v := range changeViolations {
+					if v != nil {
+						resultSet.Results = append(resultSet.Results, v)
 					}
-					return err
 				}
-
-				tui.RenderSuccess("Report generated for '%s', written to '%s'", specFile, outputFile)
-
-				if !stdIn {
-					fi, _ := os.Stat(specFile)
-					if fi != nil {
-						RenderTime(timeFlag, duration, fi.Size())
+			}
+		}
+
+		duration := time.Since(start)
+
+		var source string
+		if stdIn {
+			source = "stdin"
+		} else {
+			source = specFile
+			// Make the path relative to current working directory for consistency
+			if absPath, err := filepath.Abs(source); err == nil {
+				if cwd, err := os.Getwd(); err == nil {
+					if relPath, err := filepath.Rel(cwd, absPath); err == nil {
+						source = relPath
 					}
 				}
-				processedFiles++
 			}
+		}
+		// serialize
+		report := reportType.build(resultSet, source)
+
+		var data []byte
+		if noPretty {
+			data, _ = json.Marshal(report)
+		} else {
+			data, _ = json.MarshalIndent(report, "", "    ")
+		}
+
+		if stdOut {
+			fmt.Print(string(data))
+			return nil
+		}
+
+		// Determine output filename
+		var outputFile string
+		if isMultiFile {
+			timestamp := time.Now().Format("01-02-06-15_04_05")
+			outputFile = GenerateReportFileName(specFile, outputDir, reportType.fileType, timestamp, reportType.extension)
+		} else {
+			outputFile = reportOutput
+		}
+
+		if err = os.WriteFile(outputFile, data, 0664); err != nil {
+			tui.RenderErrorString("Unable to write report file: '%s': %s", outputFile, err.Error())
+			if isMultiFile {
+				continue
+			}
+			return err
+		}
+
+		tui.RenderSuccess("Report generated for '%s', written to '%s'", specFile, outputFile)
 
-			// Summary for multi-file mode
-			if isMultiFile && !stdOut {
-				tui.RenderInfo("Processed %d files successfully", processedFiles)
+		if !stdIn {
+			fi, _ := os.Stat(specFile)
+			if fi != nil {
+				RenderTime(timeFlag, duration, fi.Size())
 			}
+		}
+		processedFiles++
+	}
 
-			return nil
-		},
+	// Summary for multi-file mode
+	if isMultiFile && !stdOut {
+		tui.RenderInfo("Processed %d files successfully", processedFiles)
 	}
-	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
-	cmd.Flags().BoolP("stdout", "o", false, "Use stdout as output, instead of a file")
-	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
-	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
-	cmd.Flags().String("ignore-file", "", "Path to ignore file")
-	cmd.Flags().Bool("ignore-array-circle-ref", false, "Ignore circular array references")
-	cmd.Flags().Bool("ignore-polymorph-circle-ref", false, "Ignore circular polymorphic references")
-	cmd.Flags().String("globbed-files", "", "Glob pattern of files to process (e.g., 'specs/*.yaml')")
-	cmd.Flags().String("output-dir", "", "Directory to write report files to (default: current directory)")
-	return cmd
 
+	return nil
 }
@@ -0,0 +1,449 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/tui"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v4"
+)
+
+// RuleSetTestManifest describes the test cases run by the test-ruleset command. Paths in the manifest are relative
+// to the manifest.
+type RuleSetTestManifest struct {
+	Ruleset   string             `json:"ruleset,omitempty" yaml:"ruleset,omitempty"`     // ruleset used by test cases without their own
+	Functions string             `json:"functions,omitempty" yaml:"functions,omitempty"` // directory of custom functions used by the rulesets
+	Tests     []*RuleSetTestCase `json:"tests" yaml:"tests"`
+}
+
+// RuleSetTestCase lints a fixture spec with a ruleset, and checks the results are exactly the expected results.
+// A test case without expected results checks that no results are produced.
+type RuleSetTestCase struct {
+	Name    string                    `json:"name" yaml:"name"`
+	Ruleset string                    `json:"ruleset,omitempty" yaml:"ruleset,omitempty"`
+	Spec    string                    `json:"spec" yaml:"spec"`
+	Rules   []string                  `json:"rules,omitempty" yaml:"rules,omitempty"` // only check results of these rules
+	Expect  []*RuleSetTestExpectation `json:"expect,omitempty" yaml:"expect,omitempty"`
+}
+
+// RuleSetTestExpectation is a result a test case expects. Empty fields match anything, and the message only needs
+// to be contained in the message of the result.
+type RuleSetTestExpectation struct {
+	RuleId   string `json:"ruleId" yaml:"ruleId"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ruleSetTestResult is the outcome of running a single test case.
+type ruleSetTestResult struct {
+	testCase   *RuleSetTestCase
+	spec       string
+	missing    []*RuleSetTestExpectation
+	unexpected []*model.RuleFunctionResult
+	err        error
+	duration   time.Duration
+}
+
+func (r *ruleSetTestResult) passed() bool {
+	return r.err == nil && len(r.missing) == 0 && len(r.unexpected) == 0
+}
+
+// diff describes why a test case failed, missing results are prefixed with '-', unexpected results with '+'.
+func (r *ruleSetTestResult) diff() string {
+	if r.err != nil {
+		return r.err.Error()
+	}
+	var sb strings.Builder
+	for _, m := range r.missing {
+		sb.WriteString(fmt.Sprintf("- %s\n", describeRuleSetTestResult(m.RuleId, m.Path, m.Severity, m.Message, 0)))
+	}
+	for _, u := range r.unexpected {
+		ruleId, severity := ruleSetTestResultRule(u)
+		line := 0
+		if u.StartNode != nil {
+			line = u.StartNode.Line
+		}
+		sb.WriteString(fmt.Sprintf("+ %s\n", describeRuleSetTestResult(ruleId, u.Path, severity, u.Message, line)))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func GetTestRuleSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "test-ruleset <manifest>",
+		Short:         "Test custom rules against fixture specifications",
+		Long: `Run the test cases in a test manifest. Each test case lints a fixture specification with a ruleset, and
+checks that exactly the expected results (ruleId, path, severity) are produced, or that none are.
+
+Missing results are reported with '-', unexpected results with '+'. The command fails if any test case fails.`,
+		Example: `  vacuum test-ruleset ruleset-tests.yaml
+  vacuum test-ruleset ruleset-tests.yaml --junit ruleset-tests.xml`,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: runTestRuleSet,
+	}
+	cmd.Flags().String("junit", "", "Write a JUnit report of the test results to this file")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	return cmd
+}
+
+func runTestRuleSet(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
+	junitFlag, _ := cmd.Flags().GetString("junit")
+	noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+	rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+	functionsFlag, _ := cmd.Flags().GetString("functions")
+
+	if noStyleFlag {
+		color.DisableColors()
+	}
+
+	if len(args) < 1 {
+		tui.RenderErrorString("please supply a test manifest")
+		return NewInputError("please supply a test manifest")
+	}
+
+	manifestPath, err := ResolveConfigPath(args[0])
+	if err != nil {
+		return NewInputError("unable to resolve test manifest '%s': %s", args[0], err.Error())
+	}
+	manifest, err := LoadRuleSetTestManifest(manifestPath)
+	if err != nil {
+		tui.RenderErrorString("%s", err.Error())
+		return NewInputError("%s", err.Error())
+	}
+
+	// the ruleset and functions flags are used when the manifest does not set them.
+	if manifest.Ruleset == "" && rulesetFlag != "" {
+		manifest.Ruleset, _ = filepath.Abs(rulesetFlag)
+	}
+	if manifest.Functions == "" && functionsFlag != "" {
+		manifest.Functions, _ = filepath.Abs(functionsFlag)
+	}
+
+	httpClient, err := CreateHTTPClientFromFlags(ReadLintFlags(cmd))
+	if err != nil {
+		return NewInputError("%s", err.Error())
+	}
+
+	results, err := RunRuleSetTests(manifest, filepath.Dir(manifestPath), httpClient)
+	if err != nil {
+		tui.RenderErrorString("%s", err.Error())
+		return NewInputError("%s", err.Error())
+	}
+
+	failed := renderRuleSetTestResults(results)
+
+	if junitFlag != "" {
+		report := vacuum_report.BuildRuleSetTestJUnitReport(filepath.Base(manifestPath), ruleSetTestReportResults(results), start)
+		if err = os.WriteFile(junitFlag, report, 0o664); err != nil {
+			return NewInputError("unable to write JUnit report '%s': %s", junitFlag, err.Error())
+		}
+		fmt.Printf(" %sJUnit report written to '%s'%s\n\n", color.ASCIIGrey, junitFlag, color.ASCIIReset)
+	}
+
+	if failed > 0 {
+		return NewViolationError("%d of %d ruleset test(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// LoadRuleSetTestManifest reads and checks a test manifest.
+func LoadRuleSetTestManifest(path string) (*RuleSetTestManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read test manifest '%s': %w", path, err)
+	}
+	var manifest RuleSetTestManifest
+	if err = yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse test manifest '%s': %w", path, err)
+	}
+	if len(manifest.Tests) == 0 {
+		return nil, fmt.Errorf("test manifest '%s' does not contain any tests", path)
+	}
+	for i, testCase := range manifest.Tests {
+		if testCase == nil || testCase.Spec == "" {
+			return nil, fmt.Errorf("test %d in manifest '%s' does not have a spec", i+1, path)
+		}
+		if testCase.Name == "" {
+			testCase.Name = testCase.Spec
+		}
+		for _, expected := range testCase.Expect {
+			if expected == nil || expected.RuleId == "" {
+				return nil, fmt.Errorf("test '%s' in manifest '%s' expects a result without a ruleId", testCase.Name, path)
+			}
+		}
+	}
+	return &manifest, nil
+}
+
+// RunRuleSetTests runs every test case of a manifest, paths are resolved against dir. Rulesets are only built
+// once, and shared by the test cases that use them. An error is returned if custom functions cannot be loaded,
+// problems with a single test case fail that test case.
+func RunRuleSetTests(manifest *RuleSetTestManifest, dir string, httpClient *http.Client) ([]*ruleSetTestResult, error) {
+	var customFunctions map[string]model.RuleFunction
+	if manifest.Functions != "" {
+		var err error
+		customFunctions, err = LoadCustomFunctions(resolveRuleSetTestPath(dir, manifest.Functions), true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load custom functions '%s': %w", manifest.Functions, err)
+		}
+	}
+
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	builtRuleSets := make(map[string]*rulesets.RuleSet)
+	ruleSetErrors := make(map[string]error)
+
+	var results []*ruleSetTestResult
+	for _, testCase := range manifest.Tests {
+		caseStart := time.Now()
+		result := &ruleSetTestResult{
+			testCase: testCase,
+			spec:     resolveRuleSetTestPath(dir, testCase.Spec),
+		}
+		results = append(results, result)
+
+		rulesetLocation := testCase.Ruleset
+		if rulesetLocation == "" {
+			rulesetLocation = manifest.Ruleset
+		}
+		if rulesetLocation == "" {
+			result.err = fmt.Errorf("no ruleset for test '%s', set one for the test or the manifest", testCase.Name)
+			continue
+		}
+		rulesetLocation = resolveRuleSetTestPath(dir, rulesetLocation)
+
+		rs, built := builtRuleSets[rulesetLocation]
+		if !built {
+			if rsErr, failed := ruleSetErrors[rulesetLocation]; failed {
+				result.err = rsErr
+				continue
+			}
+			var rsErr error
+			rs, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetLocation, defaultRuleSets, true, httpClient)
+			if rsErr != nil {
+				ruleSetErrors[rulesetLocation] = fmt.Errorf("unable to load ruleset '%s': %w", rulesetLocation, rsErr)
+				result.err = ruleSetErrors[rulesetLocation]
+				continue
+			}
+			builtRuleSets[rulesetLocation] = rs
+		}
+
+		runRuleSetTestCase(result, rs, customFunctions)
+		result.duration = time.Since(caseStart)
+	}
+	return results, nil
+}
+
+// runRuleSetTestCase lints the spec of a test case, and compares the results with the expected results.
+func runRuleSetTestCase(result *ruleSetTestResult, rs *rulesets.RuleSet, customFunctions map[string]model.RuleFunction) {
+	specBytes, err := os.ReadFile(result.spec)
+	if err != nil {
+		result.err = fmt.Errorf("unable to read spec: %w", err)
+		return
+	}
+
+	execution := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:         rs,
+		Spec:            specBytes,
+		SpecFileName:    result.spec,
+		Base:            filepath.Dir(result.spec),
+		CustomFunctions: customFunctions,
+		AllowLookup:     true,
+		SilenceLogs:     true,
+	})
+	defer execution.ReleaseOwnedResources()
+
+	if len(execution.Errors) > 0 {
+		result.err = fmt.Errorf("unable to lint spec: %w", execution.Errors[0])
+		return
+	}
+
+	var actual []*model.RuleFunctionResult
+	for i := range execution.Results {
+		r := &execution.Results[i]
+		if len(result.testCase.Rules) > 0 {
+			ruleId, _ := ruleSetTestResultRule(r)
+			if !containsString(result.testCase.Rules, ruleId) {
+				continue
+			}
+		}
+		actual = append(actual, r)
+	}
+	result.missing, result.unexpected = compareRuleSetTestResults(result.testCase.Expect, actual)
+}
+
+// compareRuleSetTestResults pairs every expected result with an actual result, returning the expectations
+// without a result, and the results that were not expected. The most specific expectations are paired first, so
+// they are not robbed of their result by a vaguer expectation.
+func compareRuleSetTestResults(expected []*RuleSetTestExpectation, actual []*model.RuleFunctionResult) ([]*RuleSetTestExpectation, []*model.RuleFunctionResult) {
+	ordered := append([]*RuleSetTestExpectation(nil), expected...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].specificity() > ordered[j].specificity()
+	})
+
+	matched := make([]bool, len(actual))
+	var missing []*RuleSetTestExpectation
+	for _, e := range ordered {
+		found := false
+		for i, r := range actual {
+			if !matched[i] && e.matches(r) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+
+	var unexpected []*model.RuleFunctionResult
+	for i, r := range actual {
+		if !matched[i] {
+			unexpected = append(unexpected, r)
+		}
+	}
+	return missing, unexpected
+}
+
+func (e *RuleSetTestExpectation) matches(r *model.RuleFunctionResult) bool {
+	ruleId, severity := ruleSetTestResultRule(r)
+	if e.RuleId != ruleId {
+		return false
+	}
+	if e.Path != "" && e.Path != r.Path {
+		return false
+	}
+	if e.Severity != "" && !strings.EqualFold(e.Severity, severity) {
+		return false
+	}
+	return e.Message == "" || strings.Contains(r.Message, e.Message)
+}
+
+func (e *RuleSetTestExpectation) specificity() int {
+	specificity := 0
+	for _, field := range []string{e.Path, e.Severity, e.Message} {
+		if field != "" {
+			specificity++
+		}
+	}
+	return specificity
+}
+
+// ruleSetTestResultRule returns the rule id and severity of a result.
+func ruleSetTestResultRule(r *model.RuleFunctionResult) (string, string) {
+	if r.Rule != nil {
+		return r.Rule.Id, r.Rule.Severity
+	}
+	return r.RuleId, r.RuleSeverity
+}
+
+func describeRuleSetTestResult(ruleId, path, severity, message string, line int) string {
+	var sb strings.Builder
+	sb.WriteString(ruleId)
+	if path != "" {
+		sb.WriteString(" at " + path)
+	}
+	if line > 0 {
+		sb.WriteString(fmt.Sprintf(" (line %d)", line))
+	}
+	if severity != "" {
+		sb.WriteString(" [" + severity + "]")
+	}
+	if message != "" {
+		sb.WriteString(": " + message)
+	}
+	return sb.String()
+}
+
+// resolveRuleSetTestPath resolves a path in a test manifest against the directory of the manifest.
+func resolveRuleSetTestPath(dir, path string) string {
+	if strings.HasPrefix(path, "http") || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// renderRuleSetTestResults prints the outcome of every test case, with a diff for failed cases, and returns the
+// number of failed test cases.
+func renderRuleSetTestResults(results []*ruleSetTestResult) int {
+	failed := 0
+	fmt.Println()
+	for _, r := range results {
+		if r.passed() {
+			fmt.Printf(" %s✓%s %s %s(%s)%s\n", color.ASCIIGreen, color.ASCIIReset, r.testCase.Name,
+				color.ASCIIGrey, r.duration.Round(time.Millisecond), color.ASCIIReset)
+			continue
+		}
+		failed++
+		fmt.Printf(" %s✗ %s%s\n", color.ASCIIRed, r.testCase.Name, color.ASCIIReset)
+		for _, line := range strings.Split(r.diff(), "\n") {
+			lineColor := color.ASCIIGrey
+			switch {
+			case strings.HasPrefix(line, "- "):
+				lineColor = color.ASCIIRed
+			case strings.HasPrefix(line, "+ "):
+				lineColor = color.ASCIIGreen
+			}
+			fmt.Printf("     %s%s%s\n", lineColor, line, color.ASCIIReset)
+		}
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		fmt.Printf(" %s%d of %d test(s) failed%s\n\n", color.ASCIIRed, failed, len(results), color.ASCIIReset)
+	} else {
+		fmt.Printf(" %s%d test(s) passed%s\n\n", color.ASCIIGreen, len(results), color.ASCIIReset)
+	}
+	return failed
+}
+
+// ruleSetTestReportResults converts test results for the JUnit report.
+func ruleSetTestReportResults(results []*ruleSetTestResult) []*vacuum_report.RuleSetTestCaseResult {
+	reportResults := make([]*vacuum_report.RuleSetTestCaseResult, 0, len(results))
+	for _, r := range results {
+		reportResult := &vacuum_report.RuleSetTestCaseResult{
+			Name:     r.testCase.Name,
+			Spec:     r.spec,
+			Duration: r.duration,
+		}
+		if !r.passed() {
+			reportResult.Failure = r.diff()
+		}
+		reportResults = append(reportResults, reportResult)
+	}
+	return reportResults
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+const testRuleSetFixtureRuleset = `
+rules:
+  operation-summary-required:
+    description: operations must have a summary
+    given: $.paths[*][*]
+    severity: warn
+    then:
+      field: summary
+      function: truthy
+`
+
+const testRuleSetFixtureSpec = `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+      responses:
+        '200':
+          description: ok
+    post:
+      responses:
+        '201':
+          description: ok
+`
+
+const testRuleSetFixtureCleanSpec = `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: List pets
+      responses:
+        '200':
+          description: ok
+`
+
+func writeRuleSetTestFixtures(t *testing.T, manifest string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "rules", "ruleset.yaml"), testRuleSetFixtureRuleset)
+	writeTestFile(t, filepath.Join(dir, "fixtures", "missing-summary.yaml"), testRuleSetFixtureSpec)
+	writeTestFile(t, filepath.Join(dir, "fixtures", "clean.yaml"), testRuleSetFixtureCleanSpec)
+	writeTestFile(t, filepath.Join(dir, "tests.yaml"), manifest)
+	return dir
+}
+
+func TestRunRuleSetTests(t *testing.T) {
+	dir := writeRuleSetTestFixtures(t, `
+ruleset: rules/ruleset.yaml
+tests:
+  - name: missing summary is reported
+    spec: fixtures/missing-summary.yaml
+    expect:
+      - ruleId: operation-summary-required
+        path: $.paths['/pets'].post.summary
+        severity: warn
+        message: summary
+  - name: clean spec has no results
+    spec: fixtures/clean.yaml
+  - name: wrong expectation
+    spec: fixtures/missing-summary.yaml
+    expect:
+      - ruleId: operation-summary-required
+        path: $.paths['/pets'].get.summary
+  - name: missing ruleset
+    ruleset: rules/nope.yaml
+    spec: fixtures/clean.yaml
+`)
+	manifest, err := LoadRuleSetTestManifest(filepath.Join(dir, "tests.yaml"))
+	require.NoError(t, err)
+
+	results, err := RunRuleSetTests(manifest, dir, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.True(t, results[0].passed(), results[0].diff())
+	assert.True(t, results[1].passed(), results[1].diff())
+
+	assert.False(t, results[2].passed())
+	require.Len(t, results[2].missing, 1)
+	require.Len(t, results[2].unexpected, 1)
+	assert.Equal(t, "$.paths['/pets'].post.summary", results[2].unexpected[0].Path)
+	diff := results[2].diff()
+	assert.Contains(t, diff, "- operation-summary-required at $.paths['/pets'].get.summary")
+	assert.Contains(t, diff, "+ operation-summary-required at $.paths['/pets'].post.summary (line 13) [warn]")
+
+	assert.False(t, results[3].passed())
+	assert.Contains(t, results[3].diff(), "unable to load ruleset")
+}
+
+func TestRunRuleSetTests_RulesFilter(t *testing.T) {
+	dir := writeRuleSetTestFixtures(t, `
+tests:
+  - name: only other rules are checked
+    ruleset: rules/ruleset.yaml
+    spec: fixtures/missing-summary.yaml
+    rules: [some-other-rule]
+`)
+	manifest, err := LoadRuleSetTestManifest(filepath.Join(dir, "tests.yaml"))
+	require.NoError(t, err)
+
+	results, err := RunRuleSetTests(manifest, dir, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].passed(), results[0].diff())
+}
+
+func TestRunRuleSetTests_NoRuleset(t *testing.T) {
+	dir := writeRuleSetTestFixtures(t, `
+tests:
+  - spec: fixtures/clean.yaml
+`)
+	manifest, err := LoadRuleSetTestManifest(filepath.Join(dir, "tests.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "fixtures/clean.yaml", manifest.Tests[0].Name)
+
+	results, err := RunRuleSetTests(manifest, dir, nil)
+	require.NoError(t, err)
+	assert.Contains(t, results[0].diff(), "no ruleset for test 'fixtures/clean.yaml'")
+}
+
+func TestLoadRuleSetTestManifest_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadRuleSetTestManifest(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "unable to read test manifest")
+
+	writeTestFile(t, filepath.Join(dir, "empty.yaml"), "ruleset: rules.yaml")
+	_, err = LoadRuleSetTestManifest(filepath.Join(dir, "empty.yaml"))
+	assert.ErrorContains(t, err, "does not contain any tests")
+
+	writeTestFile(t, filepath.Join(dir, "nospec.yaml"), "tests:\n  - name: oops")
+	_, err = LoadRuleSetTestManifest(filepath.Join(dir, "nospec.yaml"))
+	assert.ErrorContains(t, err, "test 1 in manifest")
+
+	writeTestFile(t, filepath.Join(dir, "norule.yaml"), "tests:\n  - spec: a.yaml\n    expect:\n      - path: $.info")
+	_, err = LoadRuleSetTestManifest(filepath.Join(dir, "norule.yaml"))
+	assert.ErrorContains(t, err, "expects a result without a ruleId")
+}
+
+func TestCompareRuleSetTestResults_SpecificFirst(t *testing.T) {
+	rule := &model.Rule{Id: "r", Severity: model.SeverityError}
+	actual := []*model.RuleFunctionResult{
+		{Rule: rule, Path: "$.a"},
+		{Rule: rule, Path: "$.b"},
+	}
+	// the vague expectation comes first, but must not take the result the specific expectation needs.
+	missing, unexpected := compareRuleSetTestResults([]*RuleSetTestExpectation{
+		{RuleId: "r"},
+		{RuleId: "r", Path: "$.a", Severity: "ERROR"},
+	}, actual)
+	assert.Empty(t, missing)
+	assert.Empty(t, unexpected)
+}
+
+func TestGetTestRuleSetCommand(t *testing.T) {
+	dir := writeRuleSetTestFixtures(t, `
+ruleset: rules/ruleset.yaml
+tests:
+  - name: passes
+    spec: fixtures/clean.yaml
+  - name: fails
+    spec: fixtures/missing-summary.yaml
+`)
+	junitPath := filepath.Join(dir, "report.xml")
+
+	cmd := GetTestRuleSetCommand()
+	cmd.SetArgs([]string{"--no-style", "--junit", junitPath, filepath.Join(dir, "tests.yaml")})
+	err := cmd.Execute()
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitCodeViolations, exitErr.Code)
+	assert.Equal(t, "1 of 2 ruleset test(s) failed", exitErr.Message)
+
+	report, err := os.ReadFile(junitPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(report), `<testsuites tests="2" failures="1"`)
+	assert.Contains(t, string(report), `<testcase name="passes" classname="tests.yaml"`)
+	assert.Contains(t, string(report), `+ operation-summary-required at $.paths[&#39;/pets&#39;].post.summary`)
+}
+
+func TestGetTestRuleSetCommand_BadManifest(t *testing.T) {
+	cmd := GetTestRuleSetCommand()
+	cmd.SetArgs([]string{"--no-style", filepath.Join(t.TempDir(), "nope.yaml")})
+	err := cmd.Execute()
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, ExitCodeInputError, exitErr.Code)
+}
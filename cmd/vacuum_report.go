@@ -14,10 +14,10 @@ import (
 	"time"
 
 	"github.com/daveshanley/vacuum/color"
+	"github.com/daveshanley/vacuum/history"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
-	"github.com/daveshanley/vacuum/statistics"
 	"github.com/daveshanley/vacuum/tui"
 	"github.com/daveshanley/vacuum/utils"
 	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
@@ -41,7 +41,8 @@ For multiple files, use --globbed-files to specify a glob pattern:
 This generates one report per input file, named after the source spec.`,
 		Example: `vacuum report my-awesome-spec.yaml report-prefix
 vacuum report --globbed-files "specs/*.yaml" --output-dir reports/
-vacuum report --globbed-files "api/**/*.json" -c`,
+vacuum report --globbed-files "api/**/*.json" -c
+vacuum report --gitlab -o my-awesome-spec.yaml > gl-code-quality-report.json`,
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) == 0 {
 				return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
@@ -56,6 +57,8 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 			baseFlag, _ := cmd.Flags().GetString("base")
 			junitFlag, _ := cmd.Flags().GetBool("junit")
 			junitFailOnWarn, _ := cmd.Flags().GetBool("junit-fail-on-warn")
+			gitlabFlag, _ := cmd.Flags().GetBool("gitlab")
+			checkstyleFlag, _ := cmd.Flags().GetBool("checkstyle")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
 			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
 			lookupTimeoutFlag, _ := cmd.Flags().GetInt("lookup-timeout")
@@ -74,8 +77,11 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 			warnOnChanges, _ := cmd.Flags().GetBool("warn-on-changes")
 			errorOnBreaking, _ := cmd.Flags().GetBool("error-on-breaking")
 			turboFlag, _ := cmd.Flags().GetBool("turbo")
+			recordFlag, _ := cmd.Flags().GetBool("record")
+			historyDBFlag, _ := cmd.Flags().GetString("history-db")
 			resolveAllRefsFlag, _ := cmd.Flags().GetBool("resolve-all-refs")
 			nestedRefsDocContextFlag, _ := cmd.Flags().GetBool("nested-refs-doc-context")
+			cacheDirFlag, _ := cmd.Flags().GetString("cache-dir")
 
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
@@ -83,6 +89,17 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				color.DisableColors()
 			}
 
+			profiler, profileErr := newRuleProfiler(cmd)
+			if profileErr != nil {
+				return profileErr
+			}
+			defer profiler.render()
+
+			workers, workersErr := readWorkersFlag(cmd)
+			if workersErr != nil {
+				return workersErr
+			}
+
 			if !stdIn && !stdOut {
 				PrintBanner()
 			}
@@ -167,7 +184,7 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				reportOutput = args[1]
 			}
 
-			ignoredItems, err := LoadIgnoreFile(ignoreFile, stdIn || stdOut, stdOut, noStyleFlag)
+			ignoreEntries, err := LoadIgnoreFileEntries(ignoreFile, stdIn || stdOut, stdOut, noStyleFlag)
 			if err != nil {
 				return err
 			}
@@ -216,6 +233,12 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				tui.RenderInfo("Linting against %d rules: %s", len(selectedRS.Rules), selectedRS.DocumentationURI)
 			}
 
+			scoreModel, scoreErr := ResolveScoreModel(selectedRS)
+			if scoreErr != nil {
+				tui.RenderErrorString("%s", scoreErr.Error())
+				return scoreErr
+			}
+
 			deepGraph := false
 			if ignoreFile != "" {
 				deepGraph = true
@@ -232,6 +255,23 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				}
 			}
 
+			// results compared with another version of the specification need the document, so they are not cached.
+			var cache *lintCache
+			if isMultiFile || (changesFlag == "" && originalFlag == "") {
+				cache = newLintCache(cacheDirFlag, functionsFlag)
+			}
+
+			var historyStore *history.Store
+			if recordFlag && !stdIn {
+				var historyErr error
+				historyStore, historyErr = OpenHistoryStore(historyDBFlag)
+				if historyErr != nil {
+					tui.RenderErrorString("%s", historyErr.Error())
+					return historyErr
+				}
+				defer historyStore.Close()
+			}
+
 			// Track lowest score across all files for threshold check
 			var lowestScore int = 100
 			var processedFiles int
@@ -242,81 +282,56 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				filesToIterate = []string{"stdin"}
 			}
 
-			for _, specFile := range filesToIterate {
-				start := time.Now()
-
-				var specBytes []byte
-				var fileError error
+			// every file gets its own default ruleset, hard mode and turbo change the ruleset they are given.
+			lintSpec := func(specFile string) *lintedReportSpec {
+				linted := &lintedReportSpec{start: time.Now()}
 
 				if stdIn {
 					// read file from stdin
 					inputReader := cmd.InOrStdin()
 					buf := &bytes.Buffer{}
-					_, fileError = buf.ReadFrom(inputReader)
-					specBytes = buf.Bytes()
+					_, linted.readErr = buf.ReadFrom(inputReader)
+					linted.specBytes = buf.Bytes()
 				} else {
 					// read file from filesystem
-					specBytes, fileError = os.ReadFile(specFile)
+					linted.specBytes, linted.readErr = os.ReadFile(specFile)
 				}
-
-				if fileError != nil {
-					tui.RenderErrorString("Unable to read file '%s': %s", specFile, fileError.Error())
-					if isMultiFile {
-						continue // Skip this file and continue with others
-					}
-					return fileError
+				if linted.readErr != nil {
+					return linted
 				}
 
 				// Resolve base path for this specific file
 				var resolvedBase string
-				var baseErr error
 				if stdIn {
 					// For stdin input, use the provided base flag or current directory as fallback
 					if baseFlag != "" {
 						resolvedBase = baseFlag
 					} else {
-						resolvedBase, baseErr = filepath.Abs(".")
-						if baseErr != nil {
-							return fmt.Errorf("failed to resolve current directory as base path: %w", baseErr)
-						}
+						resolvedBase, linted.baseErr = filepath.Abs(".")
 					}
 				} else {
-					resolvedBase, baseErr = ResolveBasePathForFile(specFile, baseFlag)
-					if baseErr != nil {
-						tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, baseErr.Error())
-						if isMultiFile {
-							continue
-						}
-						return fmt.Errorf("failed to resolve base path: %w", baseErr)
-					}
+					resolvedBase, linted.baseErr = ResolveBasePathForFile(specFile, baseFlag)
 				}
-				resolvedSpecPath, specPathErr := ResolveSpecPathForExecution(specFile)
-				if specPathErr != nil {
-					tui.RenderErrorString("Failed to resolve spec path for '%s': %s", specFile, specPathErr.Error())
-					if isMultiFile {
-						continue
-					}
-					return fmt.Errorf("failed to resolve spec path: %w", specPathErr)
+				if linted.baseErr != nil {
+					return linted
+				}
+				var resolvedSpecPath string
+				resolvedSpecPath, linted.specPathErr = ResolveSpecPathForExecution(specFile)
+				if linted.specPathErr != nil {
+					return linted
 				}
 
-				selectedRSForFile := selectedRS
+				linted.ruleSet = selectedRS
 				specFormat := ""
 				if rulesetFlag == "" {
-					var asyncDefault bool
-					selectedRSForFile, specFormat, asyncDefault = prepareDefaultRuleSetForSpec(defaultRuleSets, specBytes, hardModeFlag, turboFlag)
-					if hardModeFlag && !asyncDefault && !hardModeBoxRendered && !stdIn && !stdOut {
-						tui.RenderStyledBox(HardModeEnabled, tui.BoxTypeHard, noStyleFlag)
-						hardModeBoxRendered = true
-					}
-					if !stdIn && !stdOut {
-						tui.RenderInfo("Linting against %d rules: %s", len(selectedRSForFile.Rules), selectedRSForFile.DocumentationURI)
-					}
+					linted.ruleSet, specFormat, linted.asyncDefault = prepareDefaultRuleSetForSpec(rulesets.BuildDefaultRuleSets(),
+						linted.specBytes, hardModeFlag, turboFlag)
 				}
 
-				executionOptions := newMotorExecutionOptions(resolveAllRefsFlag, nestedRefsDocContextFlag)
-				ruleset := motor.ApplyRulesToRuleSetWithOptions(&motor.RuleSetExecution{
-					RuleSet:                         selectedRSForFile,
-					Spec:                            specBytes,
+				linted.executionOptions = newMotorExecutionOptions(resolveAllRefsFlag, nestedRefsDocContextFlag)
+				linted.result, linted.documentStats = cache.applyRules(&motor.RuleSetExecution{
+					RuleSet:                         linted.ruleSet,
+					Spec:                            linted.specBytes,
 					SpecFileName:                    resolvedSpecPath,
 					CustomFunctions:                 customFunctions,
 					SilenceLogs:                     true,
@@ -332,8 +347,56 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 					HTTPClientConfig:                httpClientConfig,
 					FetchConfig:                     fetchConfig,
 					TurboMode:                       turboFlag,
+					ProfileRules:                    profiler.enabled(),
 					SpecFormat:                      specFormat,
-				}, executionOptions)
+				}, linted.executionOptions)
+				return linted
+			}
+
+			for i, linted := range lintInOrder(filesToIterate, workers, lintSpec) {
+				specFile := filesToIterate[i]
+				start := linted.start
+				specBytes := linted.specBytes
+
+				if linted.readErr != nil {
+					tui.RenderErrorString("Unable to read file '%s': %s", specFile, linted.readErr.Error())
+					if isMultiFile {
+						continue // Skip this file and continue with others
+					}
+					return linted.readErr
+				}
+
+				if linted.baseErr != nil {
+					if stdIn {
+						return fmt.Errorf("failed to resolve current directory as base path: %w", linted.baseErr)
+					}
+					tui.RenderErrorString("Failed to resolve base path for '%s': %s", specFile, linted.baseErr.Error())
+					if isMultiFile {
+						continue
+					}
+					return fmt.Errorf("failed to resolve base path: %w", linted.baseErr)
+				}
+				if linted.specPathErr != nil {
+					tui.RenderErrorString("Failed to resolve spec path for '%s': %s", specFile, linted.specPathErr.Error())
+					if isMultiFile {
+						continue
+					}
+					return fmt.Errorf("failed to resolve spec path: %w", linted.specPathErr)
+				}
+
+				selectedRSForFile := linted.ruleSet
+				if rulesetFlag == "" {
+					if hardModeFlag && !linted.asyncDefault && !hardModeBoxRendered && !stdIn && !stdOut {
+						tui.RenderStyledBox(HardModeEnabled, tui.BoxTypeHard, noStyleFlag)
+						hardModeBoxRendered = true
+					}
+					if !stdIn && !stdOut {
+						tui.RenderInfo("Linting against %d rules: %s", len(selectedRSForFile.Rules), selectedRSForFile.DocumentationURI)
+					}
+				}
+
+				executionOptions := linted.executionOptions
+				ruleset := linted.result
 
 				// Check for spec parsing errors before generating report
 				if ruleset.SpecInfo == nil {
@@ -343,14 +406,17 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 					}
 					return NewInputError("failed to parse specification '%s'", specFile)
 				}
+				profiler.add(specFile, ruleset)
 
 				resultSet := model.NewRuleResultSet(ruleset.Results)
 				resultSet.SortResultsByLineNumber()
 
-				resultSet.Results = utils.FilterIgnoredResultsPtrWithOptions(
+				ignoreOptions := buildIgnoreFilterOptions(specBytes, ruleset, lookupTimeoutFlag)
+				ignoreOptions.Entries = ignoreEntries
+				resultSet.Results, resultSet.Suppressed = utils.PartitionIgnoredResultsPtrWithOptions(
 					resultSet.Results,
-					ignoredItems,
-					buildIgnoreFilterOptions(specBytes, ruleset, lookupTimeoutFlag),
+					ignoreEntries.Paths(),
+					ignoreOptions,
 				)
 
 				// Apply change-based filtering if --changes or --original is specified
@@ -416,32 +482,54 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 
 				duration := time.Since(start)
 
-				// if we want jUnit output, then build the report and be done with it.
-				if junitFlag {
-					junitConfig := vacuum_report.JUnitConfig{FailOnWarn: junitFailOnWarn}
-					junitXML := vacuum_report.BuildJUnitReportWithConfig(resultSet, start, []string{specFile}, junitConfig)
+				if historyStore != nil {
+					historyStats := lintStatistics(ruleset, linted.documentStats, resultSet, scoreModel)
+					if historyErr := recordLintHistory(historyStore, specFile, selectedRSForFile, historyStats); historyErr != nil {
+						tui.RenderErrorString("%s", historyErr.Error())
+						if isMultiFile {
+							continue
+						}
+						return historyErr
+					}
+				}
+
+				// if we want jUnit, GitLab Code Quality or Checkstyle output, then build the report and be done with it.
+				if junitFlag || gitlabFlag || checkstyleFlag {
+					var formatted []byte
+					formatName, extension := "JUnit", ".xml"
+					switch {
+					case junitFlag:
+						junitConfig := vacuum_report.JUnitConfig{FailOnWarn: junitFailOnWarn}
+						formatted = vacuum_report.BuildJUnitReportWithConfig(resultSet, start, []string{specFile}, junitConfig)
+					case gitlabFlag:
+						formatName, extension = "GitLab Code Quality", ".json"
+						formatted = vacuum_report.BuildGitLabCodeQualityReport(resultSet, []string{specFile})
+					case checkstyleFlag:
+						formatName = "Checkstyle"
+						formatted = vacuum_report.BuildCheckstyleReport(resultSet, []string{specFile})
+					}
 					if stdOut {
-						fmt.Print(string(junitXML))
+						fmt.Print(string(formatted))
 						return nil
 					}
 
 					timestamp := time.Now().Format("01-02-06-15_04_05")
 					var reportOutputName string
 					if isMultiFile {
-						reportOutputName = GenerateReportFileName(specFile, outputDir, reportOutput, timestamp, ".xml")
+						reportOutputName = GenerateReportFileName(specFile, outputDir, reportOutput, timestamp, extension)
 					} else {
-						reportOutputName = fmt.Sprintf("%s-%s%s", reportOutput, timestamp, ".xml")
+						reportOutputName = fmt.Sprintf("%s-%s%s", reportOutput, timestamp, extension)
 					}
 
-					if err = os.WriteFile(reportOutputName, junitXML, 0664); err != nil {
-						tui.RenderErrorString("Unable to write junit report file: '%s': %s", reportOutputName, err.Error())
+					if err = os.WriteFile(reportOutputName, formatted, 0664); err != nil {
+						tui.RenderErrorString("Unable to write %s report file: '%s': %s", formatName, reportOutputName, err.Error())
 						if isMultiFile {
 							continue
 						}
 						return err
 					}
 
-					tui.RenderSuccess("JUnit Report generated for '%s', written to '%s'", specFile, reportOutputName)
+					tui.RenderSuccess("%s Report generated for '%s', written to '%s'", formatName, specFile, reportOutputName)
 					processedFiles++
 					continue
 				}
@@ -452,7 +540,7 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 				var data []byte
 
 				// generate statistics
-				stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+				stats := lintStatistics(ruleset, linted.documentStats, resultSet, scoreModel)
 
 				// Track lowest score for threshold check
 				if stats != nil && stats.OverallScore < lowestScore {
@@ -555,6 +643,9 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 	cmd.Flags().BoolP("stdout", "o", false, "Use stdout as output, instead of a file")
 	cmd.Flags().BoolP("junit", "j", false, "Generate report in JUnit format (cannot be compressed)")
 	cmd.Flags().Bool("junit-fail-on-warn", false, "Treat warnings as failures in JUnit report (default: only errors are failures)")
+	cmd.Flags().Bool("gitlab", false, "Generate report in GitLab Code Quality format (cannot be compressed)")
+	cmd.Flags().Bool("checkstyle", false, "Generate report in Checkstyle XML format (cannot be compressed)")
+	cmd.MarkFlagsMutuallyExclusive("junit", "gitlab", "checkstyle")
 	cmd.Flags().BoolP("compress", "c", false, "Compress results using gzip")
 	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
 	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
@@ -564,5 +655,10 @@ vacuum report --globbed-files "api/**/*.json" -c`,
 	cmd.Flags().Int("min-score", 10, "Throw an error return code if the score is below this value")
 	cmd.Flags().String("globbed-files", "", "Glob pattern of files to process (e.g., 'specs/*.yaml')")
 	cmd.Flags().String("output-dir", "", "Directory to write report files to (default: current directory)")
+	cmd.Flags().Bool("record", false, "Record the score and statistics of each report in the history database (not available with --stdin)")
+	cmd.Flags().String("history-db", "", "Path to the history database (defaults to history.db in the vacuum config directory)")
+	addProfileFlag(cmd)
+	addWorkersFlag(cmd)
+	addCacheDirFlag(cmd)
 	return cmd
 }
@@ -383,3 +383,40 @@ function runRule(input) {
 	assert.NotContains(t, report.ResultSet.Results[0].Path, "$..")
 	assert.NotContains(t, report.ResultSet.Results[0].Path, "[?")
 }
+
+func TestGetVacuumReportCommand_GitLabStdOut(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	cmd.SetArgs([]string{"--gitlab", "-o", "--no-style", "../model/test_files/petstorev3.json"})
+
+	var cmdErr error
+	output, _ := captureOSStreams(t, func() {
+		cmdErr = cmd.Execute()
+	})
+	require.NoError(t, cmdErr)
+
+	var issues []vacuum_report.CodeQualityIssue
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.NotEmpty(t, issues)
+	assert.NotEmpty(t, issues[0].Fingerprint)
+	assert.True(t, strings.HasSuffix(issues[0].Location.Path, "model/test_files/petstorev3.json"))
+}
+
+func TestGetVacuumReportCommand_Checkstyle(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	reportPrefix := filepath.Join(t.TempDir(), "vacuum-report")
+	cmd.SetArgs([]string{"--checkstyle", "--no-style", "../model/test_files/petstorev3.json", reportPrefix})
+	require.NoError(t, cmd.Execute())
+
+	report := requireSingleGeneratedFile(t, reportPrefix+"-*.xml")
+	data, err := os.ReadFile(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<checkstyle version=\"4.3\">")
+}
+
+func TestGetVacuumReportCommand_FormatsAreExclusive(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--junit", "--checkstyle", "../model/test_files/petstorev3.json"})
+	assert.Error(t, cmd.Execute())
+}
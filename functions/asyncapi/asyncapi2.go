@@ -0,0 +1,184 @@
+// Copyright 2020-2026 Dave Shanley / Quobix / Princess Beef Heavy Industries, LLC
+// https://quobix.com/vacuum/ | https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/parser"
+	"go.yaml.in/yaml/v4"
+)
+
+// asyncAPI2Operations are the operation keys of an AsyncAPI 2.x channel item.
+var asyncAPI2Operations = []string{"publish", "subscribe"}
+
+// ChannelOperations validates that AsyncAPI 2.x channels define an operation.
+type ChannelOperations struct{}
+
+// GetSchema returns the AsyncAPI 2.x channel-operations function schema.
+func (c ChannelOperations) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "asyncApi2ChannelOperations"}
+}
+
+// GetCategory returns the AsyncAPI function category.
+func (c ChannelOperations) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule checks every channel item has a `publish` or `subscribe` operation.
+// Channel items that are references are checked where they are defined.
+func (c ChannelOperations) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	for _, node := range nodes {
+		if node == nil || node.Kind != yaml.MappingNode || refValue(node) != "" {
+			continue
+		}
+		_, publish := mappingValue(node, "publish")
+		_, subscribe := mappingValue(node, "subscribe")
+		if publish == nil && subscribe == nil {
+			results = append(results, result(context, node, nodePath(context, node, ""), "Channel must define a `publish` or `subscribe` operation."))
+		}
+	}
+	return results
+}
+
+// OperationIdUnique validates AsyncAPI 2.x operationId uniqueness.
+type OperationIdUnique struct{}
+
+// GetSchema returns the AsyncAPI 2.x operationId uniqueness function schema.
+func (o OperationIdUnique) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "asyncApi2OperationIdUnique"}
+}
+
+// GetCategory returns the AsyncAPI function category.
+func (o OperationIdUnique) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule reports every operationId that is already used by an earlier
+// channel operation in the document.
+func (o OperationIdUnique) RunRule(_ []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	seen := make(map[string]string)
+	var results []model.RuleFunctionResult
+	for _, entry := range mappingEntries(channelMap(rootNode(context))) {
+		channelName := entry[0].Value
+		for _, operation := range asyncAPI2Operations {
+			_, operationNode := mappingValue(entry[1], operation)
+			_, operationID := mappingValue(operationNode, "operationId")
+			if operationID == nil || operationID.Value == "" {
+				continue
+			}
+			location := fmt.Sprintf("`%s` operation of channel `%s`", operation, channelName)
+			if previous, ok := seen[operationID.Value]; ok {
+				results = append(results, result(context, operationID, nodePath(context, operationID, ""),
+					fmt.Sprintf("Operation ID `%s` must be unique, it is already used by the %s.", operationID.Value, previous)))
+				continue
+			}
+			seen[operationID.Value] = location
+		}
+	}
+	return results
+}
+
+// MessageExamplesSchema validates AsyncAPI 2.x message examples against the
+// message payload and headers schemas.
+type MessageExamplesSchema struct{}
+
+// GetSchema returns the AsyncAPI 2.x message-examples function schema.
+func (m MessageExamplesSchema) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "asyncApi2MessageExamples"}
+}
+
+// GetCategory returns the AsyncAPI function category.
+func (m MessageExamplesSchema) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule validates the `payload` and `headers` of each message example. Only
+// messages using a JSON Schema based `schemaFormat` (the default) are checked.
+func (m MessageExamplesSchema) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	root := rootNode(context)
+	var results []model.RuleFunctionResult
+	for _, node := range nodes {
+		_, examples := mappingValue(node, "examples")
+		if examples == nil || examples.Kind != yaml.SequenceNode {
+			continue
+		}
+		_, schemaFormat := mappingValue(node, "schemaFormat")
+		if !isJSONSchemaFormat(scalarValue(schemaFormat)) {
+			continue
+		}
+		_, payload := mappingValue(node, "payload")
+		_, headers := mappingValue(node, "headers")
+		for _, example := range examples.Content {
+			_, examplePayload := mappingValue(example, "payload")
+			_, exampleHeaders := mappingValue(example, "headers")
+			results = append(results, validateExample(context, root, payload, examplePayload, "payload")...)
+			results = append(results, validateExample(context, root, headers, exampleHeaders, "headers")...)
+		}
+	}
+	return results
+}
+
+func validateExample(context model.RuleFunctionContext, root, schemaNode, exampleNode *yaml.Node, field string) []model.RuleFunctionResult {
+	if schemaNode == nil || exampleNode == nil {
+		return nil
+	}
+	schemaNode = resolveLocalRef(root, schemaNode)
+	schema, err := parser.ConvertNodeIntoJSONSchema(schemaNode, context.Index)
+	if err != nil || schema == nil {
+		return nil
+	}
+	valid, validationErrors := parser.ValidateNodeAgainstSchema(&context, schema, exampleNode, false)
+	if valid {
+		return nil
+	}
+	path := nodePath(context, exampleNode, "")
+	var results []model.RuleFunctionResult
+	for _, validationError := range validationErrors {
+		if len(validationError.SchemaValidationErrors) == 0 {
+			results = append(results, result(context, exampleNode, path,
+				fmt.Sprintf("Message example `%s` is not valid against the schema: %s", field, validationError.Message)))
+			continue
+		}
+		for _, failure := range validationError.SchemaValidationErrors {
+			results = append(results, result(context, exampleNode, path,
+				fmt.Sprintf("Message example `%s` is not valid against the schema: %s", field, failure.Reason)))
+		}
+	}
+	return results
+}
+
+// isJSONSchemaFormat reports whether a message schemaFormat is JSON Schema
+// based; an empty schemaFormat defaults to the AsyncAPI schema format.
+func isJSONSchemaFormat(schemaFormat string) bool {
+	return schemaFormat == "" ||
+		strings.HasPrefix(schemaFormat, "application/vnd.aai.asyncapi") ||
+		strings.HasPrefix(schemaFormat, "application/schema+json") ||
+		strings.HasPrefix(schemaFormat, "application/schema+yaml")
+}
+
+// resolveLocalRef follows local `#/` references from the document root,
+// returning the node unchanged when it is not a local reference.
+func resolveLocalRef(root, node *yaml.Node) *yaml.Node {
+	for depth := 0; depth < 10; depth++ {
+		ref := refValue(node)
+		if !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		target := root
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+			_, target = mappingValue(target, segment)
+			if target == nil {
+				return node
+			}
+		}
+		node = target
+	}
+	return node
+}
@@ -0,0 +1,119 @@
+package asyncapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestChannelOperationsReportsChannelsWithoutOperations(t *testing.T) {
+	context, root := testRuleContext(t, `asyncapi: 2.6.0
+channels:
+  user/signedup:
+    publish:
+      operationId: onUserSignedUp
+  user/deleted:
+    description: nothing happens here
+  user/referenced:
+    $ref: '#/components/channels/user'
+`)
+	_, channels := mappingValue(root, "channels")
+	var channelNodes []*yaml.Node
+	for _, entry := range mappingEntries(channels) {
+		channelNodes = append(channelNodes, entry[1])
+	}
+
+	results := ChannelOperations{}.RunRule(channelNodes, context)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "Channel must define a `publish` or `subscribe` operation.", results[0].Message)
+	assert.Equal(t, "$.channels['user/deleted']", results[0].Path)
+}
+
+func TestOperationIdUniqueReportsDuplicates(t *testing.T) {
+	context, _ := testRuleContext(t, `asyncapi: 2.6.0
+channels:
+  user/signedup:
+    publish:
+      operationId: onUser
+    subscribe:
+      operationId: sendUser
+  user/deleted:
+    subscribe:
+      operationId: onUser
+`)
+
+	results := OperationIdUnique{}.RunRule(nil, context)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "Operation ID `onUser` must be unique, it is already used by the `publish` operation of channel `user/signedup`.", results[0].Message)
+	assert.Equal(t, "$.channels['user/deleted'].subscribe.operationId", results[0].Path)
+}
+
+func TestMessageExamplesSchemaValidatesPayloadAndHeaders(t *testing.T) {
+	context, root := testRuleContext(t, `asyncapi: 2.6.0
+components:
+  messages:
+    UserSignedUp:
+      headers:
+        type: object
+        properties:
+          correlationId:
+            type: string
+      payload:
+        $ref: '#/components/schemas/User'
+      examples:
+        - headers:
+            correlationId: 1
+          payload:
+            email: someone@example.org
+        - payload:
+            name: nobody
+    AvroMessage:
+      schemaFormat: application/vnd.apache.avro;version=1.9.0
+      payload:
+        type: record
+      examples:
+        - payload:
+            anything: goes
+  schemas:
+    User:
+      type: object
+      required: [email]
+      properties:
+        email:
+          type: string
+`)
+	_, messages := mappingValue(componentMap(root, "messages"), "UserSignedUp")
+	_, avro := mappingValue(componentMap(root, "messages"), "AvroMessage")
+
+	results := MessageExamplesSchema{}.RunRule([]*yaml.Node{messages, avro}, context)
+
+	require.Len(t, results, 2)
+	assert.Contains(t, results[0].Message, "Message example `headers` is not valid against the schema")
+	assert.Equal(t, "$.components.messages.UserSignedUp.examples[0].headers", results[0].Path)
+	assert.Contains(t, results[1].Message, "Message example `payload` is not valid against the schema")
+	assert.Contains(t, results[1].Message, "email")
+	assert.Equal(t, "$.components.messages.UserSignedUp.examples[1].payload", results[1].Path)
+}
+
+func TestServerVariablesChecksAsyncAPI2URL(t *testing.T) {
+	context, root := testRuleContext(t, `asyncapi: 2.6.0
+servers:
+  production:
+    url: broker.example.org:{port}/{stage}
+    protocol: kafka
+    variables:
+      port:
+        default: "9092"
+`)
+	_, servers := mappingValue(root, "servers")
+	_, server := mappingValue(servers, "production")
+
+	results := ServerVariables{}.RunRule([]*yaml.Node{server}, context)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "Server variable `stage` is used but not defined.", results[0].Message)
+}
@@ -93,13 +93,15 @@ func (s ServerVariables) GetCategory() string {
 	return model.FunctionCategoryAsyncAPI
 }
 
-// RunRule checks that variables used by server host/pathname templates are
-// declared in the server variables map and that declared variables are used.
+// RunRule checks that variables used by server host/pathname templates (or the
+// AsyncAPI 2.x server url) are declared in the server variables map and that
+// declared variables are used.
 func (s ServerVariables) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
 	var results []model.RuleFunctionResult
 	for _, node := range nodes {
 		_, host := mappingValue(node, "host")
 		_, pathname := mappingValue(node, "pathname")
+		_, url := mappingValue(node, "url")
 		_, variables := mappingValue(node, "variables")
 		template := ""
 		if host != nil {
@@ -108,6 +110,9 @@ func (s ServerVariables) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 		if pathname != nil {
 			template += pathname.Value
 		}
+		if url != nil && host == nil {
+			template += url.Value
+		}
 		if template == "" {
 			continue
 		}
@@ -0,0 +1,81 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package autofix
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// SortAlphabeticallyFix re-orders an array into alphabetical order. Arrays of objects are sorted by the property
+// named by the rule's 'keyedBy' option (the same option used by the 'alphabetical' function), and mappings
+// are sorted by key.
+func SortAlphabeticallyFix(node *yaml.Node, _ *yaml.Node, context *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil {
+		return nil, errors.New("no node to sort")
+	}
+
+	keyedBy := ""
+	if context != nil {
+		keyedBy = context.GetOptionsStringMap()["keyedBy"]
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		if isNumberArray(node) {
+			sort.SliceStable(node.Content, func(i, j int) bool {
+				a, _ := strconv.ParseFloat(node.Content[i].Value, 64)
+				b, _ := strconv.ParseFloat(node.Content[j].Value, 64)
+				return a < b
+			})
+			break
+		}
+		sort.SliceStable(node.Content, func(i, j int) bool {
+			return sortValue(node.Content[i], keyedBy) < sortValue(node.Content[j], keyedBy)
+		})
+	case yaml.MappingNode:
+		pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i][0].Value < pairs[j][0].Value
+		})
+		content := make([]*yaml.Node, 0, len(node.Content))
+		for _, pair := range pairs {
+			content = append(content, pair[0], pair[1])
+		}
+		node.Content = content
+	default:
+		return nil, errors.New("only arrays and objects can be sorted")
+	}
+	return node, nil
+}
+
+func isNumberArray(node *yaml.Node) bool {
+	if len(node.Content) == 0 {
+		return false
+	}
+	for _, n := range node.Content {
+		if n.Tag != "!!int" && n.Tag != "!!float" {
+			return false
+		}
+	}
+	return true
+}
+
+func sortValue(node *yaml.Node, keyedBy string) string {
+	if node.Kind == yaml.MappingNode && keyedBy != "" {
+		if value := lookupKey(node, keyedBy); value != nil {
+			return value.Value
+		}
+		return ""
+	}
+	return node.Value
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+// Package autofix contains the built-in auto-fix functions used by vacuum's core rules when linting with --fix.
+// Each function receives the node reported by a rule violation, along with the canonical (unresolved) document
+// root, and mutates the document in place.
+package autofix
+
+import (
+	"errors"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	StripPathTrailingSlash    = "stripPathTrailingSlash"
+	StripServerTrailingSlash  = "stripServerTrailingSlash"
+	SortAlphabetically        = "sortAlphabetically"
+	GenerateOperationId       = "generateOperationId"
+	AddLicenseSkeleton        = "addLicenseSkeleton"
+	RemoveDuplicateEnumValues = "removeDuplicateEnumValues"
+)
+
+var errNodeNotFound = errors.New("unable to locate node in document")
+
+// GetBuiltInAutoFixFunctions returns a new map of all the built-in auto-fix functions, keyed by name. A new map is
+// returned for every call, so callers are free to add their own functions to it.
+func GetBuiltInAutoFixFunctions() map[string]model.AutoFixFunction {
+	return map[string]model.AutoFixFunction{
+		StripPathTrailingSlash:    StripPathTrailingSlashFix,
+		StripServerTrailingSlash:  StripServerTrailingSlashFix,
+		SortAlphabetically:        SortAlphabeticallyFix,
+		GenerateOperationId:       GenerateOperationIdFix,
+		AddLicenseSkeleton:        AddLicenseSkeletonFix,
+		RemoveDuplicateEnumValues: RemoveDuplicateEnumValuesFix,
+	}
+}
+
+// findParentChain returns every container node between the root and the target node. The last entry is the direct
+// parent of the target. nil is returned when the target cannot be found. The parent index shared by the context is
+// used when it indexes the same document, otherwise the document is indexed for this call alone.
+func findParentChain(root, target *yaml.Node, context *model.RuleFunctionContext) []*yaml.Node {
+	if root == nil || target == nil {
+		return nil
+	}
+	if context != nil && context.NodeParents != nil && context.NodeParents.Root() == root {
+		return context.NodeParents.ParentChain(target)
+	}
+	return model.NewNodeParentIndex(root).ParentChain(target)
+}
+
+// findMapValue returns the value node for a key node inside a mapping, or nil if it's not a key of the mapping.
+func findMapValue(mapNode, keyNode *yaml.Node) *yaml.Node {
+	if mapNode == nil || mapNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapNode.Content); i += 2 {
+		if mapNode.Content[i] == keyNode {
+			return mapNode.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// findMapKeyForValue returns the key node that owns the supplied value node inside a mapping.
+func findMapKeyForValue(mapNode, valueNode *yaml.Node) *yaml.Node {
+	if mapNode == nil || mapNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapNode.Content); i += 2 {
+		if mapNode.Content[i+1] == valueNode {
+			return mapNode.Content[i]
+		}
+	}
+	return nil
+}
+
+// lookupKey returns the value of a named key inside a mapping node.
+func lookupKey(mapNode *yaml.Node, key string) *yaml.Node {
+	if mapNode == nil || mapNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapNode.Content); i += 2 {
+		if mapNode.Content[i].Value == key {
+			return mapNode.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// resolveMapValue resolves a node reported by a rule into the mapping it refers to. Rules often report the
+// key node of an object, so the key is looked up in its parent to find the actual value.
+func resolveMapValue(node, document *yaml.Node, context *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil {
+		return nil, errNodeNotFound
+	}
+	if node.Kind == yaml.MappingNode {
+		return node, nil
+	}
+	chain := findParentChain(document, node, context)
+	if len(chain) == 0 {
+		return nil, errNodeNotFound
+	}
+	value := findMapValue(chain[len(chain)-1], node)
+	if value == nil || value.Kind != yaml.MappingNode {
+		return nil, errors.New("node does not reference an object")
+	}
+	return value, nil
+}
+
+func newStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,175 @@
+package autofix
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func parseDocument(t *testing.T, spec string) *yaml.Node {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &doc))
+	return &doc
+}
+
+func findKey(t *testing.T, doc *yaml.Node, path, key string) *yaml.Node {
+	nodes, err := utils.FindNodesWithoutDeserializing(doc, path)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	for i := 0; i+1 < len(nodes[0].Content); i += 2 {
+		if nodes[0].Content[i].Value == key {
+			return nodes[0].Content[i]
+		}
+	}
+	t.Fatalf("key %s not found at %s", key, path)
+	return nil
+}
+
+func render(t *testing.T, doc *yaml.Node) string {
+	out, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestGetBuiltInAutoFixFunctions(t *testing.T) {
+	fixes := GetBuiltInAutoFixFunctions()
+	assert.Len(t, fixes, 6)
+
+	// every call returns a new map
+	fixes["custom"] = nil
+	assert.Len(t, GetBuiltInAutoFixFunctions(), 6)
+}
+
+func TestStripPathTrailingSlashFix(t *testing.T) {
+	doc := parseDocument(t, `paths:
+  /pizza/:
+    get: {}
+  /burgers:
+    get: {}`)
+
+	key := findKey(t, doc, "$.paths", "/pizza/")
+	_, err := StripPathTrailingSlashFix(key, doc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/pizza", key.Value)
+}
+
+func TestStripPathTrailingSlashFix_Collision(t *testing.T) {
+	doc := parseDocument(t, `paths:
+  /pizza/:
+    get: {}
+  /pizza:
+    get: {}`)
+
+	key := findKey(t, doc, "$.paths", "/pizza/")
+	_, err := StripPathTrailingSlashFix(key, doc, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "/pizza/", key.Value)
+}
+
+func TestStripServerTrailingSlashFix(t *testing.T) {
+	node := newStringNode("https://pb33f.io//")
+	_, err := StripServerTrailingSlashFix(node, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://pb33f.io", node.Value)
+
+	_, err = StripServerTrailingSlashFix(newStringNode("/"), nil, nil)
+	assert.Error(t, err)
+}
+
+func TestSortAlphabeticallyFix_KeyedBy(t *testing.T) {
+	doc := parseDocument(t, `tags:
+  - name: pizza
+  - name: burgers
+  - name: chips`)
+
+	nodes, _ := utils.FindNodesWithoutDeserializing(doc, "$.tags")
+	ctx := &model.RuleFunctionContext{Options: map[string]string{"keyedBy": "name"}}
+
+	_, err := SortAlphabeticallyFix(nodes[0], doc, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "tags:\n    - name: burgers\n    - name: chips\n    - name: pizza\n", render(t, doc))
+}
+
+func TestSortAlphabeticallyFix_Numbers(t *testing.T) {
+	doc := parseDocument(t, `nums: [10, 9, 1.5]`)
+	nodes, _ := utils.FindNodesWithoutDeserializing(doc, "$.nums")
+
+	_, err := SortAlphabeticallyFix(nodes[0], doc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", nodes[0].Content[0].Value)
+	assert.Equal(t, "10", nodes[0].Content[2].Value)
+}
+
+func TestGenerateOperationIdFix(t *testing.T) {
+	doc := parseDocument(t, `paths:
+  /users/{userId}/orders:
+    get:
+      responses: {}
+    post:
+      operationId: getUsersByUserIdOrders
+  /:
+    get:
+      summary: root`)
+
+	key := findKey(t, doc, "$.paths['/users/{userId}/orders']", "get")
+	_, err := GenerateOperationIdFix(key, doc, nil)
+	require.NoError(t, err)
+
+	op, _ := utils.FindNodesWithoutDeserializing(doc, "$.paths['/users/{userId}/orders'].get.operationId")
+	require.Len(t, op, 1)
+	assert.Equal(t, "getUsersByUserIdOrders2", op[0].Value)
+
+	key = findKey(t, doc, "$.paths['/']", "get")
+	_, err = GenerateOperationIdFix(key, doc, nil)
+	require.NoError(t, err)
+	op, _ = utils.FindNodesWithoutDeserializing(doc, "$.paths['/'].get.operationId")
+	require.Len(t, op, 1)
+	assert.Equal(t, "getRoot", op[0].Value)
+}
+
+func TestBuildOperationId(t *testing.T) {
+	assert.Equal(t, "deletePetStoreItemsByItemId", buildOperationId("DELETE", "/pet-store/items/{item_id}"))
+}
+
+func TestAddLicenseSkeletonFix(t *testing.T) {
+	doc := parseDocument(t, `info:
+  title: pizza`)
+
+	key := findKey(t, doc, "$", "info")
+	_, err := AddLicenseSkeletonFix(key, doc, nil)
+	require.NoError(t, err)
+
+	name, _ := utils.FindNodesWithoutDeserializing(doc, "$.info.license.name")
+	require.Len(t, name, 1)
+	assert.Equal(t, LicenseNamePlaceholder, name[0].Value)
+}
+
+func TestAddLicenseSkeletonFix_MissingName(t *testing.T) {
+	doc := parseDocument(t, `info:
+  license:
+    url: https://opensource.org/licenses/MIT`)
+
+	key := findKey(t, doc, "$.info", "license")
+	_, err := AddLicenseSkeletonFix(key, doc, nil)
+	require.NoError(t, err)
+
+	name, _ := utils.FindNodesWithoutDeserializing(doc, "$.info.license.name")
+	require.Len(t, name, 1)
+	assert.Equal(t, LicenseNamePlaceholder, name[0].Value)
+}
+
+func TestRemoveDuplicateEnumValuesFix(t *testing.T) {
+	doc := parseDocument(t, `schema:
+  enum: [a, b, a, "1", 1, b]`)
+
+	key := findKey(t, doc, "$.schema", "enum")
+	_, err := RemoveDuplicateEnumValuesFix(key, doc, nil)
+	require.NoError(t, err)
+
+	enum, _ := utils.FindNodesWithoutDeserializing(doc, "$.schema.enum")
+	require.Len(t, enum[0].Content, 4)
+}
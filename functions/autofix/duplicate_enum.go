@@ -0,0 +1,47 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package autofix
+
+import (
+	"errors"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// RemoveDuplicateEnumValuesFix removes repeated values from an enum, keeping the first occurrence of each value.
+func RemoveDuplicateEnumValuesFix(node *yaml.Node, document *yaml.Node, context *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil {
+		return nil, errNodeNotFound
+	}
+	enum := node
+	if node.Kind == yaml.ScalarNode {
+		chain := findParentChain(document, node, context)
+		if len(chain) == 0 {
+			return nil, errNodeNotFound
+		}
+		enum = findMapValue(chain[len(chain)-1], node)
+	}
+	if enum == nil || enum.Kind != yaml.SequenceNode {
+		return nil, errors.New("enum is not an array")
+	}
+
+	seen := make(map[string]bool, len(enum.Content))
+	content := make([]*yaml.Node, 0, len(enum.Content))
+	for _, n := range enum.Content {
+		if n.Kind != yaml.ScalarNode {
+			content = append(content, n)
+			continue
+		}
+		key := n.ShortTag() + ":" + n.Value
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		content = append(content, n)
+	}
+	enum.Content = content
+	return enum, nil
+}
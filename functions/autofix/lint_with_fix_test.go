@@ -0,0 +1,58 @@
+package autofix_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/functions/autofix"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestBuiltInFixes_LintWithFix(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+tags:
+  - name: pizza
+  - name: burgers
+servers:
+  - url: https://api.pb33f.io/
+paths:
+  /pizza/:
+    get:
+      tags:
+        - pizza
+      responses:
+        "200":
+          description: ok`
+
+	rules := map[string]*model.Rule{}
+	for _, id := range []string{rulesets.PathKeysNoTrailingSlash, rulesets.OpenAPITagsAlphabetical,
+		rulesets.Oas3HostTrailingSlash, rulesets.OperationOperationId, rulesets.InfoLicense} {
+		rules[id] = rulesets.GetAllBuiltInRules()[id]
+	}
+
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:          &rulesets.RuleSet{Rules: rules},
+		Spec:             []byte(spec),
+		SpecFileName:     "test.yaml",
+		ApplyAutoFixes:   true,
+		AutoFixFunctions: autofix.GetBuiltInAutoFixFunctions(),
+	})
+
+	assert.Empty(t, result.Results)
+	assert.Len(t, result.FixedResults, 5)
+	require.NotNil(t, result.ModifiedSpec)
+
+	fixed := string(result.ModifiedSpec)
+	assert.Contains(t, fixed, "url: https://api.pb33f.io\n")
+	assert.Contains(t, fixed, "/pizza:")
+	assert.Contains(t, fixed, "operationId: getPizza")
+	assert.Contains(t, fixed, autofix.LicenseNamePlaceholder)
+	assert.Less(t, strings.Index(fixed, "name: burgers"), strings.Index(fixed, "name: pizza"))
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package autofix
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// GenerateOperationIdFix adds an operationId to an operation that is missing one. The ID is generated from the
+// HTTP method and path, for example 'GET /users/{userId}/orders' becomes 'getUsersByUserIdOrders'. If the
+// generated ID is already in use, a number is appended to keep it unique.
+func GenerateOperationIdFix(node *yaml.Node, document *yaml.Node, context *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return nil, errors.New("operation key must be a scalar")
+	}
+	chain := findParentChain(document, node, context)
+	if len(chain) < 2 {
+		return nil, errNodeNotFound
+	}
+	pathItem := chain[len(chain)-1]
+	operation := findMapValue(pathItem, node)
+	if operation == nil || operation.Kind != yaml.MappingNode {
+		return nil, errors.New("operation is not an object")
+	}
+	if existing := lookupKey(operation, "operationId"); existing != nil && existing.Value != "" {
+		return operation, nil
+	}
+	pathKey := findMapKeyForValue(chain[len(chain)-2], pathItem)
+	if pathKey == nil {
+		return nil, errNodeNotFound
+	}
+
+	base := buildOperationId(node.Value, pathKey.Value)
+	used := collectOperationIds(document)
+	opId := base
+	for i := 2; used[opId]; i++ {
+		opId = base + strconv.Itoa(i)
+	}
+
+	// an empty operationId is replaced, otherwise a new key is placed at the top of the operation.
+	for i := 0; i+1 < len(operation.Content); i += 2 {
+		if operation.Content[i].Value == "operationId" {
+			operation.Content[i+1] = newStringNode(opId)
+			return operation, nil
+		}
+	}
+	operation.Content = append([]*yaml.Node{newStringNode("operationId"), newStringNode(opId)},
+		operation.Content...)
+	return operation, nil
+}
+
+// buildOperationId converts a method and path into a camelCase operationId.
+func buildOperationId(method, path string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	segments := strings.Split(path, "/")
+	written := false
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			sb.WriteString("By")
+			segment = strings.Trim(segment, "{}")
+		}
+		sb.WriteString(camelSegment(segment))
+		written = true
+	}
+	if !written {
+		sb.WriteString("Root")
+	}
+	return sb.String()
+}
+
+// camelSegment strips any characters that are not letters or digits, and upper-cases the start of every word.
+func camelSegment(segment string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range segment {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func collectOperationIds(document *yaml.Node) map[string]bool {
+	ids := make(map[string]bool)
+	visited := make(map[*yaml.Node]bool)
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n == nil || visited[n] {
+			return
+		}
+		visited[n] = true
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == "operationId" && n.Content[i+1].Kind == yaml.ScalarNode {
+					ids[n.Content[i+1].Value] = true
+				}
+			}
+		}
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(document)
+	return ids
+}
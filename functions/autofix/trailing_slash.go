@@ -0,0 +1,55 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package autofix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// StripPathTrailingSlashFix removes trailing slashes from a path key. The fix is refused if the trimmed path
+// would collide with a path that is already defined.
+func StripPathTrailingSlashFix(node *yaml.Node, document *yaml.Node, context *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return nil, errors.New("path key must be a scalar")
+	}
+	trimmed := strings.TrimRight(node.Value, "/")
+	if trimmed == node.Value {
+		return node, nil
+	}
+	if trimmed == "" {
+		trimmed = "/"
+	}
+
+	chain := findParentChain(document, node, context)
+	if len(chain) == 0 {
+		return nil, errNodeNotFound
+	}
+	paths := chain[len(chain)-1]
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		if paths.Content[i] != node && paths.Content[i].Value == trimmed {
+			return nil, fmt.Errorf("path '%s' already exists, cannot rename '%s'", trimmed, node.Value)
+		}
+	}
+	node.Value = trimmed
+	return node, nil
+}
+
+// StripServerTrailingSlashFix removes trailing slashes from a server URL, or a swagger host value.
+func StripServerTrailingSlashFix(node *yaml.Node, _ *yaml.Node, _ *model.RuleFunctionContext) (*yaml.Node, error) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return nil, errors.New("server URL must be a scalar")
+	}
+	trimmed := strings.TrimRight(node.Value, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("server URL '%s' cannot be trimmed", node.Value)
+	}
+	node.Value = trimmed
+	return node, nil
+}
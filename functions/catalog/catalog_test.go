@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	drModel "github.com/pb33f/doctor/model"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/testify/require"
+)
+
+func buildCatalogTestDocument(t *testing.T, fileName, yml string) *model.CatalogDocument {
+	document, err := libopenapi.NewDocument([]byte(yml))
+	require.NoError(t, err)
+	m, err := document.BuildV3Model()
+	require.NoError(t, err)
+	return &model.CatalogDocument{
+		FileName:   fileName,
+		Document:   document,
+		Index:      m.Index,
+		DrDocument: drModel.NewDrDocument(m),
+	}
+}
+
+func buildCatalogTestContext(function string) model.RuleFunctionContext {
+	rule := &model.Rule{
+		Given: "$",
+		Then:  &model.RuleAction{Function: function},
+	}
+	return model.RuleFunctionContext{
+		RuleAction: model.CastToRuleAction(rule.Then),
+		Rule:       rule,
+	}
+}
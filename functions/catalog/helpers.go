@@ -0,0 +1,84 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// otherFiles lists the files of found, except the file of document, in the order they were linted.
+func otherFiles(document *model.CatalogDocument, found []*model.CatalogDocument) string {
+	var files []string
+	for _, doc := range found {
+		if doc == document || doc.FileName == document.FileName || slices.Contains(files, doc.FileName) {
+			continue
+		}
+		files = append(files, doc.FileName)
+	}
+	quoted := make([]string, len(files))
+	for i, file := range files {
+		quoted[i] = fmt.Sprintf("`%s`", file)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// countDocuments returns how many different documents are in found.
+func countDocuments(found []*model.CatalogDocument) int {
+	var seen []*model.CatalogDocument
+	for _, doc := range found {
+		if !slices.Contains(seen, doc) {
+			seen = append(seen, doc)
+		}
+	}
+	return len(seen)
+}
+
+// fingerprint writes node to b with mapping keys sorted, so two copies of the same thing that only differ in key
+// order, quoting or comments have the same fingerprint.
+func fingerprint(b *strings.Builder, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			fingerprint(b, n)
+		}
+	case yaml.AliasNode:
+		fingerprint(b, node.Alias)
+	case yaml.MappingNode:
+		keys := make([]int, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keys = append(keys, i)
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			return node.Content[keys[i]].Value < node.Content[keys[j]].Value
+		})
+		b.WriteByte('{')
+		for _, k := range keys {
+			b.WriteString(strconv.Quote(node.Content[k].Value))
+			b.WriteByte(':')
+			fingerprint(b, node.Content[k+1])
+			b.WriteByte(',')
+		}
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+		for _, n := range node.Content {
+			fingerprint(b, n)
+			b.WriteByte(',')
+		}
+		b.WriteByte(']')
+	default:
+		b.WriteString(strconv.Quote(node.ShortTag() + ":" + node.Value))
+	}
+}
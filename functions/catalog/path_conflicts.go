@@ -0,0 +1,101 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	v3 "github.com/pb33f/doctor/model/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+var pathParameter = regexp.MustCompile(`\{[^}/]+}`)
+
+// PathConflicts checks that an operation (a method and a path) is only defined by one document of a catalog. Paths
+// that only differ by the names of their parameters, like /pets/{id} and /pets/{petId}, are the same path.
+type PathConflicts struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the PathConflicts rule.
+func (pc PathConflicts) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "catalogPathConflicts",
+	}
+}
+
+// GetCategory returns the category of the PathConflicts rule.
+func (pc PathConflicts) GetCategory() string {
+	return model.FunctionCategoryCatalog
+}
+
+// RunRule does nothing, the rule checks every document at once, see RunCatalogRule.
+func (pc PathConflicts) RunRule(_ []*yaml.Node, _ model.RuleFunctionContext) []model.RuleFunctionResult {
+	return nil
+}
+
+type pathUse struct {
+	document  *model.CatalogDocument
+	path      string
+	method    string
+	operation *v3.Operation
+}
+
+// RunCatalogRule will execute the PathConflicts rule against every document of a catalog.
+func (pc PathConflicts) RunCatalogRule(documents []*model.CatalogDocument, context model.RuleFunctionContext) []model.CatalogRuleFunctionResult {
+	var results []model.CatalogRuleFunctionResult
+
+	uses := make(map[string][]pathUse)
+	var operations []string
+	for _, doc := range documents {
+		if doc.DrDocument == nil || doc.DrDocument.V3Document == nil || doc.DrDocument.V3Document.Paths == nil {
+			continue
+		}
+		for pathPairs := doc.DrDocument.V3Document.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+			path := pathPairs.Key()
+			for opPairs := pathPairs.Value().GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+				key := strings.ToUpper(opPairs.Key()) + " " + pathParameter.ReplaceAllString(path, "{}")
+				if _, ok := uses[key]; !ok {
+					operations = append(operations, key)
+				}
+				uses[key] = append(uses[key], pathUse{
+					document:  doc,
+					path:      path,
+					method:    opPairs.Key(),
+					operation: opPairs.Value(),
+				})
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		found := make([]*model.CatalogDocument, len(uses[operation]))
+		for i, use := range uses[operation] {
+			found[i] = use.document
+		}
+		// ambiguous paths in the same document are the job of no-ambiguous-paths.
+		if countDocuments(found) < 2 {
+			continue
+		}
+		for _, use := range uses[operation] {
+			node := use.operation.Value.GoLow().KeyNode
+			res := model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("`%s %s` is also defined by %s", strings.ToUpper(use.method), use.path,
+						otherFiles(use.document, found))),
+				StartNode: node,
+				EndNode:   vacuumUtils.BuildEndNode(node),
+				Path:      fmt.Sprintf("$.paths['%s'].%s", use.path, use.method),
+				Rule:      context.Rule,
+			}
+			use.operation.AddRuleFunctionResult(v3.ConvertRuleResult(&res))
+			results = append(results, model.CatalogRuleFunctionResult{Document: use.document, Result: res})
+		}
+	}
+	return results
+}
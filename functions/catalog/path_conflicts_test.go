@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+)
+
+func TestPathConflicts_GetSchema(t *testing.T) {
+	def := PathConflicts{}
+	assert.Equal(t, "catalogPathConflicts", def.GetSchema().Name)
+	assert.Equal(t, model.FunctionCategoryCatalog, def.GetCategory())
+}
+
+func TestPathConflicts_RunRule(t *testing.T) {
+	def := PathConflicts{}
+	assert.Empty(t, def.RunRule(nil, model.RuleFunctionContext{}))
+}
+
+func TestPathConflicts_RunCatalogRule(t *testing.T) {
+	pets := buildCatalogTestDocument(t, "pets.yaml", `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+    delete:
+      operationId: deletePet
+  /health:
+    get:
+      operationId: petsHealth`)
+	stores := buildCatalogTestDocument(t, "stores.yaml", `openapi: 3.1.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getStorePet
+  /health:
+    post:
+      operationId: storesHealth`)
+
+	def := PathConflicts{}
+	res := def.RunCatalogRule([]*model.CatalogDocument{pets, stores}, buildCatalogTestContext("catalogPathConflicts"))
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, pets, res[0].Document)
+	assert.Equal(t, "`GET /pets/{id}` is also defined by `stores.yaml`", res[0].Result.Message)
+	assert.Equal(t, "$.paths['/pets/{id}'].get", res[0].Result.Path)
+	assert.Equal(t, stores, res[1].Document)
+	assert.Equal(t, "`GET /pets/{petId}` is also defined by `pets.yaml`", res[1].Result.Message)
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	v3 "github.com/pb33f/doctor/model/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"go.yaml.in/yaml/v4"
+)
+
+// SchemaConsistency checks that a component schema shared by the documents of a catalog, like an `Error` model, is
+// the same in every document that defines it. Copies that only differ in key order, quoting or comments are the same.
+type SchemaConsistency struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaConsistency rule.
+func (sc SchemaConsistency) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "catalogSchemaConsistency",
+	}
+}
+
+// GetCategory returns the category of the SchemaConsistency rule.
+func (sc SchemaConsistency) GetCategory() string {
+	return model.FunctionCategoryCatalog
+}
+
+// RunRule does nothing, the rule checks every document at once, see RunCatalogRule.
+func (sc SchemaConsistency) RunRule(_ []*yaml.Node, _ model.RuleFunctionContext) []model.RuleFunctionResult {
+	return nil
+}
+
+type schemaCopy struct {
+	document    *model.CatalogDocument
+	schema      *v3.SchemaProxy
+	keyNode     *yaml.Node
+	fingerprint string
+}
+
+// RunCatalogRule will execute the SchemaConsistency rule against every document of a catalog.
+func (sc SchemaConsistency) RunCatalogRule(documents []*model.CatalogDocument, context model.RuleFunctionContext) []model.CatalogRuleFunctionResult {
+	var results []model.CatalogRuleFunctionResult
+
+	copies := make(map[string][]schemaCopy)
+	var names []string
+	for _, doc := range documents {
+		if doc.DrDocument == nil || doc.DrDocument.V3Document == nil {
+			continue
+		}
+		components := doc.DrDocument.V3Document.Components
+		if components == nil || components.Schemas == nil {
+			continue
+		}
+		for name, schema := range components.Schemas.FromOldest() {
+			k, v := low.FindItemInOrderedMapWithKey(name, components.Value.GoLow().Schemas.Value)
+			if k == nil || v == nil {
+				continue
+			}
+			var b strings.Builder
+			fingerprint(&b, v.ValueNode)
+			if _, ok := copies[name]; !ok {
+				names = append(names, name)
+			}
+			copies[name] = append(copies[name], schemaCopy{
+				document:    doc,
+				schema:      schema,
+				keyNode:     k.KeyNode,
+				fingerprint: b.String(),
+			})
+		}
+	}
+
+	for _, name := range names {
+		shared := copies[name]
+		for _, c := range shared {
+			// the copies that are different to this one, a schema that is only different to itself is not shared.
+			var different []*model.CatalogDocument
+			for _, other := range shared {
+				if other.document != c.document && other.fingerprint != c.fingerprint {
+					different = append(different, other.document)
+				}
+			}
+			if len(different) == 0 {
+				continue
+			}
+			res := model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("the schema `%s` is different to the copy in %s", name, otherFiles(c.document, different))),
+				StartNode: c.keyNode,
+				EndNode:   vacuumUtils.BuildEndNode(c.keyNode),
+				Path:      fmt.Sprintf("$.components.schemas['%s']", name),
+				Rule:      context.Rule,
+			}
+			c.schema.AddRuleFunctionResult(v3.ConvertRuleResult(&res))
+			results = append(results, model.CatalogRuleFunctionResult{Document: c.document, Result: res})
+		}
+	}
+	return results
+}
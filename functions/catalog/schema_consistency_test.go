@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+)
+
+func TestSchemaConsistency_GetSchema(t *testing.T) {
+	def := SchemaConsistency{}
+	assert.Equal(t, "catalogSchemaConsistency", def.GetSchema().Name)
+	assert.Equal(t, model.FunctionCategoryCatalog, def.GetCategory())
+}
+
+func TestSchemaConsistency_RunRule(t *testing.T) {
+	def := SchemaConsistency{}
+	assert.Empty(t, def.RunRule(nil, model.RuleFunctionContext{}))
+}
+
+func TestSchemaConsistency_RunCatalogRule(t *testing.T) {
+	pets := buildCatalogTestDocument(t, "pets.yaml", `openapi: 3.1.0
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+    Pet:
+      type: object`)
+	// the same Error, written differently.
+	stores := buildCatalogTestDocument(t, "stores.yaml", `openapi: 3.1.0
+components:
+  schemas:
+    Error:
+      # the error returned by every API
+      properties: {message: {type: "string"}}
+      type: object`)
+	toys := buildCatalogTestDocument(t, "toys.yaml", `openapi: 3.1.0
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+        code:
+          type: integer`)
+
+	def := SchemaConsistency{}
+	res := def.RunCatalogRule([]*model.CatalogDocument{pets, stores, toys}, buildCatalogTestContext("catalogSchemaConsistency"))
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, pets, res[0].Document)
+	assert.Equal(t, "the schema `Error` is different to the copy in `toys.yaml`", res[0].Result.Message)
+	assert.Equal(t, "$.components.schemas['Error']", res[0].Result.Path)
+	assert.Equal(t, 4, res[0].Result.StartNode.Line)
+	assert.Equal(t, stores, res[1].Document)
+	assert.Equal(t, "the schema `Error` is different to the copy in `toys.yaml`", res[1].Result.Message)
+	assert.Equal(t, toys, res[2].Document)
+	assert.Equal(t, "the schema `Error` is different to the copy in `pets.yaml`, `stores.yaml`", res[2].Result.Message)
+}
+
+func TestSchemaConsistency_RunCatalogRule_Same(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Error:
+      type: object`
+	pets := buildCatalogTestDocument(t, "pets.yaml", spec)
+	stores := buildCatalogTestDocument(t, "stores.yaml", spec)
+
+	def := SchemaConsistency{}
+	res := def.RunCatalogRule([]*model.CatalogDocument{pets, stores}, buildCatalogTestContext("catalogSchemaConsistency"))
+	assert.Empty(t, res)
+}
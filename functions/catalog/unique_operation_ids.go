@@ -0,0 +1,98 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	v3 "github.com/pb33f/doctor/model/high/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// UniqueOperationIds checks that an operationId is only used by one document of a catalog, SDKs, gateways and
+// developer portals built from the catalog cannot tell the operations apart otherwise.
+type UniqueOperationIds struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the UniqueOperationIds rule.
+func (uo UniqueOperationIds) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "catalogUniqueOperationIds",
+	}
+}
+
+// GetCategory returns the category of the UniqueOperationIds rule.
+func (uo UniqueOperationIds) GetCategory() string {
+	return model.FunctionCategoryCatalog
+}
+
+// RunRule does nothing, the rule checks every document at once, see RunCatalogRule.
+func (uo UniqueOperationIds) RunRule(_ []*yaml.Node, _ model.RuleFunctionContext) []model.RuleFunctionResult {
+	return nil
+}
+
+type operationIdUse struct {
+	document  *model.CatalogDocument
+	path      string
+	method    string
+	operation *v3.Operation
+}
+
+// RunCatalogRule will execute the UniqueOperationIds rule against every document of a catalog.
+func (uo UniqueOperationIds) RunCatalogRule(documents []*model.CatalogDocument, context model.RuleFunctionContext) []model.CatalogRuleFunctionResult {
+	var results []model.CatalogRuleFunctionResult
+
+	uses := make(map[string][]operationIdUse)
+	var operationIds []string
+	for _, doc := range documents {
+		if doc.DrDocument == nil || doc.DrDocument.V3Document == nil || doc.DrDocument.V3Document.Paths == nil {
+			continue
+		}
+		for pathPairs := doc.DrDocument.V3Document.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+			for opPairs := pathPairs.Value().GetOperations().First(); opPairs != nil; opPairs = opPairs.Next() {
+				op := opPairs.Value()
+				if op.Value.OperationId == "" {
+					continue
+				}
+				if _, ok := uses[op.Value.OperationId]; !ok {
+					operationIds = append(operationIds, op.Value.OperationId)
+				}
+				uses[op.Value.OperationId] = append(uses[op.Value.OperationId], operationIdUse{
+					document:  doc,
+					path:      pathPairs.Key(),
+					method:    opPairs.Key(),
+					operation: op,
+				})
+			}
+		}
+	}
+
+	for _, operationId := range operationIds {
+		found := make([]*model.CatalogDocument, len(uses[operationId]))
+		for i, use := range uses[operationId] {
+			found[i] = use.document
+		}
+		// an operationId used twice in the same document is the job of operation-operationId-unique.
+		if countDocuments(found) < 2 {
+			continue
+		}
+		for _, use := range uses[operationId] {
+			node := use.operation.Value.GoLow().OperationId.KeyNode
+			res := model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("the operationId `%s` is also used by %s", operationId, otherFiles(use.document, found))),
+				StartNode: node,
+				EndNode:   vacuumUtils.BuildEndNode(node),
+				Path:      fmt.Sprintf("$.paths['%s'].%s.operationId", use.path, use.method),
+				Rule:      context.Rule,
+			}
+			use.operation.AddRuleFunctionResult(v3.ConvertRuleResult(&res))
+			results = append(results, model.CatalogRuleFunctionResult{Document: use.document, Result: res})
+		}
+	}
+	return results
+}
@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+)
+
+func TestUniqueOperationIds_GetSchema(t *testing.T) {
+	def := UniqueOperationIds{}
+	assert.Equal(t, "catalogUniqueOperationIds", def.GetSchema().Name)
+	assert.Equal(t, model.FunctionCategoryCatalog, def.GetCategory())
+}
+
+func TestUniqueOperationIds_RunRule(t *testing.T) {
+	def := UniqueOperationIds{}
+	assert.Empty(t, def.RunRule(nil, model.RuleFunctionContext{}))
+}
+
+func TestUniqueOperationIds_RunCatalogRule(t *testing.T) {
+	pets := buildCatalogTestDocument(t, "pets.yaml", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listThings
+    post:
+      operationId: createPet`)
+	stores := buildCatalogTestDocument(t, "stores.yaml", `openapi: 3.1.0
+paths:
+  /stores:
+    get:
+      operationId: listThings`)
+	toys := buildCatalogTestDocument(t, "toys.yaml", `openapi: 3.1.0
+paths:
+  /toys:
+    get:
+      operationId: listThings
+    post:
+      operationId: createToy`)
+
+	def := UniqueOperationIds{}
+	res := def.RunCatalogRule([]*model.CatalogDocument{pets, stores, toys}, buildCatalogTestContext("catalogUniqueOperationIds"))
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, pets, res[0].Document)
+	assert.Equal(t, "the operationId `listThings` is also used by `stores.yaml`, `toys.yaml`", res[0].Result.Message)
+	assert.Equal(t, "$.paths['/pets'].get.operationId", res[0].Result.Path)
+	assert.Equal(t, 5, res[0].Result.StartNode.Line)
+	assert.Equal(t, stores, res[1].Document)
+	assert.Equal(t, "the operationId `listThings` is also used by `pets.yaml`, `toys.yaml`", res[1].Result.Message)
+	assert.Equal(t, toys, res[2].Document)
+}
+
+func TestUniqueOperationIds_RunCatalogRule_SameDocument(t *testing.T) {
+	// duplicates in a single document are reported by operation-operationId-unique.
+	pets := buildCatalogTestDocument(t, "pets.yaml", `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: getPets
+  /cats:
+    get:
+      operationId: getPets`)
+	stores := buildCatalogTestDocument(t, "stores.yaml", `openapi: 3.1.0
+paths:
+  /stores:
+    get:
+      operationId: getStores`)
+
+	def := UniqueOperationIds{}
+	res := def.RunCatalogRule([]*model.CatalogDocument{pets, stores}, buildCatalogTestContext("catalogUniqueOperationIds"))
+	assert.Empty(t, res)
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	asyncapi_functions "github.com/daveshanley/vacuum/functions/asyncapi"
+	"github.com/daveshanley/vacuum/functions/catalog"
 	"github.com/daveshanley/vacuum/functions/core"
 	jsonschema_functions "github.com/daveshanley/vacuum/functions/jsonschema"
 	openapi_functions "github.com/daveshanley/vacuum/functions/openapi"
@@ -79,6 +80,9 @@ func MapBuiltinFunctions() Functions {
 		funcs["asyncApiContentType"] = asyncapi_functions.ContentType{}
 		funcs["asyncApiTagsUnique"] = asyncapi_functions.TagsUnique{}
 		funcs["asyncApiUnusedComponents"] = asyncapi_functions.UnusedComponents{}
+		funcs["asyncApi2ChannelOperations"] = asyncapi_functions.ChannelOperations{}
+		funcs["asyncApi2OperationIdUnique"] = asyncapi_functions.OperationIdUnique{}
+		funcs["asyncApi2MessageExamples"] = asyncapi_functions.MessageExamplesSchema{}
 
 		// add known OpenAPI rules
 		funcs["postResponseSuccess"] = openapi_functions.PostResponseSuccess{}
@@ -156,6 +160,11 @@ func MapBuiltinFunctions() Functions {
 		funcs["owaspNoAdditionalProperties"] = owasp.NoAdditionalProperties{}
 		funcs["owaspNoAdditionalPropertiesConstrained"] = owasp.AdditionalPropertiesConstrained{}
 		funcs["owaspHostsHttps"] = owasp.HostsHttps{}
+
+		// add catalog functions, they check every document of a catalog at once.
+		funcs["catalogUniqueOperationIds"] = catalog.UniqueOperationIds{}
+		funcs["catalogPathConflicts"] = catalog.PathConflicts{}
+		funcs["catalogSchemaConsistency"] = catalog.SchemaConsistency{}
 	})
 
 	return functionsSingleton
@@ -12,7 +12,7 @@ import (
 
 func TestMapBuiltinFunctions(t *testing.T) {
 	funcs := MapBuiltinFunctions()
-	assert.Len(t, funcs.GetAllFunctions(), 99)
+	assert.Len(t, funcs.GetAllFunctions(), 105)
 	assert.Contains(t, funcs.GetAllFunctions(), "pathsSpecificityOrder")
 	assert.Contains(t, funcs.GetAllFunctions(), "requiredFieldsDefined")
 	assert.Contains(t, funcs.GetAllFunctions(), "asyncApiDocument")
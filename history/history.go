@@ -0,0 +1,310 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+// Package history records the statistics of lint runs in an embedded SQLite database, so score, error and
+// warning trends can be tracked per API over time.
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	_ "modernc.org/sqlite"
+)
+
+// DefaultFileName is the name of the history database, in the vacuum config directory.
+const DefaultFileName = "history.db"
+
+// schemaVersion is stored in the database using PRAGMA user_version, it must be bumped when the schema changes.
+const schemaVersion = 1
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS lint_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at TIMESTAMP NOT NULL,
+		spec_path TEXT NOT NULL,
+		git_sha TEXT NOT NULL DEFAULT '',
+		ruleset_hash TEXT NOT NULL DEFAULT '',
+		overall_score INTEGER NOT NULL DEFAULT 0,
+		total_errors INTEGER NOT NULL DEFAULT 0,
+		total_warnings INTEGER NOT NULL DEFAULT 0,
+		total_info INTEGER NOT NULL DEFAULT 0,
+		total_hints INTEGER NOT NULL DEFAULT 0,
+		statistics TEXT NOT NULL DEFAULT '{}'
+	)`,
+	`CREATE INDEX IF NOT EXISTS lint_runs_spec_path ON lint_runs (spec_path, created_at)`,
+	`CREATE TABLE IF NOT EXISTS lint_run_categories (
+		run_id INTEGER NOT NULL REFERENCES lint_runs (id) ON DELETE CASCADE,
+		category_id TEXT NOT NULL,
+		category_name TEXT NOT NULL,
+		score INTEGER NOT NULL DEFAULT 0,
+		num_issues INTEGER NOT NULL DEFAULT 0,
+		errors INTEGER NOT NULL DEFAULT 0,
+		warnings INTEGER NOT NULL DEFAULT 0,
+		info INTEGER NOT NULL DEFAULT 0,
+		hints INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (run_id, category_id)
+	)`,
+}
+
+// Run is a recorded lint run of a single specification.
+type Run struct {
+	ID          int64
+	CreatedAt   time.Time
+	SpecPath    string // the key of the specification, relative to the root of the git repository if there is one
+	GitSHA      string // the commit checked out when the spec was linted, if the spec is in a git repository
+	RulesetHash string // identifies the rules the spec was linted with, see RuleSetHash
+	Statistics  *reports.ReportStatistics
+}
+
+// Spec summarises the recorded runs of a single specification.
+type Spec struct {
+	SpecPath string
+	Runs     int
+	First    time.Time
+	Last     time.Time
+}
+
+// Store is a history database.
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the location of the history database used when none is configured.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vacuum", DefaultFileName), nil
+}
+
+// Open opens (or creates) a history database.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create history directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history database '%s': %w", path, err)
+	}
+	// sqlite only allows a single writer, sharing one connection avoids 'database is locked' errors.
+	db.SetMaxOpenConns(1)
+
+	s := &Store{db: db}
+	if err = s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to prepare history database '%s': %w", path, err)
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("history database was created by a newer version of vacuum (schema version %d)", version)
+	}
+	for _, statement := range schema {
+		if _, err := s.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+	_, err := s.db.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, schemaVersion))
+	return err
+}
+
+// Record stores a run. The ID and creation time of the run (and of its statistics) are set once it is stored,
+// a zero CreatedAt defaults to now.
+func (s *Store) Record(run *Run) error {
+	if run == nil || run.Statistics == nil {
+		return errors.New("cannot record a run without statistics")
+	}
+	if run.SpecPath == "" {
+		return errors.New("cannot record a run without a spec path")
+	}
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now()
+	}
+	run.CreatedAt = run.CreatedAt.UTC()
+
+	stats := run.Statistics
+	encoded, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("unable to encode statistics: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(`INSERT INTO lint_runs
+		(created_at, spec_path, git_sha, ruleset_hash, overall_score, total_errors, total_warnings, total_info, total_hints, statistics)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.CreatedAt, run.SpecPath, run.GitSHA, run.RulesetHash, stats.OverallScore,
+		stats.TotalErrors, stats.TotalWarnings, stats.TotalInfo, stats.TotalHints, string(encoded))
+	if err != nil {
+		return fmt.Errorf("unable to record run: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, cat := range stats.CategoryStatistics {
+		if cat == nil {
+			continue
+		}
+		if _, err = tx.Exec(`INSERT OR REPLACE INTO lint_run_categories
+			(run_id, category_id, category_name, score, num_issues, errors, warnings, info, hints)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, cat.CategoryId, cat.CategoryName, cat.Score, cat.NumIssues, cat.Errors, cat.Warnings, cat.Info, cat.Hints); err != nil {
+			return fmt.Errorf("unable to record category '%s': %w", cat.CategoryId, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	run.ID = id
+	stats.ID = uint(id)
+	stats.CreatedAt = run.CreatedAt
+	stats.UpdatedAt = run.CreatedAt
+	return nil
+}
+
+// Specs returns every specification with recorded runs, ordered by spec path.
+func (s *Store) Specs() ([]*Spec, error) {
+	rows, err := s.db.Query(`SELECT spec_path, COUNT(*), MIN(created_at), MAX(created_at)
+		FROM lint_runs GROUP BY spec_path ORDER BY spec_path`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var specs []*Spec
+	for rows.Next() {
+		spec := &Spec{}
+		var first, last string
+		if err = rows.Scan(&spec.SpecPath, &spec.Runs, &first, &last); err != nil {
+			return nil, err
+		}
+		spec.First, _ = parseTime(first)
+		spec.Last, _ = parseTime(last)
+		specs = append(specs, spec)
+	}
+	return specs, rows.Err()
+}
+
+// Runs returns the most recent runs of a specification, oldest first. A limit below one returns every run.
+func (s *Store) Runs(specPath string, limit int) ([]*Run, error) {
+	if limit < 1 {
+		limit = -1
+	}
+	rows, err := s.db.Query(`SELECT id, created_at, spec_path, git_sha, ruleset_hash, overall_score,
+		total_errors, total_warnings, total_info, total_hints, statistics
+		FROM lint_runs WHERE spec_path = ? ORDER BY created_at DESC, id DESC LIMIT ?`, specPath, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*Run
+	byID := make(map[int64]*Run)
+	for rows.Next() {
+		run := &Run{Statistics: &reports.ReportStatistics{}}
+		var created, encoded string
+		stats := run.Statistics
+		if err = rows.Scan(&run.ID, &created, &run.SpecPath, &run.GitSHA, &run.RulesetHash, &stats.OverallScore,
+			&stats.TotalErrors, &stats.TotalWarnings, &stats.TotalInfo, &stats.TotalHints, &encoded); err != nil {
+			return nil, err
+		}
+		// the columns are authoritative, the encoded statistics fill in everything else.
+		var decoded reports.ReportStatistics
+		if json.Unmarshal([]byte(encoded), &decoded) == nil {
+			decoded.OverallScore, decoded.TotalErrors, decoded.TotalWarnings = stats.OverallScore, stats.TotalErrors, stats.TotalWarnings
+			decoded.TotalInfo, decoded.TotalHints = stats.TotalInfo, stats.TotalHints
+			*stats = decoded
+		}
+		stats.CategoryStatistics = nil
+		if run.CreatedAt, err = parseTime(created); err != nil {
+			return nil, err
+		}
+		stats.ID = uint(run.ID)
+		stats.CreatedAt, stats.UpdatedAt = run.CreatedAt, run.CreatedAt
+		runs = append(runs, run)
+		byID[run.ID] = run
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if err = s.loadCategories(specPath, byID); err != nil {
+		return nil, err
+	}
+
+	// oldest first, so runs read as a timeline.
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	return runs, nil
+}
+
+func (s *Store) loadCategories(specPath string, runs map[int64]*Run) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	rows, err := s.db.Query(`SELECT c.run_id, c.category_id, c.category_name, c.score, c.num_issues,
+		c.errors, c.warnings, c.info, c.hints
+		FROM lint_run_categories c JOIN lint_runs r ON r.id = c.run_id
+		WHERE r.spec_path = ? ORDER BY c.run_id, c.category_id`, specPath)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var runID int64
+		cat := &reports.CategoryStatistic{}
+		if err = rows.Scan(&runID, &cat.CategoryId, &cat.CategoryName, &cat.Score, &cat.NumIssues,
+			&cat.Errors, &cat.Warnings, &cat.Info, &cat.Hints); err != nil {
+			return err
+		}
+		if run, ok := runs[runID]; ok {
+			cat.ID = uint(runID)
+			cat.CreatedAt, cat.UpdatedAt = run.CreatedAt, run.CreatedAt
+			run.Statistics.CategoryStatistics = append(run.Statistics.CategoryStatistics, cat)
+		}
+	}
+	return rows.Err()
+}
+
+// parseTime parses timestamps returned by sqlite, which may come back as text in a few layouts.
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range []string{
+		"2006-01-02 15:04:05.999999999-07:00",
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999 -0700 MST",
+		"2006-01-02 15:04:05",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse timestamp '%s'", value)
+}
@@ -0,0 +1,151 @@
+package history
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func testStats(score, errors, warnings int) *reports.ReportStatistics {
+	return &reports.ReportStatistics{
+		OverallScore:  score,
+		TotalErrors:   errors,
+		TotalWarnings: warnings,
+		TotalInfo:     1,
+		FilesizeKB:    12,
+		CategoryStatistics: []*reports.CategoryStatistic{
+			{CategoryId: "descriptions", CategoryName: "Descriptions", Score: score, NumIssues: errors + warnings, Errors: errors, Warnings: warnings},
+			{CategoryId: "schemas", CategoryName: "Schemas", Score: 100},
+		},
+	}
+}
+
+func openTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "nested", DefaultFileName)
+	store, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	return store, path
+}
+
+func TestStore_RecordAndRuns(t *testing.T) {
+	store, _ := openTestStore(t)
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	for i, stats := range []*reports.ReportStatistics{testStats(60, 4, 10), testStats(75, 2, 8), testStats(90, 0, 3)} {
+		run := &Run{
+			CreatedAt:   start.Add(time.Duration(i) * time.Hour),
+			SpecPath:    "specs/pets.yaml",
+			GitSHA:      "abc123",
+			RulesetHash: "rules",
+			Statistics:  stats,
+		}
+		require.NoError(t, store.Record(run))
+		assert.NotZero(t, run.ID)
+		assert.Equal(t, uint(run.ID), stats.ID)
+	}
+	require.NoError(t, store.Record(&Run{SpecPath: "specs/other.yaml", Statistics: testStats(50, 5, 5)}))
+
+	runs, err := store.Runs("specs/pets.yaml", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, start, runs[0].CreatedAt)
+	assert.Equal(t, 60, runs[0].Statistics.OverallScore)
+	assert.Equal(t, 90, runs[2].Statistics.OverallScore)
+	assert.Equal(t, 3, runs[2].Statistics.TotalWarnings)
+	assert.Equal(t, 1, runs[2].Statistics.TotalInfo)
+	assert.Equal(t, 12, runs[2].Statistics.FilesizeKB)
+	assert.Equal(t, "abc123", runs[2].GitSHA)
+	assert.Equal(t, "rules", runs[2].RulesetHash)
+	require.Len(t, runs[2].Statistics.CategoryStatistics, 2)
+	assert.Equal(t, "Descriptions", runs[2].Statistics.CategoryStatistics[0].CategoryName)
+	assert.Equal(t, 3, runs[2].Statistics.CategoryStatistics[0].Warnings)
+
+	// the most recent runs, still oldest first.
+	runs, err = store.Runs("specs/pets.yaml", 2)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, 75, runs[0].Statistics.OverallScore)
+	assert.Equal(t, 90, runs[1].Statistics.OverallScore)
+
+	specs, err := store.Specs()
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "specs/other.yaml", specs[0].SpecPath)
+	assert.Equal(t, "specs/pets.yaml", specs[1].SpecPath)
+	assert.Equal(t, 3, specs[1].Runs)
+	assert.Equal(t, start, specs[1].First)
+	assert.Equal(t, start.Add(2*time.Hour), specs[1].Last)
+
+	runs, err = store.Runs("specs/nope.yaml", 0)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestStore_RecordInvalid(t *testing.T) {
+	store, _ := openTestStore(t)
+	assert.ErrorContains(t, store.Record(&Run{SpecPath: "a.yaml"}), "without statistics")
+	assert.ErrorContains(t, store.Record(&Run{Statistics: testStats(1, 1, 1)}), "without a spec path")
+}
+
+func TestOpen_Reopen(t *testing.T) {
+	store, path := openTestStore(t)
+	require.NoError(t, store.Record(&Run{SpecPath: "a.yaml", Statistics: testStats(80, 1, 1)}))
+	require.NoError(t, store.Close())
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+	runs, err := reopened.Runs("a.yaml", 0)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
+
+func TestOpen_NewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`PRAGMA user_version = 99`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = Open(path)
+	assert.ErrorContains(t, err, "created by a newer version of vacuum")
+}
+
+func TestSpecKey(t *testing.T) {
+	key, sha := SpecKey("https://example.com/openapi.yaml")
+	assert.Equal(t, "https://example.com/openapi.yaml", key)
+	assert.Empty(t, sha)
+
+	// a directory outside any repository is keyed by its absolute path.
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	spec := filepath.Join(dir, "openapi.yaml")
+	key, sha = SpecKey(spec)
+	assert.Equal(t, filepath.ToSlash(spec), key)
+	assert.Empty(t, sha)
+}
+
+func TestRuleSetHash(t *testing.T) {
+	assert.Empty(t, RuleSetHash(nil))
+
+	rs := &rulesets.RuleSet{Rules: map[string]*model.Rule{
+		"a": {Id: "a", Severity: model.SeverityError},
+		"b": {Id: "b", Severity: model.SeverityWarn},
+	}}
+	hash := RuleSetHash(rs)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, RuleSetHash(rs))
+
+	rs.Rules["b"].Severity = model.SeverityError
+	assert.NotEqual(t, hash, RuleSetHash(rs))
+}
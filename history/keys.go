@@ -0,0 +1,81 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/rulesets"
+)
+
+// SpecKey returns the key the runs of a specification are recorded under, and the commit checked out if the
+// specification is in a git repository. Specifications in a git repository are keyed relative to the root of the
+// repository, so every clone shares the same keys. Other specifications are keyed by their absolute path, and
+// URLs are used as they are.
+func SpecKey(specPath string) (key string, gitSHA string) {
+	if strings.HasPrefix(specPath, "http://") || strings.HasPrefix(specPath, "https://") {
+		return specPath, ""
+	}
+	absPath, err := filepath.Abs(specPath)
+	if err != nil {
+		return specPath, ""
+	}
+
+	root, sha := gitInfo(filepath.Dir(absPath))
+	if root == "" {
+		return filepath.ToSlash(absPath), ""
+	}
+	// git reports the root with symlinks resolved (e.g. /private/var on macOS).
+	if resolved, rErr := filepath.EvalSymlinks(absPath); rErr == nil {
+		absPath = resolved
+	}
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(absPath), sha
+	}
+	return filepath.ToSlash(rel), sha
+}
+
+// gitInfo returns the root of the git repository dir is in, and the commit checked out. Both are empty when dir
+// is not in a git repository, or git is not installed.
+func gitInfo(dir string) (root string, sha string) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel", "HEAD").Output()
+	if err != nil {
+		// a repository without commits still has a root.
+		out, err = exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+		if err != nil {
+			return "", ""
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	root = filepath.Clean(strings.TrimSpace(lines[0]))
+	if resolved, rErr := filepath.EvalSymlinks(root); rErr == nil {
+		root = resolved
+	}
+	if len(lines) > 1 {
+		sha = strings.TrimSpace(lines[1])
+	}
+	return root, sha
+}
+
+// RuleSetHash identifies the rules a specification was linted with, so score changes caused by a different
+// ruleset can be told apart from changes to the specification.
+func RuleSetHash(rs *rulesets.RuleSet) string {
+	if rs == nil {
+		return ""
+	}
+	// maps are encoded with sorted keys, so the hash is stable.
+	encoded, err := json.Marshal(rs.Rules)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,330 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/functions/autofix"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pb33f/libopenapi/utils"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+// inlineIgnoreKey is the extension motor reads inline ignore directives from.
+const inlineIgnoreKey = "x-lint-ignore"
+
+var builtInAutoFixFunctions = autofix.GetBuiltInAutoFixFunctions()
+
+// codeActions returns the quick fixes for each diagnostic: a link to the rule documentation, the rule's
+// auto-fix (if it has one), and actions that ignore the violation inline or in the configured ignore file.
+func (s *ServerState) codeActions(params *protocol.CodeActionParams) []protocol.CodeAction {
+	var actions []protocol.CodeAction
+	quickFixKind := protocol.CodeActionKindQuickFix
+
+	for _, diagnostic := range params.Context.Diagnostics {
+		if diagnostic.CodeDescription != nil && diagnostic.CodeDescription.HRef != "" {
+			actions = append(actions, protocol.CodeAction{
+				Title: "View documentation",
+				Kind:  &quickFixKind,
+				Command: &protocol.Command{
+					Title:     "Open documentation",
+					Command:   "vacuum.openUrl",
+					Arguments: []interface{}{diagnostic.CodeDescription.HRef},
+				},
+			})
+		}
+	}
+
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return actions
+	}
+	doc.mu.RLock()
+	content := doc.Content
+	doc.mu.RUnlock()
+
+	ignoreFile := ""
+	if runtimeConfig, err := s.runtimeConfigForDocument(doc.URI); err == nil {
+		ignoreFile = runtimeConfig.ignoreFile
+	}
+
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		// edits are built from the node positions of the last lint, which are useless if the document has changed since.
+		if result == nil || result.RuleSetExecution == nil || string(result.RuleSetExecution.Spec) != content {
+			return
+		}
+		execution := result.RuleSetExecution
+		lines := strings.Split(content, "\n")
+
+		for _, diagnostic := range params.Context.Diagnostics {
+			violation := findResultForDiagnostic(result.Results, diagnostic)
+			if violation == nil || violation.Rule == nil {
+				continue
+			}
+			ruleID := violation.Rule.Id
+			newAction := func(title string, uri protocol.DocumentUri, edit protocol.TextEdit) protocol.CodeAction {
+				return protocol.CodeAction{
+					Title:       title,
+					Kind:        &quickFixKind,
+					Diagnostics: []protocol.Diagnostic{diagnostic},
+					Edit: &protocol.WorkspaceEdit{
+						Changes: map[protocol.DocumentUri][]protocol.TextEdit{uri: {edit}},
+					},
+				}
+			}
+
+			if edit, ok := autoFixEdit(execution, violation, lines); ok {
+				action := newAction(fmt.Sprintf("Fix '%s'", ruleID), doc.URI, edit)
+				preferred := true
+				action.IsPreferred = &preferred
+				actions = append(actions, action)
+			}
+			if edit, atRoot, ok := inlineIgnoreEdit(execution.CanonicalDocument, lines, violation.Path, ruleID); ok {
+				title := fmt.Sprintf("Ignore '%s' here (%s)", ruleID, inlineIgnoreKey)
+				if atRoot {
+					title = fmt.Sprintf("Ignore '%s' in this document (%s)", ruleID, inlineIgnoreKey)
+				}
+				actions = append(actions, newAction(title, doc.URI, edit))
+			}
+			if ignoreFile != "" && violation.Path != "" {
+				ignoreURI := filePathToURI(ignoreFile)
+				if edit, ok := s.ignoreFileEdit(ignoreFile, ignoreURI, ruleID, violation.Path); ok {
+					title := fmt.Sprintf("Ignore this '%s' violation in %s", ruleID, filepath.Base(ignoreFile))
+					actions = append(actions, newAction(title, ignoreURI, edit))
+				}
+			}
+		}
+	})
+	return actions
+}
+
+// findResultForDiagnostic returns the result a diagnostic was created from.
+func findResultForDiagnostic(results []model.RuleFunctionResult, diagnostic protocol.Diagnostic) *model.RuleFunctionResult {
+	if diagnostic.Code == nil {
+		return nil
+	}
+	code := fmt.Sprint(diagnostic.Code.Value)
+	for i := range results {
+		converted := ConvertResultIntoDiagnostic(&results[i])
+		if converted.Range == diagnostic.Range && fmt.Sprint(converted.Code.Value) == code {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+// autoFixEdit runs the rule's auto-fix function against a copy of the document, and returns an edit that
+// replaces the part of the document that was changed.
+func autoFixEdit(execution *motor.RuleSetExecution, result *model.RuleFunctionResult, lines []string) (protocol.TextEdit, bool) {
+	root := execution.CanonicalDocument
+	if root == nil || result.StartNode == nil || result.Rule.AutoFixFunction == "" {
+		return protocol.TextEdit{}, false
+	}
+	fixFunction, ok := execution.AutoFixFunctions[result.Rule.AutoFixFunction]
+	if !ok {
+		fixFunction, ok = builtInAutoFixFunctions[result.Rule.AutoFixFunction]
+	}
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+
+	fixedRoot, copies := copyNodeTree(root)
+	nodeToFix, ok := copies[result.StartNode]
+	if !ok {
+		// resolved rules report nodes from the resolved document, which need mapping back to the canonical one.
+		nodeToFix, ok = copies[motor.FindCanonicalNode(execution.IndexUnresolved, result.StartNode)]
+	}
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+
+	if _, err := fixFunction(nodeToFix, fixedRoot, autoFixContext(result.Rule, execution)); err != nil {
+		return protocol.TextEdit{}, false
+	}
+	return replaceChangedNodeEdit(lines, root, fixedRoot, detectYAMLFormat(root))
+}
+
+// autoFixContext builds the context passed to an auto-fix function, fixes read the function options of the
+// rule action (the first one, if the rule has several), just like motor does.
+func autoFixContext(rule *model.Rule, execution *motor.RuleSetExecution) *model.RuleFunctionContext {
+	ctx := &model.RuleFunctionContext{
+		Rule:  rule,
+		Given: rule.Given,
+		Index: execution.IndexUnresolved,
+	}
+	var ruleAction model.RuleAction
+	if err := mapstructure.Decode(rule.Then, &ruleAction); err != nil {
+		var ruleActions []model.RuleAction
+		if err := mapstructure.Decode(rule.Then, &ruleActions); err != nil || len(ruleActions) == 0 {
+			return ctx
+		}
+		ruleAction = ruleActions[0]
+	}
+	ctx.RuleAction = &ruleAction
+	ctx.Options = ruleAction.FunctionOptions
+	return ctx
+}
+
+// inlineIgnoreEdit adds the rule to the x-lint-ignore directive of the object at the path of a result, or the
+// object containing it. Motor honors ignores on the node at the path and its parent, and on the document root,
+// which ignores the rule for the entire document.
+func inlineIgnoreEdit(root *yaml.Node, lines []string, path, ruleID string) (edit protocol.TextEdit, atRoot bool, ok bool) {
+	if root == nil || path == "" {
+		return edit, false, false
+	}
+	nodes, err := utils.FindNodesWithoutDeserializingWithTimeout(root, path, 500*time.Millisecond)
+	if err != nil || len(nodes) == 0 || nodes[0] == nil {
+		return edit, false, false
+	}
+	rootMap := root
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		rootMap = root.Content[0]
+	}
+
+	target := nodes[0]
+	if target.Kind != yaml.MappingNode {
+		target = findParentNode(root, target)
+	}
+	if target == nil || target.Kind != yaml.MappingNode {
+		return edit, false, false
+	}
+	atRoot = target == rootMap
+
+	ruleNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ruleID}
+	flow := target.Style&yaml.FlowStyle != 0
+	if flow {
+		ruleNode.Style = yaml.DoubleQuotedStyle
+	}
+	ruleText, rendered := renderScalar(ruleNode)
+	if !rendered {
+		return edit, false, false
+	}
+
+	for i := 0; i+1 < len(target.Content); i += 2 {
+		if target.Content[i].Value != inlineIgnoreKey {
+			continue
+		}
+		existing := target.Content[i+1]
+		switch {
+		case existing.Kind == yaml.ScalarNode:
+			// a single rule becomes a flow sequence of both rules.
+			existingCopy := *existing
+			existingCopy.HeadComment, existingCopy.LineComment, existingCopy.FootComment = "", "", ""
+			ruleNode.Style = existing.Style
+			seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle, Content: []*yaml.Node{&existingCopy, ruleNode}}
+			rng, found := scalarSourceRange(lines, existing)
+			text, single := renderScalar(seq)
+			if !found || !single {
+				return edit, false, false
+			}
+			return protocol.TextEdit{Range: rng, NewText: text}, atRoot, true
+		case existing.Kind == yaml.SequenceNode && existing.Style&yaml.FlowStyle != 0:
+			pos := protocol.Position{Line: protocol.UInteger(existing.Line - 1), Character: protocol.UInteger(existing.Column)}
+			text := ruleText
+			if len(existing.Content) > 0 {
+				text += ", "
+			}
+			return protocol.TextEdit{Range: protocol.Range{Start: pos, End: pos}, NewText: text}, atRoot, true
+		case existing.Kind == yaml.SequenceNode:
+			indent := strings.Repeat(" ", existing.Column-1)
+			return insertAfterLineEdit(lines, lastLine(existing), indent+"- "+ruleText), atRoot, true
+		}
+		return edit, false, false
+	}
+
+	if flow {
+		pos := protocol.Position{Line: protocol.UInteger(target.Line - 1), Character: protocol.UInteger(target.Column)}
+		text := fmt.Sprintf("%q: %s", inlineIgnoreKey, ruleText)
+		if len(target.Content) > 0 {
+			text += ", "
+		}
+		return protocol.TextEdit{Range: protocol.Range{Start: pos, End: pos}, NewText: text}, atRoot, true
+	}
+	indent := strings.Repeat(" ", target.Column-1)
+	return insertAfterLineEdit(lines, lastLine(target), fmt.Sprintf("%s%s: %s", indent, inlineIgnoreKey, ruleText)), atRoot, true
+}
+
+// ignoreFileEdit appends the path of a result to the rule's entry in an ignore file, adding the entry if
+// the rule is not in there yet. Unsaved changes are used when the ignore file is open.
+func (s *ServerState) ignoreFileEdit(ignoreFile string, ignoreURI protocol.DocumentUri, ruleID, path string) (protocol.TextEdit, bool) {
+	var content string
+	if doc, ok := s.documentStore.Get(ignoreURI); ok {
+		doc.mu.RLock()
+		content = doc.Content
+		doc.mu.RUnlock()
+	} else {
+		raw, err := os.ReadFile(ignoreFile)
+		if err != nil {
+			return protocol.TextEdit{}, false
+		}
+		content = string(raw)
+	}
+
+	pathText, ok := renderScalar(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path})
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+	ruleText, ok := renderScalar(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ruleID})
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return protocol.TextEdit{}, false
+	}
+	lines := strings.Split(content, "\n")
+
+	if len(root.Content) > 0 {
+		ignored := root.Content[0]
+		if ignored.Kind != yaml.MappingNode || ignored.Style&yaml.FlowStyle != 0 {
+			return protocol.TextEdit{}, false
+		}
+		for i := 0; i+1 < len(ignored.Content); i += 2 {
+			if ignored.Content[i].Value != ruleID {
+				continue
+			}
+			paths := ignored.Content[i+1]
+			if paths.Kind != yaml.SequenceNode || paths.Style&yaml.FlowStyle != 0 || len(paths.Content) == 0 {
+				return protocol.TextEdit{}, false
+			}
+			indent := strings.Repeat(" ", paths.Column-1)
+			return insertAfterLineEdit(lines, lastLine(paths), indent+"- "+pathText), true
+		}
+	}
+
+	entry := fmt.Sprintf("%s:\n  - %s", ruleText, pathText)
+	if strings.TrimSpace(content) == "" {
+		return replaceLinesEdit(lines, 1, len(lines), entry+"\n"), true
+	}
+	if lines[len(lines)-1] == "" {
+		// insert before the trailing newline.
+		return insertAfterLineEdit(lines, len(lines)-1, entry), true
+	}
+	return insertAfterLineEdit(lines, len(lines), entry), true
+}
+
+// findParentNode returns the node that contains the child.
+func findParentNode(node, child *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for _, c := range node.Content {
+		if c == child {
+			return node
+		}
+		if parent := findParentNode(c, child); parent != nil {
+			return parent
+		}
+	}
+	return nil
+}
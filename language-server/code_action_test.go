@@ -0,0 +1,257 @@
+package languageserver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+const codeActionTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+servers:
+  - url: https://api.pb33f.io/
+tags:
+  - name: pizza
+  - name: calzone
+paths:
+  /pizza/:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+func buildCodeActionRuleSet(ids ...string) *rulesets.RuleSet {
+	all := rulesets.BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet()
+	rs := &rulesets.RuleSet{Rules: map[string]*model.Rule{}}
+	for _, id := range ids {
+		rs.Rules[id] = all.Rules[id]
+	}
+	return rs
+}
+
+// diagnosticsForTest returns the diagnostics of the last lint of a document, as a client would send them back.
+func diagnosticsForTest(doc *Document) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		diagnostics = ConvertResultsIntoDiagnostics(result)
+	})
+	return diagnostics
+}
+
+func codeActionsForTest(state *ServerState, doc *Document, diagnostics []protocol.Diagnostic) []protocol.CodeAction {
+	return state.codeActions(&protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: doc.URI},
+		Context:      protocol.CodeActionContext{Diagnostics: diagnostics},
+	})
+}
+
+func findCodeAction(actions []protocol.CodeAction, prefix string) *protocol.CodeAction {
+	for i := range actions {
+		if strings.HasPrefix(actions[i].Title, prefix) {
+			return &actions[i]
+		}
+	}
+	return nil
+}
+
+func applyCodeAction(t *testing.T, content string, uri protocol.DocumentUri, action *protocol.CodeAction) string {
+	t.Helper()
+	require.NotNil(t, action)
+	require.NotNil(t, action.Edit)
+	edits := action.Edit.Changes[uri]
+	require.Len(t, edits, 1)
+	start, end := edits[0].Range.IndexesIn(content)
+	return content[:start] + edits[0].NewText + content[end:]
+}
+
+func TestServerState_CodeActions_AutoFix(t *testing.T) {
+	tests := []struct {
+		name     string
+		ruleID   string
+		contains []string
+		missing  []string
+	}{
+		{
+			name:     "scalar key",
+			ruleID:   rulesets.PathKeysNoTrailingSlash,
+			contains: []string{"\n  /pizza:\n    get:\n"},
+			missing:  []string{"/pizza/:"},
+		},
+		{
+			name:     "scalar value",
+			ruleID:   rulesets.Oas3HostTrailingSlash,
+			contains: []string{"  - url: https://api.pb33f.io\n"},
+		},
+		{
+			name:     "sequence",
+			ruleID:   rulesets.OpenAPITagsAlphabetical,
+			contains: []string{"tags:\n  - name: calzone\n  - name: pizza\npaths:"},
+		},
+		{
+			name:     "mapping",
+			ruleID:   rulesets.InfoLicense,
+			contains: []string{"info:\n  title: pizza\n  version: 1.0.0\n  license:\n    name: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, doc := lintDocumentWithRuleSetForTest(t, codeActionTestSpec, buildCodeActionRuleSet(tt.ruleID))
+			diagnostics := diagnosticsForTest(doc)
+			require.Len(t, diagnostics, 1)
+
+			actions := codeActionsForTest(state, doc, diagnostics)
+			action := findCodeAction(actions, "Fix '"+tt.ruleID+"'")
+			require.NotNil(t, action)
+			require.NotNil(t, action.IsPreferred)
+			assert.True(t, *action.IsPreferred)
+
+			fixed := applyCodeAction(t, codeActionTestSpec, doc.URI, action)
+			for _, expected := range tt.contains {
+				assert.Contains(t, fixed, expected)
+			}
+			for _, unexpected := range tt.missing {
+				assert.NotContains(t, fixed, unexpected)
+			}
+
+			var node yaml.Node
+			assert.NoError(t, yaml.Unmarshal([]byte(fixed), &node))
+		})
+	}
+}
+
+func TestServerState_CodeActions_AutoFixJSON(t *testing.T) {
+	spec := `{"openapi": "3.1.0", "info": {"title": "pizza", "version": "1.0.0"},
+  "paths": {"/pizza/": {"get": {"responses": {"200": {"description": "ok"}}}}}}`
+
+	state, doc := lintDocumentWithRuleSetForTest(t, spec, buildCodeActionRuleSet(rulesets.PathKeysNoTrailingSlash))
+	diagnostics := diagnosticsForTest(doc)
+	require.Len(t, diagnostics, 1)
+
+	action := findCodeAction(codeActionsForTest(state, doc, diagnostics), "Fix '"+rulesets.PathKeysNoTrailingSlash+"'")
+	fixed := applyCodeAction(t, spec, doc.URI, action)
+	assert.Contains(t, fixed, `"paths": {"/pizza": {"get"`)
+}
+
+func TestServerState_CodeActions_NoAutoFixForChangedDocument(t *testing.T) {
+	state, doc := lintDocumentWithRuleSetForTest(t, codeActionTestSpec, buildCodeActionRuleSet(rulesets.PathKeysNoTrailingSlash))
+	diagnostics := diagnosticsForTest(doc)
+	require.Len(t, diagnostics, 1)
+
+	doc.mu.Lock()
+	doc.Content = "# edited\n" + doc.Content
+	doc.mu.Unlock()
+
+	actions := codeActionsForTest(state, doc, diagnostics)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "View documentation", actions[0].Title)
+}
+
+func TestServerState_CodeActions_InlineIgnore(t *testing.T) {
+	ruleSet := buildCodeActionRuleSet(rulesets.OperationOperationId)
+	state, doc := lintDocumentWithRuleSetForTest(t, codeActionTestSpec, ruleSet)
+	diagnostics := diagnosticsForTest(doc)
+	require.Len(t, diagnostics, 1)
+
+	action := findCodeAction(codeActionsForTest(state, doc, diagnostics), "Ignore 'operation-operationId' here")
+	ignored := applyCodeAction(t, codeActionTestSpec, doc.URI, action)
+	assert.Contains(t, ignored, "          description: ok\n      x-lint-ignore: operation-operationId\n")
+
+	_, relinted := lintDocumentWithRuleSetForTest(t, ignored, ruleSet)
+	assert.Empty(t, diagnosticsForTest(relinted))
+}
+
+func TestInlineIgnoreEdit_ExistingDirectives(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{
+			name: "scalar",
+			spec: `openapi: 3.1.0
+info:
+  title: pizza
+  x-lint-ignore: info-contact
+`,
+			expected: "  x-lint-ignore: [info-contact, info-license]\n",
+		},
+		{
+			name: "block sequence",
+			spec: `openapi: 3.1.0
+info:
+  title: pizza
+  x-lint-ignore:
+    - info-contact
+`,
+			expected: "  x-lint-ignore:\n    - info-contact\n    - info-license\n",
+		},
+		{
+			name: "flow sequence",
+			spec: `openapi: 3.1.0
+info:
+  title: pizza
+  x-lint-ignore: [info-contact]
+`,
+			expected: "  x-lint-ignore: [info-license, info-contact]\n",
+		},
+		{
+			name:     "json",
+			spec:     `{"openapi": "3.1.0", "info": {"title": "pizza"}}`,
+			expected: `"info": {"x-lint-ignore": "info-license", "title": "pizza"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var root yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.spec), &root))
+			lines := strings.Split(tt.spec, "\n")
+
+			edit, atRoot, ok := inlineIgnoreEdit(&root, lines, "$.info.title", "info-license")
+			require.True(t, ok)
+			assert.False(t, atRoot)
+
+			start, end := edit.Range.IndexesIn(tt.spec)
+			assert.Contains(t, tt.spec[:start]+edit.NewText+tt.spec[end:], tt.expected)
+		})
+	}
+}
+
+func TestServerState_IgnoreFileEdit(t *testing.T) {
+	state := &ServerState{documentStore: newDocumentStore()}
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	ignoreURI := "file://" + filepath.ToSlash(ignoreFile)
+
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("operation-operationId:\n  - $.paths['/pizza'].get\n"), 0o644))
+
+	apply := func(ruleID, path string) {
+		raw, err := os.ReadFile(ignoreFile)
+		require.NoError(t, err)
+		edit, ok := state.ignoreFileEdit(ignoreFile, ignoreURI, ruleID, path)
+		require.True(t, ok)
+		start, end := edit.Range.IndexesIn(string(raw))
+		require.NoError(t, os.WriteFile(ignoreFile, []byte(string(raw)[:start]+edit.NewText+string(raw)[end:]), 0o644))
+	}
+	apply("operation-operationId", "$.paths['/calzone'].get")
+	apply("info-license", "$.info")
+
+	ignored, err := loadIgnoreFileForLSP(ignoreFile)
+	require.NoError(t, err)
+	assert.Equal(t, model.IgnoredItems{
+		"operation-operationId": {"$.paths['/pizza'].get", "$.paths['/calzone'].get"},
+		"info-license":          {"$.info"},
+	}, ignored)
+}
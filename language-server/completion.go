@@ -0,0 +1,644 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/parser"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/index"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+// sequenceItem is the path segment used for the items of a sequence.
+const sequenceItem = "[]"
+
+type completionKind int
+
+const (
+	completeKey completionKind = iota
+	completeReference
+	completeRuleID
+)
+
+// completionContext describes what is being typed at the cursor.
+type completionContext struct {
+	kind     completionKind
+	path     []string        // keys from the root down to the object being completed.
+	siblings map[string]bool // keys already present in the object being completed.
+	replace  protocol.Range  // the text typed so far, which is replaced by the chosen item.
+	quoted   bool            // the value typed so far opens a quote.
+	json     bool            // the document is JSON, so keys and values are written as JSON strings.
+}
+
+var (
+	// keyLineRegex splits a (trimmed) line of block YAML into a key and value.
+	keyLineRegex = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s#'"{\[][^#]*?)\s*:(?:\s+(.*))?$`)
+	// partialKeyRegex matches a key that is still being typed.
+	partialKeyRegex = regexp.MustCompile(`^[\w$\-.]*$`)
+
+	// specSchemas maps the name of a specification (see specSchemaName) onto its structural schema.
+	specSchemas = map[string]*string{
+		"openapi":    &parser.OpenAPI3Schema,
+		"swagger":    &parser.Swagger2Schema,
+		"asyncapi-2": &parser.AsyncAPI2Schema,
+		"asyncapi-3": &parser.AsyncAPI3Schema,
+	}
+	keySchemas     = map[string]*keySchema{}
+	keySchemasLock sync.Mutex
+)
+
+// completion returns the keys that are valid for the object at the cursor (according to the JSON schema of the
+// specification), every component path when typing a $ref, and the IDs of the active ruleset when typing an
+// x-lint-ignore directive.
+func (s *ServerState) completion(params *protocol.CompletionParams) []protocol.CompletionItem {
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+	doc.mu.RLock()
+	content := doc.Content
+	doc.mu.RUnlock()
+
+	jsonDocument := isJSONDocument(content)
+	var ctx completionContext
+	if jsonDocument {
+		ctx, ok = jsonCompletionContextAt(content, params.Position)
+	} else {
+		ctx, ok = completionContextAt(content, params.Position)
+	}
+	if !ok {
+		return nil
+	}
+
+	switch ctx.kind {
+	case completeKey:
+		schema := keySchemaForDocument(content, jsonDocument)
+		if schema == nil {
+			return nil
+		}
+		return keyCompletionItems(schema, ctx)
+
+	case completeReference:
+		var items []protocol.CompletionItem
+		doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+			if result != nil && result.RuleSetExecution != nil {
+				items = referenceCompletionItems(componentReferences(result.RuleSetExecution.IndexUnresolved), ctx)
+			}
+		})
+		return items
+
+	case completeRuleID:
+		var ruleSet *rulesets.RuleSet
+		var items []protocol.CompletionItem
+		doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+			if result != nil && result.RuleSetExecution != nil && result.RuleSetExecution.RuleSet != nil {
+				items = ruleIDCompletionItems(result.RuleSetExecution.RuleSet, ctx)
+			}
+		})
+		if items != nil {
+			return items
+		}
+		// the document has not been linted yet, fall back to the ruleset it will be linted with.
+		if runtimeConfig, err := s.runtimeConfigForDocument(doc.URI); err == nil {
+			ruleSet, _ = s.defaultRuleSetForDocument(runtimeConfig, []byte(content))
+		}
+		if ruleSet == nil {
+			return nil
+		}
+		return ruleIDCompletionItems(ruleSet, ctx)
+	}
+	return nil
+}
+
+func keyCompletionItems(schema *keySchema, ctx completionContext) []protocol.CompletionItem {
+	kind := protocol.CompletionItemKindProperty
+	var items []protocol.CompletionItem
+	for _, key := range schema.keysAt(ctx.path) {
+		if ctx.siblings[key.name] {
+			continue
+		}
+		sortText := "1" + key.name
+		if key.required {
+			sortText = "0" + key.name
+		}
+		newText := key.name + ": "
+		if ctx.json {
+			newText = strconv.Quote(key.name) + ": "
+		}
+		items = append(items, protocol.CompletionItem{
+			Label:    key.name,
+			Kind:     &kind,
+			SortText: &sortText,
+			TextEdit: protocol.TextEdit{Range: ctx.replace, NewText: newText},
+		})
+	}
+	return items
+}
+
+func referenceCompletionItems(references []string, ctx completionContext) []protocol.CompletionItem {
+	kind := protocol.CompletionItemKindReference
+	var items []protocol.CompletionItem
+	for _, reference := range references {
+		newText := reference
+		if ctx.json {
+			newText = strconv.Quote(reference)
+		} else if !ctx.quoted {
+			// a '#' after whitespace starts a comment, so a local reference has to be quoted.
+			newText = "'" + reference + "'"
+		}
+		filterText := reference
+		items = append(items, protocol.CompletionItem{
+			Label:      reference,
+			Kind:       &kind,
+			FilterText: &filterText,
+			TextEdit:   protocol.TextEdit{Range: ctx.replace, NewText: newText},
+		})
+	}
+	return items
+}
+
+func ruleIDCompletionItems(ruleSet *rulesets.RuleSet, ctx completionContext) []protocol.CompletionItem {
+	kind := protocol.CompletionItemKindValue
+	ids := make([]string, 0, len(ruleSet.Rules))
+	for id, rule := range ruleSet.Rules {
+		if rule != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	items := make([]protocol.CompletionItem, 0, len(ids))
+	for _, id := range ids {
+		rule := ruleSet.Rules[id]
+		newText := id
+		if ctx.json {
+			newText = strconv.Quote(id)
+		}
+		filterText := id
+		item := protocol.CompletionItem{
+			Label:      id,
+			Kind:       &kind,
+			FilterText: &filterText,
+			TextEdit:   protocol.TextEdit{Range: ctx.replace, NewText: newText},
+		}
+		if rule.Description != "" {
+			detail := rule.Description
+			item.Detail = &detail
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// componentReferences returns the local reference of every component in the root document of an index.
+func componentReferences(idx *index.SpecIndex) []string {
+	if idx == nil {
+		return nil
+	}
+	var references []string
+	forEachComponent(idx.GetRootNode(), func(reference string, _, _ *yaml.Node) {
+		references = append(references, reference)
+	})
+	sort.Strings(references)
+	return references
+}
+
+// completionContextAt works out what is being typed at a position, from the text of the document alone (as the
+// document is rarely valid while it is being edited).
+func completionContextAt(content string, position protocol.Position) (completionContext, bool) {
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return completionContext{}, false
+	}
+	line := strings.TrimSuffix(lines[position.Line], "\r")
+	cursor := utf16ToByteOffset(line, position.Character)
+	prefix := line[:cursor]
+
+	column := len(prefix) - len(strings.TrimLeft(prefix, " "))
+	rest := prefix[column:]
+	dash, seqItem := column, false
+	if strings.HasPrefix(rest, "- ") {
+		seqItem = true
+		trimmed := strings.TrimLeft(rest[1:], " ")
+		column += len(rest) - len(trimmed)
+		rest = trimmed
+	}
+
+	rangeFrom := func(start int) protocol.Range {
+		return protocol.Range{
+			Start: protocol.Position{Line: position.Line, Character: utf16Len(line[:start])},
+			End:   position,
+		}
+	}
+
+	if key, value, ok := parseKeyLine(rest); ok {
+		if strings.HasSuffix(rest, ":") {
+			return completionContext{}, false // nothing to complete until the value is started.
+		}
+		valueStart := column + len(rest) - len(value)
+		switch key {
+		case "$ref":
+			ctx := completionContext{kind: completeReference}
+			if strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`) {
+				ctx.quoted = true
+				valueStart++
+			}
+			ctx.replace = rangeFrom(valueStart)
+			return ctx, true
+		case inlineIgnoreKey:
+			// the rule being typed follows the start of the value, or the last separator of a flow sequence.
+			if i := strings.LastIndexAny(value, "[,"); i >= 0 {
+				valueStart += i + 1
+				valueStart += len(value[i+1:]) - len(strings.TrimLeft(value[i+1:], " "))
+			}
+			return completionContext{kind: completeRuleID, replace: rangeFrom(valueStart)}, true
+		}
+		return completionContext{}, false
+	}
+
+	if !partialKeyRegex.MatchString(rest) {
+		return completionContext{}, false
+	}
+
+	var path []string
+	if seqItem {
+		path = yamlParentPath(lines, int(position.Line), dash, true)
+		if len(path) >= 2 && path[len(path)-2] == inlineIgnoreKey {
+			return completionContext{kind: completeRuleID, replace: rangeFrom(column)}, true
+		}
+	} else {
+		path = yamlParentPath(lines, int(position.Line), column, false)
+	}
+	if path == nil {
+		return completionContext{}, false
+	}
+	return completionContext{
+		kind:     completeKey,
+		path:     path,
+		siblings: siblingKeys(lines, int(position.Line), column, !seqItem),
+		replace:  rangeFrom(column),
+	}, true
+}
+
+// yamlParentPath walks up from a line to find the keys (and sequences) that contain the given column, returning
+// them from the root down. Returns nil if the structure cannot be worked out.
+func yamlParentPath(lines []string, line, column int, inSequence bool) []string {
+	path := []string{}
+	if inSequence {
+		path = append(path, sequenceItem)
+	}
+	for i := line - 1; i >= 0 && (column > 0 || inSequence); i-- {
+		text := strings.TrimRight(lines[i], " \r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(text) - len(trimmed)
+
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			if indent >= column {
+				continue // another item of the same sequence, or something nested deeper.
+			}
+			itemContent := strings.TrimLeft(trimmed[1:], " ")
+			contentColumn := indent + len(trimmed) - len(itemContent)
+			if column > contentColumn {
+				key, value, ok := parseKeyLine(itemContent)
+				if !ok || (value != "" && !strings.HasPrefix(value, "#")) {
+					return nil
+				}
+				path = append([]string{key}, path...)
+			}
+			path = append([]string{sequenceItem}, path...)
+			column, inSequence = indent, true
+			continue
+		}
+
+		if indent < column || (inSequence && indent == column) {
+			key, _, ok := parseKeyLine(trimmed)
+			if !ok {
+				return nil
+			}
+			path = append([]string{key}, path...)
+			column, inSequence = indent, false
+		}
+	}
+	return path
+}
+
+// siblingKeys collects the keys at a column that belong to the same mapping as the given line.
+func siblingKeys(lines []string, line, column int, searchUp bool) map[string]bool {
+	siblings := make(map[string]bool)
+	scan := func(from, step int) {
+		for i := from; i >= 0 && i < len(lines); i += step {
+			text := strings.TrimRight(lines[i], " \r")
+			trimmed := strings.TrimLeft(text, " ")
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			indent := len(text) - len(trimmed)
+			isItem := trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+			switch {
+			case indent > column, isItem && indent == column:
+				continue // nested content, or a compact sequence belonging to a sibling.
+			case indent < column:
+				// scanning up, the first key of a sequence item shares the mapping.
+				if isItem && step < 0 {
+					itemContent := strings.TrimLeft(trimmed[1:], " ")
+					if indent+len(trimmed)-len(itemContent) == column {
+						if key, _, ok := parseKeyLine(itemContent); ok {
+							siblings[key] = true
+						}
+					}
+				}
+				return
+			}
+			if key, _, ok := parseKeyLine(trimmed); ok {
+				siblings[key] = true
+			}
+		}
+	}
+	if searchUp {
+		scan(line-1, -1)
+	}
+	scan(line+1, 1)
+	return siblings
+}
+
+// parseKeyLine splits a trimmed line of block YAML into an (unquoted) key and its raw value.
+func parseKeyLine(text string) (string, string, bool) {
+	match := keyLineRegex.FindStringSubmatch(text)
+	if match == nil {
+		return "", "", false
+	}
+	key, value := match[1], match[2]
+	switch {
+	case strings.HasPrefix(key, `"`):
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			key = unquoted
+		}
+	case strings.HasPrefix(key, "'"):
+		key = strings.ReplaceAll(key[1:len(key)-1], "''", "'")
+	}
+	return key, value, true
+}
+
+// utf16ToByteOffset converts a UTF-16 character offset in a line into a byte offset.
+func utf16ToByteOffset(line string, character protocol.UInteger) int {
+	units := 0
+	for i, r := range line {
+		if units >= int(character) {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(line)
+}
+
+// keySchemaForDocument returns the structural schema of the specification a document is written in, based on
+// the root key that identifies it (and its version).
+func keySchemaForDocument(content string, jsonDocument bool) *keySchema {
+	var name string
+	if jsonDocument {
+		for key, value := range jsonRootScalars(content) {
+			if name = specSchemaName(key, value); name != "" {
+				break
+			}
+		}
+	} else {
+		for _, line := range strings.Split(content, "\n") {
+			if line == "" || line[0] == ' ' || line[0] == '#' {
+				continue
+			}
+			key, value, ok := parseKeyLine(strings.TrimRight(line, " \r"))
+			if !ok {
+				continue
+			}
+			if name = specSchemaName(key, yamlScalarValue(value)); name != "" {
+				break
+			}
+		}
+	}
+	source, found := specSchemas[name]
+	if !found {
+		return nil
+	}
+	keySchemasLock.Lock()
+	defer keySchemasLock.Unlock()
+	if schema, loaded := keySchemas[name]; loaded {
+		return schema
+	}
+	schema := newKeySchema(*source)
+	keySchemas[name] = schema
+	return schema
+}
+
+// specSchemaName returns the name of the structural schema for a root key and its value, AsyncAPI 2 and 3 documents
+// have different structures. Returns an empty string if the key does not identify a specification.
+func specSchemaName(key, version string) string {
+	switch key {
+	case "openapi", "swagger":
+		return key
+	case "asyncapi":
+		if strings.HasPrefix(version, "2.") {
+			return "asyncapi-2"
+		}
+		return "asyncapi-3"
+	}
+	return ""
+}
+
+// yamlScalarValue returns the value of a plain, single or double-quoted YAML scalar, without a trailing comment.
+func yamlScalarValue(value string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, `"`):
+		if end := strings.Index(value[1:], `"`); end >= 0 {
+			return value[1 : end+1]
+		}
+	case strings.HasPrefix(value, "'"):
+		if end := strings.Index(value[1:], "'"); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// keySchema answers which keys a JSON schema allows at a path through a document. Only the structural keywords
+// are understood: properties, patternProperties, additionalProperties, items, local $refs and the composition
+// keywords (which are treated as a union).
+type keySchema struct {
+	root     map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+type schemaKey struct {
+	name     string
+	required bool
+}
+
+func newKeySchema(source string) *keySchema {
+	schema := &keySchema{patterns: make(map[string]*regexp.Regexp)}
+	if err := yaml.Unmarshal([]byte(source), &schema.root); err != nil || schema.root == nil {
+		schema.root = map[string]any{}
+	}
+	var compile func(value any)
+	compile = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			if patternProperties, ok := v["patternProperties"].(map[string]any); ok {
+				for pattern := range patternProperties {
+					if re, err := regexp.Compile(pattern); err == nil {
+						schema.patterns[pattern] = re
+					}
+				}
+			}
+			for _, child := range v {
+				compile(child)
+			}
+		case []any:
+			for _, child := range v {
+				compile(child)
+			}
+		}
+	}
+	compile(schema.root)
+	return schema
+}
+
+// keysAt returns the sorted keys defined for the object at a path.
+func (k *keySchema) keysAt(path []string) []schemaKey {
+	schemas := k.expand(k.root, map[string]bool{})
+	for _, segment := range path {
+		var next []map[string]any
+		for _, schema := range schemas {
+			for _, child := range k.children(schema, segment) {
+				next = append(next, k.expand(child, map[string]bool{})...)
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		schemas = next
+	}
+
+	found := make(map[string]bool)
+	for _, schema := range schemas {
+		properties, _ := schema["properties"].(map[string]any)
+		required := make(map[string]bool)
+		if list, ok := schema["required"].([]any); ok {
+			for _, name := range list {
+				if s, ok := name.(string); ok {
+					required[s] = true
+				}
+			}
+		}
+		for name := range properties {
+			found[name] = found[name] || required[name]
+		}
+	}
+	keys := make([]schemaKey, 0, len(found))
+	for name, required := range found {
+		keys = append(keys, schemaKey{name: name, required: required})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+	return keys
+}
+
+// children returns the schemas that apply to the value of a key (or the items of a sequence) in an object.
+func (k *keySchema) children(schema map[string]any, segment string) []map[string]any {
+	if segment == sequenceItem {
+		if items, ok := schema["items"].(map[string]any); ok {
+			return []map[string]any{items}
+		}
+		return nil
+	}
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		if child, ok := properties[segment].(map[string]any); ok {
+			return []map[string]any{child}
+		}
+	}
+	var children []map[string]any
+	if patternProperties, ok := schema["patternProperties"].(map[string]any); ok {
+		for pattern, child := range patternProperties {
+			if re := k.patterns[pattern]; re != nil && re.MatchString(segment) {
+				if c, ok := child.(map[string]any); ok {
+					children = append(children, c)
+				}
+			}
+		}
+	}
+	if len(children) == 0 {
+		if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+			children = append(children, additional)
+		}
+	}
+	return children
+}
+
+// expand follows local references and flattens composition keywords, returning every schema that could
+// describe the same value.
+func (k *keySchema) expand(schema map[string]any, seen map[string]bool) []map[string]any {
+	if ref, ok := schema["$ref"].(string); ok {
+		if seen[ref] {
+			return nil
+		}
+		seen[ref] = true
+		if target := k.resolve(ref); target != nil {
+			return k.expand(target, seen)
+		}
+		return nil
+	}
+
+	expanded := []map[string]any{schema}
+	var branches []any
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if list, ok := schema[keyword].([]any); ok {
+			branches = append(branches, list...)
+		}
+	}
+	if cases, ok := schema["switch"].([]any); ok {
+		for _, c := range cases {
+			if m, ok := c.(map[string]any); ok {
+				branches = append(branches, m["then"])
+			}
+		}
+	}
+	branches = append(branches, schema["then"], schema["else"])
+	for _, branch := range branches {
+		if m, ok := branch.(map[string]any); ok {
+			expanded = append(expanded, k.expand(m, seen)...)
+		}
+	}
+	return expanded
+}
+
+// resolve looks up a local JSON pointer reference, remote references are not followed.
+func (k *keySchema) resolve(ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	var current any = k.root
+	for _, segment := range strings.Split(ref[2:], "/") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[unescape.Replace(segment)]
+	}
+	target, _ := current.(map[string]any)
+	return target
+}
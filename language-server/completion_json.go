@@ -0,0 +1,232 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"strconv"
+	"strings"
+
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// jsonFrame is an object or array that is open at the cursor of a JSON document.
+type jsonFrame struct {
+	object    bool
+	key       string          // the key of the member being written, in an object.
+	expectKey bool            // the next string in the object is a key.
+	keys      map[string]bool // the keys of the object before the cursor.
+}
+
+// isJSONDocument returns true if a document is written in JSON rather than YAML.
+func isJSONDocument(content string) bool {
+	return strings.HasPrefix(strings.TrimSpace(content), "{")
+}
+
+// jsonCompletionContextAt works out what is being typed at a position in a JSON document, from the text of the
+// document alone. Keys are completed inside objects, component paths inside the string value of a $ref, and rule
+// IDs inside the string (or array of strings) value of an x-lint-ignore directive.
+func jsonCompletionContextAt(content string, position protocol.Position) (completionContext, bool) {
+	lines := strings.Split(content, "\n")
+	if int(position.Line) >= len(lines) {
+		return completionContext{}, false
+	}
+	line := strings.TrimSuffix(lines[position.Line], "\r")
+	lineStart := 0
+	for _, previous := range lines[:position.Line] {
+		lineStart += len(previous) + 1
+	}
+	cursor := lineStart + utf16ToByteOffset(line, position.Character)
+
+	var stack []*jsonFrame
+	stringStart := -1
+	for i := 0; i < cursor; i++ {
+		switch content[i] {
+		case '"':
+			value, end, closed := scanJSONString(content, i, cursor)
+			if !closed {
+				stringStart = i
+				i = cursor
+				continue
+			}
+			if len(stack) > 0 {
+				if top := stack[len(stack)-1]; top.object && top.expectKey {
+					top.key, top.expectKey = value, false
+					top.keys[value] = true
+				}
+			}
+			i = end - 1
+		case '{':
+			stack = append(stack, &jsonFrame{object: true, expectKey: true, keys: make(map[string]bool)})
+		case '[':
+			stack = append(stack, &jsonFrame{})
+		case '}', ']':
+			if len(stack) == 0 {
+				return completionContext{}, false
+			}
+			stack = stack[:len(stack)-1]
+		case ',':
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+				stack[len(stack)-1].key = ""
+			}
+		}
+	}
+	if len(stack) == 0 {
+		return completionContext{}, false
+	}
+	top := stack[len(stack)-1]
+
+	path := []string{}
+	for _, frame := range stack[:len(stack)-1] {
+		if frame.object {
+			path = append(path, frame.key)
+		} else {
+			path = append(path, sequenceItem)
+		}
+	}
+
+	rangeFrom := func(start int) protocol.Range {
+		return protocol.Range{
+			Start: protocol.Position{Line: position.Line, Character: utf16Len(line[:start-lineStart])},
+			End:   position,
+		}
+	}
+
+	// the value of an x-lint-ignore directive is a rule ID, or an array of them.
+	ignoreValue := (top.object && !top.expectKey && top.key == inlineIgnoreKey) ||
+		(!top.object && len(stack) > 1 && stack[len(stack)-2].key == inlineIgnoreKey)
+
+	if stringStart >= 0 {
+		if stringStart < lineStart || (!top.object && !ignoreValue) {
+			return completionContext{}, false // strings never span lines.
+		}
+		ctx := completionContext{kind: completeKey, path: path, replace: rangeFrom(stringStart), json: true}
+		switch {
+		case ignoreValue:
+			ctx.kind, ctx.quoted = completeRuleID, true
+		case !top.expectKey:
+			if top.key != "$ref" {
+				return completionContext{}, false
+			}
+			ctx.kind, ctx.quoted = completeReference, true
+		}
+		// the string is replaced as a whole, including the closing quote an editor adds along with the opening quote.
+		after := cursor
+		if _, end, closed := scanJSONString(content, stringStart, len(content)); closed {
+			after = end
+			ctx.replace.End.Character = utf16Len(line[:end-lineStart])
+		}
+		if ctx.kind == completeKey {
+			ctx.siblings = jsonSiblingKeys(content, after, top.keys)
+		}
+		return ctx, true
+	}
+
+	if ignoreValue {
+		// a rule ID is started, the quotes are written along with it.
+		return completionContext{kind: completeRuleID, replace: rangeFrom(cursor), json: true}, true
+	}
+	if !top.object || !top.expectKey {
+		return completionContext{}, false
+	}
+	// a key that is still being typed, without its opening quote.
+	start := cursor
+	for start > lineStart && partialKeyRegex.MatchString(content[start-1:cursor]) {
+		start--
+	}
+	return completionContext{
+		kind:     completeKey,
+		path:     path,
+		siblings: jsonSiblingKeys(content, cursor, top.keys),
+		replace:  rangeFrom(start),
+		json:     true,
+	}, true
+}
+
+// jsonSiblingKeys adds the keys that follow an offset in the same object to the keys before it.
+func jsonSiblingKeys(content string, from int, before map[string]bool) map[string]bool {
+	siblings := make(map[string]bool, len(before))
+	for key := range before {
+		siblings[key] = true
+	}
+	depth := 0
+	for i := from; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			value, end, closed := scanJSONString(content, i, len(content))
+			if !closed {
+				return siblings
+			}
+			if depth == 0 && strings.HasPrefix(strings.TrimLeft(content[end:], " \t\r\n"), ":") {
+				siblings[value] = true
+			}
+			i = end - 1
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return siblings
+			}
+			depth--
+		}
+	}
+	return siblings
+}
+
+// jsonRootScalars returns the string values of the members of the root object of a JSON document, such as
+// the 'openapi' or 'asyncapi' version. The document does not need to be valid.
+func jsonRootScalars(content string) map[string]string {
+	scalars := make(map[string]string)
+	depth := 0
+	key := ""
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			value, end, closed := scanJSONString(content, i, len(content))
+			if !closed {
+				return scalars
+			}
+			rest := strings.TrimLeft(content[end:], " \t\r\n")
+			if depth == 1 {
+				if strings.HasPrefix(rest, ":") {
+					key = value
+				} else if key != "" {
+					scalars[key] = value
+					key = ""
+				}
+			}
+			i = end - 1
+		case '{', '[':
+			depth++
+			key = ""
+		case '}', ']':
+			depth--
+		}
+	}
+	return scalars
+}
+
+// scanJSONString reads the JSON string that opens at start, stopping at limit. Returns the unquoted value, the
+// offset after the closing quote and true, or false if the string is not closed before the limit.
+func scanJSONString(content string, start, limit int) (string, int, bool) {
+	if start < 0 || start >= limit || content[start] != '"' {
+		return "", start, false
+	}
+	for i := start + 1; i < limit; i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '\n':
+			return "", i, false
+		case '"':
+			raw := content[start : i+1]
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				value = raw[1 : len(raw)-1]
+			}
+			return value, i + 1, true
+		}
+	}
+	return "", limit, false
+}
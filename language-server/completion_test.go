@@ -0,0 +1,331 @@
+package languageserver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+const completionTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+tags:
+  - name: pizza
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+components:
+  schemas:
+    Pizza:
+      type: object
+    Topping/Extra:
+      type: string
+  parameters:
+    Size:
+      name: size
+      in: query
+`
+
+// splitCursor removes the '|' marking the cursor from a document, returning the document and the cursor position.
+func splitCursor(t *testing.T, marked string) (string, protocol.Position) {
+	t.Helper()
+	offset := strings.Index(marked, "|")
+	require.GreaterOrEqual(t, offset, 0)
+	before := marked[:offset]
+	line := strings.Count(before, "\n")
+	character := len(before) - strings.LastIndex(before, "\n") - 1
+	return before + marked[offset+1:], protocol.Position{Line: protocol.UInteger(line), Character: protocol.UInteger(character)}
+}
+
+func completionAt(state *ServerState, uri string, position protocol.Position) []protocol.CompletionItem {
+	return state.completion(&protocol.CompletionParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     position,
+		},
+	})
+}
+
+func completionLabels(items []protocol.CompletionItem) []string {
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	return labels
+}
+
+func TestServerState_Completion_Keys(t *testing.T) {
+	tests := []struct {
+		name     string
+		marked   string
+		contains []string
+		missing  []string
+	}{
+		{
+			name:     "root",
+			marked:   "openapi: 3.0.0\ninfo:\n  title: pizza\n|",
+			contains: []string{"paths", "components", "servers"},
+			missing:  []string{"openapi", "info"},
+		},
+		{
+			name:     "object",
+			marked:   "openapi: 3.0.0\ninfo:\n  title: pizza\n  |\npaths: {}\n",
+			contains: []string{"version", "description", "license"},
+			missing:  []string{"title", "paths"},
+		},
+		{
+			name:     "pattern properties and references",
+			marked:   "openapi: 3.0.0\npaths:\n  /pizza:\n    get:\n      responses:\n        '200':\n          |\n",
+			contains: []string{"description", "content", "headers", "$ref"},
+		},
+		{
+			name:     "sequence item",
+			marked:   "openapi: 3.0.0\ntags:\n  - name: pizza\n    |\n",
+			contains: []string{"description", "externalDocs"},
+			missing:  []string{"name"},
+		},
+		{
+			name:     "compact sequence item",
+			marked:   "openapi: 3.0.0\ntags:\n- desc|\n",
+			contains: []string{"name", "description"},
+		},
+		{
+			name:     "swagger",
+			marked:   "swagger: '2.0'\ninfo:\n  |\n",
+			contains: []string{"title", "version"},
+		},
+		{
+			name:     "asyncapi",
+			marked:   "asyncapi: 3.0.0\nchannels:\n  pizza:\n    |\n",
+			contains: []string{"address", "messages", "parameters"},
+			missing:  []string{"subscribe", "publish"},
+		},
+		{
+			name:     "asyncapi 3.1",
+			marked:   "asyncapi: '3.1.0'\nchannels:\n  pizza:\n    |\n",
+			contains: []string{"address", "messages"},
+		},
+		{
+			name:     "asyncapi 2",
+			marked:   "asyncapi: 2.6.0\nchannels:\n  pizza:\n    |\n",
+			contains: []string{"subscribe", "publish", "parameters"},
+			missing:  []string{"address", "messages"},
+		},
+		{
+			name:     "json root",
+			marked:   "{\n  \"openapi\": \"3.0.0\",\n  |\n}",
+			contains: []string{"paths", "components", "servers"},
+			missing:  []string{"openapi"},
+		},
+		{
+			name:     "json object",
+			marked:   `{"openapi": "3.0.0", "info": {"title": "pizza", "|"}, "paths": {}}`,
+			contains: []string{"version", "description"},
+			missing:  []string{"title", "paths"},
+		},
+		{
+			name:     "json array item",
+			marked:   `{"openapi": "3.0.0", "tags": [{"name": "pizza", |}]}`,
+			contains: []string{"description", "externalDocs"},
+			missing:  []string{"name"},
+		},
+		{
+			name:     "json asyncapi 2",
+			marked:   `{"asyncapi": "2.6.0", "channels": {"pizza": {"|}}}`,
+			contains: []string{"subscribe", "publish"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, position := splitCursor(t, tt.marked)
+			state := &ServerState{documentStore: newDocumentStore()}
+			doc := state.documentStore.Add("file:///tmp/spec.yaml", content)
+
+			labels := completionLabels(completionAt(state, doc.URI, position))
+			for _, expected := range tt.contains {
+				assert.Contains(t, labels, expected)
+			}
+			for _, unexpected := range tt.missing {
+				assert.NotContains(t, labels, unexpected)
+			}
+		})
+	}
+}
+
+func TestServerState_Completion_KeyEdit(t *testing.T) {
+	content, position := splitCursor(t, "openapi: 3.0.0\ninfo:\n  ver|\n")
+	state := &ServerState{documentStore: newDocumentStore()}
+	doc := state.documentStore.Add("file:///tmp/spec.yaml", content)
+
+	for _, item := range completionAt(state, doc.URI, position) {
+		if item.Label == "version" {
+			edit := item.TextEdit.(protocol.TextEdit)
+			start, end := edit.Range.IndexesIn(content)
+			assert.Equal(t, "openapi: 3.0.0\ninfo:\n  version: \n", content[:start]+edit.NewText+content[end:])
+			require.NotNil(t, item.SortText)
+			assert.True(t, strings.HasPrefix(*item.SortText, "0"))
+			return
+		}
+	}
+	t.Fatal("version was not suggested")
+}
+
+func TestServerState_Completion_JSONKeyEdit(t *testing.T) {
+	content, position := splitCursor(t, `{"openapi": "3.0.0", "info": {"ver|"}}`)
+	state := &ServerState{documentStore: newDocumentStore()}
+	doc := state.documentStore.Add("file:///tmp/spec.json", content)
+
+	for _, item := range completionAt(state, doc.URI, position) {
+		if item.Label == "version" {
+			edit := item.TextEdit.(protocol.TextEdit)
+			start, end := edit.Range.IndexesIn(content)
+			assert.Equal(t, `{"openapi": "3.0.0", "info": {"version": }}`, content[:start]+edit.NewText+content[end:])
+			return
+		}
+	}
+	t.Fatal("version was not suggested")
+}
+
+func TestServerState_Completion_NoSuggestions(t *testing.T) {
+	state := &ServerState{documentStore: newDocumentStore()}
+	for _, marked := range []string{
+		`{"openapi": "3.0.0", "info": {"title": "piz|"}}`,
+		`{"openapi": "3.0.0", "tags": [|]}`,
+		"openapi: 3.0.0\ninfo:\n  title: piz|\n",
+		"openapi: 3.0.0\ninfo:\n  title:|\n",
+		"openapi: 3.0.0\ninfo:\n  # a comment|\n",
+	} {
+		content, position := splitCursor(t, marked)
+		doc := state.documentStore.Add("file:///tmp/spec.yaml", content)
+		assert.Empty(t, completionAt(state, doc.URI, position), marked)
+	}
+}
+
+func TestServerState_Completion_References(t *testing.T) {
+	state, doc := lintDocumentForTest(t, completionTestSpec)
+
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{name: "unquoted", line: "                $ref: #/comp", expected: "                $ref: '#/components/schemas/Pizza'"},
+		{name: "quoted", line: "                $ref: \"#/comp", expected: "                $ref: \"#/components/schemas/Pizza"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(completionTestSpec, "\n")
+			lines[15] = tt.line
+			content := strings.Join(lines, "\n")
+			doc.mu.Lock()
+			doc.Content = content
+			doc.mu.Unlock()
+
+			items := completionAt(state, doc.URI, protocol.Position{Line: 15, Character: protocol.UInteger(len(tt.line))})
+			assert.Equal(t, []string{
+				"#/components/parameters/Size",
+				"#/components/schemas/Pizza",
+				"#/components/schemas/Topping~1Extra",
+			}, completionLabels(items))
+
+			edit := items[1].TextEdit.(protocol.TextEdit)
+			start, end := edit.Range.IndexesIn(content)
+			assert.Equal(t, tt.expected, strings.Split(content[:start]+edit.NewText+content[end:], "\n")[15])
+		})
+	}
+}
+
+func TestServerState_Completion_RuleIDs(t *testing.T) {
+	ruleSet := buildCodeActionRuleSet(rulesets.OperationOperationId, rulesets.InfoContact)
+
+	for _, marked := range []string{
+		"openapi: 3.1.0\ninfo:\n  title: pizza\n  x-lint-ignore: |\n",
+		"openapi: 3.1.0\ninfo:\n  title: pizza\n  x-lint-ignore: [info-contact, op|]\n",
+		"openapi: 3.1.0\ninfo:\n  title: pizza\n  x-lint-ignore:\n    - info-contact\n    - |\n",
+	} {
+		content, position := splitCursor(t, marked)
+		state, doc := lintDocumentWithRuleSetForTest(t, content, ruleSet)
+
+		items := completionAt(state, doc.URI, position)
+		assert.Equal(t, []string{rulesets.InfoContact, rulesets.OperationOperationId}, completionLabels(items), marked)
+		require.NotEmpty(t, items)
+		assert.NotNil(t, items[0].Detail)
+	}
+}
+
+func TestServerState_Completion_JSONRuleIDs(t *testing.T) {
+	ruleSet := buildCodeActionRuleSet(rulesets.OperationOperationId, rulesets.InfoContact)
+
+	tests := []struct {
+		marked   string
+		expected string
+	}{
+		{
+			marked:   `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": "op|"}}`,
+			expected: `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": "operation-operationId"}}`,
+		},
+		{
+			marked:   `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": ["info-contact", "op|]}}`,
+			expected: `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": ["info-contact", "operation-operationId"]}}`,
+		},
+		{
+			marked:   `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": ["info-contact", |]}}`,
+			expected: `{"openapi": "3.1.0", "info": {"title": "pizza", "x-lint-ignore": ["info-contact", "operation-operationId"]}}`,
+		},
+	}
+	for _, tt := range tests {
+		content, position := splitCursor(t, tt.marked)
+		state, doc := lintDocumentAtURIForTest(t, "file:///tmp/spec.json", content, ruleSet)
+
+		items := completionAt(state, doc.URI, position)
+		require.Equal(t, []string{rulesets.InfoContact, rulesets.OperationOperationId}, completionLabels(items), tt.marked)
+		edit := items[1].TextEdit.(protocol.TextEdit)
+		start, end := edit.Range.IndexesIn(content)
+		assert.Equal(t, tt.expected, content[:start]+edit.NewText+content[end:])
+	}
+}
+
+func TestYamlParentPath(t *testing.T) {
+	lines := strings.Split(`paths:
+  /pizza:
+    get:
+      parameters:
+      - name: size
+        schema:
+          type: string
+
+      # a comment
+      - in: query
+`, "\n")
+
+	assert.Equal(t, []string{"paths", "/pizza", "get"}, yamlParentPath(lines, 3, 6, false))
+	assert.Equal(t, []string{"paths", "/pizza", "get", "parameters", sequenceItem}, yamlParentPath(lines, 5, 8, false))
+	assert.Equal(t, []string{"paths", "/pizza", "get", "parameters", sequenceItem, "schema"}, yamlParentPath(lines, 6, 10, false))
+	assert.Equal(t, []string{"paths", "/pizza", "get", "parameters", sequenceItem}, yamlParentPath(lines, 9, 6, true))
+	assert.Empty(t, yamlParentPath(lines, 1, 0, false))
+}
+
+func TestJsonCompletionContextAt_Reference(t *testing.T) {
+	content, position := splitCursor(t, `{"paths": {"/pizza": {"$ref": "#/comp|"}}}`)
+	ctx, ok := jsonCompletionContextAt(content, position)
+	require.True(t, ok)
+	assert.Equal(t, completeReference, ctx.kind)
+	assert.True(t, ctx.json)
+
+	// the whole string is replaced, so the reference is written with its own quotes.
+	start, end := ctx.replace.IndexesIn(content)
+	assert.Equal(t, `"#/comp"`, content[start:end])
+}
@@ -16,7 +16,9 @@ package languageserver
 
 import (
 	"sync"
+	"sync/atomic"
 
+	"github.com/daveshanley/vacuum/motor"
 	protocol "github.com/tliron/glsp/protocol_3_16"
 )
 
@@ -29,6 +31,14 @@ type Document struct {
 	RunningDiagnostic bool
 	Content           string
 	mu                sync.RWMutex // Protects Content field
+
+	// The result of the most recent lint is retained (along with its index) so hover and navigation
+	// requests can use it, it's released when replaced by a newer lint, or when the document is closed.
+	lintResult     *motor.RuleSetExecutionResult
+	lintGeneration uint64
+	lintRuns       atomic.Uint64
+	closed         bool
+	lintMu         sync.RWMutex // Protects lintResult, lintGeneration and closed
 }
 
 func newDocumentStore() *DocumentStore {
@@ -60,5 +70,51 @@ func (s *DocumentStore) Remove(uri string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	doc, ok := s.documents[uri]
 	delete(s.documents, uri)
+	if ok {
+		doc.close()
+	}
+}
+
+// nextLintGeneration returns the generation of a new lint run, used to discard results of runs that
+// finish after a newer run.
+func (d *Document) nextLintGeneration() uint64 {
+	return d.lintRuns.Add(1)
+}
+
+// setLintResult retains the result of a lint run, releasing the previous result. Results from runs
+// older than the retained result, or for a closed document, are released immediately.
+func (d *Document) setLintResult(generation uint64, result *motor.RuleSetExecutionResult) {
+	d.lintMu.Lock()
+	defer d.lintMu.Unlock()
+
+	if d.closed || generation < d.lintGeneration {
+		result.ReleaseOwnedResources()
+		return
+	}
+	if d.lintResult != nil && d.lintResult != result {
+		d.lintResult.ReleaseOwnedResources()
+	}
+	d.lintResult = result
+	d.lintGeneration = generation
+}
+
+// readLintResult calls fn with the result of the most recent lint, which is nil if no lint has completed.
+// The result (and anything it holds) must not be used after fn returns.
+func (d *Document) readLintResult(fn func(result *motor.RuleSetExecutionResult)) {
+	d.lintMu.RLock()
+	defer d.lintMu.RUnlock()
+	fn(d.lintResult)
+}
+
+func (d *Document) close() {
+	d.lintMu.Lock()
+	defer d.lintMu.Unlock()
+
+	d.closed = true
+	if d.lintResult != nil {
+		d.lintResult.ReleaseOwnedResources()
+		d.lintResult = nil
+	}
 }
@@ -0,0 +1,306 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	hoverPreviewMaxLines = 20
+	hoverPreviewMaxChars = 1500
+)
+
+// hover returns the rule documentation of every diagnostic under the cursor, and a summary of the
+// resolved target when the cursor is over a $ref. Returns nil when there is nothing to show, or when
+// the document has not been linted yet.
+func (s *ServerState) hover(params *protocol.HoverParams) *protocol.Hover {
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+
+	var hover *protocol.Hover
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		if result == nil {
+			return
+		}
+
+		var sections []string
+		var hoverRange *protocol.Range
+
+		if ruleSections, rng := buildRuleHoverSections(result.Results, params.Position); len(ruleSections) > 0 {
+			sections = append(sections, ruleSections...)
+			hoverRange = rng
+		}
+		if result.RuleSetExecution != nil {
+			if refSection, rng := buildReferenceHoverSection(result.RuleSetExecution, params.Position); refSection != "" {
+				sections = append(sections, refSection)
+				hoverRange = rng
+			}
+		}
+		if len(sections) == 0 {
+			return
+		}
+
+		hover = &protocol.Hover{
+			Contents: protocol.MarkupContent{
+				Kind:  protocol.MarkupKindMarkdown,
+				Value: strings.Join(sections, "\n\n---\n\n"),
+			},
+			Range: hoverRange,
+		}
+	})
+	return hover
+}
+
+// buildRuleHoverSections renders the rule behind each result whose diagnostic range contains the position.
+// A rule is only rendered once, even if it reported multiple violations at the same location.
+func buildRuleHoverSections(results []model.RuleFunctionResult, position protocol.Position) ([]string, *protocol.Range) {
+	var sections []string
+	var hoverRange *protocol.Range
+	seen := make(map[string]bool)
+
+	for i := range results {
+		result := &results[i]
+		diagnostic := ConvertResultIntoDiagnostic(result)
+		if !diagnosticRangeContains(diagnostic.Range, position) {
+			continue
+		}
+		ruleID := fmt.Sprint(diagnostic.Code.Value)
+		if seen[ruleID] {
+			continue
+		}
+		seen[ruleID] = true
+
+		if hoverRange == nil {
+			rng := diagnostic.Range
+			hoverRange = &rng
+		}
+		sections = append(sections, renderRuleHover(result, ruleID, diagnostic.CodeDescription.HRef))
+	}
+	return sections, hoverRange
+}
+
+func renderRuleHover(result *model.RuleFunctionResult, ruleID, href string) string {
+	var sb strings.Builder
+	rule := result.Rule
+
+	header := []string{fmt.Sprintf("**%s**", ruleID)}
+	if rule != nil && rule.Severity != "" {
+		header = append(header, rule.Severity)
+	}
+	if rule != nil && rule.RuleCategory != nil {
+		header = append(header, rule.RuleCategory.Name)
+	}
+	sb.WriteString(strings.Join(header, " · "))
+	sb.WriteString("\n\n")
+	sb.WriteString(result.Message)
+
+	if rule != nil && rule.Description != "" && rule.Description != result.Message {
+		sb.WriteString("\n\n")
+		sb.WriteString(rule.Description)
+	}
+	if rule != nil && rule.HowToFix != "" {
+		sb.WriteString("\n\n**How to fix:** ")
+		sb.WriteString(rule.HowToFix)
+	}
+	if rule != nil && rule.RuleCategory != nil && rule.RuleCategory.Description != "" {
+		sb.WriteString(fmt.Sprintf("\n\n_%s: %s_", rule.RuleCategory.Name, rule.RuleCategory.Description))
+	}
+	if href != "" {
+		sb.WriteString(fmt.Sprintf("\n\n[Documentation](%s)", href))
+	}
+	return sb.String()
+}
+
+// diagnosticRangeContains checks if a position is inside a diagnostic range. Results often start and end
+// on the same node, which creates an empty range, so those match the entire starting line.
+func diagnosticRangeContains(rng protocol.Range, position protocol.Position) bool {
+	start, end := rng.Start, rng.End
+	if end.Line < start.Line || (end.Line == start.Line && end.Character <= start.Character) {
+		return position.Line == start.Line
+	}
+	if position.Line < start.Line || position.Line > end.Line {
+		return false
+	}
+	if position.Line == start.Line && position.Character < start.Character {
+		return false
+	}
+	if position.Line == end.Line && position.Character > end.Character {
+		return false
+	}
+	return true
+}
+
+// buildReferenceHoverSection renders a summary of the component a $ref under the cursor points to,
+// looked up using the unresolved index, which keeps the original references intact.
+func buildReferenceHoverSection(execution *motor.RuleSetExecution, position protocol.Position) (string, *protocol.Range) {
+	if execution.IndexUnresolved == nil {
+		return "", nil
+	}
+	root := execution.CanonicalDocument
+	if root == nil {
+		root = execution.IndexUnresolved.GetRootNode()
+	}
+
+	refNode := findReferenceAtPosition(root, position)
+	if refNode == nil {
+		return "", nil
+	}
+	rng := scalarRange(refNode)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**$ref** `%s`", refNode.Value))
+
+	ref, foundIndex := execution.IndexUnresolved.SearchIndexForReference(refNode.Value)
+	if ref == nil || ref.Node == nil {
+		sb.WriteString("\n\nReference cannot be resolved.")
+		return sb.String(), &rng
+	}
+
+	line := ref.Node.Line
+	if ref.KeyNode != nil {
+		line = ref.KeyNode.Line
+	}
+	if foundIndex != nil && foundIndex.GetSpecAbsolutePath() != "" &&
+		foundIndex.GetSpecAbsolutePath() != execution.IndexUnresolved.GetSpecAbsolutePath() {
+		sb.WriteString(fmt.Sprintf("\n\nDefined in `%s` on line %d", filepath.Base(foundIndex.GetSpecAbsolutePath()), line))
+	} else {
+		sb.WriteString(fmt.Sprintf("\n\nDefined on line %d", line))
+	}
+	if ref.Circular {
+		sb.WriteString(" (circular)")
+	}
+
+	for _, key := range []string{"title", "summary", "description"} {
+		if value := mapScalarValue(ref.Node, key); value != "" {
+			sb.WriteString("\n\n")
+			sb.WriteString(value)
+			break
+		}
+	}
+
+	if preview := renderNodePreview(ref.Node); preview != "" {
+		sb.WriteString("\n\n```yaml\n")
+		sb.WriteString(preview)
+		sb.WriteString("\n```")
+	}
+	return sb.String(), &rng
+}
+
+// findReferenceAtPosition walks the document looking for a $ref value under the position, the $ref
+// key itself also counts. Returns the value node.
+func findReferenceAtPosition(node *yaml.Node, position protocol.Position) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if found := findReferenceAtPosition(child, position); found != nil {
+				return found
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode && key.Line-1 == int(position.Line) {
+				end := scalarRange(value).End
+				if int(position.Character) >= key.Column-1 && position.Character <= end.Character {
+					return value
+				}
+			}
+			if found := findReferenceAtPosition(value, position); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// scalarRange returns the range of a scalar node, including quotes.
+func scalarRange(node *yaml.Node) protocol.Range {
+	length := len(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		length += 2
+	}
+	line := protocol.UInteger(node.Line - 1)
+	start := protocol.UInteger(node.Column - 1)
+	return protocol.Range{
+		Start: protocol.Position{Line: line, Character: start},
+		End:   protocol.Position{Line: line, Character: start + protocol.UInteger(length)},
+	}
+}
+
+func mapScalarValue(node *yaml.Node, key string) string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// renderNodePreview renders a node as block style YAML (JSON documents are flow style), truncated so
+// large components don't flood the hover.
+func renderNodePreview(node *yaml.Node) string {
+	out, err := yaml.Marshal(blockStyleCopy(node))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	truncated := false
+	if len(lines) > hoverPreviewMaxLines {
+		lines = lines[:hoverPreviewMaxLines]
+		truncated = true
+	}
+	preview := strings.Join(lines, "\n")
+	if len(preview) > hoverPreviewMaxChars {
+		cut := hoverPreviewMaxChars
+		for cut > 0 && !utf8.RuneStart(preview[cut]) {
+			cut--
+		}
+		preview = preview[:cut]
+		truncated = true
+	}
+	if truncated {
+		preview += "\n# ..."
+	}
+	return preview
+}
+
+// blockStyleCopy copies a node tree without flow styles, the original is shared with the index and must not change.
+func blockStyleCopy(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	cp := *node
+	cp.Style &^= yaml.FlowStyle
+	cp.HeadComment, cp.LineComment, cp.FootComment = "", "", ""
+	if node.Kind == yaml.AliasNode {
+		cp.Alias = nil
+		cp.Kind = yaml.ScalarNode
+		cp.Value = "*" + node.Value
+		return &cp
+	}
+	if len(node.Content) > 0 {
+		cp.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			cp.Content[i] = blockStyleCopy(child)
+		}
+	}
+	return &cp
+}
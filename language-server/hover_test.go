@@ -0,0 +1,166 @@
+package languageserver
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"github.com/tliron/glsp"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+func lintDocumentForTest(t *testing.T, content string) (*ServerState, *Document) {
+	t.Helper()
+	return lintDocumentWithRuleSetForTest(t, content, buildResolveAllRefsRuleSet())
+}
+
+func lintDocumentWithRuleSetForTest(t *testing.T, content string, ruleSet *rulesets.RuleSet) (*ServerState, *Document) {
+	t.Helper()
+	return lintDocumentAtURIForTest(t, "file:///tmp/spec.yaml", content, ruleSet)
+}
+
+func lintDocumentAtURIForTest(t *testing.T, uri, content string, ruleSet *rulesets.RuleSet) (*ServerState, *Document) {
+	t.Helper()
+	state := &ServerState{
+		documentStore: newDocumentStore(),
+		lintRequest: &utils.LintFileRequest{
+			SelectedRS:        ruleSet,
+			TimeoutFlag:       1,
+			LookupTimeoutFlag: 50,
+			Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+	doc := state.documentStore.Add(uri, content)
+	t.Cleanup(func() { state.documentStore.Remove(doc.URI) })
+
+	published := make(chan struct{}, 1)
+	state.runDiagnostic(doc, glsp.NotifyFunc(func(method string, params any) {
+		if method == protocol.ServerTextDocumentPublishDiagnostics {
+			published <- struct{}{}
+		}
+	}))
+
+	select {
+	case <-published:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for diagnostics notification")
+	}
+	return state, doc
+}
+
+func hoverAt(state *ServerState, uri string, line, character protocol.UInteger) *protocol.Hover {
+	return state.hover(&protocol.HoverParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     protocol.Position{Line: line, Character: character},
+		},
+	})
+}
+
+func TestServerState_Hover_Diagnostic(t *testing.T) {
+	state, doc := lintDocumentForTest(t, resolveAllRefsServerTestSpec)
+
+	// the violation is reported against the (unresolved) response, which starts on the $ref line.
+	hover := hoverAt(state, doc.URI, 9, 2)
+	require.NotNil(t, hover)
+	require.NotNil(t, hover.Range)
+	assert.Equal(t, protocol.UInteger(9), hover.Range.Start.Line)
+
+	content, ok := hover.Contents.(protocol.MarkupContent)
+	require.True(t, ok)
+	assert.Equal(t, protocol.MarkupKindMarkdown, content.Kind)
+	assert.Contains(t, content.Value, "**response-has-content**")
+	assert.Contains(t, content.Value, "Ensure referenced responses expose content")
+	assert.Contains(t, content.Value, "Validation")
+	assert.NotContains(t, content.Value, "$ref")
+}
+
+func TestServerState_Hover_Reference(t *testing.T) {
+	state, doc := lintDocumentForTest(t, resolveAllRefsServerTestSpec)
+
+	hover := hoverAt(state, doc.URI, 9, 20)
+	require.NotNil(t, hover)
+	require.NotNil(t, hover.Range)
+	assert.Equal(t, protocol.UInteger(9), hover.Range.Start.Line)
+	assert.Equal(t, protocol.UInteger(16), hover.Range.Start.Character)
+
+	content := hover.Contents.(protocol.MarkupContent).Value
+	assert.Contains(t, content, "`#/components/responses/NotFound`")
+	assert.Contains(t, content, "Defined on line 13")
+	assert.Contains(t, content, "Not Found")
+	assert.Contains(t, content, "```yaml\ndescription: Not Found")
+
+	// the diagnostic on the same line is rendered first.
+	assert.Contains(t, content, "**response-has-content**")
+}
+
+func TestServerState_Hover_UnresolvableReference(t *testing.T) {
+	spec := `openapi: "3.0.2"
+info:
+  title: Test
+  version: "1.0"
+paths:
+  /test:
+    get:
+      responses:
+        '200':
+          $ref: '#/components/responses/Missing'
+`
+	state, doc := lintDocumentForTest(t, spec)
+
+	hover := hoverAt(state, doc.URI, 9, 12)
+	require.NotNil(t, hover)
+	assert.Contains(t, hover.Contents.(protocol.MarkupContent).Value, "Reference cannot be resolved.")
+}
+
+func TestServerState_Hover_NothingToShow(t *testing.T) {
+	state, doc := lintDocumentForTest(t, resolveAllRefsServerTestSpec)
+
+	assert.Nil(t, hoverAt(state, doc.URI, 0, 2))
+	assert.Nil(t, hoverAt(state, "file:///tmp/not-open.yaml", 9, 20))
+}
+
+func TestDiagnosticRangeContains(t *testing.T) {
+	rng := protocol.Range{
+		Start: protocol.Position{Line: 2, Character: 4},
+		End:   protocol.Position{Line: 4, Character: 6},
+	}
+	assert.True(t, diagnosticRangeContains(rng, protocol.Position{Line: 2, Character: 4}))
+	assert.True(t, diagnosticRangeContains(rng, protocol.Position{Line: 3, Character: 0}))
+	assert.True(t, diagnosticRangeContains(rng, protocol.Position{Line: 4, Character: 6}))
+	assert.False(t, diagnosticRangeContains(rng, protocol.Position{Line: 2, Character: 3}))
+	assert.False(t, diagnosticRangeContains(rng, protocol.Position{Line: 4, Character: 7}))
+	assert.False(t, diagnosticRangeContains(rng, protocol.Position{Line: 5, Character: 0}))
+
+	empty := protocol.Range{
+		Start: protocol.Position{Line: 2, Character: 4},
+		End:   protocol.Position{Line: 2, Character: 4},
+	}
+	assert.True(t, diagnosticRangeContains(empty, protocol.Position{Line: 2, Character: 0}))
+	assert.False(t, diagnosticRangeContains(empty, protocol.Position{Line: 3, Character: 4}))
+}
+
+func TestDocument_SetLintResultDiscardsStaleRuns(t *testing.T) {
+	doc := &Document{}
+	older := doc.nextLintGeneration()
+	newer := doc.nextLintGeneration()
+
+	latest := &motor.RuleSetExecutionResult{}
+	doc.setLintResult(newer, latest)
+	doc.setLintResult(older, &motor.RuleSetExecutionResult{})
+
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		assert.Same(t, latest, result)
+	})
+
+	doc.close()
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		assert.Nil(t, result)
+	})
+}
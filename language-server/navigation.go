@@ -0,0 +1,386 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"strings"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/pb33f/libopenapi/index"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+// operationMethods are the keys of a path item (or webhook) that hold operations.
+var operationMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// nodeLocation identifies a node in one of the files of the rolodex.
+type nodeLocation struct {
+	file   string
+	line   int
+	column int
+}
+
+// definition returns the location of the component a $ref under the cursor points to, looked up using the
+// unresolved index, which follows multi-file references through the rolodex.
+func (s *ServerState) definition(params *protocol.DefinitionParams) []protocol.Location {
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+
+	var locations []protocol.Location
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		if result == nil || result.RuleSetExecution == nil || result.RuleSetExecution.IndexUnresolved == nil {
+			return
+		}
+		execution := result.RuleSetExecution
+		refNode := findReferenceAtPosition(documentRoot(execution), params.Position)
+		if refNode == nil {
+			return
+		}
+		ref, foundIndex := execution.IndexUnresolved.SearchIndexForReference(refNode.Value)
+		if ref == nil || ref.Node == nil {
+			return
+		}
+		target := ref.KeyNode
+		if target == nil {
+			target = ref.Node
+		}
+		locations = append(locations, protocol.Location{
+			URI:   indexURI(execution.IndexUnresolved, foundIndex, doc.URI),
+			Range: nodeRange(target),
+		})
+	})
+	return locations
+}
+
+// references returns every $ref (in any file of the rolodex) that points to the component under the cursor.
+// The cursor can be on the name of a component, or on a $ref to it.
+func (s *ServerState) references(params *protocol.ReferenceParams) []protocol.Location {
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+
+	var locations []protocol.Location
+	doc.readLintResult(func(result *motor.RuleSetExecutionResult) {
+		if result == nil || result.RuleSetExecution == nil || result.RuleSetExecution.IndexUnresolved == nil {
+			return
+		}
+		execution := result.RuleSetExecution
+		rootIndex := execution.IndexUnresolved
+		root := documentRoot(execution)
+
+		// work out which component the cursor is on, and where it is declared.
+		var target nodeLocation
+		var declaration protocol.Location
+		if refNode := findReferenceAtPosition(root, params.Position); refNode != nil {
+			ref, foundIndex := rootIndex.SearchIndexForReference(refNode.Value)
+			if ref == nil || ref.Node == nil {
+				return
+			}
+			target = locationOf(indexPath(rootIndex, foundIndex), ref.Node)
+			declarationNode := ref.KeyNode
+			if declarationNode == nil {
+				declarationNode = ref.Node
+			}
+			declaration = protocol.Location{URI: indexURI(rootIndex, foundIndex, doc.URI), Range: nodeRange(declarationNode)}
+		} else {
+			found := false
+			forEachComponent(root, func(_ string, key, value *yaml.Node) {
+				if !found && key.Line-1 == int(params.Position.Line) {
+					target = locationOf(rootIndex.GetSpecAbsolutePath(), value)
+					declaration = protocol.Location{URI: doc.URI, Range: nodeRange(key)}
+					found = true
+				}
+			})
+			if !found {
+				return
+			}
+		}
+
+		if params.Context.IncludeDeclaration {
+			locations = append(locations, declaration)
+		}
+		for _, idx := range rolodexIndexes(rootIndex) {
+			idxRoot := idx.GetRootNode()
+			if idx == rootIndex {
+				idxRoot = root
+			}
+			uri := indexURI(rootIndex, idx, doc.URI)
+			forEachReference(idxRoot, func(refNode *yaml.Node) {
+				ref, foundIndex := idx.SearchIndexForReference(refNode.Value)
+				if ref == nil || ref.Node == nil {
+					return
+				}
+				if locationOf(indexPath(idx, foundIndex), ref.Node) == target {
+					locations = append(locations, protocol.Location{URI: uri, Range: scalarRange(refNode)})
+				}
+			})
+		}
+	})
+	return locations
+}
+
+// documentSymbols outlines the paths, webhooks and operations, the components, and the AsyncAPI channels and
+// operations of the current content of a document. Returns nil if the document cannot be parsed.
+func (s *ServerState) documentSymbols(params *protocol.DocumentSymbolParams) []protocol.DocumentSymbol {
+	doc, ok := s.documentStore.Get(params.TextDocument.URI)
+	if !ok {
+		return nil
+	}
+	doc.mu.RLock()
+	content := doc.Content
+	doc.mu.RUnlock()
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	_, isAsyncAPI := mappingValue(root, "asyncapi")
+
+	symbols := []protocol.DocumentSymbol{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.MappingNode {
+			continue
+		}
+		switch key.Value {
+		case "paths", "webhooks":
+			group := newSymbol(lines, key, value, protocol.SymbolKindNamespace, "")
+			forEachMapping(value, func(pathKey, pathItem *yaml.Node) {
+				pathSymbol := newSymbol(lines, pathKey, pathItem, protocol.SymbolKindModule, "")
+				forEachMapping(pathItem, func(methodKey, operation *yaml.Node) {
+					if operationMethods[strings.ToLower(methodKey.Value)] {
+						pathSymbol.Children = append(pathSymbol.Children, operationSymbol(lines, methodKey, operation))
+					}
+				})
+				group.Children = append(group.Children, pathSymbol)
+			})
+			symbols = append(symbols, group)
+
+		case "channels":
+			group := newSymbol(lines, key, value, protocol.SymbolKindNamespace, "")
+			forEachMapping(value, func(channelKey, channel *yaml.Node) {
+				address, _ := mappingValue(channel, "address")
+				group.Children = append(group.Children, newSymbol(lines, channelKey, channel, protocol.SymbolKindModule, address))
+			})
+			symbols = append(symbols, group)
+
+		case "operations":
+			if !isAsyncAPI {
+				continue
+			}
+			group := newSymbol(lines, key, value, protocol.SymbolKindNamespace, "")
+			forEachMapping(value, func(operationKey, operation *yaml.Node) {
+				group.Children = append(group.Children, operationSymbol(lines, operationKey, operation))
+			})
+			symbols = append(symbols, group)
+
+		case "components":
+			group := newSymbol(lines, key, value, protocol.SymbolKindNamespace, "")
+			forEachMapping(value, func(sectionKey, section *yaml.Node) {
+				group.Children = append(group.Children, componentSectionSymbol(lines, sectionKey, section))
+			})
+			symbols = append(symbols, group)
+
+		case "definitions", "parameters", "responses", "securityDefinitions":
+			symbols = append(symbols, componentSectionSymbol(lines, key, value))
+		}
+	}
+	return symbols
+}
+
+func componentSectionSymbol(lines []string, key, section *yaml.Node) protocol.DocumentSymbol {
+	kind := protocol.SymbolKindObject
+	if key.Value == "schemas" || key.Value == "definitions" {
+		kind = protocol.SymbolKindStruct
+	}
+	symbol := newSymbol(lines, key, section, protocol.SymbolKindNamespace, "")
+	forEachMapping(section, func(componentKey, component *yaml.Node) {
+		symbol.Children = append(symbol.Children, newSymbol(lines, componentKey, component, kind, ""))
+	})
+	return symbol
+}
+
+// operationSymbol names an HTTP operation by its (upper case) method, and an AsyncAPI operation by its key. The
+// operationId (or AsyncAPI action) and summary are used as the detail.
+func operationSymbol(lines []string, key, operation *yaml.Node) protocol.DocumentSymbol {
+	name := key.Value
+	if operationMethods[strings.ToLower(name)] {
+		name = strings.ToUpper(name)
+	}
+	var details []string
+	for _, field := range []string{"operationId", "action", "summary"} {
+		if value, ok := mappingValue(operation, field); ok && value != "" {
+			details = append(details, value)
+		}
+	}
+	symbol := newSymbol(lines, key, operation, protocol.SymbolKindMethod, strings.Join(details, " · "))
+	symbol.Name = name
+	if deprecated, _ := mappingValue(operation, "deprecated"); deprecated == "true" {
+		symbol.Tags = []protocol.SymbolTag{protocol.SymbolTagDeprecated}
+	}
+	return symbol
+}
+
+// newSymbol creates a symbol that covers a key and every line of its value.
+func newSymbol(lines []string, key, value *yaml.Node, kind protocol.SymbolKind, detail string) protocol.DocumentSymbol {
+	selection := nodeRange(key)
+	rng := protocol.Range{Start: selection.Start, End: selection.End}
+	if end := lastLine(value); end > key.Line && end <= len(lines) {
+		rng.End = protocol.Position{Line: protocol.UInteger(end - 1), Character: utf16Len(strings.TrimRight(lines[end-1], "\r"))}
+	}
+	symbol := protocol.DocumentSymbol{
+		Name:           key.Value,
+		Kind:           kind,
+		Range:          rng,
+		SelectionRange: selection,
+		Children:       []protocol.DocumentSymbol{},
+	}
+	if detail != "" {
+		symbol.Detail = &detail
+	}
+	return symbol
+}
+
+func forEachMapping(node *yaml.Node, fn func(key, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.HasPrefix(node.Content[i].Value, "x-") {
+			continue
+		}
+		fn(node.Content[i], node.Content[i+1])
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) (string, bool) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return "", false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value, true
+		}
+	}
+	return "", false
+}
+
+// forEachComponent calls fn with the local reference, key and value of every component in a document. Swagger
+// definitions, parameters and responses, and AsyncAPI channels and operations are included.
+func forEachComponent(root *yaml.Node, fn func(reference string, key, value *yaml.Node)) {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	eachComponent := func(prefix string, section *yaml.Node) {
+		if section.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			fn(prefix+"/"+escape.Replace(section.Content[i].Value), section.Content[i], section.Content[i+1])
+		}
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		switch key {
+		case "components":
+			if value.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				eachComponent("#/components/"+escape.Replace(value.Content[j].Value), value.Content[j+1])
+			}
+		case "definitions", "parameters", "responses", "channels", "operations":
+			eachComponent("#/"+key, value)
+		}
+	}
+}
+
+// forEachReference calls fn with the value node of every $ref in a tree.
+func forEachReference(node *yaml.Node, fn func(refNode *yaml.Node)) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				fn(node.Content[i+1])
+			}
+		}
+	}
+	for _, child := range node.Content {
+		forEachReference(child, fn)
+	}
+}
+
+// rolodexIndexes returns the root index followed by the index of every other file in its rolodex.
+func rolodexIndexes(rootIndex *index.SpecIndex) []*index.SpecIndex {
+	indexes := []*index.SpecIndex{rootIndex}
+	if rolodex := rootIndex.GetRolodex(); rolodex != nil {
+		for _, idx := range rolodex.GetIndexes() {
+			if idx != nil && idx != rootIndex && idx.GetSpecAbsolutePath() != rootIndex.GetSpecAbsolutePath() {
+				indexes = append(indexes, idx)
+			}
+		}
+	}
+	return indexes
+}
+
+// documentRoot returns the unresolved root node of the linted document.
+func documentRoot(execution *motor.RuleSetExecution) *yaml.Node {
+	if execution.CanonicalDocument != nil {
+		return execution.CanonicalDocument
+	}
+	return execution.IndexUnresolved.GetRootNode()
+}
+
+// indexPath returns the path of the file a reference was found in, which is the searched index if the
+// reference is local.
+func indexPath(searched, found *index.SpecIndex) string {
+	if found != nil && found.GetSpecAbsolutePath() != "" {
+		return found.GetSpecAbsolutePath()
+	}
+	return searched.GetSpecAbsolutePath()
+}
+
+// indexURI returns the URI of the file an index belongs to, the root index is the open document.
+func indexURI(rootIndex, idx *index.SpecIndex, documentURI protocol.DocumentUri) protocol.DocumentUri {
+	if idx == nil || idx == rootIndex || idx.GetSpecAbsolutePath() == "" ||
+		idx.GetSpecAbsolutePath() == rootIndex.GetSpecAbsolutePath() {
+		return documentURI
+	}
+	path := idx.GetSpecAbsolutePath()
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return filePathToURI(path)
+}
+
+func locationOf(file string, node *yaml.Node) nodeLocation {
+	return nodeLocation{file: file, line: node.Line, column: node.Column}
+}
+
+// nodeRange returns the range of a scalar, or the start position of a collection.
+func nodeRange(node *yaml.Node) protocol.Range {
+	if node.Kind == yaml.ScalarNode {
+		return scalarRange(node)
+	}
+	start := protocol.Position{Line: protocol.UInteger(max(node.Line-1, 0)), Character: protocol.UInteger(max(node.Column-1, 0))}
+	return protocol.Range{Start: start, End: start}
+}
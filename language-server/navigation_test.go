@@ -0,0 +1,186 @@
+package languageserver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+const navigationTestSpec = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Pizza'
+    post:
+      operationId: createPizza
+      deprecated: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: './toppings.yaml#/components/schemas/Topping'
+      responses:
+        "201":
+          $ref: '#/components/responses/Pizza'
+components:
+  responses:
+    Pizza:
+      description: a pizza
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pizza'
+  schemas:
+    Pizza:
+      type: object
+`
+
+const navigationTestToppings = `components:
+  schemas:
+    Topping:
+      type: object
+      properties:
+        pizza:
+          $ref: './spec.yaml#/components/schemas/Pizza'
+`
+
+// lintNavigationSpecForTest writes the navigation specs to a temporary directory and lints the root spec, so
+// the rolodex can find the second file.
+func lintNavigationSpecForTest(t *testing.T) (*ServerState, *Document, string) {
+	t.Helper()
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	toppingsPath := filepath.Join(dir, "toppings.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(navigationTestSpec), 0o644))
+	require.NoError(t, os.WriteFile(toppingsPath, []byte(navigationTestToppings), 0o644))
+
+	state, doc := lintDocumentAtURIForTest(t, filePathToURI(specPath), navigationTestSpec, buildCodeActionRuleSet())
+	return state, doc, filePathToURI(toppingsPath)
+}
+
+func positionParams(uri string, line, character protocol.UInteger) protocol.TextDocumentPositionParams {
+	return protocol.TextDocumentPositionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+		Position:     protocol.Position{Line: line, Character: character},
+	}
+}
+
+func TestServerState_Definition(t *testing.T) {
+	state, doc, toppingsURI := lintNavigationSpecForTest(t)
+
+	tests := []struct {
+		name      string
+		line      protocol.UInteger
+		character protocol.UInteger
+		uri       string
+		target    protocol.Position
+	}{
+		{name: "local", line: 9, character: 20, uri: doc.URI, target: protocol.Position{Line: 23, Character: 4}},
+		{name: "other file", line: 17, character: 25, uri: toppingsURI, target: protocol.Position{Line: 2, Character: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations := state.definition(&protocol.DefinitionParams{
+				TextDocumentPositionParams: positionParams(doc.URI, tt.line, tt.character),
+			})
+			require.Len(t, locations, 1)
+			assert.Equal(t, tt.uri, locations[0].URI)
+			assert.Equal(t, tt.target, locations[0].Range.Start)
+		})
+	}
+
+	assert.Empty(t, state.definition(&protocol.DefinitionParams{TextDocumentPositionParams: positionParams(doc.URI, 2, 4)}))
+}
+
+func TestServerState_References(t *testing.T) {
+	state, doc, toppingsURI := lintNavigationSpecForTest(t)
+
+	references := func(line, character protocol.UInteger, includeDeclaration bool) map[string][]protocol.UInteger {
+		locations := state.references(&protocol.ReferenceParams{
+			TextDocumentPositionParams: positionParams(doc.URI, line, character),
+			Context:                    protocol.ReferenceContext{IncludeDeclaration: includeDeclaration},
+		})
+		found := make(map[string][]protocol.UInteger)
+		for _, location := range locations {
+			found[location.URI] = append(found[location.URI], location.Range.Start.Line)
+		}
+		return found
+	}
+
+	// from the name of a component.
+	assert.Equal(t, map[string][]protocol.UInteger{doc.URI: {23, 9, 20}}, references(23, 6, true))
+
+	// from a $ref, including one in another file.
+	assert.Equal(t, map[string][]protocol.UInteger{doc.URI: {28}, toppingsURI: {6}}, references(28, 20, false))
+
+	assert.Empty(t, references(2, 4, true))
+}
+
+func TestServerState_DocumentSymbols(t *testing.T) {
+	state := &ServerState{documentStore: newDocumentStore()}
+	doc := state.documentStore.Add("file:///tmp/spec.yaml", navigationTestSpec)
+
+	symbols := state.documentSymbols(&protocol.DocumentSymbolParams{TextDocument: protocol.TextDocumentIdentifier{URI: doc.URI}})
+	require.Len(t, symbols, 2)
+
+	paths := symbols[0]
+	assert.Equal(t, "paths", paths.Name)
+	assert.Equal(t, protocol.UInteger(4), paths.Range.Start.Line)
+	assert.Equal(t, protocol.UInteger(20), paths.Range.End.Line)
+	require.Len(t, paths.Children, 1)
+	assert.Equal(t, "/pizza", paths.Children[0].Name)
+
+	operations := paths.Children[0].Children
+	require.Len(t, operations, 2)
+	assert.Equal(t, "GET", operations[0].Name)
+	assert.Equal(t, protocol.SymbolKindMethod, operations[0].Kind)
+	assert.Equal(t, "POST", operations[1].Name)
+	require.NotNil(t, operations[1].Detail)
+	assert.Equal(t, "createPizza", *operations[1].Detail)
+	assert.Equal(t, []protocol.SymbolTag{protocol.SymbolTagDeprecated}, operations[1].Tags)
+
+	components := symbols[1]
+	assert.Equal(t, "components", components.Name)
+	require.Len(t, components.Children, 2)
+	assert.Equal(t, "responses", components.Children[0].Name)
+	assert.Equal(t, protocol.SymbolKindObject, components.Children[0].Children[0].Kind)
+	assert.Equal(t, "schemas", components.Children[1].Name)
+	assert.Equal(t, "Pizza", components.Children[1].Children[0].Name)
+	assert.Equal(t, protocol.SymbolKindStruct, components.Children[1].Children[0].Kind)
+	assert.Equal(t, protocol.Position{Line: 30, Character: 4}, components.Children[1].Children[0].SelectionRange.Start)
+}
+
+func TestServerState_DocumentSymbols_AsyncAPI(t *testing.T) {
+	state := &ServerState{documentStore: newDocumentStore()}
+	doc := state.documentStore.Add("file:///tmp/asyncapi.yaml", `asyncapi: 3.0.0
+channels:
+  orders:
+    address: orders.created
+operations:
+  onOrder:
+    action: receive
+    channel:
+      $ref: '#/channels/orders'
+`)
+
+	symbols := state.documentSymbols(&protocol.DocumentSymbolParams{TextDocument: protocol.TextDocumentIdentifier{URI: doc.URI}})
+	require.Len(t, symbols, 2)
+	require.Len(t, symbols[0].Children, 1)
+	require.NotNil(t, symbols[0].Children[0].Detail)
+	assert.Equal(t, "orders.created", *symbols[0].Children[0].Detail)
+	require.Len(t, symbols[1].Children, 1)
+	assert.Equal(t, "onOrder", symbols[1].Children[0].Name)
+	assert.Equal(t, protocol.SymbolKindMethod, symbols[1].Children[0].Kind)
+
+	doc.Content = "openapi: [3.1.0"
+	assert.Nil(t, state.documentSymbols(&protocol.DocumentSymbolParams{TextDocument: protocol.TextDocumentIdentifier{URI: doc.URI}}))
+}
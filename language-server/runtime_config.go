@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/plugin"
@@ -30,6 +32,7 @@ type documentRuntimeConfig struct {
 	selectedRS                     *rulesets.RuleSet
 	functions                      map[string]model.RuleFunction
 	ignoredResults                 model.IgnoredItems
+	ignoreFile                     string // resolved path of the ignore file, if one is configured.
 	httpClientConfig               utils.HTTPClientConfig
 	logger                         *slog.Logger
 	remote                         bool
@@ -362,11 +365,13 @@ func (s *ServerState) buildDocumentRuntimeConfig(config *LSPConfig, uri protocol
 	}
 
 	ignoredResults := snapshot.ignoredResults
+	ignoreFile := ""
 	if config.IgnoreFile != "" {
 		resolvedIgnoreFile, err := s.resolveDocumentConfigPath(config.IgnoreFile, uri)
 		if err != nil {
 			return nil, err
 		}
+		ignoreFile = resolvedIgnoreFile
 		ignoredResults, err = loadIgnoreFileForLSP(resolvedIgnoreFile)
 		if err != nil {
 			return nil, err
@@ -379,6 +384,7 @@ func (s *ServerState) buildDocumentRuntimeConfig(config *LSPConfig, uri protocol
 		selectedRS:                     selectedRS,
 		functions:                      functions,
 		ignoredResults:                 ignoredResults,
+		ignoreFile:                     ignoreFile,
 		httpClientConfig:               httpClientConfig,
 		logger:                         snapshot.logger,
 		remote:                         boolValue(config.Remote, snapshot.remote),
@@ -405,7 +411,7 @@ func (s *ServerState) loadRulesetForDocument(rulesetLocation string, config *LSP
 		if err != nil {
 			return nil, err
 		}
-		return defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient), nil
+		return generateRulesetForDocument(defaultRuleSets, downloadedRS, httpClient)
 	}
 
 	resolvedRuleset, err := s.resolveDocumentConfigPath(rulesetLocation, uri)
@@ -420,7 +426,29 @@ func (s *ServerState) loadRulesetForDocument(rulesetLocation string, config *LSP
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ruleset: %w", err)
 	}
-	return defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient), nil
+	// override file patterns are relative to the ruleset.
+	if absRuleset, absErr := filepath.Abs(resolvedRuleset); absErr == nil {
+		userRS.OverridesBase = filepath.Dir(absRuleset)
+		userRS.Location = absRuleset
+	}
+	return generateRulesetForDocument(defaultRuleSets, userRS, httpClient)
+}
+
+// generateRulesetForDocument generates a ruleset and loads the functions shipped with it, failing if a pinned or
+// offline ruleset it extends cannot be used.
+func generateRulesetForDocument(defaultRuleSets rulesets.RuleSets, userRS *rulesets.RuleSet, httpClient *http.Client) (*rulesets.RuleSet, error) {
+	generated := defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient)
+	if err := generated.ExtendsError(); err != nil {
+		return nil, err
+	}
+	if len(generated.FunctionLocations()) > 0 {
+		pm, err := plugin.LoadRuleSetFunctions(generated, httpClient, true)
+		if err != nil {
+			return nil, err
+		}
+		generated.LoadedFunctions = pm.GetCustomFunctions()
+	}
+	return generated, nil
 }
 
 func loadIgnoreFileForLSP(ignoreFile string) (model.IgnoredItems, error) {
@@ -433,10 +461,15 @@ func loadIgnoreFileForLSP(ignoreFile string) (model.IgnoredItems, error) {
 	if err != nil {
 		return ignoredItems, fmt.Errorf("failed to read ignore file %s: %w", ignoreFile, err)
 	}
-	if err := yaml.Unmarshal(raw, &ignoredItems); err != nil {
+	var entries model.IgnoreEntries
+	if err := yaml.Unmarshal(raw, &entries); err != nil {
 		return ignoredItems, fmt.Errorf("failed to parse ignore file %s: %w", ignoreFile, err)
 	}
-	return ignoredItems, nil
+	if err := entries.Validate(); err != nil {
+		return ignoredItems, fmt.Errorf("invalid ignore file %s: %w", ignoreFile, err)
+	}
+	// expired entries are no longer honored.
+	return entries.Active(time.Now()), nil
 }
 
 func (s *ServerState) resolveDocumentConfigPath(raw string, uri protocol.DocumentUri) (string, error) {
@@ -521,6 +554,14 @@ func currentWorkingDirectory() string {
 	return cwd
 }
 
+func filePathToURI(path string) protocol.DocumentUri {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
 func fileURIToPath(uri protocol.DocumentUri) string {
 	raw := string(uri)
 	parsed, err := url.Parse(raw)
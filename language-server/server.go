@@ -158,7 +158,9 @@ func NewServerWithExecutionOptions(version string, lintRequest *utils.LintFileRe
 
 		serverCapabilities := handler.CreateServerCapabilities()
 		serverCapabilities.TextDocumentSync = protocol.TextDocumentSyncKindIncremental
-		serverCapabilities.CompletionProvider = &protocol.CompletionOptions{}
+		serverCapabilities.CompletionProvider = &protocol.CompletionOptions{
+			TriggerCharacters: []string{"#", "/", ","},
+		}
 		serverCapabilities.CodeActionProvider = &protocol.CodeActionOptions{
 			CodeActionKinds: []protocol.CodeActionKind{protocol.CodeActionKindQuickFix},
 		}
@@ -223,29 +225,28 @@ func NewServerWithExecutionOptions(version string, lintRequest *utils.LintFileRe
 		return nil
 	}
 
+	handler.TextDocumentHover = func(context *glsp.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+		return state.hover(params), nil
+	}
+
 	handler.TextDocumentCompletion = func(context *glsp.Context, params *protocol.CompletionParams) (any, error) {
-		return nil, nil
+		return state.completion(params), nil
 	}
 
-	handler.TextDocumentCodeAction = func(context *glsp.Context, params *protocol.CodeActionParams) (any, error) {
-		var actions []protocol.CodeAction
-
-		for _, diagnostic := range params.Context.Diagnostics {
-			if diagnostic.CodeDescription != nil && diagnostic.CodeDescription.HRef != "" {
-				quickFixKind := protocol.CodeActionKindQuickFix
-				actions = append(actions, protocol.CodeAction{
-					Title: "View documentation",
-					Kind:  &quickFixKind,
-					Command: &protocol.Command{
-						Title:     "Open documentation",
-						Command:   "vacuum.openUrl",
-						Arguments: []interface{}{diagnostic.CodeDescription.HRef},
-					},
-				})
-			}
-		}
+	handler.TextDocumentDefinition = func(context *glsp.Context, params *protocol.DefinitionParams) (any, error) {
+		return state.definition(params), nil
+	}
+
+	handler.TextDocumentReferences = func(context *glsp.Context, params *protocol.ReferenceParams) ([]protocol.Location, error) {
+		return state.references(params), nil
+	}
 
-		return actions, nil
+	handler.TextDocumentDocumentSymbol = func(context *glsp.Context, params *protocol.DocumentSymbolParams) (any, error) {
+		return state.documentSymbols(params), nil
+	}
+
+	handler.TextDocumentCodeAction = func(context *glsp.Context, params *protocol.CodeActionParams) (any, error) {
+		return state.codeActions(params), nil
 	}
 
 	handler.WorkspaceExecuteCommand = func(context *glsp.Context, params *protocol.ExecuteCommandParams) (any, error) {
@@ -371,9 +372,9 @@ func (s *ServerState) runDiagnostic(doc *Document, notify glsp.NotifyFunc) {
 		ruleExec.RuleSet = s.rulesetSelector(docCtx)
 	}
 
+	generation := doc.nextLintGeneration()
 	go func() {
 		result := motor.ApplyRulesToRuleSetWithOptions(ruleExec, s.executionOptions)
-		defer result.ReleaseOwnedResources()
 
 		ignoreOptions := utils.IgnoreMatcherOptions{
 			SpecBytes: []byte(content),
@@ -384,6 +385,7 @@ func (s *ServerState) runDiagnostic(doc *Document, notify glsp.NotifyFunc) {
 		filteredResults := utils.FilterIgnoredResultsWithOptions(result.Results, ignoredResults, ignoreOptions)
 		result.Results = filteredResults
 		diagnostics := ConvertResultsIntoDiagnostics(result)
+		doc.setLintResult(generation, result)
 
 		notify(protocol.ServerTextDocumentPublishDiagnostics, protocol.PublishDiagnosticsParams{
 			URI:         uri,
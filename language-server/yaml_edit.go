@@ -0,0 +1,344 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"go.yaml.in/yaml/v4"
+)
+
+// yamlFormat captures how a document is laid out, so rendered nodes blend in with the surrounding text.
+type yamlFormat struct {
+	indent     int
+	compactSeq bool
+}
+
+// detectYAMLFormat looks at nested mappings and sequences to work out the indentation the document uses,
+// falling back to two spaces and indented sequences.
+func detectYAMLFormat(root *yaml.Node) yamlFormat {
+	format := yamlFormat{indent: 2}
+	foundIndent, foundSeq := false, false
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node == nil || (foundIndent && foundSeq) {
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if value.Style&yaml.FlowStyle == 0 && value.Line > key.Line {
+					switch value.Kind {
+					case yaml.MappingNode:
+						if !foundIndent && value.Column-key.Column >= 2 && value.Column-key.Column <= 9 {
+							format.indent = value.Column - key.Column
+							foundIndent = true
+						}
+					case yaml.SequenceNode:
+						if !foundSeq {
+							format.compactSeq = value.Column == key.Column
+							foundSeq = true
+						}
+					}
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(root)
+	return format
+}
+
+// copyNodeTree deep copies a node tree, returning the copy and a map of every original node to its copy.
+func copyNodeTree(node *yaml.Node) (*yaml.Node, map[*yaml.Node]*yaml.Node) {
+	copies := make(map[*yaml.Node]*yaml.Node)
+	var cp func(n *yaml.Node) *yaml.Node
+	cp = func(n *yaml.Node) *yaml.Node {
+		if n == nil {
+			return nil
+		}
+		if existing, ok := copies[n]; ok {
+			return existing
+		}
+		c := *n
+		copies[n] = &c
+		if len(n.Content) > 0 {
+			c.Content = make([]*yaml.Node, len(n.Content))
+			for i, child := range n.Content {
+				c.Content[i] = cp(child)
+			}
+		}
+		if n.Alias != nil {
+			c.Alias = cp(n.Alias)
+		}
+		return &c
+	}
+	return cp(node), copies
+}
+
+type nodePair struct {
+	original *yaml.Node
+	fixed    *yaml.Node
+}
+
+// changedPath walks both trees from the root, returning the pairs of nodes leading down to the smallest
+// pair that contains every difference between them. Returns nil if the trees are the same.
+func changedPath(original, fixed *yaml.Node) []nodePair {
+	var path []nodePair
+	for !nodesEqual(original, fixed) {
+		path = append(path, nodePair{original: original, fixed: fixed})
+		if original == nil || fixed == nil || original.Kind != fixed.Kind || original.Value != fixed.Value ||
+			original.Tag != fixed.Tag || len(original.Content) != len(fixed.Content) {
+			return path
+		}
+		changed := -1
+		for i := range original.Content {
+			if !nodesEqual(original.Content[i], fixed.Content[i]) {
+				if changed >= 0 {
+					return path
+				}
+				changed = i
+			}
+		}
+		if changed < 0 {
+			return path
+		}
+		original, fixed = original.Content[changed], fixed.Content[changed]
+	}
+	return path
+}
+
+func nodesEqual(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Kind != b.Kind || a.Tag != b.Tag || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceChangedNodeEdit builds a text edit that replaces the smallest part of the document that contains every
+// difference between the original and fixed trees. Flow style collections (JSON documents) have no known end
+// position, so only scalar changes can be made to them.
+func replaceChangedNodeEdit(lines []string, original, fixed *yaml.Node, format yamlFormat) (protocol.TextEdit, bool) {
+	path := changedPath(original, fixed)
+	for i := len(path) - 1; i >= 0; i-- {
+		pair := path[i]
+		if pair.original == nil || pair.fixed == nil {
+			continue
+		}
+		switch pair.original.Kind {
+		case yaml.ScalarNode:
+			if edit, ok := replaceScalarEdit(lines, pair.original, pair.fixed); ok {
+				return edit, true
+			}
+		case yaml.MappingNode, yaml.SequenceNode:
+			if edit, ok := replaceBlockEdit(lines, pair.original, pair.fixed, format); ok {
+				return edit, true
+			}
+		}
+	}
+	return protocol.TextEdit{}, false
+}
+
+// replaceScalarEdit replaces a single line scalar with another scalar, keeping the original quoting style.
+func replaceScalarEdit(lines []string, original, fixed *yaml.Node) (protocol.TextEdit, bool) {
+	if fixed.Kind != yaml.ScalarNode || strings.Contains(fixed.Value, "\n") {
+		return protocol.TextEdit{}, false
+	}
+	rng, ok := scalarSourceRange(lines, original)
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+	replacement := *fixed
+	if replacement.Style == 0 {
+		replacement.Style = original.Style
+	}
+	replacement.HeadComment, replacement.LineComment, replacement.FootComment = "", "", ""
+	text, ok := renderScalar(&replacement)
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+	return protocol.TextEdit{Range: rng, NewText: text}, true
+}
+
+// replaceBlockEdit replaces every line of a block collection with the rendered fixed node. Anything before the
+// collection on its first line (a '- ' for instance) is kept.
+func replaceBlockEdit(lines []string, original, fixed *yaml.Node, format yamlFormat) (protocol.TextEdit, bool) {
+	if original.Style&yaml.FlowStyle != 0 || fixed.Kind == yaml.ScalarNode || fixed.Style&yaml.FlowStyle != 0 {
+		return protocol.TextEdit{}, false
+	}
+	start, end := original.Line, lastLine(original)
+	if start < 1 || end > len(lines) {
+		return protocol.TextEdit{}, false
+	}
+	firstLine := []rune(lines[start-1])
+	if original.Column-1 > len(firstLine) {
+		return protocol.TextEdit{}, false
+	}
+	prefix := string(firstLine[:original.Column-1])
+	indent := strings.Repeat(" ", original.Column-1)
+
+	rendered, ok := renderBlock(fixed, format)
+	if !ok {
+		return protocol.TextEdit{}, false
+	}
+	out := strings.Split(rendered, "\n")
+	for i := range out {
+		if i == 0 {
+			out[i] = prefix + out[i]
+		} else if out[i] != "" {
+			out[i] = indent + out[i]
+		}
+	}
+	return replaceLinesEdit(lines, start, end, strings.Join(out, "\n")), true
+}
+
+// replaceLinesEdit replaces the (1-based, inclusive) lines with text.
+func replaceLinesEdit(lines []string, start, end int, text string) protocol.TextEdit {
+	rng := protocol.Range{Start: protocol.Position{Line: protocol.UInteger(start - 1)}}
+	if end < len(lines) {
+		rng.End = protocol.Position{Line: protocol.UInteger(end)}
+		text += "\n"
+	} else {
+		rng.End = protocol.Position{Line: protocol.UInteger(end - 1), Character: utf16Len(lines[end-1])}
+	}
+	return protocol.TextEdit{Range: rng, NewText: text}
+}
+
+// insertAfterLineEdit inserts text on a new line after the (1-based) line.
+func insertAfterLineEdit(lines []string, line int, text string) protocol.TextEdit {
+	if line < len(lines) {
+		pos := protocol.Position{Line: protocol.UInteger(line)}
+		return protocol.TextEdit{Range: protocol.Range{Start: pos, End: pos}, NewText: text + "\n"}
+	}
+	last := len(lines) - 1
+	pos := protocol.Position{Line: protocol.UInteger(last), Character: utf16Len(lines[last])}
+	return protocol.TextEdit{Range: protocol.Range{Start: pos, End: pos}, NewText: "\n" + text}
+}
+
+// lastLine returns the last (1-based) line a node occupies.
+func lastLine(node *yaml.Node) int {
+	line := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		line += strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		if l := lastLine(child); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+// scalarSourceRange finds the range of a single line scalar in the source, including any quotes.
+func scalarSourceRange(lines []string, node *yaml.Node) (protocol.Range, bool) {
+	if node.Kind != yaml.ScalarNode || node.Line < 1 || node.Line > len(lines) ||
+		node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return protocol.Range{}, false
+	}
+	line := []rune(lines[node.Line-1])
+	start := node.Column - 1
+	if start < 0 || start >= len(line) {
+		return protocol.Range{}, false
+	}
+
+	end := -1
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+				continue
+			}
+			if line[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				end = i + 1
+				break
+			}
+		}
+	default:
+		value := []rune(node.Value)
+		if start+len(value) <= len(line) && string(line[start:start+len(value)]) == node.Value {
+			end = start + len(value)
+		}
+	}
+	if end < 0 {
+		return protocol.Range{}, false
+	}
+
+	lineNumber := protocol.UInteger(node.Line - 1)
+	return protocol.Range{
+		Start: protocol.Position{Line: lineNumber, Character: utf16Len(string(line[:start]))},
+		End:   protocol.Position{Line: lineNumber, Character: utf16Len(string(line[:end]))},
+	}, true
+}
+
+// renderScalar renders a scalar (or a flow collection) as it would appear in a document, returns false if it
+// needs more than one line.
+func renderScalar(node *yaml.Node) (string, bool) {
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(out), "\n")
+	return text, !strings.Contains(text, "\n")
+}
+
+// renderBlock renders a collection in block style, using the format of the document. Comments that sit
+// outside the lines of the collection are dropped, as they are not being replaced.
+func renderBlock(node *yaml.Node, format yamlFormat) (string, bool) {
+	node.HeadComment = ""
+	for first := node; len(first.Content) > 0; first = first.Content[0] {
+		first.Content[0].HeadComment = ""
+	}
+	for last := node; len(last.Content) > 0; last = last.Content[len(last.Content)-1] {
+		last.FootComment = ""
+		for _, child := range last.Content[max(0, len(last.Content)-2):] {
+			child.FootComment = ""
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(format.indent)
+	if format.compactSeq {
+		encoder.CompactSeqIndent()
+	} else {
+		encoder.DefaultSeqIndent()
+	}
+	if err := encoder.Encode(node); err != nil {
+		return "", false
+	}
+	if err := encoder.Close(); err != nil {
+		return "", false
+	}
+	return strings.TrimRight(buf.String(), "\n"), true
+}
+
+func utf16Len(s string) protocol.UInteger {
+	return protocol.UInteger(len(utf16.Encode([]rune(s))))
+}
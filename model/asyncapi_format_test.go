@@ -20,3 +20,29 @@ func TestFormatMatches_AsyncAPI3Family(t *testing.T) {
 		t.Fatal("asyncapi3_0 should not match asyncapi3_1")
 	}
 }
+
+func TestFormatMatches_AsyncAPI2Family(t *testing.T) {
+	if !FormatMatches(AsyncAPI2, AsyncAPI20) {
+		t.Fatal("asyncapi2 should match asyncapi2_0")
+	}
+	if !FormatMatches(AsyncAPI2, AsyncAPI26) {
+		t.Fatal("asyncapi2 should match asyncapi2_6")
+	}
+	if FormatMatches(AsyncAPI26, AsyncAPI25) {
+		t.Fatal("asyncapi2_6 should not match asyncapi2_5")
+	}
+	if FormatMatches(AsyncAPI2, AsyncAPI30) || FormatMatches(AsyncAPI3, AsyncAPI26) {
+		t.Fatal("asyncapi2 and asyncapi3 families should not overlap")
+	}
+}
+
+func TestIsAsyncAPIFormat(t *testing.T) {
+	for _, format := range AsyncAPIAllFormats {
+		if !IsAsyncAPIFormat(format) {
+			t.Fatalf("%s should be an AsyncAPI format", format)
+		}
+	}
+	if IsAsyncAPIFormat(OAS3) || IsAsyncAPIFormat("") {
+		t.Fatal("OpenAPI and empty formats are not AsyncAPI formats")
+	}
+}
@@ -6,3 +6,98 @@ import (
 
 // AutoFixFunction defines the signature for auto-fix functions
 type AutoFixFunction func(node *yaml.Node, document *yaml.Node, context *RuleFunctionContext) (*yaml.Node, error)
+
+// AutoFixProvider is implemented by rule functions that can also fix the violations they report, such as
+// JavaScript functions with a fix hook. GetAutoFixFunction returns nil if the function cannot fix anything.
+type AutoFixProvider interface {
+	GetAutoFixFunction() AutoFixFunction
+}
+
+// NodeParentIndex maps the nodes of a document to the container node they belong to, so auto-fixes can find the
+// parents of a node without walking the whole document for every fix. The index is built the first time it is
+// used, and rebuilt if a fix has moved a node to a different container since.
+type NodeParentIndex struct {
+	root    *yaml.Node
+	parents map[*yaml.Node]*yaml.Node
+}
+
+// NewNodeParentIndex returns an index of the parents of every node in a document.
+func NewNodeParentIndex(root *yaml.Node) *NodeParentIndex {
+	return &NodeParentIndex{root: root}
+}
+
+// Root returns the root of the indexed document.
+func (p *NodeParentIndex) Root() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.root
+}
+
+// ParentChain returns every container node between the root and the target node, the last entry is the direct
+// parent of the target. nil is returned when the target is not part of the document.
+func (p *NodeParentIndex) ParentChain(target *yaml.Node) []*yaml.Node {
+	if p == nil || p.root == nil || target == nil {
+		return nil
+	}
+	if p.parents == nil {
+		p.build()
+	}
+	chain, ok := p.chain(target)
+	if !ok {
+		// fixes change the document, so a stale index is rebuilt once before giving up.
+		p.build()
+		chain, ok = p.chain(target)
+	}
+	if !ok {
+		return nil
+	}
+	return chain
+}
+
+// chain walks up from the target, checking every parent still contains its child.
+func (p *NodeParentIndex) chain(target *yaml.Node) ([]*yaml.Node, bool) {
+	var chain []*yaml.Node
+	for node := target; node != p.root; {
+		parent, ok := p.parents[node]
+		if !ok || !containsNode(parent, node) {
+			return nil, false
+		}
+		chain = append(chain, parent)
+		node = parent
+	}
+	if len(chain) == 0 {
+		return nil, false
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, true
+}
+
+func (p *NodeParentIndex) build() {
+	p.parents = make(map[*yaml.Node]*yaml.Node)
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		for _, child := range n.Content {
+			if child == nil || child == p.root {
+				continue
+			}
+			if _, seen := p.parents[child]; seen {
+				continue
+			}
+			p.parents[child] = n
+			walk(child)
+		}
+	}
+	walk(p.root)
+}
+
+func containsNode(parent, child *yaml.Node) bool {
+	for _, n := range parent.Content {
+		if n == child {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestNodeParentIndex_ParentChain(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("info:\n  license:\n    name: MIT\npaths: {}\n"), &doc))
+
+	root := doc.Content[0]
+	info := root.Content[1]
+	license := info.Content[1]
+	name := license.Content[0]
+
+	parents := NewNodeParentIndex(&doc)
+	assert.Same(t, &doc, parents.Root())
+	assert.Equal(t, []*yaml.Node{&doc, root, info, license}, parents.ParentChain(name))
+	assert.Nil(t, parents.ParentChain(&doc))
+	assert.Nil(t, parents.ParentChain(&yaml.Node{}))
+
+	// a node moved by a fix is found where it is now.
+	paths := root.Content[3]
+	info.Content = nil
+	paths.Content = append(paths.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "license"}, license)
+	assert.Equal(t, []*yaml.Node{&doc, root, paths, license}, parents.ParentChain(name))
+
+	var missing *NodeParentIndex
+	assert.Nil(t, missing.ParentChain(name))
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/pb33f/doctor/model"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+)
+
+// CatalogDocument is one of the documents of a catalog, a set of specifications linted together.
+type CatalogDocument struct {
+	FileName   string              // the file the document was read from, violations are reported against it.
+	SpecInfo   *datamodel.SpecInfo // the spec info of the document.
+	Index      *index.SpecIndex    // the index of the document.
+	Document   libopenapi.Document // the document, nil when it could not be built.
+	DrDocument *model.DrDocument   // the doctor document, nil when it could not be built.
+}
+
+// CatalogRuleFunctionResult is a violation found by a catalog rule, reported against the document it occurs in.
+type CatalogRuleFunctionResult struct {
+	Document *CatalogDocument
+	Result   RuleFunctionResult
+}
+
+// CatalogRuleFunction is implemented by rule functions that check every document of a catalog at once, such as an
+// operationId used by more than one specification. Rules using a catalog function are skipped when a single
+// document is linted, and only run in catalog mode.
+//
+// The context is the same one passed to RunRule, but without the fields that belong to a single document, those
+// are found on each CatalogDocument.
+type CatalogRuleFunction interface {
+	RuleFunction
+	RunCatalogRule(documents []*CatalogDocument, context RuleFunctionContext) []CatalogRuleFunctionResult
+}
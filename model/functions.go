@@ -10,3 +10,5 @@ const FunctionCategoryAsyncAPI = "asyncapi"
 const FunctionCategoryJSONSchema = "jsonschema"
 const FunctionCategoryOWASP = "owasp"
 const FunctionCategoryCustomJS = "customjs"
+const FunctionCategoryCustomWasm = "customwasm"
+const FunctionCategoryCatalog = "catalog"
@@ -0,0 +1,224 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// IgnoreExpiryFormat is the date format used by the 'expires' property of an ignored path.
+const IgnoreExpiryFormat = "2006-01-02"
+
+// IgnoreEntries is a map of the rule ID to the ignore file entries for that rule. It is the same file as
+// IgnoredItems, read with the reason, owner and expiry of each entry.
+type IgnoreEntries map[string][]IgnoredPath
+
+// IgnoredPath is a single entry in an ignore file. An entry is either a path (or JSONPath expression), or a
+// mapping with the path and the justification for ignoring it:
+//
+//	operation-description:
+//	  - $.paths['/pizza'].get
+//	  - path: $.paths['/cake'].get
+//	    reason: documented elsewhere
+//	    owner: platform-team
+//	    expires: 2026-12-31
+//
+// An entry stops being honored on the day it expires.
+type IgnoredPath struct {
+	Path    string `json:"path" yaml:"path"`
+	Reason  string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Owner   string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// SuppressedResult is a result that was suppressed by an ignore file, along with the ignore entry (and its
+// justification) that suppressed it.
+type SuppressedResult struct {
+	Result      *RuleFunctionResult `json:"result" yaml:"result"`
+	Suppression IgnoredPath         `json:"suppression" yaml:"suppression"`
+}
+
+// Validate checks every expiry date can be parsed.
+func (i IgnoreEntries) Validate() error {
+	for _, ruleID := range i.ruleIDs() {
+		for _, ignored := range i[ruleID] {
+			if _, _, err := ignored.ExpiresAt(); err != nil {
+				return fmt.Errorf("rule '%s', path '%s': %w", ruleID, ignored.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Expired returns the entries that have expired by the given time.
+func (i IgnoreEntries) Expired(now time.Time) IgnoreEntries {
+	expired := make(IgnoreEntries)
+	for ruleID, paths := range i {
+		for _, ignored := range paths {
+			if ignored.IsExpired(now) {
+				expired[ruleID] = append(expired[ruleID], ignored)
+			}
+		}
+	}
+	return expired
+}
+
+// Paths returns the paths of every entry as ignored items.
+func (i IgnoreEntries) Paths() IgnoredItems {
+	items := make(IgnoredItems, len(i))
+	for ruleID, paths := range i {
+		for _, ignored := range paths {
+			items[ruleID] = append(items[ruleID], ignored.Path)
+		}
+	}
+	return items
+}
+
+// Active returns the paths of the entries that have not expired by the given time, as ignored items.
+func (i IgnoreEntries) Active(now time.Time) IgnoredItems {
+	items := make(IgnoredItems, len(i))
+	for ruleID, paths := range i {
+		for _, ignored := range paths {
+			if !ignored.IsExpired(now) {
+				items[ruleID] = append(items[ruleID], ignored.Path)
+			}
+		}
+	}
+	return items
+}
+
+// Entry returns the entry for a rule and path, or an entry with just the path if there is none. When a path has
+// more than one entry, the first one that has not expired by the given time is returned, then the first one.
+func (i IgnoreEntries) Entry(ruleID, path string, now time.Time) IgnoredPath {
+	entry, found := IgnoredPath{Path: path}, false
+	for _, ignored := range i[ruleID] {
+		if ignored.Path != path {
+			continue
+		}
+		if !ignored.IsExpired(now) {
+			return ignored
+		}
+		if !found {
+			entry, found = ignored, true
+		}
+	}
+	return entry
+}
+
+func (i IgnoreEntries) ruleIDs() []string {
+	ids := make([]string, 0, len(i))
+	for ruleID := range i {
+		ids = append(ids, ruleID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ExpiresAt returns the date an entry expires, and false if the entry never expires.
+func (p IgnoredPath) ExpiresAt() (time.Time, bool, error) {
+	if p.Expires == "" {
+		return time.Time{}, false, nil
+	}
+	if expires, err := time.Parse(IgnoreExpiryFormat, p.Expires); err == nil {
+		return expires, true, nil
+	}
+	expires, err := time.Parse(time.RFC3339, p.Expires)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expiry date '%s' is not a valid date (YYYY-MM-DD)", p.Expires)
+	}
+	return expires, true, nil
+}
+
+// IsExpired returns true if the entry has expired by the given time, entries with an invalid expiry date
+// are treated as expired.
+func (p IgnoredPath) IsExpired(now time.Time) bool {
+	expires, ok, err := p.ExpiresAt()
+	if err != nil {
+		return true
+	}
+	return ok && !now.Before(expires)
+}
+
+// HasJustification returns true if the entry has any more than a path.
+func (p IgnoredPath) HasJustification() bool {
+	return p.Reason != "" || p.Owner != "" || p.Expires != ""
+}
+
+// Justification renders the reason, owner and expiry of an entry on a single line.
+func (p IgnoredPath) Justification() string {
+	var parts []string
+	if p.Reason != "" {
+		parts = append(parts, p.Reason)
+	}
+	if p.Owner != "" {
+		parts = append(parts, "owner: "+p.Owner)
+	}
+	if p.Expires != "" {
+		parts = append(parts, "expires: "+p.Expires)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// UnmarshalYAML reads an entry as either a plain path, or a mapping of the path and its justification.
+func (p *IgnoredPath) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*p = IgnoredPath{Path: node.Value}
+		return nil
+	case yaml.MappingNode:
+		// values are read as written, YAML would otherwise read an unquoted expiry date as a timestamp.
+		var entry IgnoredPath
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := node.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: ignore entry property '%s' must be a string", value.Line, node.Content[i].Value)
+			}
+			switch node.Content[i].Value {
+			case "path":
+				entry.Path = value.Value
+			case "reason":
+				entry.Reason = value.Value
+			case "owner":
+				entry.Owner = value.Value
+			case "expires":
+				entry.Expires = value.Value
+			}
+		}
+		*p = entry
+		return nil
+	}
+	return fmt.Errorf("line %d: an ignore entry must be a path, or a mapping with a path", node.Line)
+}
+
+// MarshalYAML writes entries without a justification as a plain path, so ignore files keep the simple format.
+func (p IgnoredPath) MarshalYAML() (any, error) {
+	if !p.HasJustification() {
+		return p.Path, nil
+	}
+	type plain IgnoredPath
+	return plain(p), nil
+}
+
+// UnmarshalJSON reads an entry as either a plain path, or an object of the path and its justification.
+func (p *IgnoredPath) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*p = IgnoredPath{Path: path}
+		return nil
+	}
+	type plain IgnoredPath
+	var entry plain
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+	*p = IgnoredPath(entry)
+	return nil
+}
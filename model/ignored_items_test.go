@@ -0,0 +1,111 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const ignoredItemsTestFile = `operation-description:
+  - $.paths['/pizza'].get
+  - path: $.paths['/cake'].get
+    reason: documented in the cake guide
+    owner: bakery-team
+    expires: 2026-12-31
+info-contact:
+  - path: $.info
+    expires: "2020-01-01"
+`
+
+func TestIgnoreEntries_UnmarshalYAML(t *testing.T) {
+	var items IgnoreEntries
+	require.NoError(t, yaml.Unmarshal([]byte(ignoredItemsTestFile), &items))
+
+	assert.Equal(t, IgnoreEntries{
+		"operation-description": {
+			{Path: "$.paths['/pizza'].get"},
+			{Path: "$.paths['/cake'].get", Reason: "documented in the cake guide", Owner: "bakery-team", Expires: "2026-12-31"},
+		},
+		"info-contact": {{Path: "$.info", Expires: "2020-01-01"}},
+	}, items)
+	assert.NoError(t, items.Validate())
+}
+
+func TestIgnoreEntries_MarshalYAML(t *testing.T) {
+	items := IgnoreEntries{
+		"info-contact": {{Path: "$.info"}, {Path: "$.info.contact", Reason: "no contact yet"}},
+	}
+	out, err := yaml.Marshal(items)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "- $.info\n")
+	assert.Contains(t, string(out), "reason: no contact yet")
+
+	var roundTrip IgnoreEntries
+	require.NoError(t, yaml.Unmarshal(out, &roundTrip))
+	assert.Equal(t, items, roundTrip)
+}
+
+func TestIgnoreEntries_UnmarshalJSON(t *testing.T) {
+	var items IgnoreEntries
+	require.NoError(t, json.Unmarshal([]byte(`{"info-contact": ["$.info", {"path": "$.tags", "owner": "docs"}]}`), &items))
+	assert.Equal(t, IgnoreEntries{
+		"info-contact": {{Path: "$.info"}, {Path: "$.tags", Owner: "docs"}},
+	}, items)
+}
+
+func TestIgnoreEntries_Expired(t *testing.T) {
+	var items IgnoreEntries
+	require.NoError(t, yaml.Unmarshal([]byte(ignoredItemsTestFile), &items))
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, IgnoreEntries{"info-contact": {{Path: "$.info", Expires: "2020-01-01"}}}, items.Expired(now))
+
+	// entries stop being honored on the day they expire.
+	expiryDay := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	assert.Len(t, items.Expired(expiryDay), 2)
+}
+
+func TestIgnoreEntries_Active(t *testing.T) {
+	var items IgnoreEntries
+	require.NoError(t, yaml.Unmarshal([]byte(ignoredItemsTestFile), &items))
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, IgnoredItems{
+		"operation-description": {"$.paths['/pizza'].get", "$.paths['/cake'].get"},
+	}, items.Active(now))
+	assert.Equal(t, []string{"$.info"}, items.Paths()["info-contact"])
+	assert.Equal(t, "bakery-team", items.Entry("operation-description", "$.paths['/cake'].get", now).Owner)
+	assert.Equal(t, IgnoredPath{Path: "$.tags"}, items.Entry("info-contact", "$.tags", now))
+}
+
+func TestIgnoreEntries_Entry_PrefersActive(t *testing.T) {
+	items := IgnoreEntries{"info-contact": {
+		{Path: "$.info", Reason: "old", Owner: "old-team", Expires: "2020-01-01"},
+		{Path: "$.info", Reason: "new", Owner: "new-team", Expires: "2030-01-01"},
+	}}
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	entry := items.Entry("info-contact", "$.info", now)
+	assert.Equal(t, "new-team", entry.Owner)
+	assert.False(t, entry.IsExpired(now))
+
+	// once every entry has expired, the first one is returned.
+	later := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "old-team", items.Entry("info-contact", "$.info", later).Owner)
+}
+
+func TestIgnoreEntries_Validate(t *testing.T) {
+	items := IgnoreEntries{"info-contact": {{Path: "$.info", Expires: "next tuesday"}}}
+	assert.ErrorContains(t, items.Validate(), "rule 'info-contact', path '$.info': expiry date 'next tuesday' is not a valid date")
+	assert.True(t, items["info-contact"][0].IsExpired(time.Now()))
+}
+
+func TestIgnoredPath_Justification(t *testing.T) {
+	assert.Empty(t, IgnoredPath{Path: "$.info"}.Justification())
+	assert.Equal(t, "legacy, owner: api-team, expires: 2027-01-01",
+		IgnoredPath{Path: "$.info", Reason: "legacy", Owner: "api-team", Expires: "2027-01-01"}.Justification())
+}
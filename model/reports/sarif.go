@@ -0,0 +1,113 @@
+package reports
+
+// SarifSchema is the JSON schema location for SARIF 2.1.0 documents.
+const SarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SarifVersion is the version of SARIF generated by vacuum.
+const SarifVersion = "2.1.0"
+
+// SarifReport is the root of a SARIF 2.1.0 (Static Analysis Results Interchange Format) log.
+type SarifReport struct {
+	Schema  string     `json:"$schema" yaml:"$schema"`
+	Version string     `json:"version" yaml:"version"`
+	Runs    []SarifRun `json:"runs" yaml:"runs"`
+}
+
+// SarifSourceRoot is the URI base id of the directory vacuum was run from, artifact URIs inside it are relative.
+const SarifSourceRoot = "%SRCROOT%"
+
+// SarifRun represents a single invocation of vacuum.
+type SarifRun struct {
+	Tool               SarifTool                        `json:"tool" yaml:"tool"`
+	OriginalURIBaseIDs map[string]SarifArtifactLocation `json:"originalUriBaseIds,omitempty" yaml:"originalUriBaseIds,omitempty"`
+	Results            []SarifResult                    `json:"results" yaml:"results"`
+}
+
+// SarifTool describes the tool that generated the results.
+type SarifTool struct {
+	Driver SarifDriver `json:"driver" yaml:"driver"`
+}
+
+// SarifDriver describes vacuum and the rules it ran.
+type SarifDriver struct {
+	Name           string      `json:"name" yaml:"name"`
+	Version        string      `json:"version,omitempty" yaml:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty" yaml:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules" yaml:"rules"`
+}
+
+// SarifRule is the metadata for a single rule (a 'reportingDescriptor' in the SARIF spec).
+type SarifRule struct {
+	Id                   string                  `json:"id" yaml:"id"`
+	Name                 string                  `json:"name,omitempty" yaml:"name,omitempty"`
+	ShortDescription     *SarifMessage           `json:"shortDescription,omitempty" yaml:"shortDescription,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty" yaml:"helpUri,omitempty"`
+	Help                 *SarifMessage           `json:"help,omitempty" yaml:"help,omitempty"`
+	DefaultConfiguration *SarifRuleConfiguration `json:"defaultConfiguration,omitempty" yaml:"defaultConfiguration,omitempty"`
+	Properties           *SarifPropertyBag       `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// SarifRuleConfiguration holds the default severity level of a rule.
+type SarifRuleConfiguration struct {
+	Level string `json:"level" yaml:"level"`
+}
+
+// SarifPropertyBag holds additional properties for a rule, tags are used by GitHub code scanning to filter results.
+type SarifPropertyBag struct {
+	Category string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// SarifMessage is a plain text message.
+type SarifMessage struct {
+	Text string `json:"text" yaml:"text"`
+}
+
+// SarifResult is a single rule violation.
+type SarifResult struct {
+	RuleId       string             `json:"ruleId" yaml:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex" yaml:"ruleIndex"`
+	Level        string             `json:"level" yaml:"level"`
+	Message      SarifMessage       `json:"message" yaml:"message"`
+	Locations    []SarifLocation    `json:"locations,omitempty" yaml:"locations,omitempty"`
+	Suppressions []SarifSuppression `json:"suppressions,omitempty" yaml:"suppressions,omitempty"`
+}
+
+// SarifSuppression records that a result was suppressed, and why.
+type SarifSuppression struct {
+	Kind          string `json:"kind" yaml:"kind"`
+	Status        string `json:"status,omitempty" yaml:"status,omitempty"`
+	Justification string `json:"justification,omitempty" yaml:"justification,omitempty"`
+}
+
+// SarifLocation is the physical location in a file, and the logical (JSON path) location of a result.
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty" yaml:"physicalLocation,omitempty"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty" yaml:"logicalLocations,omitempty"`
+}
+
+// SarifPhysicalLocation is the file and region of a result.
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation" yaml:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty" yaml:"region,omitempty"`
+}
+
+// SarifArtifactLocation is the URI of the file containing a result. A relative URI is resolved against the
+// base URI named by URIBaseID.
+type SarifArtifactLocation struct {
+	URI       string `json:"uri" yaml:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty" yaml:"uriBaseId,omitempty"`
+}
+
+// SarifRegion is the range of a result within a file. Lines and columns start at 1.
+type SarifRegion struct {
+	StartLine   int `json:"startLine" yaml:"startLine"`
+	StartColumn int `json:"startColumn,omitempty" yaml:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty" yaml:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty" yaml:"endColumn,omitempty"`
+}
+
+// SarifLogicalLocation is the JSON path of a result.
+type SarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName" yaml:"fullyQualifiedName"`
+}
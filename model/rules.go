@@ -84,6 +84,10 @@ type RuleFunctionContext struct {
 	// when multiple OWASP rules check the same schema. May be nil.
 	SchemaPathCache *sync.Map `json:"-" yaml:"-"`
 
+	// NodeParents is a shared index of the parents of every node in the document auto-fixes are applied to, so
+	// fixes do not walk the whole document to find the parents of a node. May be nil.
+	NodeParents *NodeParentIndex `json:"-" yaml:"-"`
+
 	// optionsCache caches the converted options map to avoid repeated interface conversions
 	optionsCache map[string]string `json:"-" yaml:"-"`
 }
@@ -117,6 +121,7 @@ type IgnoredItems map[string][]string
 type RuleResultSet struct {
 	Results        []*RuleFunctionResult                   `json:"results,omitempty" yaml:"results,omitempty"`           // All the results!
 	FixedResults   []*RuleFunctionResult                   `json:"fixedResults,omitempty" yaml:"fixedResults,omitempty"` // Results that were automatically fixed
+	Suppressed     []*SuppressedResult                     `json:"suppressed,omitempty" yaml:"suppressed,omitempty"`     // Results suppressed by an ignore file
 	WarnCount      int                                     `json:"warningCount" yaml:"warningCount"`                     // Total warnings
 	ErrorCount     int                                     `json:"errorCount" yaml:"errorCount"`                         // Total errors
 	InfoCount      int                                     `json:"infoCount" yaml:"infoCount"`                           // Total info
@@ -0,0 +1,213 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// GenerateSarifReport will return a SARIF 2.1.0 report of all results, ready to be serialized into JSON. The source is
+// the location of the linted document, results that originate from other (referenced) documents use the location
+// of that document instead. Files inside baseDir are relative to the %SRCROOT% base URI, which is baseDir, other
+// files use an absolute 'file' URI. An empty baseDir leaves relative locations as they are. The version is the
+// version of vacuum used to generate the report. Results suppressed by an ignore file are included with an
+// external suppression, justified by the ignore entry.
+func (rr *RuleResultSet) GenerateSarifReport(source, baseDir, version string) *reports.SarifReport {
+	rules := make([]reports.SarifRule, 0)
+	ruleIndexes := make(map[string]int)
+	results := make([]reports.SarifResult, 0, len(rr.Results)+len(rr.Suppressed))
+
+	ruleIndex := func(rule *Rule) int {
+		idx, seen := ruleIndexes[rule.Id]
+		if !seen {
+			idx = len(rules)
+			ruleIndexes[rule.Id] = idx
+			rules = append(rules, buildSarifRule(rule))
+		}
+		return idx
+	}
+
+	for _, result := range rr.Results {
+		if result == nil || result.Rule == nil {
+			continue
+		}
+		results = append(results, buildSarifResult(result, ruleIndex(result.Rule), source, baseDir))
+	}
+	for _, suppressed := range rr.Suppressed {
+		if suppressed == nil || suppressed.Result == nil || suppressed.Result.Rule == nil {
+			continue
+		}
+		result := buildSarifResult(suppressed.Result, ruleIndex(suppressed.Result.Rule), source, baseDir)
+		result.Suppressions = []reports.SarifSuppression{{
+			Kind:          "external",
+			Status:        "accepted",
+			Justification: suppressed.Suppression.Justification(),
+		}}
+		results = append(results, result)
+	}
+
+	var baseIDs map[string]reports.SarifArtifactLocation
+	if baseDir != "" {
+		// a base URI must end with a slash, or the last segment is replaced when resolving relative URIs.
+		baseIDs = map[string]reports.SarifArtifactLocation{
+			reports.SarifSourceRoot: {URI: strings.TrimSuffix(sarifFileURI(baseDir), "/") + "/"},
+		}
+	}
+
+	return &reports.SarifReport{
+		Schema:  reports.SarifSchema,
+		Version: reports.SarifVersion,
+		Runs: []reports.SarifRun{
+			{
+				OriginalURIBaseIDs: baseIDs,
+				Tool: reports.SarifTool{
+					Driver: reports.SarifDriver{
+						Name:           "vacuum",
+						Version:        version,
+						InformationURI: "https://quobix.com/vacuum",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func buildSarifResult(result *RuleFunctionResult, ruleIndex int, source, baseDir string) reports.SarifResult {
+	uri := source
+	if result.Origin != nil && result.Origin.AbsoluteLocation != "" {
+		uri = result.Origin.AbsoluteLocation
+	}
+
+	location := reports.SarifLocation{
+		PhysicalLocation: &reports.SarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation(uri, baseDir),
+			Region:           buildSarifRegion(result),
+		},
+	}
+	if result.Path != "" {
+		location.LogicalLocations = []reports.SarifLogicalLocation{{FullyQualifiedName: result.Path}}
+	}
+
+	return reports.SarifResult{
+		RuleId:    result.Rule.Id,
+		RuleIndex: ruleIndex,
+		Level:     sarifLevel(result.Rule.Severity),
+		Message:   reports.SarifMessage{Text: result.Message},
+		Locations: []reports.SarifLocation{location},
+	}
+}
+
+func buildSarifRule(rule *Rule) reports.SarifRule {
+	sr := reports.SarifRule{
+		Id:      rule.Id,
+		HelpURI: rule.DocumentationURL,
+		DefaultConfiguration: &reports.SarifRuleConfiguration{
+			Level: sarifLevel(rule.Severity),
+		},
+	}
+	if rule.Description != "" {
+		sr.ShortDescription = &reports.SarifMessage{Text: rule.Description}
+	}
+	if rule.HowToFix != "" {
+		sr.Help = &reports.SarifMessage{Text: rule.HowToFix}
+	}
+	if rule.RuleCategory != nil {
+		sr.Properties = &reports.SarifPropertyBag{
+			Category: rule.RuleCategory.Id,
+			Tags:     []string{rule.RuleCategory.Id},
+		}
+	}
+	return sr
+}
+
+// buildSarifRegion returns the region of a result, SARIF regions must start on line 1 or greater, so results
+// without a known location have no region.
+func buildSarifRegion(result *RuleFunctionResult) *reports.SarifRegion {
+	var region reports.SarifRegion
+	if result.StartNode != nil {
+		region.StartLine = result.StartNode.Line
+		region.StartColumn = result.StartNode.Column
+	}
+	if result.EndNode != nil {
+		region.EndLine = result.EndNode.Line
+		region.EndColumn = result.EndNode.Column
+	}
+	if result.Origin != nil {
+		region = reports.SarifRegion{
+			StartLine:   result.Origin.Line,
+			StartColumn: result.Origin.Column,
+		}
+	}
+	if region.StartLine < 1 {
+		return nil
+	}
+	if region.EndLine < region.StartLine ||
+		(region.EndLine == region.StartLine && region.EndColumn < region.StartColumn) {
+		region.EndLine = 0
+		region.EndColumn = 0
+	}
+	return &region
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarn:
+		return "warning"
+	case SeverityInfo, SeverityHint:
+		return "note"
+	}
+	return "none"
+}
+
+// sarifArtifactLocation converts a file location into an artifact location. Files inside the base directory are
+// relative to %SRCROOT%, so consumers like GitHub code scanning can match them to the repository, other files use
+// a 'file' URI.
+func sarifArtifactLocation(location, baseDir string) reports.SarifArtifactLocation {
+	if location == "" || strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return reports.SarifArtifactLocation{URI: location}
+	}
+	if baseDir == "" {
+		if !filepath.IsAbs(location) {
+			return reports.SarifArtifactLocation{URI: sarifRelativeURI(location)}
+		}
+		return reports.SarifArtifactLocation{URI: sarifFileURI(location)}
+	}
+	if !filepath.IsAbs(location) {
+		location = filepath.Join(baseDir, location)
+	}
+	if rel, err := filepath.Rel(baseDir, location); err == nil && rel != ".." &&
+		!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return reports.SarifArtifactLocation{URI: sarifRelativeURI(rel), URIBaseID: reports.SarifSourceRoot}
+	}
+	return reports.SarifArtifactLocation{URI: sarifFileURI(location)}
+}
+
+// sarifRelativeURI converts a relative file path into a relative URI reference.
+func sarifRelativeURI(path string) string {
+	u := url.URL{Path: filepath.ToSlash(path)}
+	return u.String()
+}
+
+// sarifFileURI converts an absolute file path into a 'file' URI. Windows drive letters become the first segment
+// of the path (file:///C:/specs/api.yaml), and UNC paths use the server as the host (file://server/share/api.yaml).
+func sarifFileURI(path string) string {
+	path = filepath.ToSlash(path)
+	u := url.URL{Scheme: "file", Path: path}
+	if strings.HasPrefix(path, "//") {
+		host, rest, _ := strings.Cut(strings.TrimPrefix(path, "//"), "/")
+		u.Host, u.Path = host, "/"+rest
+	} else if !strings.HasPrefix(path, "/") {
+		u.Path = "/" + path
+	}
+	return u.String()
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestRuleResultSet_GenerateSarifReport(t *testing.T) {
+	pathRule := &Rule{
+		Id:               "path-keys-no-trailing-slash",
+		Description:      "Path must not end with a slash",
+		DocumentationURL: "https://quobix.com/vacuum/rules/operations/path-keys-no-trailing-slash",
+		HowToFix:         "Remove the trailing slash",
+		Severity:         SeverityWarn,
+		RuleCategory:     RuleCategories[CategoryOperations],
+	}
+	infoRule := &Rule{
+		Id:       "info-contact",
+		Severity: SeverityInfo,
+	}
+
+	base := filepath.Join(t.TempDir(), "repo")
+	results := NewRuleResultSet([]RuleFunctionResult{
+		{
+			Rule:      pathRule,
+			Message:   "path ends with a slash",
+			Path:      "$.paths['/pizza/']",
+			StartNode: &yaml.Node{Line: 10, Column: 3},
+			EndNode:   &yaml.Node{Line: 10, Column: 11},
+		},
+		{
+			Rule:      pathRule,
+			Message:   "path ends with a slash",
+			Path:      "$.paths['/cake/']",
+			StartNode: &yaml.Node{Line: 20, Column: 3},
+			EndNode:   &yaml.Node{Line: 20, Column: 10},
+			Origin: &index.NodeOrigin{
+				Line:             5,
+				Column:           7,
+				AbsoluteLocation: filepath.Join(base, "specs", "cake.yaml"),
+			},
+		},
+		{
+			Rule:    infoRule,
+			Message: "no contact",
+		},
+	})
+
+	report := results.GenerateSarifReport("openapi.yaml", base, "1.2.3")
+	assert.Equal(t, "2.1.0", report.Version)
+	require.Len(t, report.Runs, 1)
+	assert.Equal(t, map[string]reports.SarifArtifactLocation{
+		"%SRCROOT%": {URI: "file://" + filepath.ToSlash(base) + "/"},
+	}, report.Runs[0].OriginalURIBaseIDs)
+
+	driver := report.Runs[0].Tool.Driver
+	assert.Equal(t, "vacuum", driver.Name)
+	assert.Equal(t, "1.2.3", driver.Version)
+	require.Len(t, driver.Rules, 2)
+	assert.Equal(t, "path-keys-no-trailing-slash", driver.Rules[0].Id)
+	assert.Equal(t, pathRule.DocumentationURL, driver.Rules[0].HelpURI)
+	assert.Equal(t, "Remove the trailing slash", driver.Rules[0].Help.Text)
+	assert.Equal(t, "warning", driver.Rules[0].DefaultConfiguration.Level)
+	assert.Nil(t, driver.Rules[1].Help)
+
+	res := report.Runs[0].Results
+	require.Len(t, res, 3)
+
+	assert.Equal(t, 0, res[0].RuleIndex)
+	assert.Equal(t, "warning", res[0].Level)
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "openapi.yaml", URIBaseID: "%SRCROOT%"},
+		res[0].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, &reports.SarifRegion{StartLine: 10, StartColumn: 3, EndLine: 10, EndColumn: 11},
+		res[0].Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, "$.paths['/pizza/']", res[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	// results from referenced documents point to that document.
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "specs/cake.yaml", URIBaseID: "%SRCROOT%"},
+		res[1].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, &reports.SarifRegion{StartLine: 5, StartColumn: 7}, res[1].Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, 1, res[2].RuleIndex)
+	assert.Equal(t, "note", res[2].Level)
+	assert.Nil(t, res[2].Locations[0].PhysicalLocation.Region)
+	assert.Empty(t, res[2].Locations[0].LogicalLocations)
+}
+
+func TestSarifArtifactLocation(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "repo")
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "https://pb33f.io/spec.yaml"},
+		sarifArtifactLocation("https://pb33f.io/spec.yaml", base))
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "specs/my%20spec.yaml", URIBaseID: "%SRCROOT%"},
+		sarifArtifactLocation(filepath.Join(base, "specs", "my spec.yaml"), base))
+
+	outside := filepath.Join(filepath.Dir(base), "spec.yaml")
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "file://" + filepath.ToSlash(outside)},
+		sarifArtifactLocation(outside, base))
+
+	// without a base directory, relative locations are kept as they are.
+	assert.Equal(t, reports.SarifArtifactLocation{URI: "specs/spec.yaml"},
+		sarifArtifactLocation(filepath.Join("specs", "spec.yaml"), ""))
+}
+
+func TestSarifFileURI(t *testing.T) {
+	assert.Equal(t, "file:///specs/api.yaml", sarifFileURI("/specs/api.yaml"))
+	assert.Equal(t, "file:///C:/specs/my%20api.yaml", sarifFileURI("C:/specs/my api.yaml"))
+	assert.Equal(t, "file://server/share/api.yaml", sarifFileURI("//server/share/api.yaml"))
+}
+
+func TestRuleResultSet_GenerateSarifReport_Suppressed(t *testing.T) {
+	rule := &Rule{Id: "info-contact", Severity: SeverityWarn}
+	results := NewRuleResultSet([]RuleFunctionResult{{Rule: rule, Message: "no contact", Path: "$.info"}})
+	results.Suppressed = []*SuppressedResult{{
+		Result:      &RuleFunctionResult{Rule: rule, Message: "no contact", Path: "$.info", StartNode: &yaml.Node{Line: 2, Column: 1}},
+		Suppression: IgnoredPath{Path: "$.info", Reason: "internal API", Owner: "platform"},
+	}}
+
+	report := results.GenerateSarifReport("openapi.yaml", "", "1.2.3")
+	require.Len(t, report.Runs[0].Results, 2)
+	assert.Len(t, report.Runs[0].Tool.Driver.Rules, 1)
+	assert.Empty(t, report.Runs[0].Results[0].Suppressions)
+
+	suppressed := report.Runs[0].Results[1]
+	assert.Equal(t, 0, suppressed.RuleIndex)
+	assert.Equal(t, []reports.SarifSuppression{{
+		Kind:          "external",
+		Status:        "accepted",
+		Justification: "internal API, owner: platform",
+	}}, suppressed.Suppressions)
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScoreModel describes how a quality score is calculated from a set of results. Every score starts at 100, and
+// each result deducts a weight from it. A scoring section can be set in a ruleset or in the configuration file:
+//
+//	scoring:
+//	  severity:
+//	    error: 20
+//	    warn: 1
+//	  categories:
+//	    security: 2        # security results deduct twice their severity weight
+//	  rules:
+//	    operation-tags: 5  # each operation-tags result deducts exactly 5
+//	  floors:
+//	    minimum: 0
+//	    noErrors: 50
+//	  fatal:
+//	    - oas3-schema
+//	  fatalPenalty: 100
+//
+// Anything left out keeps the value of the default model.
+type ScoreModel struct {
+	// Severity is the weight deducted for each result of a severity.
+	Severity map[string]float64 `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// Categories multiplies the severity weight of results in a rule category, the default multiplier is 1.
+	Categories map[string]float64 `json:"categories,omitempty" yaml:"categories,omitempty"`
+
+	// Rules is the weight deducted for each result of a rule, it replaces the severity and category weights.
+	Rules map[string]float64 `json:"rules,omitempty" yaml:"rules,omitempty"`
+
+	// Floors are the lowest scores that can be presented.
+	Floors *ScoreFloors `json:"floors,omitempty" yaml:"floors,omitempty"`
+
+	// Fatal are rules that mean the document is broken, each of their results deducts the fatal penalty.
+	Fatal []string `json:"fatal,omitempty" yaml:"fatal,omitempty"`
+
+	// FatalPenalty is deducted for each result of a fatal rule, on top of its weight.
+	FatalPenalty *float64 `json:"fatalPenalty,omitempty" yaml:"fatalPenalty,omitempty"`
+}
+
+// ScoreFloors are the lowest scores a ScoreModel will present.
+type ScoreFloors struct {
+	// Minimum is the score presented when results take the score to zero or below.
+	Minimum *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+
+	// NoErrors is the score presented when there are no errors, but other results take the score below zero.
+	NoErrors *float64 `json:"noErrors,omitempty" yaml:"noErrors,omitempty"`
+}
+
+// DefaultScoreModel returns the model vacuum has always used to score documents.
+func DefaultScoreModel() *ScoreModel {
+	minimum, noErrors, fatalPenalty := 10.0, 25.0, 90.0
+	return &ScoreModel{
+		Severity: map[string]float64{
+			SeverityError: 15,
+			SeverityWarn:  0.3,
+			SeverityInfo:  0.1,
+			SeverityHint:  0,
+		},
+		Categories: map[string]float64{},
+		Rules:      map[string]float64{},
+		Floors: &ScoreFloors{
+			Minimum:  &minimum,
+			NoErrors: &noErrors,
+		},
+		Fatal: []string{
+			"oas3-schema",
+			"asyncapi-2-document-resolved",
+			"asyncapi-3-document-resolved",
+			"asyncapi-3-document-unresolved",
+		},
+		FatalPenalty: &fatalPenalty,
+	}
+}
+
+// ResolveScoreModel merges each model, in order, over the default model. Nil models are skipped.
+func ResolveScoreModel(models ...*ScoreModel) *ScoreModel {
+	resolved := DefaultScoreModel()
+	for _, m := range models {
+		resolved.merge(m)
+	}
+	return resolved
+}
+
+// merge copies everything set in another model over this one. Weights are merged key by key, the fatal rules
+// are replaced as a whole so an empty list turns them off.
+func (s *ScoreModel) merge(other *ScoreModel) {
+	if other == nil {
+		return
+	}
+	for k, v := range other.Severity {
+		s.Severity[k] = v
+	}
+	for k, v := range other.Categories {
+		s.Categories[k] = v
+	}
+	for k, v := range other.Rules {
+		s.Rules[k] = v
+	}
+	if other.Floors != nil {
+		if other.Floors.Minimum != nil {
+			s.Floors.Minimum = other.Floors.Minimum
+		}
+		if other.Floors.NoErrors != nil {
+			s.Floors.NoErrors = other.Floors.NoErrors
+		}
+	}
+	if other.Fatal != nil {
+		s.Fatal = append([]string(nil), other.Fatal...)
+	}
+	if other.FatalPenalty != nil {
+		s.FatalPenalty = other.FatalPenalty
+	}
+}
+
+// Validate checks the severities are known, no weight is negative and the floors are between 0 and 100.
+func (s *ScoreModel) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, severity := range sortedKeys(s.Severity) {
+		switch severity {
+		case SeverityError, SeverityWarn, SeverityInfo, SeverityHint:
+		default:
+			return fmt.Errorf("scoring severity '%s' is not a valid severity (error, warn, info or hint)", severity)
+		}
+		if s.Severity[severity] < 0 {
+			return fmt.Errorf("scoring severity '%s' cannot have a negative weight", severity)
+		}
+	}
+	for _, category := range sortedKeys(s.Categories) {
+		if s.Categories[category] < 0 {
+			return fmt.Errorf("scoring category '%s' cannot have a negative weight", category)
+		}
+	}
+	for _, rule := range sortedKeys(s.Rules) {
+		if s.Rules[rule] < 0 {
+			return fmt.Errorf("scoring rule '%s' cannot have a negative weight", rule)
+		}
+	}
+	if s.Floors != nil {
+		if err := validateFloor("minimum", s.Floors.Minimum); err != nil {
+			return err
+		}
+		if err := validateFloor("noErrors", s.Floors.NoErrors); err != nil {
+			return err
+		}
+	}
+	if s.FatalPenalty != nil && *s.FatalPenalty < 0 {
+		return fmt.Errorf("scoring fatalPenalty cannot be negative")
+	}
+	return nil
+}
+
+// Deduction returns the weight a result deducts from the score, not including any fatal penalty.
+func (s *ScoreModel) Deduction(result *RuleFunctionResult) float64 {
+	if result == nil || result.Rule == nil {
+		return 0
+	}
+	if weight, ok := s.Rules[result.Rule.Id]; ok {
+		return weight
+	}
+	// results without a severity do not deduct anything.
+	weight := s.Severity[result.Rule.Severity]
+	if result.Rule.RuleCategory != nil {
+		if multiplier, ok := s.Categories[result.Rule.RuleCategory.Id]; ok {
+			weight = weight * multiplier
+		}
+	}
+	return weight
+}
+
+// IsFatal returns true if the result was produced by a fatal rule.
+func (s *ScoreModel) IsFatal(result *RuleFunctionResult) bool {
+	if result == nil || result.Rule == nil {
+		return false
+	}
+	for _, id := range s.Fatal {
+		if id == result.Rule.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// MinimumScore returns the score presented when results take the score to zero or below.
+func (s *ScoreModel) MinimumScore() float64 {
+	if s.Floors == nil || s.Floors.Minimum == nil {
+		return 0
+	}
+	return *s.Floors.Minimum
+}
+
+// NoErrorsScore returns the score presented when there are no errors but the score falls below zero, and false
+// if there is no such floor.
+func (s *ScoreModel) NoErrorsScore() (float64, bool) {
+	if s.Floors == nil || s.Floors.NoErrors == nil {
+		return 0, false
+	}
+	return *s.Floors.NoErrors, true
+}
+
+// FatalDeduction returns the penalty deducted for each result of a fatal rule.
+func (s *ScoreModel) FatalDeduction() float64 {
+	if s.FatalPenalty == nil {
+		return 0
+	}
+	return *s.FatalPenalty
+}
+
+func validateFloor(name string, floor *float64) error {
+	if floor != nil && (*floor < 0 || *floor > 100) {
+		return fmt.Errorf("scoring floor '%s' must be between 0 and 100", name)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
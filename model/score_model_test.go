@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestResolveScoreModel_MergesOverDefault(t *testing.T) {
+	var userModel ScoreModel
+	require.NoError(t, yaml.Unmarshal([]byte(`severity:
+  warn: 1
+categories:
+  security: 2
+rules:
+  operation-tags: 5
+floors:
+  noErrors: 50
+fatal: []
+`), &userModel))
+
+	resolved := ResolveScoreModel(&userModel, nil)
+
+	assert.Equal(t, 15.0, resolved.Severity[SeverityError])
+	assert.Equal(t, 1.0, resolved.Severity[SeverityWarn])
+	assert.Equal(t, 2.0, resolved.Categories["security"])
+	assert.Equal(t, 5.0, resolved.Rules["operation-tags"])
+	assert.Equal(t, 10.0, resolved.MinimumScore())
+	noErrors, ok := resolved.NoErrorsScore()
+	assert.True(t, ok)
+	assert.Equal(t, 50.0, noErrors)
+	assert.Empty(t, resolved.Fatal)
+	assert.Equal(t, 90.0, resolved.FatalDeduction())
+
+	// the default model is never modified.
+	assert.Equal(t, 0.3, DefaultScoreModel().Severity[SeverityWarn])
+	assert.Len(t, DefaultScoreModel().Fatal, 4)
+}
+
+func TestScoreModel_Deduction(t *testing.T) {
+	scoreModel := ResolveScoreModel(&ScoreModel{
+		Categories: map[string]float64{CategorySecurity: 2},
+		Rules:      map[string]float64{"operation-tags": 5},
+	})
+
+	warning := &RuleFunctionResult{Rule: &Rule{Id: "some-rule", Severity: SeverityWarn, RuleCategory: RuleCategories[CategoryInfo]}}
+	security := &RuleFunctionResult{Rule: &Rule{Id: "owasp-rule", Severity: SeverityError, RuleCategory: RuleCategories[CategorySecurity]}}
+	perRule := &RuleFunctionResult{Rule: &Rule{Id: "operation-tags", Severity: SeverityError, RuleCategory: RuleCategories[CategorySecurity]}}
+	noSeverity := &RuleFunctionResult{Rule: &Rule{Id: "custom"}}
+
+	assert.Equal(t, 0.3, scoreModel.Deduction(warning))
+	assert.Equal(t, 30.0, scoreModel.Deduction(security))
+	assert.Equal(t, 5.0, scoreModel.Deduction(perRule))
+	assert.Equal(t, 0.0, scoreModel.Deduction(noSeverity))
+	assert.Equal(t, 0.0, scoreModel.Deduction(&RuleFunctionResult{}))
+
+	assert.True(t, scoreModel.IsFatal(&RuleFunctionResult{Rule: &Rule{Id: "oas3-schema"}}))
+	assert.False(t, scoreModel.IsFatal(warning))
+}
+
+func TestScoreModel_Validate(t *testing.T) {
+	negative, outOfRange := -1.0, 101.0
+
+	assert.NoError(t, DefaultScoreModel().Validate())
+	assert.EqualError(t, (&ScoreModel{Severity: map[string]float64{"fatal": 1}}).Validate(),
+		"scoring severity 'fatal' is not a valid severity (error, warn, info or hint)")
+	assert.EqualError(t, (&ScoreModel{Severity: map[string]float64{SeverityWarn: -1}}).Validate(),
+		"scoring severity 'warn' cannot have a negative weight")
+	assert.EqualError(t, (&ScoreModel{Categories: map[string]float64{"schemas": -1}}).Validate(),
+		"scoring category 'schemas' cannot have a negative weight")
+	assert.EqualError(t, (&ScoreModel{Rules: map[string]float64{"oas3-schema": -1}}).Validate(),
+		"scoring rule 'oas3-schema' cannot have a negative weight")
+	assert.EqualError(t, (&ScoreModel{Floors: &ScoreFloors{Minimum: &outOfRange}}).Validate(),
+		"scoring floor 'minimum' must be between 0 and 100")
+	assert.EqualError(t, (&ScoreModel{FatalPenalty: &negative}).Validate(),
+		"scoring fatalPenalty cannot be negative")
+}
@@ -19,6 +19,14 @@ const (
 	OAS30               = "oas3_0" // exact 3.0 only - does not match 3.1 or 3.2
 	OAS31               = "oas3_1"
 	OAS32               = "oas3_2"
+	AsyncAPI2           = "asyncapi2"   // family format - matches all 2.x versions
+	AsyncAPI20          = "asyncapi2_0" // exact 2.0 only
+	AsyncAPI21          = "asyncapi2_1"
+	AsyncAPI22          = "asyncapi2_2"
+	AsyncAPI23          = "asyncapi2_3"
+	AsyncAPI24          = "asyncapi2_4"
+	AsyncAPI25          = "asyncapi2_5"
+	AsyncAPI26          = "asyncapi2_6"
 	AsyncAPI3           = "asyncapi3"
 	AsyncAPI30          = "asyncapi3_0"
 	AsyncAPI31          = "asyncapi3_1"
@@ -35,8 +43,11 @@ var AllExceptOAS3_1 = []string{OAS2, OAS30} // uses OAS30 to avoid matching 3.1
 var OAS3Format = []string{OAS3}
 var OAS3AllFormat = []string{OAS3, OAS31, OAS32}
 var OAS2Format = []string{OAS2}
+var AsyncAPI2Format = []string{AsyncAPI2}
+var AsyncAPI2AllFormats = []string{AsyncAPI2, AsyncAPI20, AsyncAPI21, AsyncAPI22, AsyncAPI23, AsyncAPI24, AsyncAPI25, AsyncAPI26}
 var AsyncAPI3Format = []string{AsyncAPI3}
 var AsyncAPI3AllFormats = []string{AsyncAPI3, AsyncAPI30, AsyncAPI31}
+var AsyncAPIAllFormats = append(append([]string{}, AsyncAPI2AllFormats...), AsyncAPI3AllFormats...)
 var AllFormats = []string{OAS3, OAS31, OAS32, OAS2}
 var JSONSchemaAllFormats = []string{JSONSchema, JSONSchemaDraft2020, JSONSchemaDraft2019, JSONSchemaDraft07}
 
@@ -60,6 +71,10 @@ func FormatMatches(ruleFormat, specFormat string) bool {
 	if ruleFormat == AsyncAPI3 && (specFormat == AsyncAPI30 || specFormat == AsyncAPI31) {
 		return true
 	}
+	// asyncapi2 is a family format that matches all 2.x versions
+	if ruleFormat == AsyncAPI2 && strings.HasPrefix(specFormat, AsyncAPI2+"_") {
+		return true
+	}
 	if ruleFormat == JSONSchema && (specFormat == JSONSchemaDraft2020 ||
 		specFormat == JSONSchemaDraft2019 || specFormat == JSONSchemaDraft07) {
 		return true
@@ -67,6 +82,11 @@ func FormatMatches(ruleFormat, specFormat string) bool {
 	return false
 }
 
+// IsAsyncAPIFormat returns true if the spec format is any AsyncAPI 2.x or 3.x format.
+func IsAsyncAPIFormat(format string) bool {
+	return FormatMatches(AsyncAPI2, format) || FormatMatches(AsyncAPI3, format)
+}
+
 // buildResultMessage efficiently builds a result message without fmt.Sprintf
 func buildResultMessage(key, message string, value interface{}) string {
 	var builder strings.Builder
@@ -35,8 +35,7 @@ func ApplyAsyncAPIRulesToRuleSet(
 	if format == "" {
 		detected, err := asyncapi_context.DetectFormat(execution.Spec)
 		if err != nil {
-			if errors.Is(err, libasyncapi.ErrAsyncAPI2NotSupported) ||
-				errors.Is(err, libasyncapi.ErrInvalidAsyncAPIVersion) ||
+			if errors.Is(err, libasyncapi.ErrInvalidAsyncAPIVersion) ||
 				errors.Is(err, libasyncapi.ErrNoAsyncAPIVersion) {
 				return &RuleSetExecutionResult{RuleSetExecution: execution, Errors: []error{err}}, true
 			}
@@ -47,7 +46,7 @@ func ApplyAsyncAPIRulesToRuleSet(
 		}
 		format = detected
 	}
-	if !isAsyncAPIFormat(format) {
+	if !model.IsAsyncAPIFormat(format) {
 		return nil, false
 	}
 
@@ -116,8 +115,10 @@ func ApplyAsyncAPIRulesToRuleSet(
 	execution.IndexResolved = asyncCtx.Index
 	execution.IndexUnresolved = asyncCtx.Index
 
-	documentResults := asyncAPIDocumentErrorResults(asyncCtx, asyncAPIDocumentErrorRule(execution.RuleSet))
-	ruleResults, ignoredResults, fixedResults, errs := runAsyncAPIRules(execution, opts, builtinFunctions, asyncCtx, logger)
+	documentResults := asyncAPIDocumentErrorResults(asyncCtx, asyncAPIDocumentErrorRule(execution.RuleSet, format))
+	overrides := execution.RuleSet.CompileOverrides(execution.SpecFileName)
+	ruleResults, ignoredResults, fixedResults, errs, ruleProfiles := runAsyncAPIRules(execution, opts, builtinFunctions,
+		asyncCtx, overrides, logger)
 	if len(documentResults) > 0 {
 		ruleResults = append(documentResults, ruleResults...)
 		ruleResults = dedupeAsyncAPIResults(ruleResults)
@@ -152,6 +153,8 @@ func ApplyAsyncAPIRulesToRuleSet(
 		false,
 	)
 
+	ruleResults = applyOverridesToResults(overrides, ruleResults)
+
 	return &RuleSetExecutionResult{
 		RuleSetExecution: execution,
 		Results:          ruleResults,
@@ -163,21 +166,26 @@ func ApplyAsyncAPIRulesToRuleSet(
 		FilesProcessed:   filesProcessed,
 		FileSize:         fileSize,
 		AsyncAPI:         asyncCtx,
+		RuleProfiles:     ruleProfiles,
 	}, true
 }
 
-func asyncAPIDocumentErrorRule(ruleSet *rulesets.RuleSet) *model.Rule {
+func asyncAPIDocumentErrorRule(ruleSet *rulesets.RuleSet, format string) *model.Rule {
+	ruleID, name := rulesets.AsyncAPI3DocumentResolved, "Check resolved AsyncAPI v3 document structure"
+	if model.FormatMatches(model.AsyncAPI2, format) {
+		ruleID, name = rulesets.AsyncAPI2DocumentResolved, "Check resolved AsyncAPI v2 document references"
+	}
 	if ruleSet != nil && ruleSet.Rules != nil {
-		if rule := ruleSet.Rules[rulesets.AsyncAPI3DocumentResolved]; rule != nil {
+		if rule := ruleSet.Rules[ruleID]; rule != nil {
 			return rule
 		}
 	}
-	if rule := rulesets.GetAllAsyncAPIRules()[rulesets.AsyncAPI3DocumentResolved]; rule != nil {
+	if rule := rulesets.GetAllAsyncAPIRules()[ruleID]; rule != nil {
 		return rule
 	}
 	return &model.Rule{
-		Id:           rulesets.AsyncAPI3DocumentResolved,
-		Name:         "Check resolved AsyncAPI v3 document structure",
+		Id:           ruleID,
+		Name:         name,
 		Severity:     model.SeverityError,
 		RuleCategory: model.RuleCategories[model.CategoryValidation],
 	}
@@ -242,28 +250,32 @@ func runAsyncAPIRules(
 	opts *ExecutionOptions,
 	builtinFunctions functions.Functions,
 	asyncCtx *asyncapi_context.Context,
+	overrides *rulesets.CompiledOverrides,
 	logger *slog.Logger,
-) ([]model.RuleFunctionResult, []model.RuleFunctionResult, []model.RuleFunctionResult, []error) {
+) ([]model.RuleFunctionResult, []model.RuleFunctionResult, []model.RuleFunctionResult, []error, []*RuleProfile) {
 	var ruleResults []model.RuleFunctionResult
 	var ignoredResults []model.RuleFunctionResult
 	var fixedResults []model.RuleFunctionResult
 	var errs []error
 	if execution.RuleSet == nil || asyncCtx.Index == nil {
-		return ruleResults, ignoredResults, fixedResults, errs
+		return ruleResults, ignoredResults, fixedResults, errs, nil
 	}
 
 	ignoreIdx := buildInlineIgnoreIndex(execution.CanonicalDocument)
 	specHasInlineIgnores := ignoreIdx != nil
 	resolvedAliases := resolveExecutionAliases(execution.RuleSet, asyncCtx.Format, logger)
+	customFunctions := resolveExecutionCustomFunctions(execution)
+	autoFixFunctions := resolveExecutionAutoFixFunctions(execution, customFunctions)
 
 	applicableRules := applicableRulesForFormat(execution.RuleSet, asyncCtx.Format)
+	applicableRules = applicableRulesForOverrides(overrides, applicableRules, execution.SpecFileName, asyncCtx.Rolodex)
 	totalRules := len(applicableRules)
 	if totalRules == 0 {
-		return ruleResults, ignoredResults, fixedResults, errs
+		return ruleResults, ignoredResults, fixedResults, errs, nil
 	}
 
 	var schemaPathCache sync.Map
-	runResults, runIgnored, runFixed, runErrs := runRuleContexts(
+	runResults, runIgnored, runFixes, runErrs, runProfiles := runRuleContexts(
 		execution,
 		applicableRules,
 		logger,
@@ -278,8 +290,8 @@ func runAsyncAPIRules(
 				index:              asyncCtx.Index,
 				indexUnresolved:    asyncCtx.Index,
 				asyncAPI:           asyncCtx,
-				customFunctions:    execution.CustomFunctions,
-				autoFixFunctions:   execution.AutoFixFunctions,
+				customFunctions:    customFunctions,
+				autoFixFunctions:   autoFixFunctions,
 				panicFunc:          execution.PanicFunction,
 				silenceLogs:        execution.SilenceLogs,
 				skipDocumentCheck:  execution.SkipDocumentCheck,
@@ -298,9 +310,11 @@ func runAsyncAPIRules(
 	)
 	ruleResults = append(ruleResults, runResults...)
 	ignoredResults = append(ignoredResults, runIgnored...)
+	runFixed, runUnfixed := applyPendingAutoFixes(applicableAutoFixes(overrides, runFixes))
 	fixedResults = append(fixedResults, runFixed...)
+	ruleResults = append(ruleResults, runUnfixed...)
 	errs = append(errs, runErrs...)
-	return ruleResults, ignoredResults, fixedResults, errs
+	return ruleResults, ignoredResults, fixedResults, errs, runProfiles
 }
 
 func asyncAPIIndexBuildRule() *model.Rule {
@@ -320,9 +334,3 @@ func asyncAPIIndexBuildRule() *model.Rule {
 		HowToFix: "An index is required to use vacuum. If an index cannot be created then the AsyncAPI document cannot be read. Check the document syntax.",
 	}
 }
-
-func isAsyncAPIFormat(format string) bool {
-	return format == model.AsyncAPI3 ||
-		format == model.AsyncAPI30 ||
-		format == model.AsyncAPI31
-}
@@ -81,19 +81,55 @@ paths: {}
 	assert.False(t, sawAsyncAPI.Load())
 }
 
-func TestAsyncAPI2ExecutionReturnsInputError(t *testing.T) {
+func TestAsyncAPI1ExecutionReturnsInputError(t *testing.T) {
 	result := ApplyRulesToRuleSet(&RuleSetExecution{
 		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
-		Spec: []byte(`asyncapi: 2.6.0
+		Spec: []byte(`asyncapi: 1.2.0
 info:
   title: Legacy
   version: 1.0.0
-channels: {}
+topics: {}
 `),
 	})
 
 	require.NotEmpty(t, result.Errors)
-	assert.True(t, errors.Is(result.Errors[0], libasyncapi.ErrAsyncAPI2NotSupported))
+	assert.True(t, errors.Is(result.Errors[0], libasyncapi.ErrInvalidAsyncAPIVersion))
+}
+
+func TestAsyncAPI2ExecutionRunsAsyncAPI2Rules(t *testing.T) {
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateAsyncAPIRecommendedRuleSet(),
+		Spec: []byte(`asyncapi: 2.6.0
+info:
+  title: Legacy
+  version: 1.0.0
+channels:
+  user/signedup:
+    publish:
+      operationId: onUser
+      message:
+        $ref: '#/components/messages/Missing'
+  user/deleted:
+    subscribe:
+      operationId: onUser
+components:
+  messages: {}
+`),
+	})
+
+	require.Empty(t, result.Errors)
+	require.NotNil(t, result.AsyncAPI)
+	assert.Equal(t, model.AsyncAPI26, result.SpecInfo.SpecFormat)
+
+	ruleIDs := make(map[string]bool)
+	for _, r := range result.Results {
+		ruleIDs[r.RuleId] = true
+	}
+	assert.True(t, ruleIDs[rulesets.AsyncAPI2DocumentResolved])
+	assert.True(t, ruleIDs[rulesets.AsyncAPI2OperationIdUniqueness])
+	assert.True(t, ruleIDs[rulesets.AsyncAPIInfoContact])
+	assert.False(t, ruleIDs[rulesets.AsyncAPI3Tags])
+	assert.False(t, ruleIDs[rulesets.AsyncAPI3DocumentResolved])
 }
 
 func TestMalformedAsyncAPIDoesNotEnterOpenAPIPath(t *testing.T) {
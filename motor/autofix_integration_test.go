@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/plugin/javascript"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pb33f/testify/assert"
 	"go.yaml.in/yaml/v4"
@@ -286,13 +287,13 @@ func TestAutoFixResolvedRuleSkipsWithoutUnresolvedIndex(t *testing.T) {
 	}
 
 	var ruleResults []model.RuleFunctionResult
-	var fixedResults []model.RuleFunctionResult
+	var autoFixes []pendingAutoFix
 	ctx := ruleContext{
 		rule:               rule,
 		specNodeUnresolved: &yaml.Node{},
 		autoFixFunctions:   map[string]model.AutoFixFunction{"fixDescription": fixDescription},
 		ruleResults:        &ruleResults,
-		fixedResults:       &fixedResults,
+		autoFixes:          &autoFixes,
 		silenceLogs:        true,
 		logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
 		indexUnresolved:    nil,
@@ -307,6 +308,58 @@ func TestAutoFixResolvedRuleSkipsWithoutUnresolvedIndex(t *testing.T) {
 	}, &model.RuleFunctionContext{})
 
 	assert.False(t, autoFixCalled)
-	assert.Equal(t, 0, len(fixedResults))
+	assert.Equal(t, 0, len(autoFixes))
 	assert.Equal(t, 1, len(ruleResults))
 }
+
+func TestAutoFixIntegration_JavaScriptFixHook(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+  description: ""
+`
+
+	jsFunction := javascript.NewJSRuleFunction("descriptionRequired", `
+function getSchema() {
+    return { "name": "descriptionRequired" };
+}
+
+function runRule(input) {
+    if (input === "") {
+        return [{ message: "description is empty" }];
+    }
+    return [];
+}
+
+function fix(node, document, context) {
+    return "A description of " + document.info.title;
+}`)
+
+	customRule := model.Rule{
+		Id:              "js-description-autofix",
+		Given:           "$.info.description",
+		Severity:        model.SeverityWarn,
+		AutoFixFunction: "fillDescription",
+		Then: &model.RuleAction{
+			Function: "descriptionRequired",
+		},
+	}
+
+	execution := &RuleSetExecution{
+		RuleSet:          &rulesets.RuleSet{Rules: map[string]*model.Rule{"js-description-autofix": &customRule}},
+		Spec:             []byte(spec),
+		SpecFileName:     "test.yaml",
+		ApplyAutoFixes:   true,
+		CustomFunctions:  map[string]model.RuleFunction{"descriptionRequired": jsFunction},
+		AutoFixFunctions: map[string]model.AutoFixFunction{},
+	}
+
+	result := ApplyRulesToRuleSet(execution)
+
+	assert.Empty(t, result.Results)
+	if assert.Len(t, result.FixedResults, 1) {
+		assert.Equal(t, "A description of Test API", result.FixedResults[0].StartNode.Value)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/daveshanley/vacuum/functions"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/mitchellh/mapstructure"
+	"go.yaml.in/yaml/v4"
+)
+
+// CatalogExecution is an instruction set for running catalog rules against a catalog, a set of documents linted
+// together. Catalog rules are the rules that use a model.CatalogRuleFunction, every other rule of the ruleset is
+// skipped (they have already run against each document on its own).
+type CatalogExecution struct {
+	RuleSet         *rulesets.RuleSet             // The RuleSet with the catalog rules to run.
+	Documents       []*model.CatalogDocument      // Every document of the catalog.
+	CustomFunctions map[string]model.RuleFunction // custom functions loaded from plugin.
+	PanicFunction   func(p any)                   // In case of emergency, do this thing here.
+	Logger          *slog.Logger                  // A custom logger.
+}
+
+// CatalogExecutionResult returns the results of running catalog rules against a catalog.
+type CatalogExecutionResult struct {
+	Results [][]model.RuleFunctionResult // The results of each document, in the same order as CatalogExecution.Documents.
+}
+
+// CatalogDocument returns the document linted by the execution, ready to be part of a CatalogExecution. The
+// document belongs to the result, so the catalog must be linted before the result is released.
+func (r *RuleSetExecutionResult) CatalogDocument(fileName string) *model.CatalogDocument {
+	doc := &model.CatalogDocument{
+		FileName: fileName,
+		SpecInfo: r.SpecInfo,
+		Index:    r.Index,
+		Document: r.unresolvedDoc,
+	}
+	if execution := r.RuleSetExecution; execution != nil {
+		if execution.IndexUnresolved != nil {
+			doc.Index = execution.IndexUnresolved
+		}
+		if doc.Document == nil {
+			doc.Document = execution.Document
+		}
+		doc.DrDocument = execution.DrDocument
+	}
+	return doc
+}
+
+// AddCatalogResults adds the results of catalog rules for the document linted by the execution. Catalog rules run
+// after the document has been linted, so the overrides of the execution are applied to them here, the same as every
+// other result of the document.
+func (r *RuleSetExecutionResult) AddCatalogResults(results []model.RuleFunctionResult) {
+	if execution := r.RuleSetExecution; execution != nil && execution.RuleSet != nil {
+		results = applyOverridesToResults(execution.RuleSet.CompileOverrides(execution.SpecFileName), results)
+	}
+	r.Results = append(r.Results, results...)
+}
+
+// ApplyCatalogRules runs every catalog rule of a ruleset once, against all the documents of a catalog. Each
+// violation is reported against the document it was found in.
+func ApplyCatalogRules(execution *CatalogExecution) *CatalogExecutionResult {
+	result := &CatalogExecutionResult{
+		Results: make([][]model.RuleFunctionResult, len(execution.Documents)),
+	}
+	if execution.RuleSet == nil || len(execution.Documents) == 0 {
+		return result
+	}
+
+	builtinFunctions := functions.MapBuiltinFunctions()
+	customFunctions := resolveExecutionCustomFunctions(&RuleSetExecution{
+		RuleSet:         execution.RuleSet,
+		CustomFunctions: execution.CustomFunctions,
+	})
+
+	positions := make(map[*model.CatalogDocument]int, len(execution.Documents))
+	for i, doc := range execution.Documents {
+		positions[doc] = i
+	}
+
+	ruleIds := make([]string, 0, len(execution.RuleSet.Rules))
+	for id, rule := range execution.RuleSet.Rules {
+		if rule != nil {
+			ruleIds = append(ruleIds, id)
+		}
+	}
+	sort.Strings(ruleIds)
+
+	for _, id := range ruleIds {
+		rule := execution.RuleSet.Rules[id]
+
+		var actions []model.RuleAction
+		var action model.RuleAction
+		if err := mapstructure.Decode(rule.Then, &action); err == nil {
+			actions = append(actions, action)
+		} else if err = mapstructure.Decode(rule.Then, &actions); err != nil {
+			continue
+		}
+
+		for _, ruleAction := range actions {
+			ruleFunction := builtinFunctions.FindFunction(ruleAction.Function)
+			if ruleFunction == nil && customFunctions != nil {
+				ruleFunction = customFunctions[ruleAction.Function]
+			}
+			catalogFunction, ok := ruleFunction.(model.CatalogRuleFunction)
+			if !ok {
+				continue
+			}
+
+			documents := catalogDocumentsForRule(execution.RuleSet, rule, execution.Documents)
+			if len(documents) == 0 {
+				continue
+			}
+
+			rfc := model.RuleFunctionContext{
+				Options:    ruleAction.FunctionOptions,
+				RuleAction: &ruleAction,
+				Rule:       rule,
+				Given:      rule.Given,
+				Logger:     execution.Logger,
+			}
+
+			// validate the rule is configured correctly before running it.
+			if valid, errs := model.ValidateRuleFunctionContextAgainstSchema(catalogFunction, rfc); !valid {
+				for _, doc := range documents {
+					for _, e := range errs {
+						result.Results[positions[doc]] = append(result.Results[positions[doc]], model.RuleFunctionResult{
+							Message:      e,
+							Rule:         rule,
+							StartNode:    &yaml.Node{},
+							EndNode:      &yaml.Node{},
+							RuleId:       rule.Id,
+							RuleSeverity: rule.Severity,
+							Path:         fmt.Sprint(rule.Given),
+						})
+					}
+				}
+				continue
+			}
+
+			for _, found := range runCatalogFunction(execution, catalogFunction, documents, rfc) {
+				position, known := positions[found.Document]
+				if !known {
+					continue
+				}
+				res := found.Result
+				if res.RuleId == "" {
+					res.RuleId = rule.Id
+				}
+				if res.RuleSeverity == "" {
+					res.RuleSeverity = rule.Severity
+				}
+				if res.Rule == nil {
+					res.Rule = rule
+				}
+				result.Results[position] = append(result.Results[position], res)
+			}
+		}
+	}
+	return result
+}
+
+func runCatalogFunction(execution *CatalogExecution, function model.CatalogRuleFunction,
+	documents []*model.CatalogDocument, rfc model.RuleFunctionContext) []model.CatalogRuleFunctionResult {
+	if execution.PanicFunction != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				execution.PanicFunction(r)
+			}
+		}()
+	}
+	return function.RunCatalogRule(documents, rfc)
+}
+
+// catalogDocumentsForRule returns the documents in a format the rule applies to, the same way
+// applicableRulesForFormat picks the rules for a single document.
+func catalogDocumentsForRule(ruleSet *rulesets.RuleSet, rule *model.Rule, documents []*model.CatalogDocument) []*model.CatalogDocument {
+	ruleFormats := applicableRuleFormats(ruleSet, rule)
+	var applicable []*model.CatalogDocument
+	for _, doc := range documents {
+		format := ""
+		if doc.SpecInfo != nil {
+			format = doc.SpecInfo.SpecFormat
+		}
+		if len(ruleFormats) == 0 && model.IsAsyncAPIFormat(format) {
+			continue
+		}
+		if len(ruleFormats) > 0 && format != "" {
+			matches := false
+			for _, ruleFormat := range ruleFormats {
+				if model.FormatMatches(ruleFormat, format) {
+					matches = true
+					break
+				}
+			}
+			if !matches {
+				continue
+			}
+		}
+		applicable = append(applicable, doc)
+	}
+	return applicable
+}
@@ -0,0 +1,134 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const catalogPetsSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listThings
+      responses:
+        '200':
+          description: ok`
+
+const catalogStoresSpec = `openapi: 3.1.0
+info:
+  title: Stores
+  version: 1.0.0
+paths:
+  /stores:
+    get:
+      operationId: listThings
+      responses:
+        '200':
+          description: ok`
+
+func lintCatalogTestSpec(t *testing.T, ruleSet *rulesets.RuleSet, customFunctions map[string]model.RuleFunction,
+	fileName, spec string) (*RuleSetExecutionResult, *model.CatalogDocument) {
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:         ruleSet,
+		Spec:            []byte(spec),
+		SpecFileName:    fileName,
+		CustomFunctions: customFunctions,
+	})
+	require.Empty(t, result.Errors)
+	t.Cleanup(result.ReleaseOwnedResources)
+	return result, result.CatalogDocument(fileName)
+}
+
+func TestApplyRulesToRuleSet_SkipsCatalogRules(t *testing.T) {
+	result, _ := lintCatalogTestSpec(t, rulesets.GenerateCatalogRuleSet(), nil, "pets.yaml", catalogPetsSpec)
+	assert.Empty(t, result.Results)
+}
+
+func TestApplyCatalogRules(t *testing.T) {
+	ruleSet := rulesets.GenerateCatalogRuleSet()
+	_, pets := lintCatalogTestSpec(t, ruleSet, nil, "pets.yaml", catalogPetsSpec)
+	_, stores := lintCatalogTestSpec(t, ruleSet, nil, "stores.yaml", catalogStoresSpec)
+
+	assert.NotNil(t, pets.DrDocument)
+	assert.NotNil(t, pets.Index)
+	assert.NotNil(t, pets.Document)
+
+	catalog := ApplyCatalogRules(&CatalogExecution{
+		RuleSet:   ruleSet,
+		Documents: []*model.CatalogDocument{pets, stores},
+	})
+
+	require.Len(t, catalog.Results, 2)
+	require.Len(t, catalog.Results[0], 1)
+	assert.Equal(t, rulesets.CatalogOperationIdUnique, catalog.Results[0][0].RuleId)
+	assert.Equal(t, model.SeverityWarn, catalog.Results[0][0].RuleSeverity)
+	assert.Equal(t, "the operationId `listThings` is also used by `stores.yaml`", catalog.Results[0][0].Message)
+	require.Len(t, catalog.Results[1], 1)
+	assert.Equal(t, "the operationId `listThings` is also used by `pets.yaml`", catalog.Results[1][0].Message)
+}
+
+func TestApplyCatalogRules_SkipsOtherFormats(t *testing.T) {
+	ruleSet := rulesets.GenerateCatalogRuleSet()
+	_, pets := lintCatalogTestSpec(t, ruleSet, nil, "pets.yaml", catalogPetsSpec)
+	_, stores := lintCatalogTestSpec(t, ruleSet, nil, "stores.yaml", catalogStoresSpec)
+	stores.SpecInfo.SpecFormat = model.OAS2
+
+	catalog := ApplyCatalogRules(&CatalogExecution{
+		RuleSet:   ruleSet,
+		Documents: []*model.CatalogDocument{pets, stores},
+	})
+	assert.Empty(t, catalog.Results[0])
+	assert.Empty(t, catalog.Results[1])
+}
+
+type panickingCatalogFunction struct{}
+
+func (p panickingCatalogFunction) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "panicking"}
+}
+
+func (p panickingCatalogFunction) GetCategory() string {
+	return model.FunctionCategoryCatalog
+}
+
+func (p panickingCatalogFunction) RunRule(_ []*yaml.Node, _ model.RuleFunctionContext) []model.RuleFunctionResult {
+	return nil
+}
+
+func (p panickingCatalogFunction) RunCatalogRule(_ []*model.CatalogDocument, _ model.RuleFunctionContext) []model.CatalogRuleFunctionResult {
+	panic("catalog on fire")
+}
+
+func TestApplyCatalogRules_CustomFunctionPanic(t *testing.T) {
+	ruleSet := &rulesets.RuleSet{
+		Rules: map[string]*model.Rule{
+			"catalog-panic": {
+				Id:       "catalog-panic",
+				Given:    "$",
+				Severity: model.SeverityError,
+				Then:     model.RuleAction{Function: "panicking"},
+			},
+		},
+	}
+	customFunctions := map[string]model.RuleFunction{"panicking": panickingCatalogFunction{}}
+	result, pets := lintCatalogTestSpec(t, ruleSet, customFunctions, "pets.yaml", catalogPetsSpec)
+	assert.Empty(t, result.Results)
+
+	var recovered any
+	catalog := ApplyCatalogRules(&CatalogExecution{
+		RuleSet:         ruleSet,
+		Documents:       []*model.CatalogDocument{pets},
+		CustomFunctions: customFunctions,
+		PanicFunction:   func(p any) { recovered = p },
+	})
+	assert.Equal(t, "catalog on fire", recovered)
+	assert.Empty(t, catalog.Results[0])
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	vacuumUtils "github.com/daveshanley/vacuum/utils"
@@ -83,6 +84,7 @@ type RuleSetExecution struct {
 	ExtractReferencesSequentially   bool                             // Extract references sequentially, defaults to false, can be slow.
 	ExtractReferencesFromExtensions bool                             // Extract references from extension objects (x-), this may pull in all kinds of non-parsable files in.
 	ApplyAutoFixes                  bool                             // Apply auto-fixes for rules that support it
+	ProfileRules                    bool                             // Record how each rule performed in RuleSetExecutionResult.RuleProfiles
 	CanonicalDocument               *yaml.Node                       // The single source of truth for all modifications
 
 	// https://pb33f.io/libopenapi/circular-references/#circular-reference-results
@@ -123,6 +125,7 @@ type RuleSetExecutionResult struct {
 	DocumentConfig   *datamodel.DocumentConfiguration // The document configuration used to create the document.
 	AsyncAPI         *asyncapi_context.Context        // The AsyncAPI context created for AsyncAPI execution.
 	ModifiedSpec     []byte                           // The spec with autofix changes applied (if any fixes were made).
+	RuleProfiles     []*RuleProfile                   // How each rule performed, only collected when RuleSetExecution.ProfileRules is set.
 	ownedDocument    libopenapi.Document
 	unresolvedDoc    libopenapi.Document
 	ownedIndex       *index.SpecIndex
@@ -135,7 +138,7 @@ type ruleContext struct {
 	builtinFunctions   functions.Functions
 	ruleResults        *[]model.RuleFunctionResult
 	ignoredResults     *[]model.RuleFunctionResult
-	fixedResults       *[]model.RuleFunctionResult
+	autoFixes          *[]pendingAutoFix
 	errors             *[]error
 	index              *index.SpecIndex
 	indexUnresolved    *index.SpecIndex
@@ -158,6 +161,7 @@ type ruleContext struct {
 	ignoreIndex        *inlineIgnoreIndex
 	schemaPathCache    *sync.Map
 	expandedAliases    map[string][]string // all aliases resolved for this spec's format; nil when no aliases
+	matchedNodes       *atomic.Int64       // counts the nodes matched by given paths, nil unless profiling
 }
 
 func (e *RuleLookupError) Error() string {
@@ -391,6 +395,7 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 	var ruleResults []model.RuleFunctionResult
 	var ignoredResults []model.RuleFunctionResult
 	var fixedResults []model.RuleFunctionResult
+	var ruleProfiles []*RuleProfile
 	// (ruleWaitGroup removed — synchronization uses done channel below)
 
 	if asyncResult, handled := ApplyAsyncAPIRulesToRuleSet(execution, &opts, builtinFunctions); handled {
@@ -1120,6 +1125,10 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 		ruleResults = append(ruleResults, res)
 	}
 
+	// Spectral-compatible overrides turn rules off (or on) per file and path, rules that are off everywhere do
+	// not run at all.
+	overrides := execution.RuleSet.CompileOverrides(execution.SpecFileName)
+
 	if execution.RuleSet != nil && indexUnresolved != nil {
 
 		// One-time scan for x-lint-ignore presence. Builds an index of node -> ignored rule IDs
@@ -1136,7 +1145,10 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 			specFormat = specInfo.SpecFormat
 		}
 		applicableRules := applicableRulesForFormat(execution.RuleSet, specFormat)
+		applicableRules = applicableRulesForOverrides(overrides, applicableRules, execution.SpecFileName, rolodexResolved)
 		resolvedAliases = resolveExecutionAliases(execution.RuleSet, specFormat, indexConfig.Logger)
+		customFunctions := resolveExecutionCustomFunctions(execution)
+		autoFixFunctions := resolveExecutionAutoFixFunctions(execution, customFunctions)
 
 		totalRules := len(applicableRules)
 		indexConfig.Logger.Debug("running rules", "total", totalRules, "filtered_from", len(execution.RuleSet.Rules))
@@ -1147,7 +1159,7 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 		// LocateModelsByKeyAndValue lookups.
 		var schemaPathCache sync.Map
 
-		runResults, runIgnored, runFixed, runErrs := runRuleContexts(
+		runResults, runIgnored, runFixes, runErrs, runProfiles := runRuleContexts(
 			execution,
 			applicableRules,
 			docConfigResolved.Logger,
@@ -1198,8 +1210,8 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 					indexUnresolved:    indexUnresolved,
 					document:           ruleDocument,
 					drDocument:         drDocument,
-					customFunctions:    execution.CustomFunctions,
-					autoFixFunctions:   execution.AutoFixFunctions,
+					customFunctions:    customFunctions,
+					autoFixFunctions:   autoFixFunctions,
 					panicFunc:          execution.PanicFunction,
 					silenceLogs:        execution.SilenceLogs,
 					skipDocumentCheck:  execution.SkipDocumentCheck,
@@ -1218,8 +1230,11 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 		)
 		ruleResults = append(ruleResults, runResults...)
 		ignoredResults = append(ignoredResults, runIgnored...)
+		runFixed, runUnfixed := applyPendingAutoFixes(applicableAutoFixes(overrides, runFixes))
 		fixedResults = append(fixedResults, runFixed...)
+		ruleResults = append(ruleResults, runUnfixed...)
 		errs = append(errs, runErrs...)
+		ruleProfiles = runProfiles
 		then = time.Since(now).Milliseconds()
 		indexConfig.Logger.Debug("rules completed", "totalRules", totalRules, "ms", then)
 	}
@@ -1254,6 +1269,8 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 		true,
 	)
 
+	ruleResults = applyOverridesToResults(overrides, ruleResults)
+
 	then = time.Since(now).Milliseconds()
 	indexConfig.Logger.Debug("applied all rules and completed", "ms", then)
 
@@ -1282,6 +1299,7 @@ func ApplyRulesToRuleSetWithOptions(execution *RuleSetExecution, executionOption
 		FileSize:         fileSize,
 		DocumentConfig:   docConfigResolved,
 		ModifiedSpec:     modifiedSpec,
+		RuleProfiles:     ruleProfiles,
 	}
 	ownedResources.transfer(result)
 	return result
@@ -1370,6 +1388,9 @@ func runRule(ctx ruleContext, doneChan chan struct{}) {
 			})
 			return
 		}
+		if ctx.matchedNodes != nil {
+			ctx.matchedNodes.Add(int64(len(nodes)))
+		}
 		if len(nodes) <= 0 {
 			continue
 		}
@@ -1421,6 +1442,11 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 		}
 	}
 
+	// catalog rules check every document at once, they only run in ApplyCatalogRules.
+	if _, ok := ruleFunction.(model.CatalogRuleFunction); ok {
+		return ctx.ruleResults
+	}
+
 	if ruleFunction != nil {
 
 		rfc := model.RuleFunctionContext{
@@ -1642,8 +1668,8 @@ func applyAutoFixesToResults(ctx ruleContext, results []model.RuleFunctionResult
 		nodeToFix := results[i].StartNode
 		if resolvedExecution {
 			if ctx.indexUnresolved != nil {
-				origin := ctx.indexUnresolved.FindNodeOrigin(results[i].StartNode)
-				if origin == nil || origin.Node == nil {
+				nodeToFix = FindCanonicalNode(ctx.indexUnresolved, results[i].StartNode)
+				if nodeToFix == nil {
 					if !ctx.silenceLogs {
 						ctx.logger.Warn("Auto-fix skipped: unable to map resolved node to canonical document",
 							"ruleId", ctx.rule.Id, "path", results[i].Path)
@@ -1651,7 +1677,6 @@ func applyAutoFixesToResults(ctx ruleContext, results []model.RuleFunctionResult
 					*ctx.ruleResults = append(*ctx.ruleResults, results[i])
 					continue
 				}
-				nodeToFix = origin.Node
 			} else {
 				if !ctx.silenceLogs {
 					ctx.logger.Warn("Auto-fix skipped: unresolved index not available",
@@ -1662,20 +1687,79 @@ func applyAutoFixesToResults(ctx ruleContext, results []model.RuleFunctionResult
 			}
 		}
 
-		_, err := autoFixFunc(nodeToFix, ctx.specNodeUnresolved, rfc)
-		if err != nil {
-			if !ctx.silenceLogs {
-				ctx.logger.Warn("Auto-fix failed", "ruleId", ctx.rule.Id, "error", err)
+		// other rules are still reading the canonical document, so the fix is applied once they have all finished.
+		*ctx.autoFixes = append(*ctx.autoFixes, pendingAutoFix{
+			result:      results[i],
+			node:        nodeToFix,
+			document:    ctx.specNodeUnresolved,
+			fix:         autoFixFunc,
+			context:     *rfc,
+			logger:      ctx.logger,
+			silenceLogs: ctx.silenceLogs,
+		})
+	}
+}
+
+// pendingAutoFix is a result that can be auto-fixed, and everything needed to fix it once the rules have finished.
+type pendingAutoFix struct {
+	result      model.RuleFunctionResult
+	node        *yaml.Node
+	document    *yaml.Node
+	fix         model.AutoFixFunction
+	context     model.RuleFunctionContext
+	logger      *slog.Logger
+	silenceLogs bool
+}
+
+// applyPendingAutoFixes applies auto-fixes one after another, after every rule has finished reading the document.
+// The results that were fixed are returned, along with the results that could not be.
+func applyPendingAutoFixes(fixes []pendingAutoFix) ([]model.RuleFunctionResult, []model.RuleFunctionResult) {
+	var fixed, unfixed []model.RuleFunctionResult
+	// fixes find the parents of the nodes they change, so each document is only indexed once for all of them.
+	parents := make(map[*yaml.Node]*model.NodeParentIndex)
+	for _, pending := range fixes {
+		result := pending.result
+		if pending.document != nil {
+			if parents[pending.document] == nil {
+				parents[pending.document] = model.NewNodeParentIndex(pending.document)
 			}
-			*ctx.ruleResults = append(*ctx.ruleResults, results[i])
-		} else {
-			results[i].AutoFixed = true
-			*ctx.fixedResults = append(*ctx.fixedResults, results[i])
-			if !ctx.silenceLogs {
-				ctx.logger.Debug("Auto-fix applied", "ruleId", ctx.rule.Id, "path", results[i].Path)
+			pending.context.NodeParents = parents[pending.document]
+		}
+		if _, err := pending.fix(pending.node, pending.document, &pending.context); err != nil {
+			if !pending.silenceLogs && pending.logger != nil {
+				pending.logger.Warn("Auto-fix failed", "ruleId", result.RuleId, "error", err)
 			}
+			unfixed = append(unfixed, result)
+			continue
+		}
+		result.AutoFixed = true
+		fixed = append(fixed, result)
+		if !pending.silenceLogs && pending.logger != nil {
+			pending.logger.Debug("Auto-fix applied", "ruleId", result.RuleId, "path", result.Path)
 		}
 	}
+	return fixed, unfixed
+}
+
+// FindCanonicalNode maps a node from the resolved document back to the node it originated from in the
+// unresolved (canonical) document, which is the document auto-fixes are applied to. Returns nil if the
+// origin of the node cannot be found.
+func FindCanonicalNode(indexUnresolved *index.SpecIndex, node *yaml.Node) *yaml.Node {
+	if indexUnresolved == nil || node == nil {
+		return nil
+	}
+	origin := indexUnresolved.FindNodeOrigin(node)
+	if origin == nil || origin.Node == nil {
+		return nil
+	}
+	canonical := origin.Node
+	// the first key of a map shares a position with the map itself, so the origin lookup returns the map.
+	if node.Kind == yaml.ScalarNode && canonical.Kind == yaml.MappingNode && len(canonical.Content) > 0 &&
+		canonical.Content[0].Line == node.Line && canonical.Content[0].Column == node.Column &&
+		canonical.Content[0].Value == node.Value {
+		canonical = canonical.Content[0]
+	}
+	return canonical
 }
 
 // buildNodeOwnerCache creates a reverse lookup from yaml.Node pointers to the
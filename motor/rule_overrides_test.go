@@ -0,0 +1,148 @@
+package motor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func overridesTestRuleSet(t *testing.T, base, ruleset string) *rulesets.RuleSet {
+	supplied, err := rulesets.CreateRuleSetFromData([]byte(ruleset))
+	require.NoError(t, err)
+	supplied.OverridesBase = base
+	return rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(supplied)
+}
+
+func resultsForRule(results []model.RuleFunctionResult, ruleId string) []model.RuleFunctionResult {
+	var found []model.RuleFunctionResult
+	for _, result := range results {
+		if result.RuleId == ruleId {
+			found = append(found, result)
+		}
+	}
+	return found
+}
+
+func TestApplyRulesToRuleSet_OverridesMultiFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "legacy"), 0o755))
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: overrides
+  version: 1.0.0
+paths:
+  /v1/pets:
+    $ref: './legacy/pets.yaml'
+  /v2/pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: ok
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "legacy", "pets.yaml"), []byte(`get:
+  operationId: listLegacyPets
+  responses:
+    '200':
+      description: ok
+`), 0o644))
+	specFile := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, os.WriteFile(specFile, spec, 0o644))
+
+	rs := overridesTestRuleSet(t, dir, `extends: [[vacuum:oas, off]]
+rules:
+  operation-description: true
+overrides:
+  - files: ["legacy/**/*.yaml"]
+    rules:
+      operation-description: off
+  - files: ["openapi.yaml#/paths/~1v2~1pets"]
+    rules:
+      operation-description: error
+      operation-tags: warn
+`)
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:      rs,
+		Spec:         spec,
+		SpecFileName: specFile,
+		Base:         dir,
+	})
+
+	descriptions := resultsForRule(result.Results, rulesets.OperationDescription)
+	require.Len(t, descriptions, 1)
+	assert.Equal(t, "$.paths['/v2/pets'].get", descriptions[0].Path)
+	assert.Equal(t, model.SeverityError, descriptions[0].Rule.Severity)
+	assert.Equal(t, model.SeverityError, descriptions[0].RuleSeverity)
+
+	// operation-tags is not part of the ruleset, so it only runs where the override turns it on.
+	tags := resultsForRule(result.Results, rulesets.OperationTags)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "$.paths['/v2/pets'].get", tags[0].Path)
+}
+
+func TestApplyRulesToRuleSet_OverridesSkipAutoFixes(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "openapi.yaml")
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: overrides
+  version: 1.0.0
+  description: ""
+`)
+	require.NoError(t, os.WriteFile(specFile, spec, 0o644))
+
+	fixes := 0
+	fixDescription := func(node *yaml.Node, _ *yaml.Node, _ *model.RuleFunctionContext) (*yaml.Node, error) {
+		fixes++
+		node.Value = "fixed"
+		return node, nil
+	}
+	lint := func(files string) *RuleSetExecutionResult {
+		rule := &model.Rule{
+			Id:              "empty-description",
+			Given:           "$.info.description",
+			Severity:        model.SeverityWarn,
+			AutoFixFunction: "fixDescription",
+			Then:            &model.RuleAction{Function: "truthy"},
+		}
+		return ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet: &rulesets.RuleSet{
+				Rules:         map[string]*model.Rule{rule.Id: rule},
+				OverridesBase: dir,
+				Overrides: []*rulesets.RuleSetOverride{
+					{Files: []string{files}, Rules: map[string]interface{}{rule.Id: "off"}},
+				},
+			},
+			Spec:             spec,
+			SpecFileName:     specFile,
+			Base:             dir,
+			ApplyAutoFixes:   true,
+			AutoFixFunctions: map[string]model.AutoFixFunction{"fixDescription": fixDescription},
+			SilenceLogs:      true,
+		})
+	}
+
+	// a rule turned off for the whole file does not run.
+	result := lint("openapi.yaml")
+	assert.Zero(t, fixes)
+	assert.Empty(t, result.FixedResults)
+	assert.Empty(t, result.ModifiedSpec)
+
+	// a rule turned off for a path runs, but cannot fix the document there.
+	result = lint("openapi.yaml#/info")
+	assert.Zero(t, fixes)
+	assert.Empty(t, result.FixedResults)
+	assert.Empty(t, resultsForRule(result.Results, "empty-description"))
+
+	result = lint("legacy/*.yaml")
+	assert.Equal(t, 1, fixes)
+	require.Len(t, result.FixedResults, 1)
+	assert.Contains(t, string(result.ModifiedSpec), "fixed")
+}
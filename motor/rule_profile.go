@@ -0,0 +1,39 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// NearTimeoutRatio is the share of RuleSetExecution.Timeout a rule can use before its profile is flagged as
+// coming close to the timeout.
+const NearTimeoutRatio = 0.8
+
+// RuleProfile records how a single rule performed during an execution. Profiles are only collected when
+// RuleSetExecution.ProfileRules is set.
+type RuleProfile struct {
+	RuleId       string        // The ID of the rule.
+	Duration     time.Duration // Wall time spent running the rule, auto-fixes run later and are not included.
+	NodesMatched int           // The number of nodes matched by the rule's given paths.
+	Results      int           // The number of results produced, including ignored and fixed results.
+	TimedOut     bool          // The rule ran out of time and its results were discarded.
+	NearTimeout  bool          // The rule finished, but used more than NearTimeoutRatio of the timeout.
+}
+
+func newRuleProfile(rule *model.Rule, start time.Time, timeout time.Duration, matched *atomic.Int64, results int, timedOut bool) *RuleProfile {
+	duration := time.Since(start)
+	return &RuleProfile{
+		RuleId:       rule.Id,
+		Duration:     duration,
+		NodesMatched: int(matched.Load()),
+		Results:      results,
+		TimedOut:     timedOut,
+		NearTimeout:  !timedOut && float64(duration) >= float64(timeout)*NearTimeoutRatio,
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"context"
 	"log/slog"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/index"
 )
 
 const (
@@ -26,8 +28,9 @@ type ruleContextResult struct {
 	index          int
 	ruleResults    []model.RuleFunctionResult
 	ignoredResults []model.RuleFunctionResult
-	fixedResults   []model.RuleFunctionResult
+	autoFixes      []pendingAutoFix
 	errors         []error
+	profile        *RuleProfile
 }
 
 type ruleJob struct {
@@ -40,14 +43,15 @@ func runRuleContexts(
 	rules []*model.Rule,
 	logger *slog.Logger,
 	buildContext ruleContextBuilder,
-) ([]model.RuleFunctionResult, []model.RuleFunctionResult, []model.RuleFunctionResult, []error) {
+) ([]model.RuleFunctionResult, []model.RuleFunctionResult, []pendingAutoFix, []error, []*RuleProfile) {
 	var ruleResults []model.RuleFunctionResult
 	var ignoredResults []model.RuleFunctionResult
-	var fixedResults []model.RuleFunctionResult
+	var autoFixes []pendingAutoFix
 	var errs []error
+	var profiles []*RuleProfile
 
 	if execution == nil || len(rules) == 0 {
-		return ruleResults, ignoredResults, fixedResults, errs
+		return ruleResults, ignoredResults, autoFixes, errs, profiles
 	}
 	if execution.Timeout <= 0 {
 		execution.Timeout = time.Second * 5
@@ -83,10 +87,13 @@ func runRuleContexts(
 	for _, result := range resultsByRule {
 		ruleResults = append(ruleResults, result.ruleResults...)
 		ignoredResults = append(ignoredResults, result.ignoredResults...)
-		fixedResults = append(fixedResults, result.fixedResults...)
+		autoFixes = append(autoFixes, result.autoFixes...)
 		errs = append(errs, result.errors...)
+		if result.profile != nil {
+			profiles = append(profiles, result.profile)
+		}
 	}
-	return ruleResults, ignoredResults, fixedResults, errs
+	return ruleResults, ignoredResults, autoFixes, errs, profiles
 }
 
 func ruleConcurrencyLimit(ruleCount int) int {
@@ -116,14 +123,21 @@ func executeRuleContext(
 
 	localResults := []model.RuleFunctionResult{}
 	localIgnored := []model.RuleFunctionResult{}
-	localFixed := []model.RuleFunctionResult{}
+	localFixes := []pendingAutoFix{}
 	localErrs := []error{}
 	localCtx := ctx
 	localCtx.ruleResults = &localResults
 	localCtx.ignoredResults = &localIgnored
-	localCtx.fixedResults = &localFixed
+	localCtx.autoFixes = &localFixes
 	localCtx.errors = &localErrs
 
+	// the matched node count is atomic, a rule that timed out may still be counting.
+	var matchedNodes atomic.Int64
+	if execution.ProfileRules {
+		localCtx.matchedNodes = &matchedNodes
+	}
+	start := time.Now()
+
 	go runRule(localCtx, doneChan)
 	select {
 	case <-timeoutCtx.Done():
@@ -132,14 +146,23 @@ func executeRuleContext(
 		}
 		// runRule is not cancellable; on timeout its goroutine may finish later,
 		// writing only to these orphaned local slices.
-		return ruleContextResult{}
+		var result ruleContextResult
+		if execution.ProfileRules {
+			result.profile = newRuleProfile(rule, start, execution.Timeout, &matchedNodes, 0, true)
+		}
+		return result
 	case <-doneChan:
-		return ruleContextResult{
+		result := ruleContextResult{
 			ruleResults:    localResults,
 			ignoredResults: localIgnored,
-			fixedResults:   localFixed,
+			autoFixes:      localFixes,
 			errors:         localErrs,
 		}
+		if execution.ProfileRules {
+			result.profile = newRuleProfile(rule, start, execution.Timeout, &matchedNodes,
+				len(localResults)+len(localIgnored)+len(localFixes), false)
+		}
+		return result
 	}
 }
 
@@ -153,7 +176,7 @@ func applicableRulesForFormat(ruleSet *rulesets.RuleSet, format string) []*model
 			continue
 		}
 		ruleFormats := applicableRuleFormats(ruleSet, rule)
-		if len(ruleFormats) == 0 && model.FormatMatches(model.AsyncAPI3, format) {
+		if len(ruleFormats) == 0 && model.IsAsyncAPIFormat(format) {
 			continue
 		}
 		if len(ruleFormats) > 0 && format != "" {
@@ -200,3 +223,111 @@ func resolveExecutionAliases(ruleSet *rulesets.RuleSet, format string, logger *s
 	}
 	return expanded
 }
+
+// resolveExecutionCustomFunctions returns the functions shipped with the ruleset, merged with the custom functions
+// of the execution. Functions supplied to the execution take precedence.
+func resolveExecutionCustomFunctions(execution *RuleSetExecution) map[string]model.RuleFunction {
+	if execution.RuleSet == nil || len(execution.RuleSet.LoadedFunctions) == 0 {
+		return execution.CustomFunctions
+	}
+	merged := make(map[string]model.RuleFunction, len(execution.RuleSet.LoadedFunctions)+len(execution.CustomFunctions))
+	for name, function := range execution.RuleSet.LoadedFunctions {
+		merged[name] = function
+	}
+	for name, function := range execution.CustomFunctions {
+		merged[name] = function
+	}
+	return merged
+}
+
+// resolveExecutionAutoFixFunctions adds the fix hooks of custom functions to the auto-fix functions of the execution,
+// under the autoFixFunction name of every rule that uses them. Auto-fix functions supplied to the execution take
+// precedence.
+func resolveExecutionAutoFixFunctions(execution *RuleSetExecution, customFunctions map[string]model.RuleFunction) map[string]model.AutoFixFunction {
+	if !execution.ApplyAutoFixes || execution.RuleSet == nil || len(customFunctions) == 0 {
+		return execution.AutoFixFunctions
+	}
+	// functions are checked in name order, so a rule using more than one function with a fix hook always gets the same one.
+	var providers []string
+	for name, function := range customFunctions {
+		if _, ok := function.(model.AutoFixProvider); ok {
+			providers = append(providers, name)
+		}
+	}
+	sort.Strings(providers)
+
+	var resolved map[string]model.AutoFixFunction
+	for _, rule := range execution.RuleSet.Rules {
+		if rule == nil || rule.AutoFixFunction == "" {
+			continue
+		}
+		if _, exists := execution.AutoFixFunctions[rule.AutoFixFunction]; exists {
+			continue
+		}
+		for _, name := range providers {
+			if !ruleUsesFunction(rule, name) {
+				continue
+			}
+			if fix := customFunctions[name].(model.AutoFixProvider).GetAutoFixFunction(); fix != nil {
+				if resolved == nil {
+					resolved = make(map[string]model.AutoFixFunction, len(execution.AutoFixFunctions)+1)
+					for k, v := range execution.AutoFixFunctions {
+						resolved[k] = v
+					}
+				}
+				resolved[rule.AutoFixFunction] = fix
+				break
+			}
+		}
+	}
+	if resolved == nil {
+		return execution.AutoFixFunctions
+	}
+	return resolved
+}
+
+// applicableRulesForOverrides drops the rules that overrides turn off for the specification and every file it
+// references, they cannot report anything, and their auto-fixes must not change the document.
+func applicableRulesForOverrides(overrides *rulesets.CompiledOverrides, rules []*model.Rule,
+	specFileName string, rolodex *index.Rolodex) []*model.Rule {
+	if overrides == nil {
+		return rules
+	}
+	files := []string{specFileName}
+	if rolodex != nil {
+		for _, idx := range rolodex.GetIndexes() {
+			if idx != nil && idx.GetSpecAbsolutePath() != "" {
+				files = append(files, idx.GetSpecAbsolutePath())
+			}
+		}
+	}
+	applicable := make([]*model.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if overrides.RuleRuns(rule.Id, files) {
+			applicable = append(applicable, rule)
+		}
+	}
+	return applicable
+}
+
+// applyOverridesToResults applies Spectral-compatible overrides to the results of an execution. Overrides change
+// rules per file and path, so they are applied once every result knows where it came from (after its origin and
+// path have been finalized).
+func applyOverridesToResults(overrides *rulesets.CompiledOverrides, results []model.RuleFunctionResult) []model.RuleFunctionResult {
+	return overrides.Apply(results)
+}
+
+// applicableAutoFixes drops the auto-fixes of results whose rule is turned off by overrides where the result
+// was found, only paths of a file can be turned off before the rules run.
+func applicableAutoFixes(overrides *rulesets.CompiledOverrides, fixes []pendingAutoFix) []pendingAutoFix {
+	if overrides == nil {
+		return fixes
+	}
+	applicable := make([]pendingAutoFix, 0, len(fixes))
+	for _, pending := range fixes {
+		if overrides.Enabled(&pending.result) {
+			applicable = append(applicable, pending)
+		}
+	}
+	return applicable
+}
@@ -154,3 +154,67 @@ paths: {}`
 	assert.Greater(t, tracker.maxActive.Load(), int32(1))
 	assert.Less(t, elapsed, 500*time.Millisecond)
 }
+
+func TestRuleProfiles(t *testing.T) {
+	yml := `openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /a:
+    get: {}
+  /b:
+    get: {}`
+
+	newRule := func(id, given, function string) *model.Rule {
+		return &model.Rule{
+			Id:           id,
+			Given:        given,
+			RuleCategory: model.RuleCategories[model.CategoryValidation],
+			Type:         rulesets.Validation,
+			Severity:     model.SeverityError,
+			Then:         model.RuleAction{Function: function},
+		}
+	}
+	ex := &RuleSetExecution{
+		RuleSet: &rulesets.RuleSet{
+			Rules: map[string]*model.Rule{
+				"fast":  newRule("fast", "$.paths[*]", "fast"),
+				"close": newRule("close", "$", "close"),
+				"slow":  newRule("slow", "$", "slow"),
+			},
+		},
+		Spec:    []byte(yml),
+		Timeout: 100 * time.Millisecond,
+		CustomFunctions: map[string]model.RuleFunction{
+			"fast":  &slowRule{},
+			"close": &slowRule{sleep: 85 * time.Millisecond},
+			"slow":  &slowRule{sleep: 300 * time.Millisecond},
+		},
+		ProfileRules: true,
+		SilenceLogs:  true,
+	}
+
+	results := ApplyRulesToRuleSet(ex)
+	profiles := make(map[string]*RuleProfile)
+	for _, profile := range results.RuleProfiles {
+		profiles[profile.RuleId] = profile
+	}
+	assert.Len(t, profiles, 3)
+
+	assert.Equal(t, 2, profiles["fast"].NodesMatched)
+	assert.Equal(t, 2, profiles["fast"].Results)
+	assert.False(t, profiles["fast"].NearTimeout)
+
+	assert.True(t, profiles["close"].NearTimeout)
+	assert.False(t, profiles["close"].TimedOut)
+	assert.GreaterOrEqual(t, profiles["close"].Duration, 85*time.Millisecond)
+
+	assert.True(t, profiles["slow"].TimedOut)
+	assert.False(t, profiles["slow"].NearTimeout)
+	assert.Equal(t, 0, profiles["slow"].Results)
+
+	ex.ProfileRules = false
+	ex.RuleSet.Rules = map[string]*model.Rule{"fast": newRule("fast", "$.paths[*]", "fast")}
+	assert.Empty(t, ApplyRulesToRuleSet(ex).RuleProfiles)
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package parser
+
+import _ "embed"
+
+// OpenAPI3Schema is the JSON Schema (in YAML) that describes the structure of an OpenAPI 3 document.
+//
+//go:embed schemas/oas3-schema.yaml
+var OpenAPI3Schema string
+
+// Swagger2Schema is the JSON Schema (in YAML) that describes the structure of a Swagger 2 document.
+//
+//go:embed schemas/swagger2-schema.yaml
+var Swagger2Schema string
+
+// AsyncAPI2Schema is the JSON Schema (in YAML) that describes the structure of an AsyncAPI 2 document.
+//
+//go:embed schemas/asyncapi2-schema.yaml
+var AsyncAPI2Schema string
+
+// AsyncAPI3Schema is the JSON Schema (in YAML) that describes the structure of an AsyncAPI 3 document.
+//
+//go:embed schemas/asyncapi3-schema.yaml
+var AsyncAPI3Schema string
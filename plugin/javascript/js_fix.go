@@ -0,0 +1,174 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package javascript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/plugin/javascript/eventloop"
+	"github.com/daveshanley/vacuum/plugin/javascript/eventloop/fetch"
+	"github.com/dop251/goja"
+	"go.yaml.in/yaml/v4"
+)
+
+// HasFix returns true if the script defines a `fix` hook.
+func (j *JSRuleFunction) HasFix() bool {
+	j.l.Lock()
+	defer j.l.Unlock()
+
+	if !j.scriptParsed {
+		if err := j.runScriptUnsafe(); err != nil {
+			return false
+		}
+	}
+	_, ok := goja.AssertFunction(j.runtime.Get("fix"))
+	return ok
+}
+
+// GetAutoFixFunction returns an auto-fix function that runs the `fix` hook of the script, or nil if the script
+// does not define one. The hook is registered under the autoFixFunction name of every rule using this function.
+// Each call returns a new fix session, which is asked for once per rule run, so the script is run and the document
+// is decoded once per run, not once per violation.
+func (j *JSRuleFunction) GetAutoFixFunction() model.AutoFixFunction {
+	if !j.HasFix() {
+		return nil
+	}
+	return (&fixSession{function: j}).fix
+}
+
+// Fix runs the `fix(node, document, context)` hook of the script. The hook receives the node of a violation and the
+// whole document as plain values, and returns a replacement value (or a Promise of one). The replacement is converted
+// back into YAML and replaces the node in place.
+func (j *JSRuleFunction) Fix(node *yaml.Node, document *yaml.Node, ruleContext *model.RuleFunctionContext) (*yaml.Node, error) {
+	return (&fixSession{function: j}).fix(node, document, ruleContext)
+}
+
+// fixSession runs the fix hook for every violation of a rule run, using a single runtime. The document is decoded
+// the first time it is fixed, so the hook sees the document as it was before any fix of the run was applied.
+type fixSession struct {
+	function      *JSRuleFunction
+	l             sync.Mutex
+	rt            *goja.Runtime
+	loop          *eventloop.EventLoop
+	fixFn         goja.Callable
+	document      *yaml.Node
+	documentValue goja.Value
+}
+
+func (s *fixSession) fix(node *yaml.Node, document *yaml.Node, ruleContext *model.RuleFunctionContext) (*yaml.Node, error) {
+	j := s.function
+	if node == nil {
+		return nil, fmt.Errorf("no node to fix for JavaScript function '%s'", j.ruleName)
+	}
+	if ruleContext == nil {
+		ruleContext = &model.RuleFunctionContext{}
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.rt == nil {
+		if err := s.start(ruleContext); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.rt.Set("context", *ruleContext); err != nil {
+		return nil, fmt.Errorf("unable to set context in JavaScript function '%s': %w", j.ruleName, err)
+	}
+	if s.documentValue == nil || document != s.document {
+		var documentValue interface{}
+		if document != nil {
+			documentValue = decodeNodeForJS(document)
+		}
+		s.document, s.documentValue = document, s.rt.ToValue(documentValue)
+	}
+	nodeValue := s.rt.ToValue(decodeNodeForJS(node))
+	contextValue := s.rt.ToValue(*ruleContext)
+
+	ctx, cancel := context.WithTimeout(context.Background(), j.getTimeout())
+	defer cancel()
+
+	output, rErr := s.loop.Run(ctx, func(vm *goja.Runtime) (goja.Value, error) {
+		return s.fixFn(goja.Undefined(), nodeValue, s.documentValue, contextValue)
+	})
+	if rErr != nil {
+		if errors.Is(rErr, context.DeadlineExceeded) {
+			// the runtime may still be busy, the next fix starts a new one.
+			s.rt = nil
+			return nil, fmt.Errorf("JavaScript fix '%s' timed out after %v", j.ruleName, j.getTimeout())
+		}
+		var jsErr *goja.Exception
+		if errors.As(rErr, &jsErr) {
+			return nil, fmt.Errorf("unable to execute JavaScript fix '%s': %s", j.ruleName, jsErr.Value().String())
+		}
+		return nil, fmt.Errorf("JavaScript fix '%s' failed: %w", j.ruleName, rErr)
+	}
+
+	exported, err := eventloop.ExtractPromiseValue(output)
+	if err != nil {
+		return nil, err
+	}
+	if exported == nil {
+		return nil, fmt.Errorf("JavaScript fix '%s' did not return a replacement value", j.ruleName)
+	}
+
+	var replacement yaml.Node
+	if err = replacement.Encode(exported); err != nil {
+		return nil, fmt.Errorf("unable to convert the value returned by JavaScript fix '%s': %w", j.ruleName, err)
+	}
+	replaceNodeValue(node, &replacement)
+	return node, nil
+}
+
+// start builds the runtime and event loop of the session, and runs the script.
+func (s *fixSession) start(ruleContext *model.RuleFunctionContext) error {
+	j := s.function
+	rt := BuildVM()
+	loop := eventloop.New(rt)
+
+	fetchModule, fetchErr := fetch.NewFetchModuleFromConfig(loop, ruleContext.FetchConfig)
+	if fetchErr != nil {
+		return fmt.Errorf("failed to configure fetch() for JavaScript function '%s': %w", j.ruleName, fetchErr)
+	}
+	fetchModule.Register()
+
+	if err := rt.Set("context", *ruleContext); err != nil {
+		return fmt.Errorf("unable to set context in JavaScript function '%s': %w", j.ruleName, err)
+	}
+	if _, err := rt.RunString(j.script); err != nil {
+		return fmt.Errorf("unable to run JavaScript function '%s': %w", j.ruleName, err)
+	}
+	for name, function := range j.coreFunctions {
+		if err := rt.Set(fmt.Sprintf("vacuum_%s", name), function); err != nil {
+			return fmt.Errorf("unable to set core vacuum function '%s': '%s': %w", name, j.ruleName, err)
+		}
+	}
+
+	fixFn, ok := goja.AssertFunction(rt.Get("fix"))
+	if !ok {
+		return fmt.Errorf("'fix' is not defined as a JavaScript function: '%s'", j.ruleName)
+	}
+	s.rt, s.loop, s.fixFn = rt, loop, fixFn
+	s.document, s.documentValue = nil, nil
+	return nil
+}
+
+// replaceNodeValue replaces the value of a node in place, so the parent of the node does not need to be found.
+// Comments and the position of the original node are kept.
+func replaceNodeValue(node, replacement *yaml.Node) {
+	if replacement.Kind == yaml.DocumentNode && len(replacement.Content) > 0 {
+		replacement = replacement.Content[0]
+	}
+	node.Kind = replacement.Kind
+	node.Tag = replacement.Tag
+	node.Value = replacement.Value
+	node.Style = replacement.Style
+	node.Content = replacement.Content
+	node.Alias = replacement.Alias
+}
@@ -0,0 +1,108 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func parseFixTestDocument(t *testing.T, spec string) *yaml.Node {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &doc))
+	return &doc
+}
+
+func Test_JSPlugin_Fix_ReplacesScalar(t *testing.T) {
+	script := `function runRule(input) { return []; }
+function fix(node, document, context) {
+    return node.toUpperCase() + " for " + document.info.title;
+}`
+	f := NewJSRuleFunction("upper", script).(*JSRuleFunction)
+	require.True(t, f.HasFix())
+	require.NotNil(t, f.GetAutoFixFunction())
+
+	doc := parseFixTestDocument(t, "info:\n  title: pets\n  description: hello # keep me\n")
+	node := doc.Content[0].Content[1].Content[3]
+
+	fixed, err := f.Fix(node, doc, &model.RuleFunctionContext{})
+	require.NoError(t, err)
+	assert.Same(t, node, fixed)
+	assert.Equal(t, "HELLO for pets", node.Value)
+	assert.Equal(t, "# keep me", node.LineComment)
+}
+
+func Test_JSPlugin_Fix_ReplacesMapping(t *testing.T) {
+	script := `function runRule(input) { return []; }
+async function fix(node, document, context) {
+    return { name: "MIT", url: "https://opensource.org/licenses/MIT" };
+}`
+	f := NewJSRuleFunction("license", script).(*JSRuleFunction)
+
+	doc := parseFixTestDocument(t, "info:\n  license: MIT\n")
+	node := doc.Content[0].Content[1].Content[1]
+
+	_, err := f.Fix(node, doc, nil)
+	require.NoError(t, err)
+
+	out, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "name: MIT")
+	assert.Contains(t, string(out), "url: https://opensource.org/licenses/MIT")
+}
+
+func Test_JSPlugin_Fix_SessionReusesRuntimeAndDocument(t *testing.T) {
+	script := `var fixes = 0;
+function runRule(input) { return []; }
+function fix(node, document, context) {
+    fixes++;
+    document.seen = (document.seen || 0) + 1;
+    return node + "-" + fixes + "-" + document.seen;
+}`
+	f := NewJSRuleFunction("session", script).(*JSRuleFunction)
+	doc := parseFixTestDocument(t, "a: b\nc: d\n")
+
+	// the script runs once, and the document is decoded once, for every fix of a rule run.
+	fix := f.GetAutoFixFunction()
+	require.NotNil(t, fix)
+	_, err := fix(doc.Content[0].Content[1], doc, nil)
+	require.NoError(t, err)
+	_, err = fix(doc.Content[0].Content[3], doc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "b-1-1", doc.Content[0].Content[1].Value)
+	assert.Equal(t, "d-2-2", doc.Content[0].Content[3].Value)
+
+	// a new rule run starts again.
+	_, err = f.GetAutoFixFunction()(doc.Content[0].Content[3], doc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "d-2-2-1-1", doc.Content[0].Content[3].Value)
+}
+
+func Test_JSPlugin_Fix_NoHook(t *testing.T) {
+	f := NewJSRuleFunction("nofix", `function runRule(input) { return []; }`).(*JSRuleFunction)
+	assert.False(t, f.HasFix())
+	assert.Nil(t, f.GetAutoFixFunction())
+}
+
+func Test_JSPlugin_Fix_NoReplacement(t *testing.T) {
+	f := NewJSRuleFunction("empty", `function runRule(input) { return []; }
+function fix(node) { return null; }`).(*JSRuleFunction)
+
+	doc := parseFixTestDocument(t, "a: b\n")
+	node := doc.Content[0].Content[1]
+
+	_, err := f.Fix(node, doc, nil)
+	assert.ErrorContains(t, err, "did not return a replacement value")
+	assert.Equal(t, "b", node.Value)
+}
+
+func Test_JSPlugin_Fix_Exception(t *testing.T) {
+	f := NewJSRuleFunction("boom", `function runRule(input) { return []; }
+function fix(node) { throw new Error("cannot fix"); }`).(*JSRuleFunction)
+
+	doc := parseFixTestDocument(t, "a: b\n")
+	_, err := f.Fix(doc.Content[0].Content[1], doc, nil)
+	assert.ErrorContains(t, err, "cannot fix")
+}
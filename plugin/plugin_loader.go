@@ -1,15 +1,20 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"github.com/daveshanley/vacuum/functions/core"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/plugin/javascript"
+	"github.com/daveshanley/vacuum/plugin/wasm"
+	"github.com/daveshanley/vacuum/rulesets"
 	"go.yaml.in/yaml/v4"
+	"net/http"
 	"os"
 	"path/filepath"
 	"plugin"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -62,39 +67,115 @@ func LoadFunctions(path string, silence bool) (*Manager, error) {
 				return nil, e
 			}
 
-			function := javascript.NewJSRuleFunction(fName, string(p))
+			if err = registerJavaScriptFunction(pm, fName, string(p), fPath, silence); err != nil {
+				fmt.Printf("✗ Failed to load function '%s': %s\n", fName, err.Error())
+				continue // Skip registering invalid functions
+			}
+		}
 
-			// found something
-			if !silence {
-				fmt.Printf("● Located custom javascript function: '%s' from file: %s\n", function.GetSchema().Name, fPath)
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".wasm") {
+			fPath := filepath.Join(path, entry.Name())
+			fName := strings.TrimSuffix(entry.Name(), ".wasm")
+
+			// let's try and read the file
+			p, e := os.ReadFile(fPath)
+			if e != nil {
+				return nil, e
 			}
-			// check if the function is valid
-			sErr := function.CheckScript()
 
-			if sErr != nil {
-				fmt.Printf("✗ Failed to load function '%s': %s\n", fName, sErr.Error())
+			if err = registerWasmFunction(pm, fName, p, fPath, silence); err != nil {
+				fmt.Printf("✗ Failed to load function '%s': %s\n", fName, err.Error())
 				continue // Skip registering invalid functions
-			} else {
-				if !silence {
-					fmt.Printf("✓ Successfully validated JavaScript function: '%s'\n", fName)
-				}
 			}
+		}
+	}
+	return pm, nil
+}
 
-			// register core functions with this custom function.
-			RegisterCoreFunctions(function)
-
-			// register this function with the plugin manager using the schema name
-			schemaName := function.GetSchema().Name
-			pm.RegisterFunction(schemaName, function)
+// LoadRuleSetFunctions will load the JavaScript functions shipped with a ruleset (declared using `functions` and
+// `functionsDir`). Functions of remote rulesets are downloaded from next to the ruleset, and must match the digest
+// they are pinned to with `functionDigests`. Unlike LoadFunctions, a function that cannot be loaded is an error,
+// because the rules of the ruleset depend on it.
+func LoadRuleSetFunctions(rs *rulesets.RuleSet, httpClient *http.Client, silence bool) (*Manager, error) {
+	pm := CreatePluginManager()
+	locations := rs.FunctionLocations()
+	pins := rs.FunctionPins()
 
-			if !silence {
-				fmt.Printf("● Registered custom function: '%s' -> available for use in rulesets\n", schemaName)
-			}
+	names := make([]string, 0, len(locations))
+	for name := range locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		location := locations[name]
+		var script []byte
+		var err error
+		if strings.HasPrefix(location, "http") {
+			script, err = rulesets.DownloadRemoteRuleSetFile(context.Background(), location, pins[name], httpClient)
+		} else {
+			script, err = os.ReadFile(location)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to load function '%s' from '%s': %w", name, location, err)
+		}
+		if err = registerJavaScriptFunction(pm, name, string(script), location, silence); err != nil {
+			return nil, fmt.Errorf("unable to load function '%s' from '%s': %w", name, location, err)
 		}
 	}
 	return pm, nil
 }
 
+// registerJavaScriptFunction checks a JavaScript function and registers it with the plugin manager, using the
+// name from its schema.
+func registerJavaScriptFunction(pm *Manager, name, script, location string, silence bool) error {
+	function := javascript.NewJSRuleFunction(name, script)
+
+	// found something
+	if !silence {
+		fmt.Printf("● Located custom javascript function: '%s' from file: %s\n", function.GetSchema().Name, location)
+	}
+	// check if the function is valid
+	if sErr := function.CheckScript(); sErr != nil {
+		return sErr
+	}
+	if !silence {
+		fmt.Printf("✓ Successfully validated JavaScript function: '%s'\n", name)
+	}
+
+	// register core functions with this custom function.
+	RegisterCoreFunctions(function)
+
+	// register this function with the plugin manager using the schema name
+	schemaName := function.GetSchema().Name
+	pm.RegisterFunction(schemaName, function)
+
+	if !silence {
+		fmt.Printf("● Registered custom function: '%s' -> available for use in rulesets\n", schemaName)
+	}
+	return nil
+}
+
+// registerWasmFunction compiles a WebAssembly function and registers it with the plugin manager, using the
+// name from its schema.
+func registerWasmFunction(pm *Manager, name string, binary []byte, location string, silence bool) error {
+	function, err := wasm.NewWasmRuleFunction(name, binary)
+	if err != nil {
+		return err
+	}
+
+	schemaName := function.GetSchema().Name
+	if !silence {
+		fmt.Printf("● Located custom WebAssembly function: '%s' from file: %s\n", schemaName, location)
+	}
+	pm.RegisterFunction(schemaName, function)
+
+	if !silence {
+		fmt.Printf("● Registered custom function: '%s' -> available for use in rulesets\n", schemaName)
+	}
+	return nil
+}
+
 var extractInput = func(input any) *yaml.Node {
 	var y yaml.Node
 	switch reflect.TypeOf(input).Kind() {
@@ -1,8 +1,20 @@
 package plugin
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -47,3 +59,86 @@ func TestLoadFunctions_TestCompile(t *testing.T) {
 		assert.Equal(t, 0, pm.LoadedFunctionCount())
 	}
 }
+
+func TestLoadRuleSetFunctions(t *testing.T) {
+	script, err := os.ReadFile("sample/js/useless_func.js")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/governance/ruleset.yaml":
+			_, _ = rw.Write([]byte(`extends: [[vacuum:oas, off]]
+functionsDir: js
+functions: [useless_func]
+rules:
+  use-remote-function:
+    given: $.info
+    then:
+      function: uselessFunc`))
+		case "/governance/js/useless_func.js":
+			_, _ = rw.Write(script)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	downloaded, err := rulesets.DownloadRemoteRuleSet(context.Background(), server.URL+"/governance/ruleset.yaml", nil)
+	require.NoError(t, err)
+	rs := rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(downloaded)
+	assert.Equal(t, server.URL+"/governance/js/useless_func.js", rs.FunctionLocations()["useless_func"])
+
+	pm, err := LoadRuleSetFunctions(rs, nil, true)
+	require.NoError(t, err)
+	assert.NotNil(t, pm.GetCustomFunctions()["uselessFunc"])
+
+	// a pinned function must match its digest.
+	sum := sha256.Sum256(script)
+	downloaded.FunctionDigests = map[string]string{"useless_func": hex.EncodeToString(sum[:])}
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(downloaded)
+	_, err = LoadRuleSetFunctions(rs, nil, true)
+	require.NoError(t, err)
+
+	downloaded.FunctionDigests = map[string]string{"useless_func": strings.Repeat("0", 64)}
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(downloaded)
+	_, err = LoadRuleSetFunctions(rs, nil, true)
+	assert.ErrorIs(t, err, rulesets.ErrRuleSetDigestMismatch)
+
+	downloaded.FunctionDigests = nil
+	downloaded.Functions = []string{"missing"}
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(downloaded)
+	_, err = LoadRuleSetFunctions(rs, nil, true)
+	assert.ErrorContains(t, err, "unable to load function 'missing'")
+}
+
+// noopWasmFunction is a minimal WebAssembly module implementing the vacuum ABI, with a schema named 'wasmNoop'
+// and a vacuum_run_rule that always returns no results.
+var noopWasmFunction = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x10, 0x03, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x60, 0x00, 0x01, 0x7e, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e, 0x03, 0x04, 0x03, 0x00, 0x01, 0x02,
+	0x05, 0x03, 0x01, 0x00, 0x01, 0x06, 0x07, 0x01, 0x7f, 0x01, 0x41, 0x80, 0x08, 0x0b, 0x07, 0x3b,
+	0x04, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x0c, 0x76, 0x61, 0x63, 0x75, 0x75,
+	0x6d, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x00, 0x00, 0x0d, 0x76, 0x61, 0x63, 0x75, 0x75, 0x6d,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x00, 0x01, 0x0f, 0x76, 0x61, 0x63, 0x75, 0x75, 0x6d,
+	0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x00, 0x02, 0x0a, 0x22, 0x03, 0x0b, 0x00,
+	0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00, 0x0b, 0x09, 0x00, 0x42, 0x93, 0x80, 0x80,
+	0x80, 0x80, 0x02, 0x0b, 0x0a, 0x00, 0x42, 0x82, 0x80, 0x80, 0x80, 0x80, 0xc0, 0x00, 0x0b, 0x0b,
+	0x21, 0x02, 0x00, 0x41, 0x10, 0x0b, 0x13, 0x7b, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22,
+	0x77, 0x61, 0x73, 0x6d, 0x4e, 0x6f, 0x6f, 0x70, 0x22, 0x7d, 0x00, 0x41, 0x80, 0x04, 0x0b, 0x02,
+	0x5b, 0x5d,
+}
+
+func TestLoadFunctions_Wasm(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "noop.wasm"), noopWasmFunction, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.wasm"), []byte("not wasm"), 0o644))
+
+	pm, err := LoadFunctions(dir, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pm.LoadedFunctionCount())
+
+	fn := pm.GetCustomFunctions()["wasmNoop"]
+	require.NotNil(t, fn)
+	assert.Equal(t, model.FunctionCategoryCustomWasm, fn.GetCategory())
+	assert.Empty(t, fn.RunRule([]*yaml.Node{{Kind: yaml.ScalarNode, Value: "pets"}}, model.RuleFunctionContext{}))
+}
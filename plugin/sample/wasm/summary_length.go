@@ -0,0 +1,93 @@
+//go:build wasip1
+
+// summary_length is an example custom function compiled to WebAssembly. Build it with Go (1.24+):
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o summaryLength.wasm summary_length.go
+//
+// The same module can be built by TinyGo, or written in any other language, as long as it exports the
+// functions described by the vacuum WebAssembly ABI.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"unsafe"
+)
+
+func main() {}
+
+type ruleContext struct {
+	RuleId  string         `json:"ruleId"`
+	Given   any            `json:"given"`
+	Options map[string]any `json:"options"`
+}
+
+type ruleInput struct {
+	Node    map[string]any `json:"node"`
+	Context ruleContext    `json:"context"`
+}
+
+type ruleResult struct {
+	Message string `json:"message"`
+}
+
+// buffers keeps memory handed to vacuum alive, until vacuum frees it.
+var buffers = map[uintptr][]byte{}
+
+//go:wasmexport vacuum_alloc
+func vacuumAlloc(size uint32) uint32 {
+	if size == 0 {
+		size = 1
+	}
+	buf := make([]byte, size)
+	ptr := uintptr(unsafe.Pointer(&buf[0]))
+	buffers[ptr] = buf
+	return uint32(ptr)
+}
+
+//go:wasmexport vacuum_free
+func vacuumFree(ptr, _ uint32) {
+	delete(buffers, uintptr(ptr))
+}
+
+//go:wasmexport vacuum_schema
+func vacuumSchema() uint64 {
+	return output(map[string]any{
+		"name": "summaryLength",
+		"properties": []map[string]any{
+			{"name": "min", "description": "minimum length of an operation summary"},
+		},
+	})
+}
+
+//go:wasmexport vacuum_run_rule
+func vacuumRunRule(ptr, size uint32) uint64 {
+	input := unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), size)
+
+	var in ruleInput
+	if err := json.Unmarshal(input, &in); err != nil {
+		return output([]ruleResult{{Message: fmt.Sprintf("invalid input: %s", err)}})
+	}
+
+	min := 10
+	if v, ok := in.Context.Options["min"].(float64); ok {
+		min = int(v)
+	}
+
+	results := []ruleResult{}
+	summary, _ := in.Node["summary"].(string)
+	if len(summary) < min {
+		results = append(results, ruleResult{
+			Message: fmt.Sprintf("operation summary '%s' must be at least %d characters", summary, min),
+		})
+	}
+	return output(results)
+}
+
+// output encodes a value as JSON, and returns its location packed as (ptr << 32) | len.
+func output(v any) uint64 {
+	encoded, _ := json.Marshal(v)
+	ptr := vacuumAlloc(uint32(len(encoded)))
+	copy(buffers[uintptr(ptr)], encoded)
+	return uint64(ptr)<<32 | uint64(len(encoded))
+}
@@ -0,0 +1,397 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+// Package wasm runs custom functions compiled to WebAssembly, using wazero (a pure Go runtime). Unlike Go plugins,
+// WebAssembly functions do not depend on the Go toolchain or dependencies used to build vacuum, so they can be
+// written in any language that compiles to WebAssembly (Rust, TinyGo, Go, AssemblyScript, ...) and distributed
+// independently of vacuum releases.
+//
+// # ABI
+//
+// All data is exchanged as UTF-8 JSON in the linear memory of the module. A module must export:
+//
+//   - memory: the linear memory of the module.
+//   - vacuum_alloc(size i32) i32: returns a pointer to size bytes of memory, the host writes input there.
+//   - vacuum_run_rule(ptr i32, len i32) i64: runs the function against the input at ptr, and returns the
+//     location of the output, packed as (ptr << 32) | len.
+//
+// A module may also export:
+//
+//   - vacuum_schema() i64: returns the location of a JSON RuleFunctionSchema (name, properties, required,
+//     minProperties, maxProperties, errorMessage), packed as (ptr << 32) | len. Without it, the name of the file
+//     (without .wasm) is used as the function name.
+//   - vacuum_free(ptr i32, len i32): called by the host for every buffer it no longer needs, the input it
+//     allocated with vacuum_alloc as well as the output of vacuum_run_rule and vacuum_schema.
+//   - _initialize: called after instantiation, this is how WASI reactors (e.g. Go c-shared or TinyGo modules)
+//     initialize themselves.
+//
+// WASI preview 1 is available to modules, with stderr connected to the stderr of vacuum.
+//
+// The input of vacuum_run_rule is a JSON object, vacuum_run_rule is called once for every node matched by the
+// rule.
+//
+//	{
+//	  "node": <the matched node as a plain JSON value>,
+//	  "context": {"ruleId": "...", "given": "...", "field": "...", "options": {...}}
+//	}
+//
+// The output of vacuum_run_rule is a JSON array of results, an empty array means there are no violations. The
+// path of a result defaults to the given path of the rule.
+//
+//	[{"message": "summary must not be empty", "path": "$.paths['/pets'].get.summary"}]
+//
+// A module is instantiated for every run of a rule, and the nodes matched by the rule are passed to that instance
+// one at a time, so functions do not need to be safe for concurrent use.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"go.yaml.in/yaml/v4"
+)
+
+// DefaultRuleTimeout is the default timeout for running a WebAssembly function against a single node.
+const DefaultRuleTimeout = 5 * time.Second
+
+const (
+	memoryExport  = "memory"
+	allocExport   = "vacuum_alloc"
+	freeExport    = "vacuum_free"
+	runRuleExport = "vacuum_run_rule"
+	schemaExport  = "vacuum_schema"
+	initExport    = "_initialize"
+)
+
+// WasmRuleFunction is a custom function compiled to WebAssembly.
+type WasmRuleFunction struct {
+	name        string
+	runtime     wazero.Runtime
+	compiled    wazero.CompiledModule
+	schema      model.RuleFunctionSchema
+	ruleTimeout time.Duration
+}
+
+// functionContext is the part of the model.RuleFunctionContext passed to WebAssembly functions.
+type functionContext struct {
+	RuleId  string      `json:"ruleId,omitempty"`
+	Given   interface{} `json:"given,omitempty"`
+	Field   string      `json:"field,omitempty"`
+	Options interface{} `json:"options,omitempty"`
+}
+
+// ruleInput is the input of vacuum_run_rule.
+type ruleInput struct {
+	Node    interface{}     `json:"node"`
+	Context functionContext `json:"context"`
+}
+
+// NewWasmRuleFunction compiles a WebAssembly module and checks that it implements the ABI. The name is used when
+// the module does not export a schema.
+func NewWasmRuleFunction(name string, binary []byte) (*WasmRuleFunction, error) {
+	ctx := context.Background()
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("unable to set up WASI for WebAssembly function '%s': %w", name, err)
+	}
+
+	compiled, err := rt.CompileModule(ctx, binary)
+	if err != nil {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("unable to compile WebAssembly function '%s': %w", name, err)
+	}
+
+	w := &WasmRuleFunction{
+		name:     name,
+		runtime:  rt,
+		compiled: compiled,
+		schema:   model.RuleFunctionSchema{Name: name},
+	}
+	if err = w.checkExports(); err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	if err = w.loadSchema(); err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	return w, nil
+}
+
+// SetTimeout sets the timeout for running the function against a single node.
+// If not set, DefaultRuleTimeout (5 seconds) is used.
+func (w *WasmRuleFunction) SetTimeout(timeout time.Duration) {
+	w.ruleTimeout = timeout
+}
+
+// getTimeout returns the configured timeout or the default
+func (w *WasmRuleFunction) getTimeout() time.Duration {
+	if w.ruleTimeout > 0 {
+		return w.ruleTimeout
+	}
+	return DefaultRuleTimeout
+}
+
+// Close releases the runtime of the function, the function cannot be used afterward.
+func (w *WasmRuleFunction) Close() error {
+	return w.runtime.Close(context.Background())
+}
+
+func (w *WasmRuleFunction) GetCategory() string {
+	return model.FunctionCategoryCustomWasm
+}
+
+func (w *WasmRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return w.schema
+}
+
+func (w *WasmRuleFunction) RunRule(nodes []*yaml.Node, ruleContext model.RuleFunctionContext) []model.RuleFunctionResult {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	input := ruleInput{Context: functionContext{Given: ruleContext.Given, Options: ruleContext.Options}}
+	if ruleContext.Rule != nil {
+		input.Context.RuleId = ruleContext.Rule.Id
+	}
+	if ruleContext.RuleAction != nil {
+		input.Context.Field = ruleContext.RuleAction.Field
+	}
+
+	mod, err := w.instantiate()
+	if err != nil {
+		return w.createErrorResult(err.Error(), nodes[0], ruleContext)
+	}
+	defer mod.Close(context.Background())
+
+	var results []model.RuleFunctionResult
+	for _, node := range nodes {
+		input.Node = nodeToJSONValue(node)
+		functionResults, err := w.runNode(mod, input)
+		if err != nil {
+			return w.createErrorResult(err.Error(), node, ruleContext)
+		}
+		for i := range functionResults {
+			populateResultDefaults(&functionResults[i], node, ruleContext)
+		}
+		results = append(results, functionResults...)
+	}
+	return results
+}
+
+// runNode runs the function against a single node.
+func (w *WasmRuleFunction) runNode(mod api.Module, input ruleInput) ([]model.RuleFunctionResult, error) {
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode input for WebAssembly function '%s': %w", w.name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.getTimeout())
+	defer cancel()
+
+	output, err := call(ctx, mod, runRuleExport, encoded)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("WebAssembly function '%s' timed out after %v", w.name, w.getTimeout())
+		}
+		return nil, fmt.Errorf("WebAssembly function '%s' failed: %w", w.name, err)
+	}
+
+	var results []model.RuleFunctionResult
+	if err = json.Unmarshal(output, &results); err != nil {
+		return nil, fmt.Errorf("unable to decode results from WebAssembly function '%s': %w", w.name, err)
+	}
+	return results, nil
+}
+
+// checkExports makes sure the module exports everything the ABI requires.
+func (w *WasmRuleFunction) checkExports() error {
+	if _, ok := w.compiled.ExportedMemories()[memoryExport]; !ok {
+		return fmt.Errorf("WebAssembly function '%s' does not export '%s'", w.name, memoryExport)
+	}
+	functions := w.compiled.ExportedFunctions()
+	for _, export := range []string{allocExport, runRuleExport} {
+		if _, ok := functions[export]; !ok {
+			return fmt.Errorf("WebAssembly function '%s' does not export '%s'", w.name, export)
+		}
+	}
+	return nil
+}
+
+// loadSchema reads the schema exported by the module, if there is one.
+func (w *WasmRuleFunction) loadSchema() error {
+	if _, ok := w.compiled.ExportedFunctions()[schemaExport]; !ok {
+		return nil
+	}
+
+	mod, err := w.instantiate()
+	if err != nil {
+		return fmt.Errorf("unable to instantiate WebAssembly function '%s': %w", w.name, err)
+	}
+	defer mod.Close(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.getTimeout())
+	defer cancel()
+
+	output, err := call(ctx, mod, schemaExport, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read the schema of WebAssembly function '%s': %w", w.name, err)
+	}
+	var schema model.RuleFunctionSchema
+	if err = json.Unmarshal(output, &schema); err != nil {
+		return fmt.Errorf("unable to decode the schema of WebAssembly function '%s': %w", w.name, err)
+	}
+	if schema.Name == "" {
+		schema.Name = w.name
+	}
+	w.schema = schema
+	return nil
+}
+
+// instantiate creates a new instance of the module, running _initialize if the module exports it.
+func (w *WasmRuleFunction) instantiate() (api.Module, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.getTimeout())
+	defer cancel()
+
+	return w.runtime.InstantiateModule(ctx, w.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions(initExport).
+		WithStderr(os.Stderr))
+}
+
+// call calls an exported function of a module instance with the input (if there is any input), returning the
+// output of the function.
+func call(ctx context.Context, mod api.Module, export string, input []byte) ([]byte, error) {
+	var params []uint64
+	if input != nil {
+		inputPtr, allocErr := allocate(ctx, mod, input)
+		if allocErr != nil {
+			return nil, allocErr
+		}
+		params = []uint64{uint64(inputPtr), uint64(len(input))}
+		// the input is no longer needed once the function returns.
+		defer free(ctx, mod, inputPtr, uint32(len(input)))
+	}
+
+	packed, err := mod.ExportedFunction(export).Call(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	if len(packed) != 1 {
+		return nil, fmt.Errorf("'%s' must return a single i64", export)
+	}
+
+	ptr, size := uint32(packed[0]>>32), uint32(packed[0])
+	output, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("'%s' returned memory out of range (ptr %d, len %d)", export, ptr, size)
+	}
+	// the memory is reused by the next call, and gone when the module is closed, so the output is copied.
+	output = append([]byte(nil), output...)
+
+	free(ctx, mod, ptr, size)
+	return output, nil
+}
+
+// free hands a buffer back to the module, if the module exports vacuum_free.
+func free(ctx context.Context, mod api.Module, ptr, size uint32) {
+	if fn := mod.ExportedFunction(freeExport); fn != nil {
+		_, _ = fn.Call(ctx, uint64(ptr), uint64(size))
+	}
+}
+
+// allocate copies input into memory allocated by the module.
+func allocate(ctx context.Context, mod api.Module, input []byte) (uint32, error) {
+	allocated, err := mod.ExportedFunction(allocExport).Call(ctx, uint64(len(input)))
+	if err != nil {
+		return 0, err
+	}
+	if len(allocated) != 1 {
+		return 0, fmt.Errorf("'%s' must return a single i32", allocExport)
+	}
+	ptr := uint32(allocated[0])
+	if !mod.Memory().Write(ptr, input) {
+		return 0, fmt.Errorf("'%s' returned memory out of range (ptr %d, len %d)", allocExport, ptr, len(input))
+	}
+	return ptr, nil
+}
+
+// createErrorResult creates a single error result with the given message
+func (w *WasmRuleFunction) createErrorResult(message string, node *yaml.Node, ruleContext model.RuleFunctionContext) []model.RuleFunctionResult {
+	result := model.RuleFunctionResult{Message: message}
+	populateResultDefaults(&result, node, ruleContext)
+	return []model.RuleFunctionResult{result}
+}
+
+func populateResultDefaults(result *model.RuleFunctionResult, node *yaml.Node, ruleContext model.RuleFunctionContext) {
+	result.StartNode = node
+	result.EndNode = node
+	result.Range = reports.Range{
+		Start: reports.RangeItem{
+			Line: node.Line,
+			Char: node.Column,
+		},
+		End: reports.RangeItem{
+			Line: node.Line,
+			Char: node.Column,
+		},
+	}
+	if result.Path == "" {
+		result.Path = fmt.Sprint(ruleContext.Given)
+		result.PathFromRuleGiven = true
+	}
+	result.Rule = ruleContext.Rule
+}
+
+// nodeToJSONValue converts a YAML node into a value that can be encoded as JSON. Unlike decoding the node into
+// an interface, mapping keys are always strings (e.g. response codes).
+func nodeToJSONValue(node *yaml.Node) interface{} {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			return nodeToJSONValue(node.Content[0])
+		}
+		return nil
+	case yaml.AliasNode:
+		return nodeToJSONValue(node.Alias)
+	case yaml.MappingNode:
+		value := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value[node.Content[i].Value] = nodeToJSONValue(node.Content[i+1])
+		}
+		return value
+	case yaml.SequenceNode:
+		value := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			value = append(value, nodeToJSONValue(item))
+		}
+		return value
+	default:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return node.Value
+		}
+		switch value.(type) {
+		case nil, bool, string, int, int64, uint64, float64:
+			return value
+		default:
+			// timestamps, binary and other values JSON does not have are passed as strings.
+			return node.Value
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package wasm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	testSchemaOffset  = 16
+	testResultsOffset = 512
+)
+
+// wasm instructions used by the test modules.
+var (
+	returnResults = func(results string) []byte {
+		return append(append([]byte{0x42}, sleb(int64(testResultsOffset)<<32|int64(len(results)))...), 0x0b)
+	}
+	trap         = []byte{0x00, 0x0b}
+	infiniteLoop = []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x00, 0x0b}
+)
+
+// buildTestModule assembles a minimal module implementing the ABI. vacuum_alloc is a bump allocator,
+// vacuum_schema returns schema, and vacuum_run_rule runs the supplied body (which must leave an i64 on the stack).
+func buildTestModule(schema, results string, runRuleBody []byte) []byte {
+	return assembleTestModule(schema, results, runRuleBody, false)
+}
+
+// buildFreeCountingTestModule assembles a test module that also exports vacuum_free, which counts the buffers
+// handed back to it in the exported 'frees' global, and their total length in the exported 'freed' global.
+func buildFreeCountingTestModule(results string) []byte {
+	return assembleTestModule(`{}`, results, returnResults(results), true)
+}
+
+func assembleTestModule(schema, results string, runRuleBody []byte, countFrees bool) []byte {
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	// types: (i32) -> i32, () -> i64, (i32, i32) -> i64, (i32, i32) -> ()
+	module = append(module, section(1, vec(
+		[]byte{0x60, 0x01, 0x7f, 0x01, 0x7f},
+		[]byte{0x60, 0x00, 0x01, 0x7e},
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e},
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x00},
+	))...)
+	functions := [][]byte{{0x00}, {0x01}, {0x02}}
+	if countFrees {
+		functions = append(functions, []byte{0x03})
+	}
+	module = append(module, section(3, vec(functions...))...)
+	module = append(module, section(5, vec([]byte{0x00, 0x01}))...)
+	// mutable i32 globals, the heap pointer of vacuum_alloc, then the number and total length of freed buffers
+	module = append(module, section(6, vec(
+		append(append([]byte{0x7f, 0x01, 0x41}, sleb(1024)...), 0x0b),
+		[]byte{0x7f, 0x01, 0x41, 0x00, 0x0b},
+		[]byte{0x7f, 0x01, 0x41, 0x00, 0x0b},
+	))...)
+	exports := [][]byte{
+		append(name("memory"), 0x02, 0x00),
+		append(name("vacuum_alloc"), 0x00, 0x00),
+		append(name("vacuum_schema"), 0x00, 0x01),
+		append(name("vacuum_run_rule"), 0x00, 0x02),
+	}
+	if countFrees {
+		exports = append(exports,
+			append(name("vacuum_free"), 0x00, 0x03),
+			append(name("frees"), 0x03, 0x01),
+			append(name("freed"), 0x03, 0x02),
+		)
+	}
+	module = append(module, section(7, vec(exports...))...)
+
+	alloc := []byte{0x00, 0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00, 0x0b}
+	schemaBody := append([]byte{0x00, 0x42}, sleb(int64(testSchemaOffset)<<32|int64(len(schema)))...)
+	schemaBody = append(schemaBody, 0x0b)
+	bodies := [][]byte{
+		append(uleb(uint64(len(alloc))), alloc...),
+		append(uleb(uint64(len(schemaBody))), schemaBody...),
+		append(uleb(uint64(len(runRuleBody)+1)), append([]byte{0x00}, runRuleBody...)...),
+	}
+	if countFrees {
+		// frees += 1, freed += len
+		free := []byte{0x00, 0x23, 0x01, 0x41, 0x01, 0x6a, 0x24, 0x01, 0x23, 0x02, 0x20, 0x01, 0x6a, 0x24, 0x02, 0x0b}
+		bodies = append(bodies, append(uleb(uint64(len(free))), free...))
+	}
+	module = append(module, section(10, vec(bodies...))...)
+
+	module = append(module, section(11, vec(
+		dataSegment(testSchemaOffset, schema),
+		dataSegment(testResultsOffset, results),
+	))...)
+	return module
+}
+
+func dataSegment(offset int64, data string) []byte {
+	segment := append([]byte{0x00, 0x41}, sleb(offset)...)
+	segment = append(segment, 0x0b)
+	return append(segment, name(data)...)
+}
+
+func section(id byte, contents []byte) []byte {
+	return append(append([]byte{id}, uleb(uint64(len(contents)))...), contents...)
+}
+
+func vec(items ...[]byte) []byte {
+	out := uleb(uint64(len(items)))
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+func name(s string) []byte {
+	return append(uleb(uint64(len(s))), s...)
+}
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if v == 0 {
+			return out
+		}
+	}
+}
+
+func sleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func parseTestNodes(t *testing.T, spec string) *yaml.Node {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &doc))
+	return doc.Content[0]
+}
+
+func TestNewWasmRuleFunction_Schema(t *testing.T) {
+	results := `[]`
+	fn, err := NewWasmRuleFunction("fallback", buildTestModule(
+		`{"name":"checkSummary","properties":[{"name":"min","description":"minimum length"}]}`, results,
+		returnResults(results)))
+	require.NoError(t, err)
+	defer fn.Close()
+
+	schema := fn.GetSchema()
+	assert.Equal(t, "checkSummary", schema.Name)
+	require.Len(t, schema.Properties, 1)
+	assert.Equal(t, "min", schema.Properties[0].Name)
+	assert.Equal(t, model.FunctionCategoryCustomWasm, fn.GetCategory())
+}
+
+func TestNewWasmRuleFunction_SchemaWithoutName(t *testing.T) {
+	results := `[]`
+	fn, err := NewWasmRuleFunction("fallback", buildTestModule(`{}`, results, returnResults(results)))
+	require.NoError(t, err)
+	defer fn.Close()
+	assert.Equal(t, "fallback", fn.GetSchema().Name)
+}
+
+func TestNewWasmRuleFunction_Invalid(t *testing.T) {
+	_, err := NewWasmRuleFunction("broken", []byte("not wasm"))
+	assert.ErrorContains(t, err, "unable to compile WebAssembly function 'broken'")
+}
+
+func TestNewWasmRuleFunction_MissingExports(t *testing.T) {
+	// an empty module
+	_, err := NewWasmRuleFunction("empty", []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00})
+	assert.ErrorContains(t, err, "does not export 'memory'")
+}
+
+func TestNewWasmRuleFunction_BadSchema(t *testing.T) {
+	results := `[]`
+	_, err := NewWasmRuleFunction("bad", buildTestModule(`{"name":`, results, returnResults(results)))
+	assert.ErrorContains(t, err, "unable to decode the schema of WebAssembly function 'bad'")
+}
+
+func TestWasmRuleFunction_RunRule(t *testing.T) {
+	results := `[{"message":"summary is too short"},{"message":"has a path","path":"$.custom"}]`
+	fn, err := NewWasmRuleFunction("checkSummary", buildTestModule(`{}`, results, returnResults(results)))
+	require.NoError(t, err)
+	defer fn.Close()
+
+	node := parseTestNodes(t, "paths:\n  /pets:\n    get:\n      summary: pets\n")
+	rule := &model.Rule{Id: "summary-length"}
+	out := fn.RunRule([]*yaml.Node{node, node}, model.RuleFunctionContext{
+		Rule:    rule,
+		Given:   "$.paths[*][*]",
+		Options: map[string]interface{}{"min": 10},
+	})
+
+	require.Len(t, out, 4)
+	assert.Equal(t, "summary is too short", out[0].Message)
+	assert.Equal(t, "$.paths[*][*]", out[0].Path)
+	assert.True(t, out[0].PathFromRuleGiven)
+	assert.Same(t, node, out[0].StartNode)
+	assert.Same(t, rule, out[0].Rule)
+	assert.Equal(t, node.Line, out[0].Range.Start.Line)
+	assert.Equal(t, "$.custom", out[1].Path)
+	assert.False(t, out[1].PathFromRuleGiven)
+}
+
+func TestCall_FreesInputAndOutput(t *testing.T) {
+	results := `[{"message":"summary is too short"}]`
+	fn, err := NewWasmRuleFunction("counter", buildFreeCountingTestModule(results))
+	require.NoError(t, err)
+	defer fn.Close()
+
+	mod, err := fn.instantiate()
+	require.NoError(t, err)
+	defer mod.Close(context.Background())
+
+	input := []byte(`{"node":{"summary":"pets"},"context":{}}`)
+	for i := 0; i < 3; i++ {
+		output, err := call(context.Background(), mod, runRuleExport, input)
+		require.NoError(t, err)
+		assert.Equal(t, results, string(output))
+	}
+
+	// every input and every output is handed back to the module.
+	assert.Equal(t, uint64(6), mod.ExportedGlobal("frees").Get())
+	assert.Equal(t, uint64(3*(len(input)+len(results))), mod.ExportedGlobal("freed").Get())
+}
+
+func TestWasmRuleFunction_RunRule_NoNodes(t *testing.T) {
+	results := `[]`
+	fn, err := NewWasmRuleFunction("noop", buildTestModule(`{}`, results, returnResults(results)))
+	require.NoError(t, err)
+	defer fn.Close()
+	assert.Empty(t, fn.RunRule(nil, model.RuleFunctionContext{}))
+}
+
+func TestWasmRuleFunction_RunRule_BadResults(t *testing.T) {
+	results := `{"message":"not an array"}`
+	fn, err := NewWasmRuleFunction("bad", buildTestModule(`{}`, results, returnResults(results)))
+	require.NoError(t, err)
+	defer fn.Close()
+
+	out := fn.RunRule([]*yaml.Node{parseTestNodes(t, "a: b")}, model.RuleFunctionContext{Given: "$"})
+	require.Len(t, out, 1)
+	assert.Contains(t, out[0].Message, "unable to decode results from WebAssembly function 'bad'")
+}
+
+func TestWasmRuleFunction_RunRule_Trap(t *testing.T) {
+	fn, err := NewWasmRuleFunction("trap", buildTestModule(`{}`, `[]`, trap))
+	require.NoError(t, err)
+	defer fn.Close()
+
+	out := fn.RunRule([]*yaml.Node{parseTestNodes(t, "a: b")}, model.RuleFunctionContext{Given: "$"})
+	require.Len(t, out, 1)
+	assert.Contains(t, out[0].Message, "WebAssembly function 'trap' failed")
+}
+
+func TestWasmRuleFunction_RunRule_Timeout(t *testing.T) {
+	fn, err := NewWasmRuleFunction("loop", buildTestModule(`{}`, `[]`, infiniteLoop))
+	require.NoError(t, err)
+	defer fn.Close()
+	fn.SetTimeout(50 * time.Millisecond)
+
+	out := fn.RunRule([]*yaml.Node{parseTestNodes(t, "a: b")}, model.RuleFunctionContext{Given: "$"})
+	require.Len(t, out, 1)
+	assert.Equal(t, "WebAssembly function 'loop' timed out after 50ms", out[0].Message)
+}
+
+func TestNodeToJSONValue(t *testing.T) {
+	node := parseTestNodes(t, `
+responses:
+  200:
+    description: ok
+tags: [a, b]
+count: 3
+ratio: 0.5
+enabled: true
+nothing: null
+created: 2026-01-02
+anchor: &x hello
+alias: *x
+`)
+	assert.Equal(t, map[string]interface{}{
+		"responses": map[string]interface{}{"200": map[string]interface{}{"description": "ok"}},
+		"tags":      []interface{}{"a", "b"},
+		"count":     3,
+		"ratio":     0.5,
+		"enabled":   true,
+		"nothing":   nil,
+		"created":   "2026-01-02",
+		"anchor":    "hello",
+		"alias":     "hello",
+	}, nodeToJSONValue(node))
+	assert.Nil(t, nodeToJSONValue(nil))
+}
@@ -12,12 +12,12 @@ import (
 
 const asyncAPILatestVersion = "3.1.0"
 
-// GenerateDefaultAsyncAPIRuleSet returns all built-in AsyncAPI rules. The rules
-// are scoped to AsyncAPI 3.x only; AsyncAPI 2.x is intentionally unsupported.
+// GenerateDefaultAsyncAPIRuleSet returns all built-in AsyncAPI rules. Each rule
+// is scoped to AsyncAPI 2.x, 3.x or both, so one ruleset lints either version.
 func GenerateDefaultAsyncAPIRuleSet() *RuleSet {
 	return &RuleSet{
 		DocumentationURI: "https://quobix.com/vacuum/rulesets/asyncapi",
-		Formats:          model.AsyncAPI3AllFormats,
+		Formats:          model.AsyncAPIAllFormats,
 		Description:      "All built-in AsyncAPI rules supported by vacuum.",
 		Rules:            GetAllAsyncAPIRules(),
 		Extends:          map[string]string{VacuumAsyncAPI: VacuumAll},
@@ -27,16 +27,16 @@ func GenerateDefaultAsyncAPIRuleSet() *RuleSet {
 // GetAllAsyncAPIRules returns every built-in AsyncAPI rule.
 func GetAllAsyncAPIRules() map[string]*model.Rule {
 	rules := GetAsyncAPIRecommendedRules()
-	rules[AsyncAPIInfoLicenseURL] = asyncAPITruthyRule(AsyncAPIInfoLicenseURL, "Check AsyncAPI license URL", "License object must include `url`.", "$", "info.license.url", model.SeverityInfo, false, model.CategoryInfo)
+	rules[AsyncAPIInfoLicenseURL] = asyncAPISharedRule(asyncAPITruthyRule(AsyncAPIInfoLicenseURL, "Check AsyncAPI license URL", "License object must include `url`.", "$", "info.license.url", model.SeverityInfo, false, model.CategoryInfo))
 	rules[AsyncAPI3ServerNotExampleCom] = asyncAPIPatternRule(AsyncAPI3ServerNotExampleCom, "Check AsyncAPI server host is not example.com", "Server host must not point at example.com.", "$.servers.*", "host", "", "example\\.com", model.SeverityInfo, false, model.CategoryValidation)
 	rules[AsyncAPI3TagDescription] = asyncAPITruthyRule(AsyncAPI3TagDescription, "Check AsyncAPI tag descriptions", "Tag object must have `description`.", "$.tags[*]", "description", model.SeverityInfo, false, model.CategoryTags)
 	rules[AsyncAPI3TagsAlphabetical] = asyncAPIRule(AsyncAPI3TagsAlphabetical, "Check AsyncAPI tags are alphabetical", "AsyncAPI tags must be ordered alphabetically.", "$", "tags", "alphabetical", map[string]string{"keyedBy": "name"}, model.SeverityInfo, false, model.CategoryTags)
 	return rules
 }
 
-// GetAsyncAPIRecommendedRules returns the recommended AsyncAPI 3.x rule set.
+// GetAsyncAPIRecommendedRules returns the recommended AsyncAPI 2.x and 3.x rule set.
 func GetAsyncAPIRecommendedRules() map[string]*model.Rule {
-	return map[string]*model.Rule{
+	rules := map[string]*model.Rule{
 		AsyncAPI3DocumentResolved:          asyncAPIDocumentRule(AsyncAPI3DocumentResolved, "Check resolved AsyncAPI v3 document structure", true),
 		AsyncAPI3DocumentUnresolved:        asyncAPIDocumentRule(AsyncAPI3DocumentUnresolved, "Check unresolved AsyncAPI v3 document structure", false),
 		AsyncAPI3ChannelNoEmptyParameter:   asyncAPIPatternRule(AsyncAPI3ChannelNoEmptyParameter, "Check AsyncAPI channel address parameters are not empty", "Channel address must not have empty parameter substitution pattern.", "$.channels.*", "address", "", "\\{\\}", model.SeverityError, true, model.CategoryValidation),
@@ -45,22 +45,22 @@ func GetAsyncAPIRecommendedRules() map[string]*model.Rule {
 		AsyncAPIChannelParameters:          asyncAPICustomRule(AsyncAPIChannelParameters, "Check AsyncAPI channel parameters", "Channel parameters must be defined and there must be no redundant parameters.", []string{"$.channels.*", "$.components.channels.*"}, "asyncApiChannelParameters", nil, model.SeverityError, true, model.CategoryValidation),
 		AsyncAPI3ChannelServers:            asyncAPICustomRule(AsyncAPI3ChannelServers, "Check AsyncAPI channel server references", "Channel servers must be defined in the `servers` object.", "$.channels.*", "asyncApiChannelServers", nil, model.SeverityError, true, model.CategoryValidation),
 		AsyncAPI3HeadersSchemaTypeObject:   asyncAPIHeadersSchemaRule(),
-		AsyncAPIInfoContactProperties:      asyncAPIContactPropertiesRule(),
-		AsyncAPIInfoContact:                asyncAPITruthyRule(AsyncAPIInfoContact, "Check AsyncAPI contact object", "Info object must have `contact` object.", "$", "info.contact", model.SeverityError, true, model.CategoryInfo),
-		AsyncAPIInfoDescription:            asyncAPITruthyRule(AsyncAPIInfoDescription, "Check AsyncAPI info description", "Info `description` must be present and non-empty.", "$", "info.description", model.SeverityError, true, model.CategoryInfo),
-		AsyncAPIInfoLicense:                asyncAPITruthyRule(AsyncAPIInfoLicense, "Check AsyncAPI license object", "Info object must have `license` object.", "$", "info.license", model.SeverityError, true, model.CategoryInfo),
+		AsyncAPIInfoContactProperties:      asyncAPISharedRule(asyncAPIContactPropertiesRule()),
+		AsyncAPIInfoContact:                asyncAPISharedRule(asyncAPITruthyRule(AsyncAPIInfoContact, "Check AsyncAPI contact object", "Info object must have `contact` object.", "$", "info.contact", model.SeverityError, true, model.CategoryInfo)),
+		AsyncAPIInfoDescription:            asyncAPISharedRule(asyncAPITruthyRule(AsyncAPIInfoDescription, "Check AsyncAPI info description", "Info `description` must be present and non-empty.", "$", "info.description", model.SeverityError, true, model.CategoryInfo)),
+		AsyncAPIInfoLicense:                asyncAPISharedRule(asyncAPITruthyRule(AsyncAPIInfoLicense, "Check AsyncAPI license object", "Info object must have `license` object.", "$", "info.license", model.SeverityError, true, model.CategoryInfo)),
 		AsyncAPILatestVersion:              asyncAPILatestVersionRule(),
 		AsyncAPI3OperationDescription:      asyncAPITruthyRule(AsyncAPI3OperationDescription, "Check AsyncAPI operation descriptions", "Operation `description` must be present and non-empty.", "$.operations.*", "description", model.SeverityError, true, model.CategoryOperations),
 		AsyncAPI3OperationSecurity:         asyncAPICustomRule(AsyncAPI3OperationSecurity, "Check AsyncAPI operation security", "Operation security must reference defined security schemes.", "$.operations.*.security.*", "asyncApiSecurity", map[string]string{"objectType": "Operation"}, model.SeverityError, true, model.CategorySecurity),
-		AsyncAPIParameterDescription:       asyncAPITruthyRule(AsyncAPIParameterDescription, "Check AsyncAPI parameter descriptions", "Parameter objects must have `description`.", []string{"$.components.parameters.*", "$.channels.*.parameters.*"}, "description", model.SeverityWarn, true, model.CategoryDescriptions),
+		AsyncAPIParameterDescription:       asyncAPISharedRule(asyncAPITruthyRule(AsyncAPIParameterDescription, "Check AsyncAPI parameter descriptions", "Parameter objects must have `description`.", []string{"$.components.parameters.*", "$.channels.*.parameters.*"}, "description", model.SeverityWarn, true, model.CategoryDescriptions)),
 		AsyncAPI3PayloadUnsupportedFormat:  asyncAPIRule(AsyncAPI3PayloadUnsupportedFormat, "Check AsyncAPI payload schema formats", "Message schema validation is only supported with default unspecified `schemaFormat`.", []string{"$.components.messages.*", "$.channels.*.messages.*"}, "schemaFormat", "undefined", nil, model.SeverityInfo, true, model.CategorySchemas),
 		AsyncAPI3ServerNoEmptyVariable:     asyncAPIPatternRule(AsyncAPI3ServerNoEmptyVariable, "Check AsyncAPI server variables are not empty", "Server host and pathname must not have empty variable substitution pattern.", []string{"$.servers.*.host", "$.servers.*.pathname", "$.components.servers.*.host", "$.components.servers.*.pathname"}, "", "", "\\{\\}", model.SeverityError, true, model.CategoryValidation),
 		AsyncAPI3ServerNoTrailingSlash:     asyncAPIPatternRule(AsyncAPI3ServerNoTrailingSlash, "Check AsyncAPI server host or pathname has no trailing slash", "Server host and pathname must not end with slash.", []string{"$.servers.*.host", "$.servers.*.pathname", "$.components.servers.*.host", "$.components.servers.*.pathname"}, "", "", "\\/$", model.SeverityError, true, model.CategoryValidation),
-		AsyncAPIServers:                    asyncAPIServersRule(),
+		AsyncAPIServers:                    asyncAPISharedRule(asyncAPIServersRule()),
 		AsyncAPI3TagsUniqueness:            asyncAPICustomRule(AsyncAPI3TagsUniqueness, "Check AsyncAPI tag uniqueness", "Each tag must have a unique name.", []string{"$.tags", "$.servers.*.tags", "$.components.servers.*.tags", "$.operations.*.tags", "$.components.operations.*.tags", "$.components.operationTraits.*.tags", "$.channels.*.messages.*.tags", "$.components.channels.*.messages.*.tags", "$.components.messages.*.tags", "$.components.messageTraits.*.tags"}, "asyncApiTagsUnique", nil, model.SeverityError, true, model.CategoryTags),
 		AsyncAPI3Tags:                      asyncAPITruthyRule(AsyncAPI3Tags, "Check AsyncAPI tags", "AsyncAPI document must have non-empty tags array.", "$", "tags", model.SeverityError, true, model.CategoryTags),
-		AsyncAPIServerVariables:            asyncAPICustomRule(AsyncAPIServerVariables, "Check AsyncAPI server variables", "Server variables must be defined and there must be no redundant variables.", []string{"$.servers.*", "$.components.servers.*"}, "asyncApiServerVariables", nil, model.SeverityError, true, model.CategoryValidation),
-		AsyncAPIServerSecurity:             asyncAPICustomRule(AsyncAPIServerSecurity, "Check AsyncAPI server security", "Server security must reference defined security schemes.", "$.servers.*.security.*", "asyncApiSecurity", map[string]string{"objectType": "Server"}, model.SeverityError, true, model.CategorySecurity),
+		AsyncAPIServerVariables:            asyncAPISharedRule(asyncAPICustomRule(AsyncAPIServerVariables, "Check AsyncAPI server variables", "Server variables must be defined and there must be no redundant variables.", []string{"$.servers.*", "$.components.servers.*"}, "asyncApiServerVariables", nil, model.SeverityError, true, model.CategoryValidation)),
+		AsyncAPIServerSecurity:             asyncAPISharedRule(asyncAPICustomRule(AsyncAPIServerSecurity, "Check AsyncAPI server security", "Server security must reference defined security schemes.", "$.servers.*.security.*", "asyncApiSecurity", map[string]string{"objectType": "Server"}, model.SeverityError, true, model.CategorySecurity)),
 		AsyncAPIOperationChannel:           asyncAPICustomRule(AsyncAPIOperationChannel, "Check AsyncAPI operation channels", "Operations must reference declared channels.", "$.operations.*", "asyncApiOperationChannel", nil, model.SeverityError, true, model.CategoryOperations),
 		AsyncAPIOperationMessages:          asyncAPICustomRule(AsyncAPIOperationMessages, "Check AsyncAPI operation messages", "Operations must reference declared messages.", "$.operations.*", "asyncApiOperationMessages", nil, model.SeverityError, true, model.CategoryOperations),
 		AsyncAPIOperationReply:             asyncAPICustomRule(AsyncAPIOperationReply, "Check AsyncAPI operation replies", "Operation replies must reference declared channels and messages.", "$.operations.*", "asyncApiOperationReply", nil, model.SeverityError, true, model.CategoryOperations),
@@ -68,6 +68,26 @@ func GetAsyncAPIRecommendedRules() map[string]*model.Rule {
 		AsyncAPIUnusedComponents:           asyncAPICustomRule(AsyncAPIUnusedComponents, "Check AsyncAPI unused components", "Reusable AsyncAPI components should be referenced.", "$", "asyncApiUnusedComponents", nil, model.SeverityWarn, true, model.CategorySchemas),
 		AsyncAPIContentType:                asyncAPICustomRule(AsyncAPIContentType, "Check AsyncAPI content types", "Messages with payloads should define a content type or use document defaultContentType.", []string{"$.components.messages.*", "$.channels.*.messages.*"}, "asyncApiContentType", nil, model.SeverityWarn, true, model.CategoryValidation),
 	}
+	for ruleID, rule := range getAsyncAPI2RecommendedRules() {
+		rules[ruleID] = rule
+	}
+	return rules
+}
+
+// getAsyncAPI2RecommendedRules returns the recommended rules that only apply to AsyncAPI 2.x, where operations
+// live on channels as `publish` and `subscribe`.
+func getAsyncAPI2RecommendedRules() map[string]*model.Rule {
+	asyncAPI2Operations := []string{"$.channels.*.publish", "$.channels.*.subscribe"}
+	asyncAPI2Messages := []string{"$.channels.*.publish.message", "$.channels.*.subscribe.message", "$.channels.*.publish.message.oneOf[*]", "$.channels.*.subscribe.message.oneOf[*]", "$.components.messages.*"}
+	documentRule := asyncAPICustomRule(AsyncAPI2DocumentResolved, "Check resolved AsyncAPI v2 document references", "AsyncAPI v2 document references must resolve.", "$", "asyncApiDocument", map[string]bool{"resolved": true}, model.SeverityError, true, model.CategoryValidation)
+	return map[string]*model.Rule{
+		AsyncAPI2DocumentResolved:      asyncAPI2Rule(documentRule),
+		AsyncAPI2ChannelOperations:     asyncAPI2Rule(asyncAPICustomRule(AsyncAPI2ChannelOperations, "Check AsyncAPI v2 channel operations", "Channels should define a `publish` or `subscribe` operation.", "$.channels.*", "asyncApi2ChannelOperations", nil, model.SeverityWarn, true, model.CategoryOperations)),
+		AsyncAPI2OperationOperationId:  asyncAPI2Rule(asyncAPITruthyRule(AsyncAPI2OperationOperationId, "Check AsyncAPI v2 operation IDs", "Operation must have `operationId`.", asyncAPI2Operations, "operationId", model.SeverityError, true, model.CategoryOperations)),
+		AsyncAPI2OperationIdUniqueness: asyncAPI2Rule(asyncAPICustomRule(AsyncAPI2OperationIdUniqueness, "Check AsyncAPI v2 operation ID uniqueness", "Every operation must have a unique `operationId`.", "$", "asyncApi2OperationIdUnique", nil, model.SeverityError, true, model.CategoryOperations)),
+		AsyncAPI2OperationDescription:  asyncAPI2Rule(asyncAPITruthyRule(AsyncAPI2OperationDescription, "Check AsyncAPI v2 operation descriptions", "Operation `description` must be present and non-empty.", asyncAPI2Operations, "description", model.SeverityWarn, true, model.CategoryOperations)),
+		AsyncAPI2MessageExamples:       asyncAPI2Rule(asyncAPICustomRule(AsyncAPI2MessageExamples, "Check AsyncAPI v2 message examples", "Message examples must be valid against the message `payload` and `headers` schemas.", asyncAPI2Messages, "asyncApi2MessageExamples", nil, model.SeverityError, true, model.CategoryExamples)),
+	}
 }
 
 func asyncAPIDocumentRule(id, name string, resolved bool) *model.Rule {
@@ -113,6 +133,18 @@ func asyncAPIServersRule() *model.Rule {
 	}, model.SeverityError, true, model.CategoryValidation)
 }
 
+// asyncAPISharedRule scopes a rule to both AsyncAPI 2.x and 3.x.
+func asyncAPISharedRule(rule *model.Rule) *model.Rule {
+	rule.Formats = model.AsyncAPIAllFormats
+	return rule
+}
+
+// asyncAPI2Rule scopes a rule to AsyncAPI 2.x only.
+func asyncAPI2Rule(rule *model.Rule) *model.Rule {
+	rule.Formats = model.AsyncAPI2AllFormats
+	return rule
+}
+
 func asyncAPITruthyRule(id, name, description string, given any, field string, severity string, recommended bool, category string) *model.Rule {
 	return asyncAPIRule(id, name, description, given, field, "truthy", nil, severity, recommended, category)
 }
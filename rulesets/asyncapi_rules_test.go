@@ -5,6 +5,7 @@
 package rulesets
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -23,11 +24,24 @@ func TestBuildDefaultRuleSets_AsyncAPIRecommended(t *testing.T) {
 	assert.Contains(t, recommended.Rules, AsyncAPIOperationChannel)
 	assert.Contains(t, recommended.Rules, AsyncAPIUnusedComponents)
 	assert.NotContains(t, recommended.Rules, AsyncAPI3ServerNotExampleCom)
+	assert.Contains(t, recommended.Rules, AsyncAPI2OperationIdUniqueness)
 	for _, rule := range recommended.Rules {
-		assert.Contains(t, rule.Formats, model.AsyncAPI3)
+		assert.True(t, slices.Contains(rule.Formats, model.AsyncAPI3) || slices.Contains(rule.Formats, model.AsyncAPI2),
+			"rule %s should be scoped to AsyncAPI", rule.Id)
 	}
 }
 
+func TestAsyncAPIRulesAreScopedToVersions(t *testing.T) {
+	rules := GetAllAsyncAPIRules()
+
+	assert.Equal(t, model.AsyncAPI2AllFormats, rules[AsyncAPI2MessageExamples].Formats)
+	assert.Equal(t, model.AsyncAPI3AllFormats, rules[AsyncAPI3Tags].Formats)
+	assert.Equal(t, model.AsyncAPI3AllFormats, rules[AsyncAPILatestVersion].Formats)
+	assert.Equal(t, model.AsyncAPIAllFormats, rules[AsyncAPIInfoContact].Formats)
+	assert.Equal(t, model.AsyncAPIAllFormats, rules[AsyncAPIServerVariables].Formats)
+	assert.Equal(t, model.AsyncAPIAllFormats, rules[AsyncAPIInfoLicenseURL].Formats)
+}
+
 func TestGenerateRuleSetFromSuppliedRuleSet_AsyncAPIExtends(t *testing.T) {
 	defaultRS := BuildDefaultRuleSets()
 	ruleSet := defaultRS.GenerateRuleSetFromSuppliedRuleSet(&RuleSet{
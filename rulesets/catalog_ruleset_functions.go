@@ -0,0 +1,68 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"github.com/daveshanley/vacuum/model"
+)
+
+// catalog rules check every document of a catalog at once, they are skipped when a single document is linted.
+
+func GetCatalogOperationIdUniqueRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check operationIds are unique across the catalog",
+		Id:           CatalogOperationIdUnique,
+		Formats:      model.OAS3AllFormat,
+		Description:  "Every operationId must be unique across all the APIs of a catalog",
+		Given:        `$`,
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "catalogUniqueOperationIds",
+		},
+		HowToFix: catalogOperationIdUniqueFix,
+	}
+}
+
+func GetCatalogPathConflictRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check operations are only defined by one API of the catalog",
+		Id:           CatalogPathConflict,
+		Formats:      model.OAS3AllFormat,
+		Description:  "An operation (a method and a path) must only be defined by one API of a catalog",
+		Given:        `$`,
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "catalogPathConflicts",
+		},
+		HowToFix: catalogPathConflictFix,
+	}
+}
+
+func GetCatalogSchemaConsistentRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check shared schemas are the same across the catalog",
+		Id:           CatalogSchemaConsistent,
+		Formats:      model.OAS3AllFormat,
+		Description:  "A component schema defined by more than one API of a catalog must be the same in every API",
+		Given:        `$`,
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "catalogSchemaConsistency",
+		},
+		HowToFix: catalogSchemaConsistentFix,
+	}
+}
@@ -90,7 +90,7 @@ func flushBufferedRuleSetLogs(logger *slog.Logger, records []bufferedRuleSetLogR
 	}
 }
 
-func (rsm ruleSetsModel) loadExternalRulesetsWithTimeout(extends map[string]string, rs *RuleSet, httpClient *http.Client) {
+func (rsm ruleSetsModel) loadExternalRulesetsWithTimeout(extends, pins map[string]string, rs *RuleSet, httpClient *http.Client) {
 	ctx, cancel := context.WithTimeout(context.Background(), externalRulesetFetchTimeout)
 	defer cancel()
 
@@ -104,14 +104,14 @@ func (rsm ruleSetsModel) loadExternalRulesetsWithTimeout(extends map[string]stri
 		}
 
 		remote := strings.HasPrefix(location, "http")
-		if !rsm.loadExternalRulesetWithTimeout(ctx, location, rs, remote, httpClient) {
+		if !rsm.loadExternalRulesetWithTimeout(ctx, location, pins[location], rs, remote, httpClient) {
 			rsm.logger.Error("external ruleset fetch timed out", "timeout", externalRulesetFetchTimeout)
 			break
 		}
 	}
 }
 
-func (rsm ruleSetsModel) loadExternalRulesetWithTimeout(ctx context.Context, location string, rs *RuleSet, remote bool, httpClient *http.Client) bool {
+func (rsm ruleSetsModel) loadExternalRulesetWithTimeout(ctx context.Context, location, digest string, rs *RuleSet, remote bool, httpClient *http.Client) bool {
 	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 		return false
 	}
@@ -124,7 +124,7 @@ func (rsm ruleSetsModel) loadExternalRulesetWithTimeout(ctx context.Context, loc
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		SniffOutAllExternalRules(ctx, &workerRuleSets, location, nil, workingRuleSet, remote, httpClient)
+		SniffOutAllExternalRules(ctx, &workerRuleSets, location, digest, nil, workingRuleSet, remote, httpClient)
 	}()
 
 	select {
@@ -155,15 +155,20 @@ func cloneRuleSetForExternalLoad(source *RuleSet) *RuleSet {
 	defer source.mutex.Unlock()
 
 	return &RuleSet{
-		Description:      source.Description,
-		DocumentationURI: source.DocumentationURI,
-		Formats:          append([]string(nil), source.Formats...),
-		RuleDefinitions:  cloneRuleDefinitionMap(source.RuleDefinitions),
-		Rules:            cloneRuleMap(source.Rules),
-		Extends:          source.Extends,
-		Aliases:          cloneInterfaceMap(source.Aliases),
-		ParsedAliases:    cloneParsedAliasMap(source.ParsedAliases),
-		extendsMeta:      cloneStringMap(source.extendsMeta),
+		Description:       source.Description,
+		DocumentationURI:  source.DocumentationURI,
+		Formats:           append([]string(nil), source.Formats...),
+		RuleDefinitions:   cloneRuleDefinitionMap(source.RuleDefinitions),
+		Rules:             cloneRuleMap(source.Rules),
+		Extends:           source.Extends,
+		Aliases:           cloneInterfaceMap(source.Aliases),
+		ParsedAliases:     cloneParsedAliasMap(source.ParsedAliases),
+		Scoring:           source.Scoring,
+		extendsMeta:       cloneStringMap(source.extendsMeta),
+		extendsErrors:     append([]error(nil), source.extendsErrors...),
+		functionLocations: cloneStringMap(source.functionLocations),
+		functionPins:      cloneStringMap(source.functionPins),
+		Overrides:         append([]*RuleSetOverride(nil), source.Overrides...),
 	}
 }
 
@@ -184,7 +189,12 @@ func copyRuleSetExternalState(target, source *RuleSet) {
 	target.Rules = replaceRuleMap(target.Rules, source.Rules)
 	target.Aliases = replaceInterfaceMap(target.Aliases, source.Aliases)
 	target.ParsedAliases = replaceParsedAliasMap(target.ParsedAliases, source.ParsedAliases)
+	target.Scoring = source.Scoring
 	target.extendsMeta = replaceStringMap(target.extendsMeta, source.extendsMeta)
+	target.extendsErrors = append([]error(nil), source.extendsErrors...)
+	target.functionLocations = replaceStringMap(target.functionLocations, source.functionLocations)
+	target.functionPins = replaceStringMap(target.functionPins, source.functionPins)
+	target.Overrides = append([]*RuleSetOverride(nil), source.Overrides...)
 }
 
 func cloneRuleDefinitionMap(source map[string]interface{}) map[string]interface{} {
@@ -0,0 +1,464 @@
+// Copyright 2020-2026 Dave Shanley / Quobix / Princess Beef Heavy Industries, LLC
+// https://quobix.com/vacuum/ | https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// RuleSetOverride is a Spectral compatible `overrides` entry. It changes the severity of, or disables, rules for
+// the files matched by its glob patterns. A pattern can be followed by a JSON pointer to only change the rules
+// for a part of the matched files.
+//
+//	overrides:
+//	  - files:
+//	      - legacy/**/*.yaml
+//	      - "**/*.json#/paths/~1v1"
+//	    rules:
+//	      operation-tags: off
+//	      operation-description: info
+//
+// Overrides are applied in order, so later entries win.
+type RuleSetOverride struct {
+	Files []string               `json:"files" yaml:"files"`
+	Rules map[string]interface{} `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// overrideRuleState is the state of a rule for a single result, after overrides have been applied.
+type overrideRuleState struct {
+	enabled  bool
+	severity string
+}
+
+// overrideMatcher is a compiled override file pattern, with an optional JSON pointer.
+type overrideMatcher struct {
+	file    *regexp.Regexp
+	pointer []string
+	scoped  bool
+}
+
+// HasOverrides returns true if the ruleset has any overrides.
+func (rs *RuleSet) HasOverrides() bool {
+	return rs != nil && len(rs.Overrides) > 0
+}
+
+// CompiledOverrides are the overrides of a ruleset, compiled for linting a single specification.
+type CompiledOverrides struct {
+	ruleSet       *RuleSet
+	matchers      [][]overrideMatcher
+	specPath      string
+	adjustedRules map[string]*model.Rule
+	lock          sync.Mutex
+}
+
+// CompileOverrides compiles the overrides of the ruleset for linting specFileName. It returns nil if the ruleset
+// has no overrides, a nil CompiledOverrides runs every rule and keeps every result.
+func (rs *RuleSet) CompileOverrides(specFileName string) *CompiledOverrides {
+	if !rs.HasOverrides() {
+		return nil
+	}
+	base := rs.OverridesBase
+	if base == "" {
+		base, _ = os.Getwd()
+	}
+	compiled := &CompiledOverrides{
+		ruleSet:       rs,
+		matchers:      make([][]overrideMatcher, len(rs.Overrides)),
+		specPath:      absoluteOverridePath(specFileName),
+		adjustedRules: make(map[string]*model.Rule),
+	}
+	for i, override := range rs.Overrides {
+		compiled.matchers[i] = compileOverrideMatchers(base, override.Files)
+	}
+	return compiled
+}
+
+// ApplyOverrides applies the overrides of the ruleset to the results of linting a specification. Results for
+// rules that are turned off for a file (or path) are removed, and results for rules with a changed severity get
+// a copy of the rule with the new severity. Results from other files in a multi-file specification are matched
+// against the file they came from, JSON pointers are matched against the path of the result.
+func (rs *RuleSet) ApplyOverrides(specFileName string, results []model.RuleFunctionResult) []model.RuleFunctionResult {
+	return rs.CompileOverrides(specFileName).Apply(results)
+}
+
+// Apply applies the overrides to the results of linting the specification, see RuleSet.ApplyOverrides. The
+// results are returned in a new slice.
+func (o *CompiledOverrides) Apply(results []model.RuleFunctionResult) []model.RuleFunctionResult {
+	if o == nil || len(results) == 0 {
+		return results
+	}
+	filtered := make([]model.RuleFunctionResult, 0, len(results))
+	for _, result := range results {
+		if result.Rule == nil {
+			filtered = append(filtered, result)
+			continue
+		}
+		state := o.state(&result)
+		if !state.enabled {
+			continue
+		}
+		if state.severity != result.Rule.Severity {
+			result.Rule = o.adjustedRule(result.Rule, state.severity)
+			result.RuleSeverity = state.severity
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
+
+// Enabled returns true if the rule of a result is turned on where the result was found.
+func (o *CompiledOverrides) Enabled(result *model.RuleFunctionResult) bool {
+	if o == nil || result == nil || result.Rule == nil {
+		return true
+	}
+	return o.state(result).enabled
+}
+
+// RuleRuns returns true if a rule is turned on for any of files (the specification and the files it references),
+// or for any path in them. Rules that are turned off everywhere do not need to run.
+func (o *CompiledOverrides) RuleRuns(ruleId string, files []string) bool {
+	if o == nil {
+		return true
+	}
+	for _, file := range files {
+		file = absoluteOverridePath(file)
+		enabled := !o.ruleSet.overrideOnly[ruleId]
+		for i, override := range o.ruleSet.Overrides {
+			value, ok := override.Rules[ruleId]
+			if !ok {
+				continue
+			}
+			whole, scoped := overrideMatchesFile(o.matchers[i], file)
+			switch {
+			case whole:
+				enabled = applyOverrideValue(overrideRuleState{enabled: enabled}, value).enabled
+			case scoped:
+				// only part of the file, the rule keeps its state everywhere else.
+				enabled = enabled || overrideEnablesRule(value)
+			}
+		}
+		if enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// state returns the state of the rule of a result, after the overrides for where it was found are applied.
+func (o *CompiledOverrides) state(result *model.RuleFunctionResult) overrideRuleState {
+	resultPath := o.specPath
+	if result.Origin != nil && result.Origin.AbsoluteLocation != "" {
+		resultPath = absoluteOverridePath(result.Origin.AbsoluteLocation)
+	}
+	state := overrideRuleState{enabled: !o.ruleSet.overrideOnly[result.Rule.Id], severity: result.Rule.Severity}
+	for i, override := range o.ruleSet.Overrides {
+		value, ok := override.Rules[result.Rule.Id]
+		if !ok || !overrideMatches(o.matchers[i], resultPath, result.Path) {
+			continue
+		}
+		state = applyOverrideValue(state, value)
+	}
+	return state
+}
+
+// adjustedRule returns a copy of a rule with a changed severity, shared by every result of the rule.
+func (o *CompiledOverrides) adjustedRule(rule *model.Rule, severity string) *model.Rule {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	key := rule.Id + "|" + severity
+	adjusted, ok := o.adjustedRules[key]
+	if !ok {
+		copied := *rule
+		copied.Severity = severity
+		adjusted = &copied
+		o.adjustedRules[key] = adjusted
+	}
+	return adjusted
+}
+
+// applySuppliedOverrides carries the overrides of a supplied ruleset over to the generated ruleset, after the
+// overrides of the rulesets it extends, so its own overrides win. Rules that are not part of the ruleset, but are
+// turned on by an override, are added so they run only where they are turned on.
+func (rsm ruleSetsModel) applySuppliedOverrides(supplied, rs *RuleSet) {
+	if !supplied.HasOverrides() && !rs.HasOverrides() {
+		return
+	}
+	rs.Overrides = append(rs.Overrides, supplied.Overrides...)
+	if supplied.OverridesBase != "" {
+		rs.OverridesBase = supplied.OverridesBase
+	}
+	for _, override := range rs.Overrides {
+		for ruleId, value := range override.Rules {
+			if _, ok := value.(map[string]interface{}); ok {
+				rsm.logger.Warn("Rule definitions are not supported in overrides, only severities, ignoring it", "rule", ruleId)
+				continue
+			}
+			if rs.Rules[ruleId] != nil || !overrideEnablesRule(value) {
+				continue
+			}
+			rule := rsm.findBuiltInRule(ruleId)
+			if rule == nil {
+				rsm.logger.Warn("Rule does not exist, ignoring it", "rule", ruleId)
+				continue
+			}
+			if rs.overrideOnly == nil {
+				rs.overrideOnly = make(map[string]bool)
+			}
+			rs.Rules[ruleId] = rule
+			rs.overrideOnly[ruleId] = true
+		}
+	}
+}
+
+func (rsm ruleSetsModel) findBuiltInRule(ruleId string) *model.Rule {
+	for _, set := range []*RuleSet{rsm.openAPIRuleSet, rsm.jsonSchemaSet, rsm.asyncAPISet} {
+		if set != nil && set.Rules[ruleId] != nil {
+			return set.Rules[ruleId]
+		}
+	}
+	return GetAllOWASPRules()[ruleId]
+}
+
+// overrideEnablesRule returns true if an override value turns a rule on.
+func overrideEnablesRule(value interface{}) bool {
+	state := applyOverrideValue(overrideRuleState{}, value)
+	return state.enabled
+}
+
+// applyOverrideValue applies a single override rule value, which is a severity name, a Spectral diagnostic
+// severity number, `off` or a boolean.
+func applyOverrideValue(state overrideRuleState, value interface{}) overrideRuleState {
+	switch v := value.(type) {
+	case bool:
+		state.enabled = v
+	case string:
+		switch v {
+		case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint:
+			state.enabled = true
+			state.severity = v
+		case VacuumOff:
+			state.enabled = false
+		}
+	case float64:
+		// Spectral diagnostic severities: -1 (off), 0 (error), 1 (warn), 2 (info), 3 (hint).
+		severities := []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint}
+		if v == -1 {
+			state.enabled = false
+		} else if i := int(v); float64(i) == v && i >= 0 && i < len(severities) {
+			state.enabled = true
+			state.severity = severities[i]
+		}
+	case int:
+		return applyOverrideValue(state, float64(v))
+	}
+	return state
+}
+
+func compileOverrideMatchers(base string, files []string) []overrideMatcher {
+	var matchers []overrideMatcher
+	for _, file := range files {
+		pattern, pointer, scoped := strings.Cut(file, "#")
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(base, pattern)
+		}
+		re, err := overrideGlobRegex(filepath.ToSlash(pattern))
+		if err != nil {
+			continue
+		}
+		matchers = append(matchers, overrideMatcher{file: re, pointer: jsonPointerSegments(pointer), scoped: scoped})
+	}
+	return matchers
+}
+
+// overrideMatchesFile returns whether any of the matchers match the whole of a file, or only a part of it.
+func overrideMatchesFile(matchers []overrideMatcher, file string) (whole, scoped bool) {
+	if file == "" {
+		return false, false
+	}
+	for _, matcher := range matchers {
+		if !matcher.file.MatchString(filepath.ToSlash(file)) {
+			continue
+		}
+		if !matcher.scoped || len(matcher.pointer) == 0 {
+			return true, false
+		}
+		scoped = true
+	}
+	return false, scoped
+}
+
+// extendedOverrides returns the overrides of an extended ruleset, with file patterns that are relative to the
+// ruleset instead of the working directory. The patterns of remote rulesets are left relative to the working
+// directory, they cannot be relative to a URL.
+func extendedOverrides(location string, remote bool, overrides []*RuleSetOverride) []*RuleSetOverride {
+	if len(overrides) == 0 {
+		return nil
+	}
+	base := ""
+	if !remote {
+		base = filepath.Dir(absoluteOverridePath(location))
+	}
+	extended := make([]*RuleSetOverride, 0, len(overrides))
+	for _, override := range overrides {
+		if override == nil {
+			continue
+		}
+		files := make([]string, len(override.Files))
+		for i, file := range override.Files {
+			pattern, pointer, scoped := strings.Cut(file, "#")
+			if base != "" && !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(base, pattern)
+			}
+			if scoped {
+				pattern += "#" + pointer
+			}
+			files[i] = pattern
+		}
+		extended = append(extended, &RuleSetOverride{Files: files, Rules: override.Rules})
+	}
+	return extended
+}
+
+func overrideMatches(matchers []overrideMatcher, file, resultPath string) bool {
+	if file == "" {
+		return false
+	}
+	for _, matcher := range matchers {
+		if !matcher.file.MatchString(filepath.ToSlash(file)) {
+			continue
+		}
+		if !matcher.scoped || len(matcher.pointer) == 0 {
+			return true
+		}
+		if segmentsHavePrefix(resultPathSegments(resultPath), matcher.pointer) {
+			return true
+		}
+	}
+	return false
+}
+
+func absoluteOverridePath(location string) string {
+	if location == "" || strings.Contains(location, "://") {
+		return location
+	}
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return location
+}
+
+// overrideGlobRegex converts a glob into a regular expression. `**` matches any number of directories,
+// `*` and `?` match within a single path segment and `{a,b}` matches either alternative.
+func overrideGlobRegex(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	inBraces := false
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			if i+2 < len(pattern) && pattern[i+2] == '/' {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else {
+				b.WriteString(".*")
+				i++
+			}
+		case ch == '*':
+			b.WriteString("[^/]*")
+		case ch == '?':
+			b.WriteString("[^/]")
+		case ch == '{':
+			inBraces = true
+			b.WriteString("(?:")
+		case ch == '}' && inBraces:
+			inBraces = false
+			b.WriteString(")")
+		case ch == ',' && inBraces:
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// jsonPointerSegments splits a JSON pointer (for example /paths/~1v1) into unescaped segments.
+func jsonPointerSegments(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+// resultPathSegments splits a result path (for example $.paths['/v1'].get.tags[0]) into segments.
+func resultPathSegments(path string) []string {
+	path = strings.TrimPrefix(path, "$")
+	var segments []string
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			end := i + 1
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			segments = append(segments, path[i+1:end])
+			i = end
+		case '[':
+			if i+1 < len(path) && (path[i+1] == '\'' || path[i+1] == '"') {
+				quote := path[i+1]
+				var key strings.Builder
+				end := i + 2
+				for end < len(path) && path[end] != quote {
+					if path[end] == '\\' && end+1 < len(path) {
+						end++
+					}
+					key.WriteByte(path[end])
+					end++
+				}
+				segments = append(segments, key.String())
+				i = end + 2 // closing quote and bracket
+				continue
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return segments
+			}
+			index := path[i+1 : i+end]
+			if _, err := strconv.Atoi(index); err == nil {
+				segments = append(segments, index)
+			}
+			i += end + 1
+		default:
+			i++
+		}
+	}
+	return segments
+}
+
+func segmentsHavePrefix(segments, prefix []string) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i := range prefix {
+		if segments[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package rulesets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestCreateRuleSetFromData_Overrides(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`extends: [[vacuum:oas, recommended]]
+overrides:
+  - files: ["legacy/**/*.yaml"]
+    rules:
+      operation-tags: off
+  - files: ["**/*.json#/paths/~1v1"]
+    rules:
+      operation-description: 2
+`))
+	require.NoError(t, err)
+	require.Len(t, rs.Overrides, 2)
+	assert.Equal(t, []string{"legacy/**/*.yaml"}, rs.Overrides[0].Files)
+	assert.Equal(t, "off", rs.Overrides[0].Rules["operation-tags"])
+	assert.Equal(t, 2.0, rs.Overrides[1].Rules["operation-description"])
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, generated.Overrides, 2)
+}
+
+func TestCreateRuleSetFromData_OverridesPointerOnlyAllowsRules(t *testing.T) {
+	_, err := CreateRuleSetFromData([]byte(`rules: {}
+overrides:
+  - files: ["api.yaml#/paths"]
+    formats: [oas3]
+    rules:
+      operation-tags: off
+`))
+	assert.Error(t, err)
+}
+
+func TestRuleSet_ApplyOverrides(t *testing.T) {
+	base := t.TempDir()
+	warnRule := &model.Rule{Id: "operation-tags", Severity: model.SeverityWarn}
+	rs := &RuleSet{
+		OverridesBase: base,
+		Overrides: []*RuleSetOverride{
+			{Files: []string{"legacy/**/*.{yaml,yml}"}, Rules: map[string]interface{}{"operation-tags": "off"}},
+			{Files: []string{"api.yaml#/paths/~1pets"}, Rules: map[string]interface{}{"operation-tags": 0.0}},
+			{Files: []string{"api.yaml#/paths/~1pets/get"}, Rules: map[string]interface{}{"operation-tags": "hint"}},
+		},
+	}
+	specFile := filepath.Join(base, "api.yaml")
+	results := []model.RuleFunctionResult{
+		{RuleId: "operation-tags", Rule: warnRule, Path: "$.paths['/pets'].post"},
+		{RuleId: "operation-tags", Rule: warnRule, Path: "$.paths['/pets'].get"},
+		{RuleId: "operation-tags", Rule: warnRule, Path: "$.paths['/cakes'].get"},
+		{RuleId: "operation-tags", Rule: warnRule, Path: "$.get",
+			Origin: &index.NodeOrigin{AbsoluteLocation: filepath.Join(base, "legacy", "v1", "pets.yml")}},
+	}
+
+	applied := rs.ApplyOverrides(specFile, results)
+
+	require.Len(t, applied, 3)
+	assert.Equal(t, model.SeverityError, applied[0].Rule.Severity)
+	assert.Equal(t, model.SeverityHint, applied[1].Rule.Severity)
+	assert.Equal(t, model.SeverityWarn, applied[2].Rule.Severity)
+	assert.Same(t, warnRule, applied[2].Rule)
+	assert.Equal(t, model.SeverityWarn, warnRule.Severity)
+}
+
+func TestRuleSet_ApplyOverrides_NewSlice(t *testing.T) {
+	base := t.TempDir()
+	rule := &model.Rule{Id: "operation-tags", Severity: model.SeverityWarn}
+	rs := &RuleSet{
+		OverridesBase: base,
+		Overrides: []*RuleSetOverride{
+			{Files: []string{"api.yaml#/paths/~1pets"}, Rules: map[string]interface{}{"operation-tags": "off"}},
+		},
+	}
+	results := []model.RuleFunctionResult{
+		{RuleId: "operation-tags", Rule: rule, Path: "$.paths['/pets'].get"},
+		{RuleId: "operation-tags", Rule: rule, Path: "$.paths['/cakes'].get"},
+	}
+
+	applied := rs.ApplyOverrides(filepath.Join(base, "api.yaml"), results)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "$.paths['/cakes'].get", applied[0].Path)
+	assert.Equal(t, "$.paths['/pets'].get", results[0].Path, "the results should not be overwritten")
+}
+
+func TestCompiledOverrides_RuleRuns(t *testing.T) {
+	base := t.TempDir()
+	rs := &RuleSet{
+		OverridesBase: base,
+		Overrides: []*RuleSetOverride{
+			{Files: []string{"api.yaml"}, Rules: map[string]interface{}{"operation-tags": "off"}},
+			{Files: []string{"api.yaml#/paths"}, Rules: map[string]interface{}{"operation-description": "off"}},
+			{Files: []string{"v2/*.yaml"}, Rules: map[string]interface{}{"info-contact": true}},
+			{Files: []string{"api.yaml#/info"}, Rules: map[string]interface{}{"info-license": "error"}},
+		},
+		overrideOnly: map[string]bool{"info-contact": true, "info-license": true},
+	}
+	spec := filepath.Join(base, "api.yaml")
+	overrides := rs.CompileOverrides(spec)
+
+	assert.False(t, overrides.RuleRuns("operation-tags", []string{spec}))
+	assert.True(t, overrides.RuleRuns("operation-tags", []string{spec, filepath.Join(base, "schemas.yaml")}))
+	assert.True(t, overrides.RuleRuns("operation-description", []string{spec}))
+	assert.False(t, overrides.RuleRuns("info-contact", []string{spec}))
+	assert.True(t, overrides.RuleRuns("info-contact", []string{filepath.Join(base, "v2", "api.yaml")}))
+	assert.True(t, overrides.RuleRuns("info-license", []string{spec}))
+	assert.True(t, overrides.RuleRuns("oas3-schema", []string{spec}))
+
+	var none *CompiledOverrides
+	assert.True(t, none.RuleRuns("operation-tags", []string{spec}))
+	assert.Nil(t, (&RuleSet{}).CompileOverrides(spec))
+}
+
+func TestGenerateRuleSetFromSuppliedRuleSet_ExtendsOverrides(t *testing.T) {
+	dir := t.TempDir()
+	extended := filepath.Join(dir, "shared", "extended.yaml")
+	require.NoError(t, os.Mkdir(filepath.Dir(extended), 0o755))
+	require.NoError(t, os.WriteFile(extended, []byte(`rules: {}
+overrides:
+  - files: ["legacy/*.yaml", "/specs/api.yaml#/paths"]
+    rules:
+      operation-tags: off
+`), 0o644))
+
+	rs, err := CreateRuleSetFromData([]byte(`extends:
+  - [vacuum:oas, recommended]
+  - ` + extended + `
+overrides:
+  - files: ["legacy/*.yaml"]
+    rules:
+      operation-tags: error
+`))
+	require.NoError(t, err)
+	rs.OverridesBase = dir
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	require.Len(t, generated.Overrides, 2)
+	assert.Equal(t, []string{filepath.Join(dir, "shared", "legacy", "*.yaml"), "/specs/api.yaml#/paths"},
+		generated.Overrides[0].Files)
+	assert.Equal(t, []string{"legacy/*.yaml"}, generated.Overrides[1].Files)
+
+	// the extended override is relative to the extended ruleset.
+	result := model.RuleFunctionResult{RuleId: OperationTags, Rule: generated.Rules[OperationTags], Path: "$.paths"}
+	assert.Empty(t, generated.ApplyOverrides(filepath.Join(dir, "shared", "legacy", "api.yaml"),
+		[]model.RuleFunctionResult{result}))
+	applied := generated.ApplyOverrides(filepath.Join(dir, "legacy", "api.yaml"), []model.RuleFunctionResult{result})
+	require.Len(t, applied, 1)
+	assert.Equal(t, model.SeverityError, applied[0].RuleSeverity)
+}
+
+func TestGenerateRuleSetFromSuppliedRuleSet_OverrideEnablesRule(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`extends: [[vacuum:oas, off]]
+overrides:
+  - files: ["v2/*.yaml"]
+    rules:
+      operation-tags: true
+`))
+	require.NoError(t, err)
+	rs.OverridesBase = t.TempDir()
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	require.Contains(t, generated.Rules, OperationTags)
+
+	result := model.RuleFunctionResult{RuleId: OperationTags, Rule: generated.Rules[OperationTags], Path: "$.paths"}
+	assert.Empty(t, generated.ApplyOverrides(filepath.Join(rs.OverridesBase, "v1", "api.yaml"),
+		[]model.RuleFunctionResult{result}))
+	assert.Len(t, generated.ApplyOverrides(filepath.Join(rs.OverridesBase, "v2", "api.yaml"),
+		[]model.RuleFunctionResult{result}), 1)
+}
+
+func TestResultPathSegments(t *testing.T) {
+	assert.Equal(t, []string{"paths", "/pets/{id}", "get", "parameters", "0", "name"},
+		resultPathSegments("$.paths['/pets/{id}'].get.parameters[0].name"))
+	assert.Equal(t, []string{"components", "schemas", "it's"}, resultPathSegments(`$.components.schemas['it\'s']`))
+	assert.Nil(t, resultPathSegments("$"))
+	assert.Equal(t, []string{"paths", "/v1"}, jsonPointerSegments("/paths/~1v1"))
+}
@@ -0,0 +1,221 @@
+// Copyright 2020-2026 Dave Shanley / Quobix / Princess Beef Heavy Industries, LLC
+// https://quobix.com/vacuum/ | https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	// ErrRuleSetDigestMismatch is returned when a remote ruleset does not match the sha256 digest it is pinned to.
+	ErrRuleSetDigestMismatch = errors.New("ruleset does not match its pinned sha256 digest")
+
+	// ErrRuleSetNotCached is returned in offline mode, when a remote ruleset has never been downloaded.
+	ErrRuleSetNotCached = errors.New("ruleset is not in the offline cache")
+
+	// ErrFunctionNotPinned is returned when a pinned remote ruleset ships a remote function that is not pinned.
+	ErrFunctionNotPinned = errors.New("function of a pinned ruleset is not pinned to a sha256 digest")
+)
+
+// RemoteRuleSetCache is an on-disk cache of remote rulesets, keyed by URL. Cached copies are revalidated with
+// the server using their ETag and Last-Modified headers, or used without asking the server in offline mode.
+type RemoteRuleSetCache struct {
+	Dir     string
+	Offline bool
+}
+
+// RemoteRuleSetCacheEntry is a cached copy of a remote ruleset.
+type RemoteRuleSetCacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	SHA256       string `json:"sha256"`
+	Content      []byte `json:"content"`
+}
+
+var remoteRuleSetCache atomic.Pointer[RemoteRuleSetCache]
+
+// DefaultRemoteRuleSetCache returns a cache in the user cache directory (for example ~/.cache/vacuum/rulesets).
+func DefaultRemoteRuleSetCache() (*RemoteRuleSetCache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteRuleSetCache{
+		Dir: filepath.Join(dir, "vacuum", "rulesets"),
+	}, nil
+}
+
+// SetRemoteRuleSetCache sets the cache used when downloading remote rulesets. A nil cache turns caching off,
+// which is the default.
+func SetRemoteRuleSetCache(cache *RemoteRuleSetCache) {
+	remoteRuleSetCache.Store(cache)
+}
+
+// GetRemoteRuleSetCache returns the cache used when downloading remote rulesets, or nil if there is none.
+func GetRemoteRuleSetCache() *RemoteRuleSetCache {
+	return remoteRuleSetCache.Load()
+}
+
+// Read returns the cached copy of a remote ruleset, and false if there is no valid copy.
+func (c *RemoteRuleSetCache) Read(location string) (*RemoteRuleSetCacheEntry, bool) {
+	if c == nil || c.Dir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(location))
+	if err != nil {
+		return nil, false
+	}
+	var entry RemoteRuleSetCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	// a corrupted (or hand edited) copy is ignored, so it is downloaded again.
+	if entry.URL != location || entry.SHA256 != ruleSetDigest(entry.Content) {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Write stores a copy of a remote ruleset in the cache.
+func (c *RemoteRuleSetCache) Write(entry *RemoteRuleSetCacheEntry) error {
+	if c == nil || c.Dir == "" || entry == nil {
+		return nil
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(entry.URL)
+	tmpFile, err := os.CreateTemp(c.Dir, filepath.Base(path)+".tmp.")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err = tmpFile.Write(encoded); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Chmod(0o644); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (c *RemoteRuleSetCache) path(location string) string {
+	return filepath.Join(c.Dir, ruleSetDigest([]byte(location))+".json")
+}
+
+// fetchRemoteRuleSetBytes downloads a remote ruleset, using and updating the cache if there is one. If a digest is
+// supplied, the content must match it, and content that does not match is never cached.
+func fetchRemoteRuleSetBytes(ctx context.Context, location, digest string, httpClient *http.Client) ([]byte, error) {
+	cache := GetRemoteRuleSetCache()
+	cached, hasCached := cache.Read(location)
+
+	if cache != nil && cache.Offline {
+		if !hasCached {
+			return nil, fmt.Errorf("remote ruleset '%s' cannot be used offline: %w", location, ErrRuleSetNotCached)
+		}
+		if err := verifyRuleSetDigest(location, digest, cached.Content); err != nil {
+			return nil, err
+		}
+		return cached.Content, nil
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", location, err)
+	}
+	if hasCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	ruleResp, ruleRemoteErr := httpClient.Do(req)
+	if ruleRemoteErr != nil {
+		return nil, ruleRemoteErr
+	}
+	defer ruleResp.Body.Close()
+
+	if ruleResp.StatusCode == http.StatusNotModified && hasCached {
+		if err = verifyRuleSetDigest(location, digest, cached.Content); err != nil {
+			return nil, err
+		}
+		return cached.Content, nil
+	}
+	if ruleResp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("remote ruleset '%s' returned status %d", location, ruleResp.StatusCode)
+	}
+
+	ruleBytes, bytesErr := io.ReadAll(ruleResp.Body)
+	if bytesErr != nil {
+		return nil, bytesErr
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err = verifyRuleSetDigest(location, digest, ruleBytes); err != nil {
+		return nil, err
+	}
+
+	if len(ruleBytes) > 0 {
+		_ = cache.Write(&RemoteRuleSetCacheEntry{
+			URL:          location,
+			ETag:         ruleResp.Header.Get("ETag"),
+			LastModified: ruleResp.Header.Get("Last-Modified"),
+			SHA256:       ruleSetDigest(ruleBytes),
+			Content:      ruleBytes,
+		})
+	}
+	return ruleBytes, nil
+}
+
+// verifyRuleSetDigest checks content matches the sha256 digest a ruleset is pinned to. An empty digest is not
+// pinned, so anything matches.
+func verifyRuleSetDigest(location, digest string, content []byte) error {
+	if digest == "" {
+		return nil
+	}
+	expected := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(digest), "sha256:"))
+	if actual := ruleSetDigest(content); actual != expected {
+		return fmt.Errorf("remote ruleset '%s' has changed, expected sha256 '%s' but got '%s': %w",
+			location, expected, actual, ErrRuleSetDigestMismatch)
+	}
+	return nil
+}
+
+func ruleSetDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,154 @@
+package rulesets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func useTestRemoteRuleSetCache(t *testing.T, offline bool) *RemoteRuleSetCache {
+	cache := &RemoteRuleSetCache{Dir: t.TempDir(), Offline: offline}
+	SetRemoteRuleSetCache(cache)
+	t.Cleanup(func() { SetRemoteRuleSetCache(nil) })
+	return cache
+}
+
+func TestDownloadRemoteRuleSet_CacheRevalidates(t *testing.T) {
+	bs, err := os.ReadFile("examples/custom-ruleset.yaml")
+	require.NoError(t, err)
+
+	var downloads, revalidations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			revalidations.Add(1)
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write(bs)
+	}))
+	defer server.Close()
+
+	cache := useTestRemoteRuleSetCache(t, false)
+
+	rs, err := DownloadRemoteRuleSet(context.Background(), server.URL, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, rs.RuleDefinitions["check-title-is-exactly-this"])
+
+	entry, ok := cache.Read(server.URL)
+	require.True(t, ok)
+	assert.Equal(t, `"v1"`, entry.ETag)
+	assert.Equal(t, ruleSetDigest(bs), entry.SHA256)
+
+	rs, err = DownloadRemoteRuleSet(context.Background(), server.URL, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, rs.RuleDefinitions["check-title-is-exactly-this"])
+	assert.Equal(t, int32(1), downloads.Load())
+	assert.Equal(t, int32(1), revalidations.Load())
+}
+
+func TestDownloadRemoteRuleSet_Offline(t *testing.T) {
+	bs, err := os.ReadFile("examples/custom-ruleset.yaml")
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		_, _ = rw.Write(bs)
+	}))
+	defer server.Close()
+
+	cache := useTestRemoteRuleSetCache(t, true)
+
+	_, err = DownloadRemoteRuleSet(context.Background(), server.URL, nil)
+	assert.ErrorIs(t, err, ErrRuleSetNotCached)
+
+	require.NoError(t, cache.Write(&RemoteRuleSetCacheEntry{URL: server.URL, SHA256: ruleSetDigest(bs), Content: bs}))
+	rs, err := DownloadRemoteRuleSet(context.Background(), server.URL, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, rs.RuleDefinitions["check-title-is-exactly-this"])
+	assert.Equal(t, int32(0), requests.Load())
+}
+
+func TestDownloadPinnedRemoteRuleSet(t *testing.T) {
+	bs, err := os.ReadFile("examples/custom-ruleset.yaml")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(bs)
+	}))
+	defer server.Close()
+
+	cache := useTestRemoteRuleSetCache(t, false)
+
+	rs, err := DownloadPinnedRemoteRuleSet(context.Background(), server.URL, "sha256:"+ruleSetDigest(bs), nil)
+	require.NoError(t, err)
+	assert.NotNil(t, rs)
+
+	wrongDigest := ruleSetDigest([]byte("something else"))
+	_, err = DownloadPinnedRemoteRuleSet(context.Background(), server.URL, wrongDigest, nil)
+	assert.ErrorIs(t, err, ErrRuleSetDigestMismatch)
+	assert.Contains(t, err.Error(), wrongDigest)
+
+	// content that does not match its pin is never cached.
+	entry, ok := cache.Read(server.URL)
+	require.True(t, ok)
+	assert.Equal(t, ruleSetDigest(bs), entry.SHA256)
+}
+
+func TestRemoteRuleSetCache_IgnoresCorruptEntries(t *testing.T) {
+	cache := &RemoteRuleSetCache{Dir: t.TempDir()}
+	require.NoError(t, cache.Write(&RemoteRuleSetCacheEntry{URL: "https://quobix.com/rs.yaml", SHA256: "nope", Content: []byte("rules: {}")}))
+
+	_, ok := cache.Read("https://quobix.com/rs.yaml")
+	assert.False(t, ok)
+}
+
+func TestGenerateRuleSetFromSuppliedRuleSet_PinnedExtends(t *testing.T) {
+	bs, err := os.ReadFile("examples/custom-ruleset.yaml")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(bs)
+	}))
+	defer server.Close()
+
+	pinned := func(digest string) *RuleSet {
+		rs, rsErr := CreateRuleSetFromData([]byte(fmt.Sprintf(`extends:
+  - [vacuum:oas, off]
+  - location: %s
+    sha256: %s
+rules: {}`, server.URL, digest)))
+		require.NoError(t, rsErr)
+		return rs
+	}
+
+	rs := pinned(ruleSetDigest(bs))
+	assert.Equal(t, ruleSetDigest(bs), rs.GetExtendsPins()[server.URL])
+	assert.Equal(t, server.URL, rs.GetExtendsValue()[server.URL])
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.NoError(t, generated.ExtendsError())
+	assert.NotNil(t, generated.Rules["check-title-is-exactly-this"])
+
+	generated = BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(pinned(ruleSetDigest([]byte("changed"))))
+	assert.True(t, errors.Is(generated.ExtendsError(), ErrRuleSetDigestMismatch))
+	assert.Nil(t, generated.Rules["check-title-is-exactly-this"])
+}
+
+func TestCreateRuleSetFromData_InvalidPin(t *testing.T) {
+	_, err := CreateRuleSetFromData([]byte(`extends:
+  - location: https://quobix.com/rs.yaml
+    sha256: not-a-digest
+rules: {}`))
+	assert.Error(t, err)
+}
@@ -5,9 +5,9 @@ package rulesets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
-	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -42,6 +42,13 @@ func CheckForLocalExtends(extends map[string]string) bool {
 // DownloadRemoteRuleSet downloads a remote ruleset and returns a *RuleSet
 // returns an error if it cannot download the ruleset
 func DownloadRemoteRuleSet(ctx context.Context, location string, httpClient *http.Client) (*RuleSet, error) {
+	return DownloadPinnedRemoteRuleSet(ctx, location, "", httpClient)
+}
+
+// DownloadPinnedRemoteRuleSet downloads a remote ruleset that is pinned to a sha256 digest and returns a *RuleSet
+// returns an error if it cannot download the ruleset, or if the ruleset does not match the digest. An empty
+// digest is not pinned. If a remote ruleset cache is set, the cache is used and kept up to date.
+func DownloadPinnedRemoteRuleSet(ctx context.Context, location, digest string, httpClient *http.Client) (*RuleSet, error) {
 
 	if location == "" {
 		return nil, fmt.Errorf("cannot download ruleset, location is empty")
@@ -50,26 +57,8 @@ func DownloadRemoteRuleSet(ctx context.Context, location string, httpClient *htt
 		return nil, err
 	}
 
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", location, nil)
+	ruleBytes, err := fetchRemoteRuleSetBytes(ctx, location, digest, httpClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", location, err)
-	}
-
-	ruleResp, ruleRemoteErr := httpClient.Do(req)
-	if ruleRemoteErr != nil {
-		return nil, ruleRemoteErr
-	}
-	defer ruleResp.Body.Close()
-
-	ruleBytes, bytesErr := io.ReadAll(ruleResp.Body)
-	if bytesErr != nil {
-		return nil, bytesErr
-	}
-	if err = ctx.Err(); err != nil {
 		return nil, err
 	}
 
@@ -81,10 +70,24 @@ func DownloadRemoteRuleSet(ctx context.Context, location string, httpClient *htt
 	if rsErr != nil {
 		return nil, rsErr
 	}
+	downloadedRS.Location = location
 
 	return downloadedRS, nil
 }
 
+// DownloadRemoteRuleSetFile downloads a file that is shipped with a remote ruleset, such as a custom function.
+// If a remote ruleset cache is set, the file is cached in the same way as a ruleset, so it works offline. A file
+// can be pinned to a sha256 digest the same as a ruleset, an empty digest is not pinned.
+func DownloadRemoteRuleSetFile(ctx context.Context, location, digest string, httpClient *http.Client) ([]byte, error) {
+	if location == "" {
+		return nil, fmt.Errorf("cannot download file, location is empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fetchRemoteRuleSetBytes(ctx, location, digest, httpClient)
+}
+
 // LoadLocalRuleSet loads a local ruleset and returns a *RuleSet
 // returns an error if it cannot load the ruleset
 func LoadLocalRuleSet(ctx context.Context, location string) (*RuleSet, error) {
@@ -112,17 +115,20 @@ func LoadLocalRuleSet(ctx context.Context, location string) (*RuleSet, error) {
 	if rsErr != nil {
 		return nil, rsErr
 	}
+	downloadedRS.Location = location
 
 	return downloadedRS, nil
 }
 
 // SniffOutAllExternalRules takes a ruleset and sniffs out all external rules
 // it will recursively sniff out all external rulesets and add them to the ruleset
-// it will return an error if it cannot sniff out the ruleset
+// it will return an error if it cannot sniff out the ruleset. A remote ruleset can be pinned to a sha256 digest,
+// rulesets that do not match their digest, or cannot be found offline, are recorded as extends errors on rs.
 func SniffOutAllExternalRules(
 	ctx context.Context,
 	rsm *ruleSetsModel,
 	location string,
+	digest string,
 	visited []string,
 	rs *RuleSet,
 	remote bool,
@@ -139,8 +145,11 @@ func SniffOutAllExternalRules(
 	var err error
 
 	if remote {
-		drs, err = DownloadRemoteRuleSet(ctx, location, httpClient)
+		drs, err = DownloadPinnedRemoteRuleSet(ctx, location, digest, httpClient)
 	} else {
+		if digest != "" {
+			rsm.logger.Warn("sha256 pins are only checked for remote rulesets", "location", location)
+		}
 		drs, err = LoadLocalRuleSet(ctx, location)
 	}
 	if err != nil {
@@ -149,6 +158,11 @@ func SniffOutAllExternalRules(
 		}
 		rsm.logger.Error("cannot open external ruleset",
 			"location", location, "error", err.Error())
+		if errors.Is(err, ErrRuleSetDigestMismatch) || errors.Is(err, ErrRuleSetNotCached) {
+			rs.mutex.Lock()
+			rs.extendsErrors = append(rs.extendsErrors, err)
+			rs.mutex.Unlock()
+		}
 		return
 	}
 	if ctx.Err() != nil {
@@ -164,6 +178,30 @@ func SniffOutAllExternalRules(
 		rs.mutex.Unlock()
 	}
 
+	// Merge functions shipped with the external ruleset (parent takes precedence). The remote functions of a
+	// pinned ruleset must be pinned too, otherwise they could change without the ruleset changing.
+	if functionLocations := drs.declaredFunctionLocations(); len(functionLocations) > 0 {
+		rs.mutex.Lock()
+		if digest != "" {
+			for _, name := range unpinnedRemoteFunctions(functionLocations, drs.FunctionDigests) {
+				rs.extendsErrors = append(rs.extendsErrors, fmt.Errorf(
+					"function '%s' of pinned ruleset '%s' is not pinned, add its sha256 to functionDigests: %w",
+					name, location, ErrFunctionNotPinned))
+				delete(functionLocations, name)
+			}
+		}
+		rs.addFunctionLocations(functionLocations, drs.FunctionDigests)
+		rs.mutex.Unlock()
+	}
+
+	// Merge overrides from the external ruleset, before the overrides already merged, so the overrides of the
+	// rulesets that extend it win.
+	if overrides := extendedOverrides(location, remote, drs.Overrides); len(overrides) > 0 {
+		rs.mutex.Lock()
+		rs.Overrides = append(overrides, rs.Overrides...)
+		rs.mutex.Unlock()
+	}
+
 	// Merge aliases from external ruleset (parent takes precedence).
 	if drs.Aliases != nil {
 		rs.mutex.Lock()
@@ -186,6 +224,7 @@ func SniffOutAllExternalRules(
 
 	// iterate over the extends and extract everything
 	extends := drs.GetExtendsValue()
+	pins := drs.GetExtendsPins()
 
 	// default and explicitly recommended
 	if (extends[SpectralOpenAPI] == VacuumRecommended || extends[SpectralOpenAPI] == SpectralOpenAPI) ||
@@ -264,7 +303,7 @@ func SniffOutAllExternalRules(
 				}
 
 				// do down the rabbit hole.
-				SniffOutAllExternalRules(ctx, rsm, k, visited, rs, strings.HasPrefix(k, "http"), httpClient)
+				SniffOutAllExternalRules(ctx, rsm, k, pins[k], visited, rs, strings.HasPrefix(k, "http"), httpClient)
 			}
 		}
 	}
@@ -211,3 +211,9 @@ const (
 	owaspSecurityHostsHttpsOAS2Fix  = "Ensure that you are using the HTTPS protocol. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
 	owaspSecurityHostsHttpsOAS3Fix  = "Prefix server URLs with the HTTPS protocol: `https://`. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
 )
+
+const (
+	catalogOperationIdUniqueFix = "Every operationId in a catalog of APIs must be unique, SDKs, gateways and portals built from the catalog cannot tell the operations apart otherwise. Rename the operationId, prefixing it with the name of the API is a good way to keep it unique."
+	catalogPathConflictFix      = "An operation (a method and a path) is defined by more than one API in the catalog, the APIs cannot be served from the same host without a conflict. Move the operation to a single API, or give each API its own base path."
+	catalogSchemaConsistentFix  = "A schema with the same name is defined differently by APIs in the catalog. Make the copies the same, or better still, move the schema to a shared file and $ref it from every API. If the schemas really are different things, give them different names."
+)
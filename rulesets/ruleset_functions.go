@@ -6,6 +6,7 @@ package rulesets
 import (
 	"regexp"
 
+	"github.com/daveshanley/vacuum/functions/autofix"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/parser"
 )
@@ -110,7 +111,8 @@ func GetInfoLicenseRule() *model.Rule {
 		Then: model.RuleAction{
 			Function: "infoLicense",
 		},
-		HowToFix: infoLicenseFix,
+		HowToFix:        infoLicenseFix,
+		AutoFixFunction: autofix.AddLicenseSkeleton,
 	}
 }
 
@@ -213,7 +215,8 @@ func GetOpenApiTagsAlphabeticalRule() *model.Rule {
 			Function:        "alphabetical",
 			FunctionOptions: fo,
 		},
-		HowToFix: openAPITagsAlphabeticalFix,
+		HowToFix:        openAPITagsAlphabeticalFix,
+		AutoFixFunction: autofix.SortAlphabetically,
 	}
 }
 
@@ -362,6 +365,7 @@ func GetOAS2HostTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           oas2HostTrailingSlashFix,
+		AutoFixFunction:    autofix.StripServerTrailingSlash,
 	}
 }
 
@@ -388,6 +392,7 @@ func GetOAS3HostTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           oas3HostTrailingSlashFix,
+		AutoFixFunction:    autofix.StripServerTrailingSlash,
 	}
 }
 
@@ -611,6 +616,7 @@ func GetPathNoTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           pathNoTrailingSlashFix,
+		AutoFixFunction:    autofix.StripPathTrailingSlash,
 	}
 }
 
@@ -941,7 +947,8 @@ func GetOperationIdRule() *model.Rule {
 		Then: model.RuleAction{
 			Function: "oasOpId",
 		},
-		HowToFix: operationIdExistsFix,
+		HowToFix:        operationIdExistsFix,
+		AutoFixFunction: autofix.GenerateOperationId,
 	}
 }
 
@@ -982,7 +989,8 @@ func GetDuplicatedEntryInEnumRule() *model.Rule {
 		Then: model.RuleAction{
 			Function: "duplicatedEnum",
 		},
-		HowToFix: duplicatedEntryInEnumFix,
+		HowToFix:        duplicatedEntryInEnumFix,
+		AutoFixFunction: autofix.RemoveDuplicateEnumValues,
 	}
 }
 
@@ -6,6 +6,7 @@ package rulesets
 import (
 	"testing"
 
+	"github.com/daveshanley/vacuum/functions/autofix"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/pb33f/testify/assert"
 )
@@ -39,3 +40,16 @@ func ruleFormatsMatch(ruleFormats []string, specFormat string) bool {
 	}
 	return false
 }
+
+func TestBuiltInRules_AutoFixFunctionsExist(t *testing.T) {
+	fixes := autofix.GetBuiltInAutoFixFunctions()
+	found := 0
+	for id, rule := range GetAllBuiltInRules() {
+		if rule.AutoFixFunction == "" {
+			continue
+		}
+		found++
+		assert.Contains(t, fixes, rule.AutoFixFunction, "rule '%s' uses an auto-fix function that does not exist", id)
+	}
+	assert.NotZero(t, found)
+}
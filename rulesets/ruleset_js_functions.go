@@ -0,0 +1,116 @@
+// Copyright 2020-2026 Dave Shanley / Quobix / Princess Beef Heavy Industries, LLC
+// https://quobix.com/vacuum/ | https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultFunctionsDir is the directory (next to the ruleset) that functions are loaded from, when a ruleset
+// declares functions without a functionsDir. This is the same as Spectral.
+const DefaultFunctionsDir = "functions"
+
+// FunctionLocations returns the location (a file path or a URL) of every JavaScript function shipped by a generated
+// ruleset, or by the rulesets it extends, keyed by function name.
+func (rs *RuleSet) FunctionLocations() map[string]string {
+	if rs == nil {
+		return nil
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	return cloneStringMap(rs.functionLocations)
+}
+
+// FunctionPins returns the sha256 digest that remote functions shipped by a generated ruleset, or by the rulesets
+// it extends, are pinned to, keyed by function name. A function is pinned using functionDigests:
+//
+//	functions: [checkOwner]
+//	functionDigests:
+//	  checkOwner: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+func (rs *RuleSet) FunctionPins() map[string]string {
+	if rs == nil {
+		return nil
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	return cloneStringMap(rs.functionPins)
+}
+
+// declaredFunctionLocations resolves the functions a ruleset declares against its functionsDir, which is
+// relative to the location of the ruleset.
+func (rs *RuleSet) declaredFunctionLocations() map[string]string {
+	if rs == nil || len(rs.Functions) == 0 {
+		return nil
+	}
+	dir := rs.FunctionsDir
+	if dir == "" {
+		dir = DefaultFunctionsDir
+	}
+	locations := make(map[string]string, len(rs.Functions))
+	for _, name := range rs.Functions {
+		if name == "" {
+			continue
+		}
+		locations[name] = resolveFunctionLocation(rs.Location, dir, name)
+	}
+	return locations
+}
+
+// resolveFunctionLocation returns the location of a function file, relative to the location of a ruleset. Functions
+// of remote rulesets are fetched from next to the ruleset URL.
+func resolveFunctionLocation(rulesetLocation, dir, name string) string {
+	file := name
+	if !strings.HasSuffix(file, ".js") {
+		file += ".js"
+	}
+	if strings.HasPrefix(rulesetLocation, "http") {
+		if base, err := url.Parse(rulesetLocation); err == nil {
+			return base.ResolveReference(&url.URL{Path: path.Join(dir, file)}).String()
+		}
+	}
+	if filepath.IsAbs(dir) || rulesetLocation == "" {
+		return filepath.Join(dir, file)
+	}
+	return filepath.Join(filepath.Dir(rulesetLocation), dir, file)
+}
+
+// addFunctionLocations adds function locations, and the digests they are pinned to, to a ruleset. Functions the
+// ruleset already has take precedence. The caller must hold the ruleset mutex.
+func (rs *RuleSet) addFunctionLocations(locations, pins map[string]string) {
+	if len(locations) == 0 {
+		return
+	}
+	if rs.functionLocations == nil {
+		rs.functionLocations = make(map[string]string, len(locations))
+	}
+	for name, location := range locations {
+		if _, exists := rs.functionLocations[name]; exists {
+			continue
+		}
+		rs.functionLocations[name] = location
+		if pin := pins[name]; pin != "" {
+			if rs.functionPins == nil {
+				rs.functionPins = make(map[string]string)
+			}
+			rs.functionPins[name] = pin
+		}
+	}
+}
+
+// unpinnedRemoteFunctions returns the names of the remote functions in locations that have no pin, in name order.
+func unpinnedRemoteFunctions(locations, pins map[string]string) []string {
+	var unpinned []string
+	for name, location := range locations {
+		if strings.HasPrefix(location, "http") && pins[name] == "" {
+			unpinned = append(unpinned, name)
+		}
+	}
+	sort.Strings(unpinned)
+	return unpinned
+}
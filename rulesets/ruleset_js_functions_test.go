@@ -0,0 +1,78 @@
+package rulesets
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestResolveFunctionLocation(t *testing.T) {
+	assert.Equal(t, "https://quobix.com/rulesets/functions/check.js",
+		resolveFunctionLocation("https://quobix.com/rulesets/governance.yaml", DefaultFunctionsDir, "check"))
+	assert.Equal(t, "https://quobix.com/shared/check.js",
+		resolveFunctionLocation("https://quobix.com/rulesets/governance.yaml", "../shared", "check.js"))
+	assert.Equal(t, filepath.Join("rules", "fns", "check.js"),
+		resolveFunctionLocation(filepath.Join("rules", "ruleset.yaml"), "fns", "check"))
+	assert.Equal(t, filepath.Join("functions", "check.js"),
+		resolveFunctionLocation("", DefaultFunctionsDir, "check"))
+}
+
+func TestGenerateRuleSetFromSuppliedRuleSet_FunctionLocations(t *testing.T) {
+	dir := t.TempDir()
+	extended := filepath.Join(dir, "shared", "extended.yaml")
+	require.NoError(t, os.Mkdir(filepath.Dir(extended), 0o755))
+	require.NoError(t, os.WriteFile(extended, []byte(`functionsDir: lib
+functions: [shared, parent]
+rules: {}`), 0o644))
+
+	rs, err := CreateRuleSetFromData([]byte(`extends:
+  - [vacuum:oas, off]
+  - ` + extended + `
+functions: [parent]
+rules: {}`))
+	require.NoError(t, err)
+	rs.Location = filepath.Join(dir, "ruleset.yaml")
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	locations := generated.FunctionLocations()
+	assert.Equal(t, filepath.Join(dir, "functions", "parent.js"), locations["parent"])
+	assert.Equal(t, filepath.Join(dir, "shared", "lib", "shared.js"), locations["shared"])
+}
+
+func TestGenerateRuleSetFromSuppliedRuleSet_PinnedExtendsFunctions(t *testing.T) {
+	var extended []byte
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(extended)
+	}))
+	defer server.Close()
+
+	functionDigest := ruleSetDigest([]byte("function check() {}"))
+	generate := func(shipped string) *RuleSet {
+		extended = []byte(shipped)
+		rs, err := CreateRuleSetFromData([]byte(fmt.Sprintf(`extends:
+  - [vacuum:oas, off]
+  - location: %s/governance.yaml
+    sha256: %s
+rules: {}`, server.URL, ruleSetDigest(extended))))
+		require.NoError(t, err)
+		return BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	}
+
+	// a pinned ruleset cannot ship remote functions that are not pinned.
+	generated := generate("functions: [check, other]\nfunctionDigests:\n  check: " + functionDigest + "\nrules: {}\n")
+	assert.True(t, errors.Is(generated.ExtendsError(), ErrFunctionNotPinned))
+	assert.ErrorContains(t, generated.ExtendsError(), "function 'other' of pinned ruleset")
+	assert.Equal(t, server.URL+"/functions/check.js", generated.FunctionLocations()["check"])
+	assert.NotContains(t, generated.FunctionLocations(), "other")
+	assert.Equal(t, map[string]string{"check": functionDigest}, generated.FunctionPins())
+
+	generated = generate("functions: [check]\nfunctionDigests:\n  check: " + functionDigest + "\nrules: {}\n")
+	assert.NoError(t, generated.ExtendsError())
+}
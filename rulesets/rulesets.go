@@ -118,6 +118,9 @@ const (
 	OwaspNoAdditionalProperties          = "owasp-no-additionalProperties"
 	OwaspConstrainedAdditionalProperties = "owasp-constrained-additionalProperties"
 	OwaspSecurityHostsHttpsOAS3          = "owasp-security-hosts-https-oas3"
+	CatalogOperationIdUnique             = "catalog-operation-id-unique"
+	CatalogPathConflict                  = "catalog-path-conflict"
+	CatalogSchemaConsistent              = "catalog-schema-consistent"
 	PostResponseSuccess                  = "post-response-success"
 	NoRequestBody                        = "no-request-body"
 	JsonSchemaValid                      = "json-schema-valid"
@@ -164,6 +167,12 @@ const (
 	AsyncAPIMessageExamples              = "asyncapi-message-examples"
 	AsyncAPIUnusedComponents             = "asyncapi-unused-components"
 	AsyncAPIContentType                  = "asyncapi-content-type"
+	AsyncAPI2DocumentResolved            = "asyncapi-2-document-resolved"
+	AsyncAPI2ChannelOperations           = "asyncapi-2-channel-operations"
+	AsyncAPI2OperationOperationId        = "asyncapi-2-operation-operationId"
+	AsyncAPI2OperationIdUniqueness       = "asyncapi-2-operation-operationId-uniqueness"
+	AsyncAPI2OperationDescription        = "asyncapi-2-operation-description"
+	AsyncAPI2MessageExamples             = "asyncapi-2-message-examples"
 	VacuumOpenAPI                        = "vacuum:oas"
 	VacuumAsyncAPI                       = "vacuum:asyncapi"
 	VacuumAsyncAPIRecommended            = "asyncapi-recommended"
@@ -173,6 +182,7 @@ const (
 	SpectralAsyncAPI                     = "spectral:asyncapi"
 	SpectralOwasp                        = "spectral:owasp"
 	VacuumOwasp                          = "vacuum:owasp"
+	VacuumCatalog                        = "vacuum:catalog"
 	VacuumAllRulesets                    = "vacuum:all" // Combined OpenAPI + OWASP rules
 	VacuumRecommended                    = "recommended"
 	VacuumAll                            = "all"
@@ -204,11 +214,11 @@ type RuleSets interface {
 	GenerateJSONSchemaDefaultRuleSet() *RuleSet
 
 	// GenerateAsyncAPIRecommendedRuleSet generates a ready to run pointer to a RuleSet that contains only
-	// recommended AsyncAPI rules. The returned rules are scoped to AsyncAPI 3.x formats only.
+	// recommended AsyncAPI rules. The returned rules are scoped to AsyncAPI 2.x and 3.x formats.
 	GenerateAsyncAPIRecommendedRuleSet() *RuleSet
 
 	// GenerateAsyncAPIDefaultRuleSet generates a ready to run pointer to a RuleSet containing all built-in
-	// AsyncAPI rules supported by vacuum. The returned rules are scoped to AsyncAPI 3.x formats only.
+	// AsyncAPI rules supported by vacuum. The returned rules are scoped to AsyncAPI 2.x and 3.x formats.
 	GenerateAsyncAPIDefaultRuleSet() *RuleSet
 
 	// GenerateRuleSetFromSuppliedRuleSet will generate a ready to run ruleset based on a supplied configuration. This
@@ -223,16 +233,28 @@ type RuleSets interface {
 
 // RuleSet represents a collection of Rule definitions.
 type RuleSet struct {
-	Description      string                  `json:"description,omitempty" yaml:"description,omitempty"`
-	DocumentationURI string                  `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
-	Formats          []string                `json:"formats,omitempty" yaml:"formats,omitempty"`
-	RuleDefinitions  map[string]interface{}  `json:"rules" yaml:"rules"` // this can be either a string, or an entire rule (super annoying, stoplight).
-	Rules            map[string]*model.Rule  `json:"-" yaml:"-"`
-	Extends          interface{}             `json:"extends,omitempty" yaml:"extends,omitempty"` // can be string or tuple (again... why stoplight?)
-	Aliases          map[string]interface{}  `json:"aliases,omitempty" yaml:"aliases,omitempty"` // Spectral-compatible alias definitions
-	ParsedAliases    map[string]*ParsedAlias `json:"-" yaml:"-"`                                 // concrete parsed aliases, no interface boxing
-	extendsMeta      map[string]string
-	mutex            sync.Mutex
+	Description       string                        `json:"description,omitempty" yaml:"description,omitempty"`
+	DocumentationURI  string                        `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+	Formats           []string                      `json:"formats,omitempty" yaml:"formats,omitempty"`
+	RuleDefinitions   map[string]interface{}        `json:"rules" yaml:"rules"` // this can be either a string, or an entire rule (super annoying, stoplight).
+	Rules             map[string]*model.Rule        `json:"-" yaml:"-"`
+	Extends           interface{}                   `json:"extends,omitempty" yaml:"extends,omitempty"`                 // can be string or tuple (again... why stoplight?)
+	Aliases           map[string]interface{}        `json:"aliases,omitempty" yaml:"aliases,omitempty"`                 // Spectral-compatible alias definitions
+	ParsedAliases     map[string]*ParsedAlias       `json:"-" yaml:"-"`                                                 // concrete parsed aliases, no interface boxing
+	Scoring           *model.ScoreModel             `json:"scoring,omitempty" yaml:"scoring,omitempty"`                 // quality score weights, floors and fatal rules
+	Overrides         []*RuleSetOverride            `json:"overrides,omitempty" yaml:"overrides,omitempty"`             // Spectral-compatible per-file and per-path rule changes
+	OverridesBase     string                        `json:"-" yaml:"-"`                                                 // directory override file patterns are relative to, defaults to the working directory
+	FunctionsDir      string                        `json:"functionsDir,omitempty" yaml:"functionsDir,omitempty"`       // directory functions are loaded from, relative to the ruleset
+	Functions         []string                      `json:"functions,omitempty" yaml:"functions,omitempty"`             // names of the JavaScript functions shipped with the ruleset
+	FunctionDigests   map[string]string             `json:"functionDigests,omitempty" yaml:"functionDigests,omitempty"` // sha256 digests remote functions are pinned to, keyed by function name
+	Location          string                        `json:"-" yaml:"-"`                                                 // file path or URL the ruleset was loaded from
+	LoadedFunctions   map[string]model.RuleFunction `json:"-" yaml:"-"`                                                 // functions shipped with the ruleset, once loaded by the plugin package
+	overrideOnly      map[string]bool               // rules that only run where an override turns them on
+	functionLocations map[string]string             // function name to file path or URL, for this ruleset and the rulesets it extends
+	functionPins      map[string]string             // function name to the sha256 digest the function is pinned to
+	extendsMeta       map[string]string
+	extendsErrors     []error // pinned or offline extends that could not be loaded
+	mutex             sync.Mutex
 }
 
 //go:embed schemas/ruleset.schema.json
@@ -337,6 +359,10 @@ func cloneRuleSetMetadata(source *RuleSet) *RuleSet {
 		Extends:          source.Extends,
 		Aliases:          source.Aliases,
 		ParsedAliases:    source.ParsedAliases,
+		Scoring:          source.Scoring,
+		Overrides:        source.Overrides,
+		OverridesBase:    source.OverridesBase,
+		overrideOnly:     source.overrideOnly,
 		extendsMeta:      source.extendsMeta,
 	}
 }
@@ -468,6 +494,13 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 		}
 	}
 
+	// catalog rules, they only run when documents are linted as a catalog.
+	if extends[VacuumCatalog] == VacuumAll || extends[VacuumCatalog] == VacuumRecommended {
+		for ruleName, rule := range GetAllCatalogRules() {
+			rs.Rules[ruleName] = rule
+		}
+	}
+
 	// add definitions.
 	rs.RuleDefinitions = ruleset.RuleDefinitions
 
@@ -481,9 +514,22 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 		rs.Aliases = ruleset.Aliases
 	}
 
+	// the scoring model belongs to the supplied ruleset, built-in rulesets use the default model.
+	if ruleset.Scoring != nil {
+		rs.Scoring = ruleset.Scoring
+	}
+
+	// functions shipped with the supplied ruleset, functions from extended rulesets are added as they are loaded.
+	rs.functionLocations = nil
+	rs.functionPins = nil
+	rs.addFunctionLocations(ruleset.declaredFunctionLocations(), ruleset.FunctionDigests)
+
+	// overrides of the supplied ruleset are added once extended rulesets have added theirs.
+	rs.Overrides = nil
+
 	// download remote rulesets
 	if CheckForRemoteExtends(extends) || CheckForLocalExtends(extends) {
-		rsm.loadExternalRulesetsWithTimeout(extends, rs, httpClient)
+		rsm.loadExternalRulesetsWithTimeout(extends, ruleset.GetExtendsPins(), rs, httpClient)
 	}
 
 	// now all the base rules are in, let's run through the raw definitions and decide
@@ -584,6 +630,8 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 	}
 	rs.mutex.Unlock()
 
+	rsm.applySuppliedOverrides(ruleset, rs)
+
 	// Parse Spectral-compatible aliases (if any).
 	if len(rs.Aliases) > 0 {
 		parsed, err := ParseAliases(rs.Aliases)
@@ -738,6 +786,17 @@ func GetRecommendedOWASPRules() map[string]*model.Rule {
 	return GetAllOWASPRules() // change if we need to customize this in the future.
 }
 
+// GetAllCatalogRules returns a map of all the catalog rules available, ready to be used in a RuleSet.
+func GetAllCatalogRules() map[string]*model.Rule {
+	rules := make(map[string]*model.Rule)
+
+	rules[CatalogOperationIdUnique] = GetCatalogOperationIdUniqueRule()
+	rules[CatalogPathConflict] = GetCatalogPathConflictRule()
+	rules[CatalogSchemaConsistent] = GetCatalogSchemaConsistentRule()
+
+	return rules
+}
+
 // GenerateDefaultOpenAPIRuleSet generates a default ruleset for OpenAPI. All the built-in rules, ready to go.
 func GenerateDefaultOpenAPIRuleSet() *RuleSet {
 	set := &RuleSet{
@@ -758,6 +817,16 @@ func GenerateOWASPOpenAPIRuleSet() *RuleSet {
 	return set
 }
 
+// GenerateCatalogRuleSet generates our catalog ruleset, the rules that check every document of a catalog at once.
+func GenerateCatalogRuleSet() *RuleSet {
+	set := &RuleSet{
+		DocumentationURI: "https://quobix.com/vacuum/rulesets/catalog",
+		Rules:            GetAllCatalogRules(),
+		Description:      "All catalog rules, checking the consistency of many APIs linted together.",
+	}
+	return set
+}
+
 func isSeverityRuleDefinition(value string) bool {
 	switch value {
 	case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint:
@@ -866,6 +935,11 @@ func (rs *RuleSet) GetExtendsValue() map[string]string {
 				if castArr, k := arr.(string); k {
 					m[castArr] = castArr
 				}
+				if pinned, k := arr.(map[string]interface{}); k {
+					if location, ok := pinned["location"].(string); ok {
+						m[location] = location
+					}
+				}
 			}
 		}
 	}
@@ -873,6 +947,42 @@ func (rs *RuleSet) GetExtendsValue() map[string]string {
 	return m
 }
 
+// GetExtendsPins returns the sha256 digests that extended rulesets are pinned to, keyed by location. A ruleset is
+// pinned using an object in the extends array:
+//
+//	extends:
+//	  - location: https://example.com/rulesets/company.yaml
+//	    sha256: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+func (rs *RuleSet) GetExtendsPins() map[string]string {
+	pins := make(map[string]string)
+	if extArray, ok := rs.Extends.([]interface{}); ok {
+		for _, arr := range extArray {
+			pinned, k := arr.(map[string]interface{})
+			if !k {
+				continue
+			}
+			location, _ := pinned["location"].(string)
+			digest, _ := pinned["sha256"].(string)
+			if location != "" && digest != "" {
+				pins[location] = digest
+			}
+		}
+	}
+	return pins
+}
+
+// ExtendsError returns an error if an extended ruleset that is pinned to a sha256 digest has changed, or if a
+// remote ruleset could not be found in the cache in offline mode. These rulesets are left out of the generated
+// ruleset, so it should not be used.
+func (rs *RuleSet) ExtendsError() error {
+	if rs == nil {
+		return nil
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	return errors.Join(rs.extendsErrors...)
+}
+
 // CreateRuleSetUsingJSON will create a new RuleSet instance from a JSON byte array
 func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 	jsonString := string(jsonData)
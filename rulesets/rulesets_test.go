@@ -484,6 +484,24 @@ func TestRuleSetsModel_GenerateRuleSetFromConfig_Oas_VacuumOwasp(t *testing.T) {
 
 }
 
+func TestRuleSetsModel_GenerateRuleSetFromConfig_Oas_VacuumCatalog(t *testing.T) {
+
+	yaml := `extends: [[vacuum:oas, all], [vacuum:catalog, all]]`
+
+	def := BuildDefaultRuleSets()
+	rs, _ := CreateRuleSetFromData([]byte(yaml))
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, repl.Rules, len(GetAllCatalogRules())+totalRules)
+	assert.NotNil(t, repl.Rules[CatalogSchemaConsistent])
+
+}
+
+func TestGenerateCatalogRuleSet(t *testing.T) {
+	rs := GenerateCatalogRuleSet()
+	assert.Len(t, rs.Rules, 3)
+	assert.Equal(t, "catalogUniqueOperationIds", rs.Rules[CatalogOperationIdUnique].Then.(model.RuleAction).Function)
+}
+
 func TestGetAllBuiltInRules(t *testing.T) {
 	assert.Len(t, GetAllBuiltInRules(), totalRules)
 }
@@ -1089,3 +1107,33 @@ rules:
 		assert.Equal(t, "$.info", rule.Given)
 	}
 }
+
+func TestCreateRuleSetFromData_Scoring(t *testing.T) {
+	rs, err := CreateRuleSetFromData([]byte(`rules: {}
+scoring:
+  severity:
+    error: 20
+  categories:
+    security: 2
+  floors:
+    minimum: 0
+  fatal: [oas3-schema]
+  fatalPenalty: 100
+`))
+	assert.NoError(t, err)
+	if !assert.NotNil(t, rs.Scoring) {
+		return
+	}
+	assert.Equal(t, 20.0, rs.Scoring.Severity["error"])
+	assert.Equal(t, 2.0, rs.Scoring.Categories["security"])
+	assert.Equal(t, 0.0, *rs.Scoring.Floors.Minimum)
+	assert.Equal(t, []string{"oas3-schema"}, rs.Scoring.Fatal)
+	assert.Equal(t, 100.0, *rs.Scoring.FatalPenalty)
+
+	_, err = CreateRuleSetFromData([]byte(`rules: {}
+scoring:
+  severity:
+    error: -1
+`))
+	assert.Error(t, err)
+}
@@ -10,8 +10,7 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
-// CalculateQualityScore calculates the quality score based on the number of errors, warnings, and info messages.
-// This is the single source of truth for score calculation logic.
+// CalculateQualityScore calculates the quality score using the default score model.
 // Returns a score between 10 and 100, where:
 // - Info messages deduct 0.1 points each
 // - Warnings deduct 0.3 points each
@@ -20,40 +19,83 @@ import (
 // - Minimum score is 10 (never returns 0 or negative)
 // - If no errors but score would be negative, floor at 25
 func CalculateQualityScore(resultSet *model.RuleResultSet) int {
+	return CalculateQualityScoreWithModel(resultSet, nil)
+}
+
+// CalculateQualityScoreWithModel calculates the quality score of a result set using a score model, a nil model
+// uses the default. This is the single source of truth for score calculation logic.
+func CalculateQualityScoreWithModel(resultSet *model.RuleResultSet, scoreModel *model.ScoreModel) int {
 	if resultSet == nil {
 		return 100 // perfect score if no results
 	}
+	if scoreModel == nil {
+		scoreModel = model.DefaultScoreModel()
+	}
 
-	total := 100.0
-	score := total - float64(resultSet.GetInfoCount())*0.1
-	score = score - (0.3 * float64(resultSet.GetWarnCount()))
-	score = score - (15.0 * float64(resultSet.GetErrorCount())) // errors are failures they should be judged harshly.
+	score := 100.0
+	errors := 0
+	for _, result := range resultSet.Results {
+		score = score - scoreModel.Deduction(result)
+		if result.Rule != nil && result.Rule.Severity == model.SeverityError {
+			errors++ // errors are failures they should be judged harshly.
+		}
+	}
 
-	if resultSet.GetErrorCount() <= 0 && score < 0 {
-		// floor at 25% if there are no errors, but a ton of warnings lowering the score
-		score = 25.0
+	if noErrors, ok := scoreModel.NoErrorsScore(); ok && errors <= 0 && score < 0 {
+		// floor the score if there are no errors, but a ton of warnings lowering the score
+		score = noErrors
 	}
 
-	// if there are any document-schema rule violations, bottom out the score,
+	// if there are any fatal rule violations (document schema rules), bottom out the score,
 	// an invalid API description is a big deal.
 	for _, result := range resultSet.Results {
-		if result.Rule != nil && (result.Rule.Id == "oas3-schema" ||
-			result.Rule.Id == "asyncapi-3-document-resolved" ||
-			result.Rule.Id == "asyncapi-3-document-unresolved") {
-			score = score - 90
+		if scoreModel.IsFatal(result) {
+			score = score - scoreModel.FatalDeduction()
 		}
 	}
 
 	if score <= 0 {
-		score = 10 // the lowest score we want to present can't be 0, there has to be some hope!
+		score = scoreModel.MinimumScore() // the lowest score we want to present can't be 0, there has to be some hope!
 	}
 
-	return int(score)
+	// weights are summed one result at a time, so allow for floating point error before truncating.
+	return int(score + 1e-9)
+}
+
+// CalculateCategoryStatistics breaks down the results of each rule category, scoring each category using the
+// same score model as the overall score. A category without any results scores 100.
+func CalculateCategoryStatistics(results *model.RuleResultSet, scoreModel *model.ScoreModel) []*reports.CategoryStatistic {
+	var catStats []*reports.CategoryStatistic
+	for _, cat := range model.RuleCategoriesOrdered {
+		categoryResults := results.GetResultsByRuleCategory(cat.Id)
+		score := 100 // perfect
+		if len(categoryResults) > 0 {
+			score = CalculateQualityScoreWithModel(&model.RuleResultSet{Results: categoryResults}, scoreModel)
+		}
+		catStats = append(catStats, &reports.CategoryStatistic{
+			CategoryName: cat.Name,
+			CategoryId:   cat.Id,
+			NumIssues:    len(categoryResults),
+			Warnings:     len(results.GetWarningsByRuleCategory(cat.Id)),
+			Errors:       len(results.GetErrorsByRuleCategory(cat.Id)),
+			Info:         len(results.GetInfoByRuleCategory(cat.Id)),
+			Hints:        len(results.GetHintByRuleCategory(cat.Id)),
+			Score:        score,
+		})
+	}
+	return catStats
 }
 
 // CreateReportStatistics generates a ready to render breakdown of the document's statistics. A convenience function
 // that reduces churn on building stats over and over for different interfaces.
 func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, results *model.RuleResultSet) *reports.ReportStatistics {
+	return CreateReportStatisticsWithScoreModel(index, info, results, nil)
+}
+
+// CreateReportStatisticsWithScoreModel is the same as CreateReportStatistics, scoring the results with a score
+// model, a nil model uses the default.
+func CreateReportStatisticsWithScoreModel(index *index.SpecIndex, info *datamodel.SpecInfo, results *model.RuleResultSet,
+	scoreModel *model.ScoreModel) *reports.ReportStatistics {
 
 	// don't go looking for stats if we don't have the necessary data
 	if index == nil || info == nil || results == nil {
@@ -63,35 +105,10 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 	opPCount := index.GetOperationsParameterCount()
 	cPCount := index.GetComponentParameterCount()
 
-	var catStats []*reports.CategoryStatistic
-	for _, cat := range model.RuleCategoriesOrdered {
-		var numIssues, numWarnings, numErrors, numInfo, numHints int
-		numIssues = len(results.GetResultsByRuleCategory(cat.Id))
-		numWarnings = len(results.GetWarningsByRuleCategory(cat.Id))
-		numErrors = len(results.GetErrorsByRuleCategory(cat.Id))
-		numInfo = len(results.GetInfoByRuleCategory(cat.Id))
-		numHints = len(results.GetHintByRuleCategory(cat.Id))
-		numResults := len(results.Results)
-		var score int
-		if numResults == 0 && numIssues == 0 {
-			score = 100 // perfect
-		} else if numResults > 0 {
-			score = 100 - (numIssues * 100 / numResults)
-		}
-		catStats = append(catStats, &reports.CategoryStatistic{
-			CategoryName: cat.Name,
-			CategoryId:   cat.Id,
-			NumIssues:    numIssues,
-			Warnings:     numWarnings,
-			Errors:       numErrors,
-			Info:         numInfo,
-			Hints:        numHints,
-			Score:        score,
-		})
-	}
+	catStats := CalculateCategoryStatistics(results, scoreModel)
 
 	// Use the shared score calculation function
-	overallScore := CalculateQualityScore(results)
+	overallScore := CalculateQualityScoreWithModel(results, scoreModel)
 
 	stats := &reports.ReportStatistics{
 		FilesizeBytes:      len(*info.SpecBytes),
@@ -122,11 +139,27 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 	return stats
 }
 
+// RescoreReportStatistics returns a copy of stats, scored and counted using results. The counts of the document
+// (paths, schemas and so on) are kept, so the statistics of a document can be re-used without its index.
+func RescoreReportStatistics(stats *reports.ReportStatistics, results *model.RuleResultSet,
+	scoreModel *model.ScoreModel) *reports.ReportStatistics {
+	if stats == nil || results == nil {
+		return nil
+	}
+	rescored := *stats
+	rescored.OverallScore = CalculateQualityScoreWithModel(results, scoreModel)
+	rescored.TotalErrors = results.GetErrorCount()
+	rescored.TotalWarnings = results.GetWarnCount()
+	rescored.TotalInfo = results.GetInfoCount()
+	rescored.CategoryStatistics = CalculateCategoryStatistics(results, scoreModel)
+	return &rescored
+}
+
 func isAsyncAPIInfo(info *datamodel.SpecInfo) bool {
 	if info == nil {
 		return false
 	}
-	return strings.EqualFold(info.SpecType, "asyncapi") || strings.HasPrefix(info.SpecFormat, model.AsyncAPI3)
+	return strings.EqualFold(info.SpecType, "asyncapi") || model.IsAsyncAPIFormat(info.SpecFormat)
 }
 
 func applyAsyncAPIStatistics(stats *reports.ReportStatistics, specIndex *index.SpecIndex, info *datamodel.SpecInfo) {
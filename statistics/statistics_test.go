@@ -4,6 +4,7 @@ import (
 	"context"
 	asyncapi_context "github.com/daveshanley/vacuum/asyncapi"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pb33f/libasyncapi"
@@ -149,3 +150,120 @@ components:
 	assert.Equal(t, 3, stats.References)
 	assert.Equal(t, 0, stats.Paths)
 }
+
+func scoreTestResult(id, severity, category string) model.RuleFunctionResult {
+	return model.RuleFunctionResult{Rule: &model.Rule{Id: id, Severity: severity, RuleCategory: model.RuleCategories[category]}}
+}
+
+func TestCalculateQualityScore_DefaultModel(t *testing.T) {
+	var results []model.RuleFunctionResult
+	for i := 0; i < 10; i++ {
+		results = append(results, scoreTestResult("operation-tags", model.SeverityWarn, model.CategoryTags))
+	}
+	results = append(results, scoreTestResult("info-contact", model.SeverityError, model.CategoryInfo))
+
+	// 100 - (10 * 0.3) - 15
+	assert.Equal(t, 82, CalculateQualityScore(model.NewRuleResultSet(results)))
+
+	results = append(results, scoreTestResult("oas3-schema", model.SeverityError, model.CategorySchemas))
+	assert.Equal(t, 10, CalculateQualityScore(model.NewRuleResultSet(results)))
+	assert.Equal(t, 100, CalculateQualityScore(nil))
+}
+
+func TestCalculateQualityScore_NoErrorsFloor(t *testing.T) {
+	var results []model.RuleFunctionResult
+	for i := 0; i < 500; i++ {
+		results = append(results, scoreTestResult("operation-tags", model.SeverityWarn, model.CategoryTags))
+	}
+	assert.Equal(t, 25, CalculateQualityScore(model.NewRuleResultSet(results)))
+}
+
+func TestCalculateQualityScoreWithModel_MinimumOnlyBelowZero(t *testing.T) {
+	minimum := 60.0
+	scoreModel := model.ResolveScoreModel(&model.ScoreModel{Floors: &model.ScoreFloors{Minimum: &minimum}})
+
+	results := model.NewRuleResultSet([]model.RuleFunctionResult{
+		scoreTestResult("info-contact", model.SeverityError, model.CategoryInfo),
+		scoreTestResult("info-description", model.SeverityError, model.CategoryInfo),
+		scoreTestResult("info-license", model.SeverityError, model.CategoryInfo),
+		scoreTestResult("custom-rule", "", model.CategoryInfo),
+	})
+	// 100 - (15 * 3), a score above zero is not raised to the minimum, the rule without a severity deducts nothing.
+	assert.Equal(t, 55, CalculateQualityScoreWithModel(results, scoreModel))
+	results.Results = append(results.Results, results.Results...)
+	results.Results = append(results.Results, results.Results...)
+	// 100 - (15 * 12), floored at the minimum
+	assert.Equal(t, 60, CalculateQualityScoreWithModel(results, scoreModel))
+}
+
+func TestCalculateQualityScoreWithModel(t *testing.T) {
+	minimum, fatalPenalty := 0.0, 50.0
+	scoreModel := model.ResolveScoreModel(&model.ScoreModel{
+		Severity:     map[string]float64{model.SeverityWarn: 2},
+		Categories:   map[string]float64{model.CategorySecurity: 3},
+		Rules:        map[string]float64{"operation-tags": 0},
+		Floors:       &model.ScoreFloors{Minimum: &minimum},
+		Fatal:        []string{"owasp-auth-insecure-schemes"},
+		FatalPenalty: &fatalPenalty,
+	})
+
+	results := model.NewRuleResultSet([]model.RuleFunctionResult{
+		scoreTestResult("operation-tags", model.SeverityError, model.CategoryTags),
+		scoreTestResult("info-contact", model.SeverityWarn, model.CategoryInfo),
+		scoreTestResult("owasp-define-error-responses-401", model.SeverityWarn, model.CategorySecurity),
+	})
+	// 100 - 0 - 2 - (2 * 3)
+	assert.Equal(t, 92, CalculateQualityScoreWithModel(results, scoreModel))
+
+	results = model.NewRuleResultSet([]model.RuleFunctionResult{
+		scoreTestResult("info-contact", model.SeverityWarn, model.CategoryInfo),
+		scoreTestResult("owasp-auth-insecure-schemes", model.SeverityError, model.CategorySecurity),
+		scoreTestResult("owasp-auth-insecure-schemes", model.SeverityError, model.CategorySecurity),
+	})
+	// 100 - 2 - (15 * 3 * 2) - (50 * 2), floored at 0
+	assert.Equal(t, 0, CalculateQualityScoreWithModel(results, scoreModel))
+}
+
+func TestCalculateCategoryStatistics_UsesScoreModel(t *testing.T) {
+	scoreModel := model.ResolveScoreModel(&model.ScoreModel{
+		Categories: map[string]float64{model.CategoryTags: 10},
+	})
+	results := model.NewRuleResultSet([]model.RuleFunctionResult{
+		scoreTestResult("operation-tags", model.SeverityWarn, model.CategoryTags),
+		scoreTestResult("tag-description", model.SeverityWarn, model.CategoryTags),
+		scoreTestResult("info-contact", model.SeverityError, model.CategoryInfo),
+	})
+
+	stats := CalculateCategoryStatistics(results, scoreModel)
+
+	scores := make(map[string]*reports.CategoryStatistic)
+	for _, stat := range stats {
+		scores[stat.CategoryId] = stat
+	}
+	require.Len(t, stats, len(model.RuleCategoriesOrdered))
+	assert.Equal(t, 94, scores[model.CategoryTags].Score)
+	assert.Equal(t, 2, scores[model.CategoryTags].Warnings)
+	assert.Equal(t, 85, scores[model.CategoryInfo].Score)
+	assert.Equal(t, 1, scores[model.CategoryInfo].Errors)
+	assert.Equal(t, 100, scores[model.CategorySchemas].Score)
+}
+
+func TestRescoreReportStatistics(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+	specBytes, _ := os.ReadFile("../model/test_files/petstorev3.json")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: selectedRS,
+		Spec:    specBytes,
+	})
+
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+	documentStats := CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, model.NewRuleResultSet(nil))
+
+	rescored := RescoreReportStatistics(documentStats, model.NewRuleResultSet(ruleset.Results), nil)
+	assert.Equal(t, stats, rescored)
+	assert.Equal(t, 100, documentStats.OverallScore)
+	assert.Nil(t, RescoreReportStatistics(nil, resultSet, nil))
+}
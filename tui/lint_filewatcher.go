@@ -224,7 +224,10 @@ func (m *ViolationResultTableModel) performRelint() tea.Msg {
 	if m.watchConfig.IgnoreFile != "" {
 		raw, ferr := os.ReadFile(m.watchConfig.IgnoreFile)
 		if ferr == nil {
-			_ = yaml.Unmarshal(raw, &ignoredItems)
+			var entries model.IgnoreEntries
+			if yaml.Unmarshal(raw, &entries) == nil {
+				ignoredItems = entries.Active(time.Now())
+			}
 		}
 	}
 
@@ -0,0 +1,280 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// BaselineVersion is the version of the baseline file format written by vacuum.
+const BaselineVersion = 1
+
+// Baseline is a record of the violations that already exist in one or more specifications. Violations in the
+// baseline are accepted, only violations absent from the baseline are reported. Violations are matched using the
+// same stable identity (rule, path and message) used to compare specifications with --original, so a baseline
+// survives unrelated edits that move lines around.
+type Baseline struct {
+	Version    int                  `json:"version" yaml:"version"`
+	Violations []*BaselineViolation `json:"violations" yaml:"violations"`
+
+	// matched counts how many times each violation has been matched, so duplicates beyond the recorded count
+	// are still reported.
+	matched map[baselineKey]int
+
+	// filtered records the files Filter has been called for, only their violations can be resolved by a run.
+	filtered map[string]bool
+
+	// files linted at the same time share a baseline.
+	lock sync.Mutex
+}
+
+// BaselineViolation is a single accepted violation. Path and Message are informational, to make the baseline
+// reviewable, violations are matched using File and Fingerprint.
+type BaselineViolation struct {
+	File        string `json:"file" yaml:"file"`
+	Rule        string `json:"rule" yaml:"rule"`
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	Message     string `json:"message" yaml:"message"`
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+	Count       int    `json:"count,omitempty" yaml:"count,omitempty"`
+}
+
+type baselineKey struct {
+	file        string
+	fingerprint string
+}
+
+// NewBaseline creates an empty baseline, ready to record violations.
+func NewBaseline() *Baseline {
+	return &Baseline{Version: BaselineVersion}
+}
+
+// LoadBaseline reads a baseline file. The file is YAML, JSON baselines can also be read.
+func LoadBaseline(path string) (*Baseline, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+	baseline := NewBaseline()
+	if err = yaml.Unmarshal(raw, baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file '%s': %w", path, err)
+	}
+	if baseline.Version > BaselineVersion {
+		return nil, fmt.Errorf("baseline file '%s' is version %d, this version of vacuum supports up to version %d",
+			path, baseline.Version, BaselineVersion)
+	}
+	return baseline, nil
+}
+
+// Save writes the baseline to a file, violations are sorted so the file is stable between runs.
+func (b *Baseline) Save(path string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	sort.SliceStable(b.Violations, func(i, j int) bool {
+		x, y := b.Violations[i], b.Violations[j]
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Rule != y.Rule {
+			return x.Rule < y.Rule
+		}
+		if x.Path != y.Path {
+			return x.Path < y.Path
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+	raw, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to render baseline: %w", err)
+	}
+	if err = os.WriteFile(path, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	return nil
+}
+
+// Size returns the number of violations in the baseline, including duplicates.
+func (b *Baseline) Size() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	size := 0
+	for _, v := range b.Violations {
+		size += v.count()
+	}
+	return size
+}
+
+// Record adds violations found in a specification to the baseline, replacing anything previously recorded for
+// the same file.
+func (b *Baseline) Record(specPath string, results []*model.RuleFunctionResult) {
+	file := baselineFile(specPath)
+	mapper := baselineOriginMapper(specPath)
+	recorded := make(map[string]*BaselineViolation)
+	var violations []*BaselineViolation
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		fingerprint := violationFingerprint(*result, &mapper)
+		if v, ok := recorded[fingerprint]; ok {
+			v.Count = v.count() + 1
+			continue
+		}
+		v := &BaselineViolation{
+			File:        file,
+			Rule:        result.RuleId,
+			Path:        result.Path,
+			Message:     result.Message,
+			Fingerprint: fingerprint,
+		}
+		recorded[fingerprint] = v
+		violations = append(violations, v)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	kept := b.Violations[:0]
+	for _, v := range b.Violations {
+		if v.File != file {
+			kept = append(kept, v)
+		}
+	}
+	b.Violations = append(kept, violations...)
+}
+
+// Filter removes violations that are in the baseline from the results of a specification, returning the
+// remaining results and the number of results that were removed.
+func (b *Baseline) Filter(specPath string, results []*model.RuleFunctionResult) ([]*model.RuleFunctionResult, int) {
+	if b.empty() {
+		return results, 0
+	}
+	file := baselineFile(specPath)
+	mapper := baselineOriginMapper(specPath)
+	keys := make([]baselineKey, len(results))
+	for i, result := range results {
+		if result != nil {
+			keys[i] = baselineKey{file: file, fingerprint: violationFingerprint(*result, &mapper)}
+		}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	allowed := b.allowed()
+	if b.matched == nil {
+		b.matched = make(map[baselineKey]int)
+	}
+	if b.filtered == nil {
+		b.filtered = make(map[string]bool)
+	}
+	b.filtered[file] = true
+
+	filtered := make([]*model.RuleFunctionResult, 0, len(results))
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		if key := keys[i]; b.matched[key] < allowed[key] {
+			b.matched[key]++
+			continue
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered, len(results) - len(filtered)
+}
+
+// FilterValues is the same as Filter, for non-pointer results.
+func (b *Baseline) FilterValues(specPath string, results []model.RuleFunctionResult) ([]model.RuleFunctionResult, int) {
+	if b.empty() {
+		return results, 0
+	}
+	resultPtrs := make([]*model.RuleFunctionResult, len(results))
+	for i := range results {
+		resultPtrs[i] = &results[i]
+	}
+	kept, removed := b.Filter(specPath, resultPtrs)
+	filtered := make([]model.RuleFunctionResult, len(kept))
+	for i, r := range kept {
+		filtered[i] = *r
+	}
+	return filtered, removed
+}
+
+// Resolved returns the number of baselined violations that have not been matched by Filter, these have been
+// fixed and can be removed from the baseline by updating it. Only the files Filter was called for are counted, the
+// violations of files that were not linted by this run are not resolved.
+func (b *Baseline) Resolved() int {
+	if b == nil {
+		return 0
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	resolved := 0
+	for key, count := range b.allowed() {
+		if !b.filtered[key.file] {
+			continue
+		}
+		if matched := b.matched[key]; matched < count {
+			resolved += count - matched
+		}
+	}
+	return resolved
+}
+
+func (b *Baseline) empty() bool {
+	if b == nil {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.Violations) == 0
+}
+
+func (b *Baseline) allowed() map[baselineKey]int {
+	allowed := make(map[baselineKey]int, len(b.Violations))
+	for _, v := range b.Violations {
+		allowed[baselineKey{file: filepath.ToSlash(v.File), fingerprint: v.Fingerprint}] += v.count()
+	}
+	return allowed
+}
+
+func (v *BaselineViolation) count() int {
+	if v.Count < 1 {
+		return 1
+	}
+	return v.Count
+}
+
+// baselineFile returns the name a specification is recorded under, relative to the working directory when
+// possible so baselines can be shared between machines.
+func baselineFile(specPath string) string {
+	if specPath == "" || strings.Contains(specPath, "://") {
+		return specPath
+	}
+	if filepath.IsAbs(specPath) {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, specPath); err == nil && !isParentRelativePath(rel) {
+				specPath = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(specPath))
+}
+
+// baselineOriginMapper makes the locations of violations found in referenced documents relative to the
+// specification, so fingerprints do not depend on where the specification is checked out.
+func baselineOriginMapper(specPath string) canonicalOriginMapper {
+	if mapper, ok := newCanonicalOriginMapperFromSpecPath(specPath, "$root", nil, 0); ok {
+		return mapper
+	}
+	return newCanonicalOriginMapper("", "", "", nil)
+}
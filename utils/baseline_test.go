@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestBaseline_RecordAndFilter(t *testing.T) {
+	baseline := NewBaseline()
+	baseline.Record("spec.yaml", []*model.RuleFunctionResult{
+		makeResultPtr("operation-description", "$.paths['/pizza'].get", "missing description"),
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+	})
+	assert.Len(t, baseline.Violations, 2)
+	assert.Equal(t, 3, baseline.Size())
+
+	newViolation := makeResultPtr("operation-description", "$.paths['/cake'].get", "missing description")
+	filtered, removed := baseline.Filter("spec.yaml", []*model.RuleFunctionResult{
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+		newViolation,
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+	})
+
+	// the third info-contact violation is one more than the baseline accepts.
+	assert.Equal(t, 2, removed)
+	require.Len(t, filtered, 2)
+	assert.Same(t, newViolation, filtered[0])
+	assert.Equal(t, "info-contact", filtered[1].RuleId)
+
+	// the operation-description violation was never matched, so it has been fixed.
+	assert.Equal(t, 1, baseline.Resolved())
+}
+
+func TestBaseline_FilterIsScopedToFile(t *testing.T) {
+	baseline := NewBaseline()
+	baseline.Record("spec.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+
+	filtered, removed := baseline.FilterValues("other.yaml", []model.RuleFunctionResult{makeResult("info-contact", "$.info", "missing contact")})
+	assert.Zero(t, removed)
+	assert.Len(t, filtered, 1)
+
+	filtered, removed = baseline.FilterValues("./spec.yaml", []model.RuleFunctionResult{makeResult("info-contact", "$.info", "missing contact")})
+	assert.Equal(t, 1, removed)
+	assert.Empty(t, filtered)
+}
+
+func TestBaseline_ResolvedIsScopedToFilteredFiles(t *testing.T) {
+	baseline := NewBaseline()
+	baseline.Record("spec.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+	baseline.Record("other.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+
+	// other.yaml was not linted, so its violations have not been fixed.
+	_, removed := baseline.Filter("spec.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+	assert.Equal(t, 1, removed)
+	assert.Zero(t, baseline.Resolved())
+
+	_, removed = baseline.Filter("other.yaml", nil)
+	assert.Zero(t, removed)
+	assert.Equal(t, 1, baseline.Resolved())
+}
+
+func TestBaseline_RecordReplacesFile(t *testing.T) {
+	baseline := NewBaseline()
+	baseline.Record("spec.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+	baseline.Record("other.yaml", []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")})
+	baseline.Record("spec.yaml", nil)
+
+	require.Len(t, baseline.Violations, 1)
+	assert.Equal(t, "other.yaml", baseline.Violations[0].File)
+}
+
+func TestBaseline_SharedBetweenFiles(t *testing.T) {
+	baseline := NewBaseline()
+
+	// files linted at the same time record and filter against the same baseline.
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			file := fmt.Sprintf("spec-%d.yaml", i)
+			results := []*model.RuleFunctionResult{makeResultPtr("info-contact", "$.info", "missing contact")}
+			baseline.Record(file, results)
+			filtered, removed := baseline.Filter(file, results)
+			assert.Empty(t, filtered)
+			assert.Equal(t, 1, removed)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 20, baseline.Size())
+	assert.Zero(t, baseline.Resolved())
+}
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	baseline := NewBaseline()
+	baseline.Record("spec.yaml", []*model.RuleFunctionResult{
+		makeResultPtr("operation-description", "$.paths['/pizza'].get", "missing description"),
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+		makeResultPtr("info-contact", "$.info", "missing contact"),
+	})
+	require.NoError(t, baseline.Save(path))
+
+	loaded, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, BaselineVersion, loaded.Version)
+	require.Len(t, loaded.Violations, 2)
+	assert.Equal(t, "info-contact", loaded.Violations[0].Rule)
+	assert.Equal(t, 2, loaded.Violations[0].Count)
+	assert.Equal(t, "$.info", loaded.Violations[0].Path)
+	assert.Equal(t, ViolationFingerprint(makeResultPtr("info-contact", "$.info", "missing contact")), loaded.Violations[0].Fingerprint)
+	assert.Equal(t, 3, loaded.Size())
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadBaseline(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	bad := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("violations: [nope"), 0o644))
+	_, err = LoadBaseline(bad)
+	assert.ErrorContains(t, err, "failed to parse baseline file")
+
+	future := filepath.Join(dir, "future.yaml")
+	require.NoError(t, os.WriteFile(future, []byte("version: 99\nviolations: []\n"), 0o644))
+	_, err = LoadBaseline(future)
+	assert.ErrorContains(t, err, "version 99")
+}
@@ -33,6 +33,31 @@ func FilterIgnoredResultsPtrWithOptions(
 	return filteredResults
 }
 
+// PartitionIgnoredResultsPtrWithOptions splits result pointers into the results that are kept and the results
+// suppressed by the ignore file, each suppressed result carries the ignore entry (and justification) that
+// suppressed it.
+func PartitionIgnoredResultsPtrWithOptions(
+	results []*model.RuleFunctionResult,
+	ignored model.IgnoredItems,
+	options IgnoreMatcherOptions,
+) ([]*model.RuleFunctionResult, []*model.SuppressedResult) {
+	matcher := NewIgnoreMatcher(ignored, options)
+	if len(matcher.literalByRule) == 0 && len(matcher.resolvedByRule) == 0 {
+		return results, nil
+	}
+
+	kept := make([]*model.RuleFunctionResult, 0, len(results))
+	var suppressed []*model.SuppressedResult
+	for _, result := range results {
+		if ignoredPath, ok := matcher.Match(result); ok {
+			suppressed = append(suppressed, &model.SuppressedResult{Result: result, Suppression: ignoredPath})
+			continue
+		}
+		kept = append(kept, result)
+	}
+	return kept, suppressed
+}
+
 // FilterIgnoredResults does the filtering of ignored results on non-pointer result elements
 func FilterIgnoredResults(results []model.RuleFunctionResult, ignored model.IgnoredItems) []model.RuleFunctionResult {
 	return FilterIgnoredResultsWithOptions(results, ignored, IgnoreMatcherOptions{})
@@ -7,6 +7,7 @@ import (
 	"github.com/daveshanley/vacuum/model"
 	"github.com/pb33f/testify/assert"
 	"testing"
+	"time"
 
 	"go.yaml.in/yaml/v4"
 )
@@ -236,6 +237,56 @@ info:
 	assert.Equal(t, "a/b", filtered[0].Path)
 }
 
+func TestPartitionIgnoredResultsPtrWithOptions(t *testing.T) {
+	spec := []byte(`
+openapi: 3.1.0
+paths:
+  /pizza:
+    get: {}
+  /cake:
+    get: {}
+`)
+	pizza := &model.RuleFunctionResult{Path: "$.paths['/pizza'].get", RuleId: "OP"}
+	cake := &model.RuleFunctionResult{Path: "$.paths['/cake'].get", RuleId: "OP"}
+	info := &model.RuleFunctionResult{Path: "$.info", RuleId: "INFO"}
+
+	entries := model.IgnoreEntries{
+		"OP":   {{Path: "$.paths[*].get", Reason: "legacy operations", Owner: "api-team"}},
+		"INFO": {{Path: "$.info", Reason: "no info yet", Expires: "2020-01-01"}},
+	}
+	kept, suppressed := PartitionIgnoredResultsPtrWithOptions([]*model.RuleFunctionResult{pizza, cake, info}, entries.Paths(), IgnoreMatcherOptions{
+		SpecBytes: spec,
+		Entries:   entries,
+		Now:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	// the info entry has expired, so it no longer suppresses anything.
+	assert.Equal(t, []*model.RuleFunctionResult{info}, kept)
+	assert.Len(t, suppressed, 2)
+	assert.Same(t, pizza, suppressed[0].Result)
+	assert.Equal(t, "legacy operations", suppressed[0].Suppression.Reason)
+	assert.Equal(t, "api-team", suppressed[1].Suppression.Owner)
+}
+
+func TestIgnoreMatcher_Expired(t *testing.T) {
+	entries := model.IgnoreEntries{
+		"OP": {{Path: "a", Expires: "2026-03-01"}, {Path: "b", Expires: "2026-09-01"}},
+	}
+	ignored := model.IgnoredItems{"OP": {"a", "b", "c"}}
+	matcher := NewIgnoreMatcher(ignored, IgnoreMatcherOptions{
+		Entries: entries,
+		Now:     time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Equal(t, model.IgnoreEntries{"OP": {{Path: "a", Expires: "2026-03-01"}}}, matcher.Expired())
+	assert.False(t, matcher.Matches(&model.RuleFunctionResult{Path: "a", RuleId: "OP"}))
+	assert.True(t, matcher.Matches(&model.RuleFunctionResult{Path: "b", RuleId: "OP"}))
+
+	entry, ok := matcher.Match(&model.RuleFunctionResult{Path: "c", RuleId: "OP"})
+	assert.True(t, ok)
+	assert.Equal(t, "c", entry.Path)
+}
+
 func parseIgnoreMatcherRoot(t *testing.T, spec []byte) *yaml.Node {
 	t.Helper()
 
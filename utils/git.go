@@ -0,0 +1,131 @@
+// Copyright 2026 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitRepository is a local git repository, read using the git command line.
+type GitRepository struct {
+	Root string // the root of the working tree, with symlinks resolved.
+}
+
+// OpenGitRepository returns the git repository dir is in.
+func OpenGitRepository(dir string) (*GitRepository, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not in a git repository: %w", dir, gitError(err))
+	}
+	root := filepath.Clean(strings.TrimSpace(string(out)))
+	// git reports the root with symlinks resolved (e.g. /private/var on macOS).
+	if resolved, rErr := filepath.EvalSymlinks(root); rErr == nil {
+		root = resolved
+	}
+	return &GitRepository{Root: root}, nil
+}
+
+// ResolveCommit returns the commit a ref (a branch, tag, SHA or expression like HEAD~1) points to.
+func (g *GitRepository) ResolveCommit(ref string) (string, error) {
+	out, err := g.git("rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown git ref '%s'", ref)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// MergeBase returns the commit where HEAD left commit, changes made to commit since are not changes made on HEAD.
+// A commit that HEAD shares no history with is returned as it is.
+func (g *GitRepository) MergeBase(commit string) string {
+	out, err := g.git("merge-base", commit, "HEAD")
+	if err != nil {
+		return commit
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ChangedFiles returns the absolute paths of the files that are different in the working tree than they are at a
+// commit, including files that are not committed yet and files that have been deleted.
+func (g *GitRepository) ChangedFiles(commit string) ([]string, error) {
+	changed, err := g.gitPaths("diff", "--name-only", "-z", "--no-renames", commit, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := g.gitPaths("ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return append(changed, untracked...), nil
+}
+
+// Files returns the absolute paths of the files in the working tree, ignored files are left out.
+func (g *GitRepository) Files() ([]string, error) {
+	return g.gitPaths("ls-files", "-z", "--cached", "--others", "--exclude-standard")
+}
+
+// ReadFile returns the contents of a file at a commit, the path is absolute or relative to the root of the
+// repository. An error wrapping fs.ErrNotExist is returned when the file is not part of the commit.
+func (g *GitRepository) ReadFile(commit string, path string) ([]byte, error) {
+	rel, ok := g.RelativePath(path)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not in the git repository '%s': %w", path, g.Root, fs.ErrNotExist)
+	}
+	out, err := g.git("cat-file", "blob", commit+":"+rel)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' does not exist at %s: %w", rel, commit, fs.ErrNotExist)
+	}
+	return out, nil
+}
+
+// RelativePath returns the slash separated path of a file relative to the root of the repository, false is
+// returned when the file is outside the repository.
+func (g *GitRepository) RelativePath(path string) (string, bool) {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path)), !strings.HasPrefix(filepath.Clean(path), "..")
+	}
+	// the root has symlinks resolved, so the path must too. The file itself may no longer exist.
+	dir, file := filepath.Split(filepath.Clean(path))
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		path = filepath.Join(resolved, file)
+	}
+	rel, err := filepath.Rel(g.Root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func (g *GitRepository) gitPaths(args ...string) ([]string, error) {
+	out, err := g.git(args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list files with git: %w", gitError(err))
+	}
+	var paths []string
+	for _, rel := range bytes.Split(out, []byte{0}) {
+		if len(rel) > 0 {
+			paths = append(paths, filepath.Join(g.Root, filepath.FromSlash(string(rel))))
+		}
+	}
+	return paths, nil
+}
+
+func (g *GitRepository) git(args ...string) ([]byte, error) {
+	return exec.Command("git", append([]string{"-C", g.Root}, args...)...).Output()
+}
+
+// gitError adds what git printed to an error, the exit status alone says nothing.
+func gitError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		return errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
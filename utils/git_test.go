@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func newGitTestRepository(t *testing.T) (string, func(args ...string)) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=vacuum", "-c", "user.email=vacuum@pb33f.io",
+			"-c", "commit.gpgsign=false"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	return dir, git
+}
+
+func TestGitRepository(t *testing.T) {
+	dir, git := newGitTestRepository(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "specs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "openapi.yaml"), []byte("openapi: 3.1.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("pets\n"), 0o644))
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+
+	repo, err := OpenGitRepository(filepath.Join(dir, "specs"))
+	require.NoError(t, err)
+	commit, err := repo.ResolveCommit("HEAD")
+	require.NoError(t, err)
+	_, err = repo.ResolveCommit("no-such-branch")
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "openapi.yaml"), []byte("openapi: 3.1.1\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "new.yaml"), []byte("openapi: 3.1.0\n"), 0o644))
+
+	changed, err := repo.ChangedFiles(commit)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repo.Root, "specs", "openapi.yaml"),
+		filepath.Join(repo.Root, "specs", "new.yaml"),
+	}, changed)
+
+	files, err := repo.Files()
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	original, err := repo.ReadFile(commit, filepath.Join(dir, "specs", "openapi.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\n", string(original))
+
+	_, err = repo.ReadFile(commit, "specs/new.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = repo.ReadFile(commit, filepath.Join(filepath.Dir(dir), "outside.yaml"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	rel, ok := repo.RelativePath(filepath.Join(dir, "specs", "deleted.yaml"))
+	assert.True(t, ok)
+	assert.Equal(t, "specs/deleted.yaml", rel)
+
+	_, err = OpenGitRepository(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestGitRepository_MergeBase(t *testing.T) {
+	dir, git := newGitTestRepository(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("openapi: 3.1.0\n"), 0o644))
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	git("branch", "main-line")
+
+	repo, err := OpenGitRepository(dir)
+	require.NoError(t, err)
+	forked, err := repo.ResolveCommit("HEAD")
+	require.NoError(t, err)
+
+	// the ref moving on after the branch left it is not a change made on the branch.
+	git("checkout", "-q", "main-line")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("openapi: 3.1.1\n"), 0o644))
+	git("commit", "-q", "-am", "second")
+	git("checkout", "-q", "-")
+
+	mainLine, err := repo.ResolveCommit("main-line")
+	require.NoError(t, err)
+	assert.NotEqual(t, forked, mainLine)
+	assert.Equal(t, forked, repo.MergeBase(mainLine))
+}
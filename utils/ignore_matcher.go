@@ -19,25 +19,38 @@ type IgnoreMatcherOptions struct {
 	SpecBytes []byte
 	// LookupTimeout controls how long JSONPath expression lookup may run.
 	LookupTimeout time.Duration
+	// Entries carries the reason, owner and expiry of the ignored paths. An ignored path whose entry has
+	// expired is not honored.
+	Entries model.IgnoreEntries
+	// Now is the time ignore expiry dates are checked against, defaults to the current time.
+	Now time.Time
 }
 
 // IgnoreMatcher resolves ignore rules once and then performs fast per-result checks.
-// Exact literal matching is always preserved for backward compatibility.
+// Exact literal matching is always preserved for backward compatibility. Expired
+// entries are not honored.
 type IgnoreMatcher struct {
-	literalByRule  map[string]map[string]struct{}
-	resolvedByRule map[string]map[string]struct{}
+	literalByRule  map[string]map[string]model.IgnoredPath
+	resolvedByRule map[string]map[string]model.IgnoredPath
+	expired        model.IgnoreEntries
 }
 
 // NewIgnoreMatcher builds a matcher from ignored items and an optional document root.
 func NewIgnoreMatcher(ignored model.IgnoredItems, options IgnoreMatcherOptions) *IgnoreMatcher {
 	matcher := &IgnoreMatcher{
-		literalByRule:  make(map[string]map[string]struct{}),
-		resolvedByRule: make(map[string]map[string]struct{}),
+		literalByRule:  make(map[string]map[string]model.IgnoredPath),
+		resolvedByRule: make(map[string]map[string]model.IgnoredPath),
+		expired:        make(model.IgnoreEntries),
 	}
 	if len(ignored) == 0 {
 		return matcher
 	}
 
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
 	root := options.RootNode
 	if root == nil && len(options.SpecBytes) > 0 {
 		var parsed yaml.Node
@@ -58,28 +71,37 @@ func NewIgnoreMatcher(ignored model.IgnoredItems, options IgnoreMatcherOptions)
 			continue
 		}
 
-		literalSet := make(map[string]struct{}, len(ignorePaths))
-		var resolvedSet map[string]struct{}
+		literalSet := make(map[string]model.IgnoredPath, len(ignorePaths))
+		var resolvedSet map[string]model.IgnoredPath
 
-		for _, ignorePath := range ignorePaths {
-			if ignorePath == "" {
+		for _, path := range ignorePaths {
+			if path == "" {
+				continue
+			}
+			ignorePath := options.Entries.Entry(ruleID, path, now)
+			if ignorePath.IsExpired(now) {
+				matcher.expired[ruleID] = append(matcher.expired[ruleID], ignorePath)
 				continue
 			}
-			literalSet[ignorePath] = struct{}{}
+			if _, ok := literalSet[ignorePath.Path]; !ok {
+				literalSet[ignorePath.Path] = ignorePath
+			}
 
 			if root == nil || pathIndex == nil {
 				continue
 			}
 
-			exactMatches := resolveIgnoreExpressionPaths(ignorePath, root, pathIndex, options.LookupTimeout, expressionCache)
+			exactMatches := resolveIgnoreExpressionPaths(ignorePath.Path, root, pathIndex, options.LookupTimeout, expressionCache)
 			if len(exactMatches) == 0 {
 				continue
 			}
 			if resolvedSet == nil {
-				resolvedSet = make(map[string]struct{}, len(exactMatches))
+				resolvedSet = make(map[string]model.IgnoredPath, len(exactMatches))
 			}
 			for path := range exactMatches {
-				resolvedSet[path] = struct{}{}
+				if _, ok := resolvedSet[path]; !ok {
+					resolvedSet[path] = ignorePath
+				}
 			}
 		}
 
@@ -96,8 +118,14 @@ func NewIgnoreMatcher(ignored model.IgnoredItems, options IgnoreMatcherOptions)
 
 // Matches reports whether a result should be ignored.
 func (m *IgnoreMatcher) Matches(result *model.RuleFunctionResult) bool {
+	_, ok := m.Match(result)
+	return ok
+}
+
+// Match returns the ignore entry a result is ignored by, if there is one.
+func (m *IgnoreMatcher) Match(result *model.RuleFunctionResult) (model.IgnoredPath, bool) {
 	if m == nil || result == nil {
-		return false
+		return model.IgnoredPath{}, false
 	}
 
 	ruleID := result.RuleId
@@ -105,13 +133,21 @@ func (m *IgnoreMatcher) Matches(result *model.RuleFunctionResult) bool {
 		ruleID = result.Rule.Id
 	}
 	if ruleID == "" {
-		return false
+		return model.IgnoredPath{}, false
 	}
 
-	if matchesAnyPath(m.literalByRule[ruleID], result.Path, result.Paths) {
-		return true
+	if ignored, ok := matchAnyPath(m.literalByRule[ruleID], result.Path, result.Paths); ok {
+		return ignored, true
+	}
+	return matchAnyPath(m.resolvedByRule[ruleID], result.Path, result.Paths)
+}
+
+// Expired returns the entries that were not honored because they have expired.
+func (m *IgnoreMatcher) Expired() model.IgnoreEntries {
+	if m == nil {
+		return nil
 	}
-	return matchesAnyPath(m.resolvedByRule[ruleID], result.Path, result.Paths)
+	return m.expired
 }
 
 func resolveIgnoreExpressionPaths(
@@ -144,17 +180,17 @@ func resolveIgnoreExpressionPaths(
 	return matches
 }
 
-func matchesAnyPath(allowed map[string]struct{}, primary string, alternates []string) bool {
+func matchAnyPath(allowed map[string]model.IgnoredPath, primary string, alternates []string) (model.IgnoredPath, bool) {
 	if len(allowed) == 0 {
-		return false
+		return model.IgnoredPath{}, false
 	}
-	if _, ok := allowed[primary]; ok {
-		return true
+	if ignored, ok := allowed[primary]; ok {
+		return ignored, true
 	}
 	for _, path := range alternates {
-		if _, ok := allowed[path]; ok {
-			return true
+		if ignored, ok := allowed[path]; ok {
+			return ignored, true
 		}
 	}
-	return false
+	return model.IgnoredPath{}, false
 }
@@ -4,6 +4,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -389,6 +391,22 @@ func extractIdentity(result model.RuleFunctionResult, originMapper *canonicalOri
 	return "path:"
 }
 
+// ViolationFingerprint returns a stable fingerprint of a violation, a hash of the same identity (rule, path and
+// message) used to match violations between an original and a new specification. Line numbers are not part of the
+// fingerprint, so it survives unrelated edits to the document.
+func ViolationFingerprint(result *model.RuleFunctionResult) string {
+	if result == nil {
+		return ""
+	}
+	return violationFingerprint(*result, &canonicalOriginMapper{})
+}
+
+func violationFingerprint(result model.RuleFunctionResult, originMapper *canonicalOriginMapper) string {
+	identity := extractIdentity(result, originMapper)
+	sum := sha256.Sum256([]byte(result.RuleId + "\x00" + identity + "\x00" + result.Message))
+	return hex.EncodeToString(sum[:])
+}
+
 func buildViolationIdentity(result model.RuleFunctionResult, originMapper *canonicalOriginMapper) violationIdentity {
 	source := originMapper.sourcePathIdentity(result)
 	sourceLine, sourceColumn := 0, 0
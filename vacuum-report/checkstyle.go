@@ -0,0 +1,88 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"encoding/xml"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+type Checkstyle struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*CheckstyleFile `xml:"file"`
+}
+
+type CheckstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*CheckstyleError `xml:"error"`
+}
+
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleSeverities maps vacuum severities (by their int value) onto checkstyle severities.
+var checkstyleSeverities = []string{"error", "warning", "info", "info"}
+
+// BuildCheckstyleReport generates a Checkstyle XML report from linting results, grouped by the file each result
+// was found in and ordered by line.
+func BuildCheckstyleReport(resultSet *model.RuleResultSet, args []string) []byte {
+	files := make(map[string]*CheckstyleFile)
+	var names []string
+
+	for _, r := range resultSet.Results {
+		if r == nil || r.Rule == nil {
+			continue
+		}
+		name := resultFilePath(r, args)
+		file, ok := files[name]
+		if !ok {
+			file = &CheckstyleFile{Name: name}
+			files[name] = file
+			names = append(names, name)
+		}
+
+		severity := "info"
+		if sev := r.Rule.GetSeverityAsIntValue(); sev >= 0 && sev < len(checkstyleSeverities) {
+			severity = checkstyleSeverities[sev]
+		}
+		column := 0
+		if r.StartNode != nil {
+			column = r.StartNode.Column
+		}
+		if r.Origin != nil && r.Origin.Column > 0 {
+			column = r.Origin.Column
+		}
+		file.Errors = append(file.Errors, &CheckstyleError{
+			Line:     resultLine(r),
+			Column:   column,
+			Severity: severity,
+			Message:  r.Message,
+			Source:   r.Rule.Id,
+		})
+	}
+
+	sort.Strings(names)
+	report := &Checkstyle{Version: "4.3"}
+	for _, name := range names {
+		file := files[name]
+		sort.SliceStable(file.Errors, func(i, j int) bool {
+			if file.Errors[i].Line != file.Errors[j].Line {
+				return file.Errors[i].Line < file.Errors[j].Line
+			}
+			return file.Errors[i].Column < file.Errors[j].Column
+		})
+		report.Files = append(report.Files, file)
+	}
+
+	b, _ := xml.MarshalIndent(report, "", " ")
+	return append([]byte(xml.Header), b...)
+}
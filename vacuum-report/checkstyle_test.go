@@ -0,0 +1,47 @@
+package vacuum_report
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestBuildCheckstyleReport(t *testing.T) {
+	results := testhelp_severityResults()
+	results = append(results, model.RuleFunctionResult{
+		Rule:      &model.Rule{Id: "ref-rule", Severity: model.SeverityWarn},
+		Message:   "from a reference",
+		StartNode: &yaml.Node{Line: 1, Column: 1},
+		Origin:    &index.NodeOrigin{AbsoluteLocation: "/specs/components.yaml", Line: 7, Column: 5},
+	})
+
+	data := BuildCheckstyleReport(model.NewRuleResultSet(results), []string{"/specs/test.yaml"})
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	var report Checkstyle
+	require.NoError(t, xml.Unmarshal(data, &report))
+	assert.Equal(t, "4.3", report.Version)
+	require.Len(t, report.Files, 2)
+
+	assert.Equal(t, "/specs/components.yaml", report.Files[0].Name)
+	require.Len(t, report.Files[0].Errors, 1)
+	assert.Equal(t, 7, report.Files[0].Errors[0].Line)
+	assert.Equal(t, 5, report.Files[0].Errors[0].Column)
+
+	assert.Equal(t, "/specs/test.yaml", report.Files[1].Name)
+	var lines []int
+	var severities []string
+	for _, e := range report.Files[1].Errors {
+		lines = append(lines, e.Line)
+		severities = append(severities, e.Severity)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, lines)
+	assert.Equal(t, []string{"info", "info", "warning", "error"}, severities)
+	assert.Equal(t, "error-rule", report.Files[1].Errors[3].Source)
+}
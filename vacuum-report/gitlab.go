@@ -0,0 +1,121 @@
+// Copyright 2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// CodeQualityIssue is a single issue in a GitLab Code Quality report.
+// https://docs.gitlab.com/ci/testing/code_quality/#code-quality-report-format
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// codeQualitySeverities maps vacuum severities (by their int value) onto GitLab Code Quality severities.
+var codeQualitySeverities = []string{"critical", "major", "minor", "info"}
+
+// BuildGitLabCodeQualityReport generates a GitLab Code Quality report from linting results. Each issue is
+// fingerprinted using the file it was found in, the same identity (rule, path and message) vacuum uses to compare
+// specifications, and a count of the identical issues before it. GitLab requires every fingerprint to be unique,
+// and can track an issue between pipelines even when lines move.
+func BuildGitLabCodeQualityReport(resultSet *model.RuleResultSet, args []string) []byte {
+	issues := make([]CodeQualityIssue, 0, len(resultSet.Results))
+	occurrences := make(map[string]int)
+	for _, r := range resultSet.Results {
+		if r == nil || r.Rule == nil {
+			continue
+		}
+		severity := "info"
+		if sev := r.Rule.GetSeverityAsIntValue(); sev >= 0 && sev < len(codeQualitySeverities) {
+			severity = codeQualitySeverities[sev]
+		}
+		path := relativeToWorkingDirectory(resultFilePath(r, args))
+		identity := path + "\x00" + utils.ViolationFingerprint(r)
+		occurrence := occurrences[identity]
+		occurrences[identity]++
+		issues = append(issues, CodeQualityIssue{
+			Description: r.Message,
+			CheckName:   r.Rule.Id,
+			Fingerprint: codeQualityFingerprint(identity, occurrence),
+			Severity:    severity,
+			Location: CodeQualityLocation{
+				Path:  path,
+				Lines: CodeQualityLines{Begin: resultLine(r)},
+			},
+		})
+	}
+	b, _ := json.MarshalIndent(issues, "", "  ")
+	return b
+}
+
+// codeQualityFingerprint hashes the identity of an issue (its file and violation fingerprint) with the number of
+// identical issues found before it.
+func codeQualityFingerprint(identity string, occurrence int) string {
+	sum := sha256.Sum256([]byte(identity + "\x00" + strconv.Itoa(occurrence)))
+	return hex.EncodeToString(sum[:])
+}
+
+// resultFilePath returns the file a result was found in, which is the linted file unless the result originates
+// from a referenced document.
+func resultFilePath(r *model.RuleFunctionResult, args []string) string {
+	if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+		return r.Origin.AbsoluteLocation
+	}
+	if len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
+// resultLine returns the line a result starts on, defaulting to the first line.
+func resultLine(r *model.RuleFunctionResult) int {
+	line := 1
+	if r.StartNode != nil && r.StartNode.Line > 0 {
+		line = r.StartNode.Line
+	}
+	if r.Origin != nil && r.Origin.Line > 0 {
+		line = r.Origin.Line
+	}
+	return line
+}
+
+// relativeToWorkingDirectory makes an absolute path relative to the working directory (GitLab expects paths
+// relative to the repository), paths outside the working directory are left alone.
+func relativeToWorkingDirectory(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
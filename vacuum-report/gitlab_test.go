@@ -0,0 +1,96 @@
+package vacuum_report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func testhelp_severityResults() []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	for i, severity := range []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint} {
+		results = append(results, model.RuleFunctionResult{
+			Rule:      &model.Rule{Id: severity + "-rule", Severity: severity},
+			RuleId:    severity + "-rule",
+			Message:   "This is a " + severity,
+			Path:      "$.info",
+			StartNode: &yaml.Node{Line: 4 - i, Column: 3},
+		})
+	}
+	return results
+}
+
+func TestBuildGitLabCodeQualityReport(t *testing.T) {
+	resultSet := model.NewRuleResultSet(testhelp_severityResults())
+
+	var issues []CodeQualityIssue
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(resultSet, []string{"specs/test.yaml"}), &issues))
+	require.Len(t, issues, 4)
+
+	assert.Equal(t, "error-rule", issues[0].CheckName)
+	assert.Equal(t, "This is a error", issues[0].Description)
+	assert.Equal(t, "specs/test.yaml", issues[0].Location.Path)
+	assert.Equal(t, 4, issues[0].Location.Lines.Begin)
+	assert.Equal(t, codeQualityFingerprint("specs/test.yaml\x00"+utils.ViolationFingerprint(resultSet.Results[0]), 0),
+		issues[0].Fingerprint)
+
+	var severities []string
+	for _, issue := range issues {
+		severities = append(severities, issue.Severity)
+	}
+	assert.Equal(t, []string{"critical", "major", "minor", "info"}, severities)
+}
+
+func TestBuildGitLabCodeQualityReport_StableFingerprint(t *testing.T) {
+	results := testhelp_severityResults()[:1]
+	first := model.NewRuleResultSet(results)
+
+	// moving the violation to another line does not change the fingerprint, changing the message does.
+	moved := results[0]
+	moved.StartNode = &yaml.Node{Line: 40, Column: 3}
+	changed := results[0]
+	changed.Message = "something else"
+
+	var a, b, c []CodeQualityIssue
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(first, nil), &a))
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(model.NewRuleResultSet([]model.RuleFunctionResult{moved}), nil), &b))
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(model.NewRuleResultSet([]model.RuleFunctionResult{changed}), nil), &c))
+
+	assert.Len(t, a[0].Fingerprint, 64)
+	assert.Equal(t, a[0].Fingerprint, b[0].Fingerprint)
+	assert.NotEqual(t, a[0].Fingerprint, c[0].Fingerprint)
+}
+
+func TestBuildGitLabCodeQualityReport_UniqueFingerprints(t *testing.T) {
+	result := testhelp_severityResults()[0]
+	duplicate := result
+	duplicate.StartNode = &yaml.Node{Line: 12, Column: 3}
+	resultSet := model.NewRuleResultSet([]model.RuleFunctionResult{result, duplicate})
+
+	// identical violations at the same path, and the same violation in another file of a glob.
+	var first, second []CodeQualityIssue
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(resultSet, []string{"specs/a.yaml"}), &first))
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(resultSet, []string{"specs/b.yaml"}), &second))
+	require.Len(t, first, 2)
+	require.Len(t, second, 2)
+
+	fingerprints := make(map[string]bool)
+	for _, issue := range append(first, second...) {
+		assert.False(t, fingerprints[issue.Fingerprint], "duplicate fingerprint %s", issue.Fingerprint)
+		fingerprints[issue.Fingerprint] = true
+	}
+
+	// the occurrence count keeps fingerprints stable between runs.
+	var again []CodeQualityIssue
+	require.NoError(t, json.Unmarshal(BuildGitLabCodeQualityReport(resultSet, []string{"specs/a.yaml"}), &again))
+	assert.Equal(t, first, again)
+}
+
+func TestBuildGitLabCodeQualityReport_Empty(t *testing.T) {
+	assert.Equal(t, "[]", string(BuildGitLabCodeQualityReport(model.NewRuleResultSet(nil), nil)))
+}
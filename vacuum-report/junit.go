@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"html"
 	"strings"
 	"text/template"
 	"time"
@@ -46,6 +47,7 @@ type TestCase struct {
 	Failure    *Failure    `xml:"failure,omitempty"`
 	Properties *Properties `xml:"properties,omitempty"`
 	File       string      `xml:"file,attr,omitempty"`
+	Time       float64     `xml:"time,attr,omitempty"`
 }
 
 type Failure struct {
@@ -221,3 +223,49 @@ func BuildJUnitReportWithConfig(resultSet *model.RuleResultSet, t time.Time, arg
 	return b
 
 }
+
+// RuleSetTestCaseResult is the outcome of a single test case run by the test-ruleset command.
+type RuleSetTestCaseResult struct {
+	Name     string        // name of the test case
+	Spec     string        // the fixture spec linted by the test case
+	Failure  string        // why the test case failed, empty if it passed
+	Duration time.Duration // how long the test case took to run
+}
+
+// BuildRuleSetTestJUnitReport generates a JUnit XML report from ruleset test results, with a single suite
+// named after the test manifest.
+func BuildRuleSetTestJUnitReport(manifest string, results []*RuleSetTestCaseResult, t time.Time) []byte {
+	suite := &TestSuite{
+		Name:  manifest,
+		Tests: len(results),
+	}
+
+	for _, r := range results {
+		tCase := &TestCase{
+			Name:      r.Name,
+			ClassName: manifest,
+			File:      r.Spec,
+			Time:      r.Duration.Seconds(),
+		}
+		if r.Failure != "" {
+			suite.Failures++
+			tCase.Failure = &Failure{
+				Message:  strings.SplitN(r.Failure, "\n", 2)[0],
+				Type:     "FAILURE",
+				File:     r.Spec,
+				Contents: html.EscapeString(r.Failure),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tCase)
+	}
+
+	allSuites := &TestSuites{
+		TestSuites: []*TestSuite{suite},
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Time:       time.Since(t).Seconds(),
+	}
+
+	b, _ := xml.MarshalIndent(allSuites, "", " ")
+	return b
+}
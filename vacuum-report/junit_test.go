@@ -169,3 +169,18 @@ func TestBuildJUnitReport_BackwardsCompatibility(t *testing.T) {
 	assert.Contains(t, output, `failures="1"`)
 	assert.Contains(t, output, `<failure message="This is a warning" type="WARN"`)
 }
+
+func TestBuildRuleSetTestJUnitReport(t *testing.T) {
+	data := BuildRuleSetTestJUnitReport("tests.yaml", []*RuleSetTestCaseResult{
+		{Name: "passes", Spec: "ok.yaml", Duration: 2 * time.Second},
+		{Name: "fails", Spec: "bad.yaml", Failure: "- missing-rule at $.info\n+ other-rule at $.paths['/a']"},
+	}, time.Now())
+
+	report := string(data)
+	assert.Contains(t, report, `<testsuites tests="2" failures="1"`)
+	assert.Contains(t, report, `<testsuite name="tests.yaml" tests="2" failures="1">`)
+	assert.Contains(t, report, `<testcase name="passes" classname="tests.yaml" file="ok.yaml" time="2"></testcase>`)
+	assert.Contains(t, report, `<failure message="- missing-rule at $.info" type="FAILURE" file="bad.yaml">`)
+	assert.Contains(t, report, `+ other-rule at $.paths[&#39;/a&#39;]`)
+	assert.Equal(t, 1, strings.Count(report, "<failure"))
+}